@@ -26,6 +26,7 @@ import (
 	"storj.io/storj/pkg/datarepair/checker"
 	"storj.io/storj/pkg/datarepair/repairer"
 	"storj.io/storj/pkg/discovery"
+	"storj.io/storj/pkg/expiry"
 	"storj.io/storj/pkg/inspector"
 	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/miniogw"
@@ -36,6 +37,8 @@ import (
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/satellite/satelliteweb"
 	"storj.io/storj/pkg/server"
+	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/pkg/uptime"
 )
 
 // Captplanet defines Captain Planet configuration
@@ -64,13 +67,16 @@ type Satellite struct {
 	Kademlia    kademlia.SatelliteConfig
 	PointerDB   pointerdb.Config
 	Overlay     overlay.Config
+	StatDB      statdb.Config
 	Inspector   inspector.Config
 	Checker     checker.Config
 	Repairer    repairer.Config
+	Expiry      expiry.Config
 	Audit       audit.Config
 	BwAgreement bwagreement.Config
 	Web         satelliteweb.Config
 	Discovery   discovery.Config
+	Uptime      uptime.Config
 	Tally       tally.Config
 	Rollup      rollup.Config
 	Database    string `help:"satellite database connection string" default:"sqlite3://$CONFDIR/master.db"`
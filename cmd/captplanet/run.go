@@ -84,13 +84,16 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 		// Run satellite
 		errch <- satellite.Server.Run(ctx,
 			grpcauth.NewAPIKeyInterceptor(),
+			satellite.StatDB,
 			satellite.Kademlia,
 			satellite.Audit,
 			satellite.Overlay,
 			satellite.Discovery,
+			satellite.Uptime,
 			satellite.PointerDB,
 			satellite.Checker,
 			satellite.Repairer,
+			satellite.Expiry,
 			satellite.BwAgreement,
 			satellite.Web,
 			satellite.Tally,
@@ -126,6 +126,9 @@ func cmdSetup(cmd *cobra.Command, args []string) (err error) {
 		"satellite.repairer.overlay-addr":     overlayAddr,
 		"satellite.repairer.pointer-db-addr":  joinHostPort(setupCfg.ListenHost, startingPort+1),
 		"satellite.repairer.api-key":          setupCfg.APIKey,
+		"satellite.expiry.overlay-addr":       overlayAddr,
+		"satellite.expiry.pointer-db-addr":    joinHostPort(setupCfg.ListenHost, startingPort+1),
+		"satellite.expiry.api-key":            setupCfg.APIKey,
 		"uplink.identity.cert-path":           setupCfg.UplinkIdentity.CertPath,
 		"uplink.identity.key-path":            setupCfg.UplinkIdentity.KeyPath,
 		"uplink.server.address":               joinHostPort(setupCfg.ListenHost, startingPort),
@@ -141,6 +144,9 @@ func cmdSetup(cmd *cobra.Command, args []string) (err error) {
 		"kademlia.bucket-size":                4,
 		"kademlia.replacement-cache-size":     1,
 
+		// every node listens on the same loopback address
+		"satellite.overlay.node.local-network": true,
+
 		// TODO: this will eventually go away
 		"pointer-db.auth.api-key": setupCfg.APIKey,
 
@@ -12,8 +12,10 @@ import (
 	"io"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/gogo/protobuf/jsonpb"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/spf13/cobra"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -21,6 +23,7 @@ import (
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/process"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/statdb"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/transport"
 )
@@ -29,6 +32,8 @@ var (
 	// Addr is the address of Capt Planet from command flags
 	Addr = flag.String("address", "localhost:7778", "address of captplanet to inspect")
 
+	releaseState = flag.Bool("release", false, "let the node's stats move it between states again")
+
 	// ErrInspectorDial throws when there are errors dialing the inspector server
 	ErrInspectorDial = errs.Class("error dialing inspector server:")
 
@@ -54,6 +59,10 @@ var (
 		Use:   "statdb",
 		Short: "commands for statdb",
 	}
+	auditCmd = &cobra.Command{
+		Use:   "audit",
+		Short: "commands for audit containment",
+	}
 	countNodeCmd = &cobra.Command{
 		Use:   "count",
 		Short: "count nodes in kademlia and overlay",
@@ -87,6 +96,18 @@ var (
 		Short: "dump all nodes in the routing table",
 		RunE:  DumpNodes,
 	}
+	exportCmd = &cobra.Command{
+		Use:   "export <file>",
+		Short: "export the routing table to a json file",
+		Args:  cobra.ExactArgs(1),
+		RunE:  ExportRoutingTable,
+	}
+	importCmd = &cobra.Command{
+		Use:   "import <file>",
+		Short: "import the nodes of an exported routing table that respond to a ping",
+		Args:  cobra.ExactArgs(1),
+		RunE:  ImportRoutingTable,
+	}
 	getStatsCmd = &cobra.Command{
 		Use:   "getstats <node_id>",
 		Short: "Get node stats",
@@ -113,6 +134,23 @@ var (
 		Args:  cobra.MinimumNArgs(1),
 		RunE:  CreateCSVStats,
 	}
+	nodeStateCmd = &cobra.Command{
+		Use:   "state <node_id> [vetting|active|suspended|disqualified]",
+		Short: "Get a node's state, or override it until it's released with --release",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE:  NodeState,
+	}
+	listPendingAuditsCmd = &cobra.Command{
+		Use:   "contained",
+		Short: "list all contained nodes and their pending audits",
+		RunE:  ListPendingAudits,
+	}
+	getPendingAuditCmd = &cobra.Command{
+		Use:   "pending <node_id>",
+		Short: "get the pending audit of a contained node",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  GetPendingAudit,
+	}
 )
 
 // Inspector gives access to kademlia and overlay cache
@@ -254,6 +292,60 @@ func DumpNodes(cmd *cobra.Command, args []string) (err error) {
 	return nil
 }
 
+// ExportRoutingTable writes every node in the routing table to a json file
+func ExportRoutingTable(cmd *cobra.Command, args []string) (err error) {
+	i, err := NewInspector(*Addr)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+
+	table, err := i.client.ExportRoutingTable(context.Background(), &pb.ExportRoutingTableRequest{})
+	if err != nil {
+		return ErrRequest.Wrap(err)
+	}
+
+	f, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, f.Close()) }()
+
+	marshaler := jsonpb.Marshaler{Indent: "  "}
+	if err := marshaler.Marshal(f, table); err != nil {
+		return err
+	}
+
+	fmt.Printf("exported %d nodes to %s\n", len(table.Nodes), args[0])
+	return nil
+}
+
+// ImportRoutingTable pings the nodes of an exported routing table, adding those that respond
+func ImportRoutingTable(cmd *cobra.Command, args []string) (err error) {
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer func() { err = errs.Combine(err, f.Close()) }()
+
+	table := &pb.ExportRoutingTableResponse{}
+	if err := jsonpb.Unmarshal(f, table); err != nil {
+		return ErrArgs.Wrap(err)
+	}
+
+	i, err := NewInspector(*Addr)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+
+	res, err := i.client.ImportRoutingTable(context.Background(), &pb.ImportRoutingTableRequest{Nodes: table.Nodes})
+	if err != nil {
+		return ErrRequest.Wrap(err)
+	}
+
+	fmt.Printf("%d of %d nodes responded\n", res.Responding, len(table.Nodes))
+	return nil
+}
+
 func prettyPrintNode(n *pb.LookupNodeResponse) string {
 	m := jsonpb.Marshaler{Indent: "  ", EmitDefaults: false}
 	s, err := m.MarshalToString(n)
@@ -329,6 +421,65 @@ func GetStats(cmd *cobra.Command, args []string) (err error) {
 	return nil
 }
 
+// NodeState prints a node's state from statdb, or overrides it if a new state is given.
+// Overridden states are kept until they are released.
+func NodeState(cmd *cobra.Command, args []string) (err error) {
+	i, err := NewInspector(*Addr)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+
+	nodeID, err := storj.NodeIDFromString(args[0])
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case *releaseState && len(args) > 1:
+		return ErrArgs.New("a state can't be given together with --release")
+	case *releaseState:
+		_, err = i.client.UpdateNodeState(context.Background(), &pb.UpdateNodeStateRequest{
+			NodeId:  nodeID,
+			Release: true,
+		})
+		if err != nil {
+			return ErrRequest.Wrap(err)
+		}
+	case len(args) > 1:
+		state, err := statdb.ParseNodeState(args[1])
+		if err != nil {
+			return ErrArgs.Wrap(err)
+		}
+
+		_, err = i.client.UpdateNodeState(context.Background(), &pb.UpdateNodeStateRequest{
+			NodeId: nodeID,
+			State:  pb.NodeState(state),
+		})
+		if err != nil {
+			return ErrRequest.Wrap(err)
+		}
+	}
+
+	res, err := i.client.GetStats(context.Background(), &pb.GetStatsRequest{
+		NodeId: nodeID,
+	})
+	if err != nil {
+		return ErrRequest.Wrap(err)
+	}
+
+	updatedAt, err := ptypes.Timestamp(res.StateUpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	overridden := ""
+	if res.StateOverride {
+		overridden = ", overridden"
+	}
+	fmt.Printf("State for ID %s: %s (since %s%s)\n", nodeID, statdb.NodeState(res.State), updatedAt.Format(time.RFC3339), overridden)
+	return nil
+}
+
 // GetCSVStats gets node stats from statdb based on a csv
 func GetCSVStats(cmd *cobra.Command, args []string) (err error) {
 	i, err := NewInspector(*Addr)
@@ -465,9 +616,61 @@ func CreateCSVStats(cmd *cobra.Command, args []string) (err error) {
 	return nil
 }
 
+// ListPendingAudits lists all contained nodes and their pending audits
+func ListPendingAudits(cmd *cobra.Command, args []string) (err error) {
+	i, err := NewInspector(*Addr)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+
+	res, err := i.client.ListPendingAudits(context.Background(), &pb.ListPendingAuditsRequest{})
+	if err != nil {
+		return ErrRequest.Wrap(err)
+	}
+
+	fmt.Printf("Contained nodes ---------------- \n Total: %d\n", len(res.PendingAudits))
+	for _, pending := range res.PendingAudits {
+		fmt.Println(prettyPrintPendingAudit(pending))
+	}
+	return nil
+}
+
+// GetPendingAudit gets the pending audit of a contained node
+func GetPendingAudit(cmd *cobra.Command, args []string) (err error) {
+	i, err := NewInspector(*Addr)
+	if err != nil {
+		return ErrInspectorDial.Wrap(err)
+	}
+
+	nodeID, err := storj.NodeIDFromString(args[0])
+	if err != nil {
+		return err
+	}
+
+	res, err := i.client.GetPendingAudit(context.Background(), &pb.GetPendingAuditRequest{
+		NodeId: nodeID,
+	})
+	if err != nil {
+		return ErrRequest.Wrap(err)
+	}
+
+	fmt.Println(prettyPrintPendingAudit(res.PendingAudit))
+	return nil
+}
+
+func prettyPrintPendingAudit(p *pb.PendingAudit) string {
+	m := jsonpb.Marshaler{Indent: "  ", EmitDefaults: true}
+	s, err := m.MarshalToString(p)
+	if err != nil {
+		zap.S().Error("error marshaling pending audit: %s", p.NodeId)
+	}
+	return s
+}
+
 func init() {
 	rootCmd.AddCommand(kadCmd)
 	rootCmd.AddCommand(statsCmd)
+	rootCmd.AddCommand(auditCmd)
 
 	kadCmd.AddCommand(countNodeCmd)
 	kadCmd.AddCommand(getBucketsCmd)
@@ -475,11 +678,17 @@ func init() {
 	kadCmd.AddCommand(pingNodeCmd)
 	kadCmd.AddCommand(lookupNodeCmd)
 	kadCmd.AddCommand(dumpNodesCmd)
+	kadCmd.AddCommand(exportCmd)
+	kadCmd.AddCommand(importCmd)
 
 	statsCmd.AddCommand(getStatsCmd)
 	statsCmd.AddCommand(getCSVStatsCmd)
 	statsCmd.AddCommand(createStatsCmd)
 	statsCmd.AddCommand(createCSVStatsCmd)
+	statsCmd.AddCommand(nodeStateCmd)
+
+	auditCmd.AddCommand(listPendingAuditsCmd)
+	auditCmd.AddCommand(getPendingAuditCmd)
 
 	flag.Parse()
 }
@@ -0,0 +1,89 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/cfgstruct"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/linksharing"
+	"storj.io/storj/pkg/miniogw"
+	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+)
+
+// LinkSharing defines the link sharing service configuration
+type LinkSharing struct {
+	Identity identity.Config
+	Client   miniogw.ClientConfig
+	RS       miniogw.RSConfig
+	Enc      miniogw.EncryptionConfig
+
+	Server linksharing.Config
+}
+
+var (
+	rootCmd = &cobra.Command{
+		Use:   "linksharing",
+		Short: "Serves the objects of shared accesses over HTTP",
+	}
+	runCmd = &cobra.Command{
+		Use:   "run",
+		Short: "Run the link sharing service",
+		RunE:  cmdRun,
+	}
+
+	cfg LinkSharing
+)
+
+func init() {
+	defaultConfDir := fpath.ApplicationDir("storj", "linksharing")
+
+	dirParam := cfgstruct.FindConfigDirParam()
+	if dirParam != "" {
+		defaultConfDir = dirParam
+	}
+
+	rootCmd.PersistentFlags().String("config-dir", defaultConfDir, "main directory for linksharing configuration")
+
+	rootCmd.AddCommand(runCmd)
+	cfgstruct.Bind(runCmd.Flags(), &cfg, cfgstruct.ConfDir(defaultConfDir))
+}
+
+func cmdRun(cmd *cobra.Command, args []string) (err error) {
+	for _, flagname := range args {
+		return fmt.Errorf("Invalid argument %#v. Try 'linksharing run'", flagname)
+	}
+
+	identity, err := cfg.Identity.Load()
+	if err != nil {
+		return err
+	}
+
+	// every link is opened with the configured client, replacing the
+	// satellite addresses, API key and keys with those of its access
+	open := func(ctx context.Context, access string) (storj.Metainfo, streams.Store, func() error, error) {
+		config := miniogw.Config{
+			Identity: cfg.Identity,
+			Client:   cfg.Client,
+			RS:       cfg.RS,
+			Enc:      cfg.Enc,
+		}
+		config.Client.Access = access
+		return config.OpenMetainfo(ctx, identity)
+	}
+
+	return cfg.Server.Run(process.Ctx(cmd), zap.L(), open)
+}
+
+func main() {
+	process.Exec(rootCmd)
+}
@@ -23,14 +23,19 @@ import (
 	"storj.io/storj/pkg/datarepair/checker"
 	"storj.io/storj/pkg/datarepair/repairer"
 	"storj.io/storj/pkg/discovery"
+	"storj.io/storj/pkg/durability"
+	"storj.io/storj/pkg/expiry"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/miniogw"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/pointerdb"
 	"storj.io/storj/pkg/process"
 	"storj.io/storj/pkg/server"
+	"storj.io/storj/pkg/statdb"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/uptime"
 	"storj.io/storj/satellite/satellitedb"
 )
 
@@ -44,11 +49,14 @@ type Satellite struct {
 	Kademlia    kademlia.SatelliteConfig
 	PointerDB   pointerdb.Config
 	Overlay     overlay.Config
+	StatDB      statdb.Config
 	Checker     checker.Config
 	Repairer    repairer.Config
+	Expiry      expiry.Config
 	Audit       audit.Config
 	BwAgreement bwagreement.Config
 	Discovery   discovery.Config
+	Uptime      uptime.Config
 	Database    string `help:"satellite database connection string" default:"sqlite3://$CONFDIR/master.db"`
 }
 
@@ -78,6 +86,11 @@ var (
 		Short: "Repair Queue Diagnostic Tool support",
 		RunE:  cmdQDiag,
 	}
+	durabilityCmd = &cobra.Command{
+		Use:   "durability",
+		Short: "Estimate the durability of a redundancy scheme against the configured minimum",
+		RunE:  cmdDurability,
+	}
 
 	runCfg   Satellite
 	setupCfg Satellite
@@ -89,6 +102,12 @@ var (
 		Database   string `help:"satellite database connection string" default:"sqlite3://$CONFDIR/master.db"`
 		QListLimit int    `help:"maximum segments that can be requested" default:"1000"`
 	}
+	durabilityCfg struct {
+		// the durability settings keep their names in the satellite
+		// configuration, the scheme is set like for uplinks
+		PointerDB durability.Config
+		RS        miniogw.RSConfig
+	}
 
 	defaultConfDir string
 	confDir        *string
@@ -108,10 +127,12 @@ func init() {
 	rootCmd.AddCommand(setupCmd)
 	rootCmd.AddCommand(diagCmd)
 	rootCmd.AddCommand(qdiagCmd)
+	rootCmd.AddCommand(durabilityCmd)
 	cfgstruct.Bind(runCmd.Flags(), &runCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.BindSetup(setupCmd.Flags(), &setupCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.Bind(diagCmd.Flags(), &diagCfg, cfgstruct.ConfDir(defaultConfDir))
 	cfgstruct.Bind(qdiagCmd.Flags(), &qdiagCfg, cfgstruct.ConfDir(defaultConfDir))
+	cfgstruct.Bind(durabilityCmd.Flags(), &durabilityCfg, cfgstruct.ConfDir(defaultConfDir))
 }
 
 func cmdRun(cmd *cobra.Command, args []string) (err error) {
@@ -133,14 +154,17 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 	return runCfg.Server.Run(
 		ctx,
 		grpcauth.NewAPIKeyInterceptor(),
+		runCfg.StatDB,
 		runCfg.Kademlia,
 		runCfg.Overlay,
 		runCfg.PointerDB,
 		runCfg.Checker,
 		runCfg.Repairer,
+		runCfg.Expiry,
 		runCfg.Audit,
 		runCfg.BwAgreement,
 		runCfg.Discovery,
+		runCfg.Uptime,
 	)
 }
 
@@ -299,6 +323,38 @@ func cmdQDiag(cmd *cobra.Command, args []string) (err error) {
 	return w.Flush()
 }
 
+func cmdDurability(cmd *cobra.Command, args []string) (err error) {
+	scheme := storj.RedundancyScheme{
+		Algorithm:      storj.ReedSolomon,
+		ShareSize:      int32(durabilityCfg.RS.ErasureShareSize),
+		RequiredShares: int16(durabilityCfg.RS.MinThreshold),
+		RepairShares:   int16(durabilityCfg.RS.RepairThreshold),
+		OptimalShares:  int16(durabilityCfg.RS.SuccessThreshold),
+		TotalShares:    int16(durabilityCfg.RS.MaxThreshold),
+	}
+
+	estimate, err := durabilityCfg.PointerDB.Durability.Estimate(scheme)
+	if err != nil {
+		return err
+	}
+
+	const padding = 3
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, padding, ' ', tabwriter.AlignRight|tabwriter.Debug)
+	fmt.Fprintln(w, "Scheme\tExpansion Factor\tInterval Loss\tAnnual Loss\tNines\tMinimum Nines\t")
+	fmt.Fprintf(w, "%d/%d/%d/%d\t%.2f\t%.3g\t%.3g\t%.2f\t%.2f\t\n",
+		scheme.RequiredShares, scheme.RepairShares, scheme.OptimalShares, scheme.TotalShares,
+		estimate.ExpansionFactor, estimate.LossProbability, estimate.AnnualLossProbability,
+		estimate.Nines(), durabilityCfg.PointerDB.MinDurability)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if estimate.Nines() < durabilityCfg.PointerDB.MinDurability {
+		return errs.New("redundancy scheme is less durable than the configured minimum")
+	}
+	return nil
+}
+
 func main() {
 	process.Exec(rootCmd)
 }
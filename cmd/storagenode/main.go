@@ -21,6 +21,7 @@ import (
 	"storj.io/storj/pkg/cfgstruct"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/nat"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/piecestore/psserver"
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
@@ -37,6 +38,7 @@ type StorageNode struct {
 
 	Server   server.Config
 	Kademlia kademlia.StorageNodeConfig
+	NAT      nat.Config
 	Storage  psserver.Config
 	Signer   certificates.CertSigningConfig
 }
@@ -118,7 +120,7 @@ func cmdRun(cmd *cobra.Command, args []string) (err error) {
 		zap.S().Info("Operator wallet: ", operatorConfig.Wallet)
 	}
 
-	return runCfg.Server.Run(process.Ctx(cmd), nil, runCfg.Kademlia, runCfg.Storage)
+	return runCfg.Server.Run(process.Ctx(cmd), nil, runCfg.Kademlia, runCfg.NAT, runCfg.Storage)
 }
 
 func cmdSetup(cmd *cobra.Command, args []string) (err error) {
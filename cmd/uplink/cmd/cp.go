@@ -76,11 +76,7 @@ func upload(ctx context.Context, src fpath.FPath, dst fpath.FPath, showProgress
 		return err
 	}
 
-	createInfo := storj.CreateObject{
-		RedundancyScheme: cfg.GetRedundancyScheme(),
-		EncryptionScheme: cfg.GetEncryptionScheme(),
-	}
-	obj, err := metainfo.CreateObject(ctx, dst.Bucket(), dst.Path(), &createInfo)
+	obj, err := metainfo.CreateObject(ctx, dst.Bucket(), dst.Path(), nil)
 	if err != nil {
 		return convertError(err, dst)
 	}
@@ -222,11 +218,7 @@ func copy(ctx context.Context, src fpath.FPath, dst fpath.FPath) error {
 		dst = dst.Join(src.Base())
 	}
 
-	createInfo := storj.CreateObject{
-		RedundancyScheme: cfg.GetRedundancyScheme(),
-		EncryptionScheme: cfg.GetEncryptionScheme(),
-	}
-	obj, err := metainfo.CreateObject(ctx, dst.Bucket(), dst.Path(), &createInfo)
+	obj, err := metainfo.CreateObject(ctx, dst.Bucket(), dst.Path(), nil)
 	if err != nil {
 		return convertError(err, dst)
 	}
@@ -0,0 +1,41 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/pkg/cfgstruct"
+	"storj.io/storj/pkg/durability"
+)
+
+var durabilityModel durability.Model
+
+func init() {
+	durabilityCmd := addCmd(&cobra.Command{
+		Use:   "durability",
+		Short: "Estimate the durability of the configured redundancy scheme",
+		RunE:  estimateDurability,
+	}, CLICmd)
+	cfgstruct.Bind(durabilityCmd.Flags(), &durabilityModel)
+}
+
+// estimateDurability is the function executed when durabilityCmd is called
+func estimateDurability(cmd *cobra.Command, args []string) error {
+	scheme := cfg.GetRedundancyScheme()
+
+	estimate, err := durabilityModel.Estimate(scheme)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Redundancy scheme: %d/%d/%d/%d\n", scheme.RequiredShares, scheme.RepairShares, scheme.OptimalShares, scheme.TotalShares)
+	fmt.Printf("Expansion factor: %.2f\n", estimate.ExpansionFactor)
+	fmt.Printf("Loss probability per repair interval: %.3g\n", estimate.LossProbability)
+	fmt.Printf("Annual loss probability: %.3g\n", estimate.AnnualLossProbability)
+	fmt.Printf("Annual durability: %.2f nines\n", estimate.Nines())
+	return nil
+}
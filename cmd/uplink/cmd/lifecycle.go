@@ -0,0 +1,117 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/storj"
+)
+
+var (
+	lifecycleExpireDays      *int
+	lifecycleAbortUploadDays *int
+)
+
+func init() {
+	lifecycleCmd := addCmd(&cobra.Command{
+		Use:   "lifecycle",
+		Short: "Show or change the lifecycle rules of a bucket for the objects under a prefix",
+		RunE:  bucketLifecycle,
+	}, CLICmd)
+	lifecycleExpireDays = lifecycleCmd.Flags().Int("expire-days", 0, "number of days after their creation after which objects under the prefix expire, 0 for never. Existing objects are changed too.")
+	lifecycleAbortUploadDays = lifecycleCmd.Flags().Int("abort-upload-days", 0, "number of days after which pending multipart uploads under the prefix are aborted, 0 for never")
+}
+
+// bucketLifecycle is the function executed when lifecycleCmd is called. The
+// rule for the prefix is removed when all its days are set to 0. The
+// expiration of an object is set when it is created, so the objects existing
+// under the prefix are given the expiration of the changed rules too.
+func bucketLifecycle(cmd *cobra.Command, args []string) error {
+	ctx := process.Ctx(cmd)
+
+	if len(args) == 0 {
+		return fmt.Errorf("No bucket specified, use format sj://bucket/prefix")
+	}
+
+	dst, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	if dst.IsLocal() {
+		return fmt.Errorf("No bucket specified, use format sj://bucket/prefix")
+	}
+
+	if *lifecycleExpireDays < 0 || *lifecycleAbortUploadDays < 0 {
+		return fmt.Errorf("Number of days must not be negative")
+	}
+
+	metainfo, _, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	bucket, err := metainfo.GetBucket(ctx, dst.Bucket())
+	if err != nil {
+		return convertError(err, dst)
+	}
+
+	expireChanged := cmd.Flags().Changed("expire-days")
+	abortChanged := cmd.Flags().Changed("abort-upload-days")
+	if !expireChanged && !abortChanged {
+		printLifecycle(bucket)
+		return nil
+	}
+
+	rule := storj.LifecycleRule{Prefix: dst.Path()}
+	var rules []storj.LifecycleRule
+	for _, existing := range bucket.Lifecycle {
+		if existing.Prefix == rule.Prefix {
+			rule = existing
+			continue
+		}
+		rules = append(rules, existing)
+	}
+	if expireChanged {
+		rule.ExpireDays = *lifecycleExpireDays
+	}
+	if abortChanged {
+		rule.AbortUploadDays = *lifecycleAbortUploadDays
+	}
+	if rule.ExpireDays > 0 || rule.AbortUploadDays > 0 {
+		rules = append(rules, rule)
+	}
+
+	bucket, err = metainfo.SetBucketLifecycle(ctx, dst.Bucket(), rules)
+	if err != nil {
+		return convertError(err, dst)
+	}
+
+	printLifecycle(bucket)
+
+	if expireChanged {
+		changed, err := metainfo.ApplyBucketLifecycle(ctx, dst.Bucket(), dst.Path())
+		if err != nil {
+			return convertError(err, dst)
+		}
+		fmt.Printf("Changed the expiration of %d existing objects\n", changed)
+	}
+	return nil
+}
+
+// printLifecycle prints the lifecycle rules of the bucket
+func printLifecycle(bucket storj.Bucket) {
+	if len(bucket.Lifecycle) == 0 {
+		fmt.Printf("No lifecycle rules for bucket %s\n", bucket.Name)
+		return
+	}
+	for _, rule := range bucket.Lifecycle {
+		fmt.Printf("sj://%s/%s expire-days=%d abort-upload-days=%d\n", bucket.Name, rule.Prefix, rule.ExpireDays, rule.AbortUploadDays)
+	}
+}
@@ -17,6 +17,7 @@ import (
 
 var (
 	recursiveFlag *bool
+	versionsFlag  *bool
 )
 
 func init() {
@@ -26,6 +27,7 @@ func init() {
 		RunE:  list,
 	}, CLICmd)
 	recursiveFlag = lsCmd.Flags().Bool("recursive", false, "if true, list recursively")
+	versionsFlag = lsCmd.Flags().Bool("versions", false, "if true, list every version of the objects, with the delete markers")
 }
 
 func list(cmd *cobra.Command, args []string) error {
@@ -97,6 +99,7 @@ func listFiles(ctx context.Context, metainfo storj.Metainfo, prefix fpath.FPath,
 			Cursor:    startAfter,
 			Prefix:    prefix.Path(),
 			Recursive: *recursiveFlag,
+			Versions:  *versionsFlag,
 		})
 		if err != nil {
 			return err
@@ -107,10 +110,15 @@ func listFiles(ctx context.Context, metainfo storj.Metainfo, prefix fpath.FPath,
 			if prependBucket {
 				path = fmt.Sprintf("%s/%s", prefix.Bucket(), path)
 			}
-			if object.IsPrefix {
+			switch {
+			case object.IsPrefix:
 				fmt.Println("PRE", path)
-			} else {
+			case !*versionsFlag:
 				fmt.Printf("%v %v %12v %v\n", "OBJ", formatTime(object.Modified), object.Size, path)
+			case object.IsDeleteMarker:
+				fmt.Printf("%v %v %12v %v (version %d)\n", "DEL", formatTime(object.Modified), "", path, object.Version)
+			default:
+				fmt.Printf("%v %v %12v %v (version %d)\n", "OBJ", formatTime(object.Modified), object.Size, path, object.Version)
 			}
 		}
 
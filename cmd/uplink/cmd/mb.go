@@ -13,12 +13,19 @@ import (
 	"storj.io/storj/pkg/storj"
 )
 
+var (
+	versioningFlag *bool
+	defaultsFlag   *bool
+)
+
 func init() {
-	addCmd(&cobra.Command{
+	mbCmd := addCmd(&cobra.Command{
 		Use:   "mb",
 		Short: "Create a new bucket",
 		RunE:  makeBucket,
 	}, CLICmd)
+	versioningFlag = mbCmd.Flags().Bool("versioning", false, "if true, keep the previous versions of the objects when overwriting or deleting them")
+	defaultsFlag = mbCmd.Flags().Bool("defaults", false, "if true, store the segment size, redundancy and encryption settings of this uplink as the defaults for all uploads to the bucket")
 }
 
 func makeBucket(cmd *cobra.Command, args []string) error {
@@ -53,7 +60,14 @@ func makeBucket(cmd *cobra.Command, args []string) error {
 	if !storj.ErrBucketNotFound.Has(err) {
 		return err
 	}
-	_, err = metainfo.CreateBucket(ctx, dst.Bucket(), &storj.Bucket{PathCipher: storj.Cipher(cfg.Enc.PathType)})
+	bucket := storj.Bucket{PathCipher: storj.Cipher(cfg.Enc.PathType), Versioning: *versioningFlag}
+	if *defaultsFlag {
+		bucket.SegmentsSize = cfg.Client.SegmentSize
+		bucket.RedundancyScheme = cfg.GetRedundancyScheme()
+		bucket.EncryptionScheme = cfg.GetEncryptionScheme()
+	}
+
+	_, err = metainfo.CreateBucket(ctx, dst.Bucket(), &bucket)
 	if err != nil {
 		return err
 	}
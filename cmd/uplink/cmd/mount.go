@@ -191,9 +191,7 @@ func (sf *storjFS) Mkdir(name string, mode uint32, context *fuse.Context) fuse.S
 	zap.S().Debug("Mkdir: ", name)
 
 	createInfo := storj.CreateObject{
-		ContentType:      "application/directory",
-		RedundancyScheme: cfg.GetRedundancyScheme(),
-		EncryptionScheme: cfg.GetEncryptionScheme(),
+		ContentType: "application/directory",
 	}
 	object, err := sf.metainfo.CreateObject(sf.ctx, sf.bucket.Name, name+"/", &createInfo)
 	if err != nil {
@@ -414,12 +412,8 @@ func (f *storjFile) getWriter(off int64) (io.Writer, error) {
 		f.size = 0
 		f.closeWriter()
 
-		createInfo := storj.CreateObject{
-			RedundancyScheme: cfg.GetRedundancyScheme(),
-			EncryptionScheme: cfg.GetEncryptionScheme(),
-		}
 		var err error
-		f.mutableObject, err = f.metainfo.CreateObject(f.ctx, f.bucket.Name, f.name, &createInfo)
+		f.mutableObject, err = f.metainfo.CreateObject(f.ctx, f.bucket.Name, f.name, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -46,7 +46,13 @@ func deleteBucket(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	list, err := metainfo.ListObjects(ctx, dst.Bucket(), storj.ListOptions{Direction: storj.After, Recursive: true, Limit: 1})
+	bucket, err := metainfo.GetBucket(ctx, dst.Bucket())
+	if err != nil {
+		return convertError(err, dst)
+	}
+
+	// noncurrent versions and delete markers count as objects too
+	list, err := metainfo.ListObjects(ctx, dst.Bucket(), storj.ListOptions{Direction: storj.After, Recursive: true, Limit: 1, Versions: bucket.Versioning})
 	if err != nil {
 		return convertError(err, dst)
 	}
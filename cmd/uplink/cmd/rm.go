@@ -12,12 +12,17 @@ import (
 	"storj.io/storj/pkg/process"
 )
 
+var (
+	versionFlag *int64
+)
+
 func init() {
-	addCmd(&cobra.Command{
+	rmCmd := addCmd(&cobra.Command{
 		Use:   "rm",
 		Short: "Delete an object",
 		RunE:  deleteObject,
 	}, CLICmd)
+	versionFlag = rmCmd.Flags().Int64("version", -1, "version of the object to delete, the previous version becoming current when deleting the current one")
 }
 
 func deleteObject(cmd *cobra.Command, args []string) error {
@@ -41,6 +46,16 @@ func deleteObject(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if *versionFlag >= 0 {
+		err = metainfo.DeleteObjectVersion(ctx, dst.Bucket(), dst.Path(), uint32(*versionFlag))
+		if err != nil {
+			return convertError(err, dst)
+		}
+
+		fmt.Printf("Deleted version %d of %s\n", *versionFlag, dst)
+		return nil
+	}
+
 	err = metainfo.DeleteObject(ctx, dst.Bucket(), dst.Path())
 	if err != nil {
 		return convertError(err, dst)
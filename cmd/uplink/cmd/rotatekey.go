@@ -0,0 +1,86 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/pkg/miniogw"
+	"storj.io/storj/pkg/process"
+)
+
+var (
+	rotateNewKeyFile *string
+)
+
+func init() {
+	rotateKeyCmd := addCmd(&cobra.Command{
+		Use:   "rotate-key",
+		Short: "Re-encrypt the paths and metadata of all objects with a new root key",
+		Long: "Re-encrypt the paths and metadata of all objects with a new root key. " +
+			"The new key is read from --new-key-file, or from stdin when it is \"-\". " +
+			"No other uplink or gateway may write to the buckets until the rotation is done, " +
+			"objects they write meanwhile may be left on the old key.",
+		RunE: rotateKey,
+	}, CLICmd)
+	rotateNewKeyFile = rotateKeyCmd.Flags().String("new-key-file", "", "file containing the new root key, \"-\" for stdin")
+}
+
+// rotateKey is the function executed when rotateKeyCmd is called. Only the
+// pointers are re-encrypted, the pieces on the storage nodes aren't touched.
+func rotateKey(cmd *cobra.Command, args []string) error {
+	ctx := process.Ctx(cmd)
+
+	if *rotateNewKeyFile == "" {
+		return fmt.Errorf("No new key specified, use --new-key-file")
+	}
+	rotateNewKey, err := readKey(*rotateNewKeyFile)
+	if err != nil {
+		return err
+	}
+	if rotateNewKey == "" {
+		return fmt.Errorf("The new key is empty")
+	}
+	if rotateNewKey == cfg.Enc.Key {
+		return fmt.Errorf("The new key must differ from the current key")
+	}
+
+	metainfo, _, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	newKey := miniogw.EncryptionConfig{Key: rotateNewKey}.RootKey()
+
+	err = metainfo.RotateKey(ctx, newKey)
+	if err != nil {
+		fmt.Println("Rotation interrupted, run the same command again with the same keys to resume it")
+		return err
+	}
+
+	fmt.Println("Rotated all buckets and objects to the new key")
+	fmt.Println("Set enc.key to the new key to access them. Accesses shared with the old key no longer work.")
+	return nil
+}
+
+// readKey reads a key from the file at path, or from stdin if path is "-",
+// so that it doesn't show up in the shell history or the process list
+func readKey(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
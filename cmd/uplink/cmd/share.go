@@ -0,0 +1,77 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/miniogw"
+	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/storj"
+)
+
+var (
+	shareReadOnly *bool
+	shareExpires  *time.Duration
+)
+
+func init() {
+	shareCmd := addCmd(&cobra.Command{
+		Use:   "share",
+		Short: "Prints an access to a Storj bucket or path prefix, to be used with --client.access",
+		RunE:  shareMain,
+	}, CLICmd)
+	shareReadOnly = shareCmd.Flags().Bool("readonly", true, "if true, the access only allows downloading and listing")
+	shareExpires = shareCmd.Flags().Duration("expires", 0, "how long the access is valid, 0 for forever")
+}
+
+// shareMain is the function executed when shareCmd is called
+func shareMain(cmd *cobra.Command, args []string) (err error) {
+	if len(args) == 0 {
+		return fmt.Errorf("No bucket or prefix specified for sharing")
+	}
+
+	ctx := process.Ctx(cmd)
+
+	src, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	if src.IsLocal() {
+		return fmt.Errorf("No bucket specified, use format sj://bucket/")
+	}
+
+	if cfg.Client.Access != "" {
+		return fmt.Errorf("Shared accesses can't be shared further")
+	}
+
+	metainfo, _, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	bucket, err := metainfo.GetBucket(ctx, src.Bucket())
+	if err != nil {
+		return convertError(err, src)
+	}
+
+	var notAfter time.Time
+	if *shareExpires > 0 {
+		notAfter = time.Now().Add(*shareExpires)
+	}
+
+	access, err := miniogw.NewAccess(cfg.Client.PointerDBAddr, cfg.Client.APIKey, cfg.Enc.RootKey(),
+		storj.JoinPaths(src.Bucket(), src.Path()), bucket.PathCipher, *shareReadOnly, notAfter)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(access)
+	return nil
+}
@@ -0,0 +1,451 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/process"
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/stream"
+	"storj.io/storj/pkg/utils"
+)
+
+const (
+	// syncMtimeKey is the object metadata holding the modification time of
+	// the synced local file
+	syncMtimeKey = "sync-mtime"
+	// syncTempSuffix is appended to local files while they are downloaded,
+	// so that interrupted downloads are never taken for complete files
+	syncTempSuffix = ".uplink-sync"
+)
+
+var (
+	syncDelete      *bool
+	syncDryRun      *bool
+	syncInclude     *[]string
+	syncExclude     *[]string
+	syncParallelism *int
+)
+
+func init() {
+	syncCmd := addCmd(&cobra.Command{
+		Use:   "sync",
+		Short: "Synchronizes a local directory with a Storj prefix, or a Storj prefix with a local directory",
+		RunE:  syncMain,
+	}, CLICmd)
+	syncDelete = syncCmd.Flags().Bool("delete", false, "if true, delete the files or objects missing from the source")
+	syncDryRun = syncCmd.Flags().Bool("dry-run", false, "if true, only print what would be transferred or deleted")
+	syncInclude = syncCmd.Flags().StringArray("include", nil, "only sync the paths matching this glob, may be repeated")
+	syncExclude = syncCmd.Flags().StringArray("exclude", nil, "never sync the paths matching this glob, may be repeated")
+	syncParallelism = syncCmd.Flags().Int("parallelism", 4, "how many files to transfer at the same time")
+}
+
+// syncEntry is a file or an object compared by sync
+type syncEntry struct {
+	size  int64
+	mtime time.Time
+}
+
+// same checks whether the entries have the same size and modification time.
+// Modification times are compared to the second, as not all file systems
+// keep more.
+func (entry syncEntry) same(other syncEntry) bool {
+	return entry.size == other.size && entry.mtime.Unix() == other.mtime.Unix()
+}
+
+// syncMain is the function executed when syncCmd is called
+func syncMain(cmd *cobra.Command, args []string) (err error) {
+	if len(args) != 2 {
+		return fmt.Errorf("Source and destination must be specified")
+	}
+
+	ctx := process.Ctx(cmd)
+
+	src, err := fpath.New(args[0])
+	if err != nil {
+		return err
+	}
+
+	dst, err := fpath.New(args[1])
+	if err != nil {
+		return err
+	}
+
+	if src.IsLocal() == dst.IsLocal() {
+		return fmt.Errorf("One of the source and the destination must be a local directory and the other a Storj URL")
+	}
+
+	if *syncParallelism <= 0 {
+		return fmt.Errorf("Parallelism must be larger than 0")
+	}
+	for _, pattern := range append(append([]string{}, *syncInclude...), *syncExclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("Invalid glob %q: %v", pattern, err)
+		}
+	}
+
+	metainfo, streams, err := cfg.Metainfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	uploading := src.IsLocal()
+	local, remote := src, dst
+	if !uploading {
+		local, remote = dst, src
+	}
+
+	localEntries, stale, err := listLocal(local.Path(), uploading)
+	if err != nil {
+		return err
+	}
+	remoteEntries, err := listRemote(ctx, metainfo, remote)
+	if err != nil {
+		return convertError(err, remote)
+	}
+	if !uploading {
+		// object paths are chosen by whoever uploaded them, so they must not
+		// reach outside of the local directory
+		for name := range remoteEntries {
+			if !syncLocalName(local.Path(), name) {
+				fmt.Printf("Skipping %s: it would be written outside of %s\n", remote.Join(name), local)
+				delete(remoteEntries, name)
+			}
+		}
+	}
+
+	sources, targets := localEntries, remoteEntries
+	if !uploading {
+		sources, targets = remoteEntries, localEntries
+	}
+	transfers, deletes := planSync(sources, targets, *syncInclude, *syncExclude, *syncDelete)
+
+	var jobs []func() error
+	if !uploading {
+		// interrupted downloads leave their temporary files behind
+		for _, name := range stale {
+			name := name
+			if *syncDryRun {
+				fmt.Printf("Would delete %s\n", name)
+				continue
+			}
+			jobs = append(jobs, func() error {
+				return os.Remove(name)
+			})
+		}
+	}
+	for _, name := range transfers {
+		name := name
+		from, to := local.Join(name), remote.Join(name)
+		if !uploading {
+			from, to = remote.Join(name), local.Join(name)
+		}
+
+		if *syncDryRun {
+			fmt.Printf("Would copy %s to %s\n", from, to)
+			continue
+		}
+		jobs = append(jobs, func() error {
+			if uploading {
+				return syncUpload(ctx, metainfo, streams, from, to, sources[name])
+			}
+			return syncDownload(ctx, metainfo, streams, from, to, sources[name])
+		})
+	}
+	for _, name := range deletes {
+		name := name
+		target := remote.Join(name)
+		if !uploading {
+			target = local.Join(name)
+		}
+
+		if *syncDryRun {
+			fmt.Printf("Would delete %s\n", target)
+			continue
+		}
+		jobs = append(jobs, func() (err error) {
+			if uploading {
+				err = metainfo.DeleteObject(ctx, target.Bucket(), target.Path())
+			} else {
+				err = os.Remove(target.Path())
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Deleted %s\n", target)
+			return nil
+		})
+	}
+
+	err = runParallel(*syncParallelism, jobs)
+	if !uploading && !*syncDryRun {
+		removeEmptyDirs(local.Path(), deletes)
+	}
+	return err
+}
+
+// planSync returns the sorted names of the sources to transfer because they
+// are missing from targets or differ, and if deleting, of the targets to
+// delete because they are missing from sources
+func planSync(sources, targets map[string]syncEntry, include, exclude []string, deleting bool) (transfers, deletes []string) {
+	for name, entry := range sources {
+		if !syncMatches(name, include, exclude) {
+			continue
+		}
+		if target, ok := targets[name]; !ok || !entry.same(target) {
+			transfers = append(transfers, name)
+		}
+	}
+	if deleting {
+		for name := range targets {
+			if _, ok := sources[name]; !ok && syncMatches(name, include, exclude) {
+				deletes = append(deletes, name)
+			}
+		}
+	}
+	sort.Strings(transfers)
+	sort.Strings(deletes)
+	return transfers, deletes
+}
+
+// syncMatches checks whether name is included and not excluded. Globs match
+// either the whole relative path or its last element.
+func syncMatches(name string, include, exclude []string) bool {
+	matches := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+			if ok, _ := path.Match(pattern, path.Base(name)); ok {
+				return true
+			}
+		}
+		return false
+	}
+	if len(include) > 0 && !matches(include) {
+		return false
+	}
+	return !matches(exclude)
+}
+
+// syncLocalName checks whether the object name is a relative path which stays
+// under the local directory root when joined to it
+func syncLocalName(root, name string) bool {
+	if name == "" || path.IsAbs(name) || filepath.IsAbs(name) || filepath.VolumeName(name) != "" {
+		return false
+	}
+	elements := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '/' || r == filepath.Separator
+	})
+	for _, element := range elements {
+		if element == ".." {
+			return false
+		}
+	}
+
+	root = filepath.Clean(root)
+	rel, err := filepath.Rel(root, filepath.Join(root, filepath.FromSlash(name)))
+	if err != nil {
+		return false
+	}
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// listLocal returns the regular files under root by their slash separated
+// relative path, and the paths of the temporary files of interrupted
+// downloads. A missing root is empty, unless it must exist.
+func listLocal(root string, mustExist bool) (entries map[string]syncEntry, stale []string, err error) {
+	entries = make(map[string]syncEntry)
+	if _, err := os.Stat(root); os.IsNotExist(err) && !mustExist {
+		return entries, nil, nil
+	}
+
+	err = filepath.Walk(root, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		if strings.HasSuffix(name, syncTempSuffix) {
+			stale = append(stale, name)
+			return nil
+		}
+		rel, err := filepath.Rel(root, name)
+		if err != nil {
+			return err
+		}
+		entries[filepath.ToSlash(rel)] = syncEntry{size: info.Size(), mtime: info.ModTime()}
+		return nil
+	})
+	return entries, stale, err
+}
+
+// removeEmptyDirs removes the directories under root that held the deleted
+// files and are empty now, the deepest first
+func removeEmptyDirs(root string, deleted []string) {
+	dirs := make(map[string]bool)
+	for _, name := range deleted {
+		for dir := path.Dir(name); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			dirs[dir] = true
+		}
+	}
+
+	var sorted []string
+	for dir := range dirs {
+		sorted = append(sorted, dir)
+	}
+	// a directory sorts after its parent, so reversing removes children first
+	sort.Sort(sort.Reverse(sort.StringSlice(sorted)))
+	for _, dir := range sorted {
+		// directories that aren't empty are kept
+		_ = os.Remove(filepath.Join(root, filepath.FromSlash(dir)))
+	}
+}
+
+// listRemote returns the objects under remote by their path relative to it
+func listRemote(ctx context.Context, metainfo storj.Metainfo, remote fpath.FPath) (map[string]syncEntry, error) {
+	prefix := remote.Path()
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	entries := make(map[string]syncEntry)
+	options := storj.ListOptions{Prefix: prefix, Direction: storj.After, Recursive: true}
+	for {
+		list, err := metainfo.ListObjects(ctx, remote.Bucket(), options)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range list.Items {
+			entry := syncEntry{size: object.Size, mtime: object.Modified}
+			if mtime, err := time.Parse(time.RFC3339Nano, object.Metadata[syncMtimeKey]); err == nil {
+				entry.mtime = mtime
+			}
+			entries[object.Path] = entry
+		}
+		if !list.More || len(list.Items) == 0 {
+			return entries, nil
+		}
+		options.Cursor = list.Items[len(list.Items)-1].Path
+	}
+}
+
+// syncUpload uploads the local file src to dst, keeping its modification time
+// in the object metadata
+func syncUpload(ctx context.Context, metainfo storj.Metainfo, streams streams.Store, src, dst fpath.FPath, entry syncEntry) error {
+	file, err := os.Open(src.Path())
+	if err != nil {
+		return err
+	}
+	defer utils.LogClose(file)
+
+	createInfo := storj.CreateObject{
+		Metadata: map[string]string{syncMtimeKey: entry.mtime.Format(time.RFC3339Nano)},
+	}
+	obj, err := metainfo.CreateObject(ctx, dst.Bucket(), dst.Path(), &createInfo)
+	if err != nil {
+		return convertError(err, dst)
+	}
+
+	err = uploadStream(ctx, streams, obj, file)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created %s\n", dst)
+	return nil
+}
+
+// syncDownload downloads the object src to the local file dst. The object is
+// downloaded to a temporary file first, which is renamed to dst only when
+// complete and given the modification time of the object.
+func syncDownload(ctx context.Context, metainfo storj.Metainfo, streams streams.Store, src, dst fpath.FPath, entry syncEntry) (err error) {
+	readOnlyStream, err := metainfo.GetObjectStream(ctx, src.Bucket(), src.Path())
+	if err != nil {
+		return convertError(err, src)
+	}
+
+	download := stream.NewDownload(ctx, readOnlyStream, streams)
+	defer utils.LogClose(download)
+
+	if err := os.MkdirAll(filepath.Dir(dst.Path()), 0755); err != nil {
+		return err
+	}
+
+	temp := dst.Path() + syncTempSuffix
+	file, err := os.Create(temp)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(temp)
+		}
+	}()
+
+	_, err = io.Copy(file, download)
+	err = errs.Combine(err, file.Close())
+	if err != nil {
+		return err
+	}
+
+	if err := os.Chtimes(temp, entry.mtime, entry.mtime); err != nil {
+		return err
+	}
+	if err := os.Rename(temp, dst.Path()); err != nil {
+		return err
+	}
+
+	fmt.Printf("Downloaded %s to %s\n", src, dst)
+	return nil
+}
+
+// runParallel runs jobs with up to parallelism of them at the same time,
+// returning the errors of all the failed jobs
+func runParallel(parallelism int, jobs []func() error) error {
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errlist errs.Group
+	)
+
+	queue := make(chan func() error)
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				if err := job(); err != nil {
+					mu.Lock()
+					errlist.Add(err)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for _, job := range jobs {
+		queue <- job
+	}
+	close(queue)
+	wg.Wait()
+
+	return errlist.Err()
+}
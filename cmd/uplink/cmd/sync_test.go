@@ -0,0 +1,234 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/fpath"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestSyncMatches(t *testing.T) {
+	for i, tt := range []struct {
+		name     string
+		include  []string
+		exclude  []string
+		expected bool
+	}{
+		{"a/b.txt", nil, nil, true},
+		{"a/b.txt", []string{"*.txt"}, nil, true},
+		{"a/b.txt", []string{"a/*"}, nil, true},
+		{"a/b.txt", []string{"*.jpg"}, nil, false},
+		{"a/b.txt", nil, []string{"*.txt"}, false},
+		{"a/b.txt", []string{"*.txt"}, []string{"a/*"}, false},
+		{"a/b/c.txt", []string{"a/*"}, nil, false},
+	} {
+		assert.Equal(t, tt.expected, syncMatches(tt.name, tt.include, tt.exclude), i)
+	}
+}
+
+func TestPlanSync(t *testing.T) {
+	now := time.Now()
+	entry := func(size int64, mtime time.Time) syncEntry { return syncEntry{size: size, mtime: mtime} }
+
+	sources := map[string]syncEntry{
+		"same":      entry(1, now),
+		"subsecond": entry(1, now.Truncate(time.Second)),
+		"resized":   entry(2, now),
+		"touched":   entry(1, now.Add(time.Hour)),
+		"new":       entry(1, now),
+		"new.tmp":   entry(1, now),
+	}
+	targets := map[string]syncEntry{
+		"same":      entry(1, now),
+		"subsecond": entry(1, now.Truncate(time.Second).Add(time.Millisecond)),
+		"resized":   entry(1, now),
+		"touched":   entry(1, now),
+		"old":       entry(1, now),
+		"old.tmp":   entry(1, now),
+	}
+
+	for i, tt := range []struct {
+		exclude   []string
+		deleting  bool
+		transfers []string
+		deletes   []string
+	}{
+		{nil, false, []string{"new", "new.tmp", "resized", "touched"}, nil},
+		{nil, true, []string{"new", "new.tmp", "resized", "touched"}, []string{"old", "old.tmp"}},
+		{[]string{"*.tmp"}, true, []string{"new", "resized", "touched"}, []string{"old"}},
+	} {
+		transfers, deletes := planSync(sources, targets, nil, tt.exclude, tt.deleting)
+		assert.Equal(t, tt.transfers, transfers, i)
+		assert.Equal(t, tt.deletes, deletes, i)
+	}
+}
+
+func TestSyncLocalName(t *testing.T) {
+	root := filepath.Join("tmp", "sync")
+	for i, tt := range []struct {
+		name     string
+		expected bool
+	}{
+		{"a.txt", true},
+		{"sub/b.txt", true},
+		{"sub/../b.txt", false},
+		{"../../.bashrc", false},
+		{"..", false},
+		{"/etc/passwd", false},
+		{"", false},
+		{".", false},
+		{"sub/..", false},
+	} {
+		assert.Equal(t, tt.expected, syncLocalName(root, tt.name), i)
+	}
+}
+
+func TestListLocal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "uplink-sync")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	for _, name := range []string{"a.txt", "sub/b.txt", "sub/c.txt" + syncTempSuffix} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(name), 0644))
+	}
+
+	entries, stale, err := listLocal(dir, true)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.EqualValues(t, len("sub/b.txt"), entries["sub/b.txt"].size)
+	assert.Equal(t, []string{filepath.Join(dir, "sub", "c.txt"+syncTempSuffix)}, stale)
+
+	// a missing download destination is empty and isn't created
+	missing := filepath.Join(dir, "missing")
+	entries, stale, err = listLocal(missing, false)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.Empty(t, stale)
+	_, err = os.Stat(missing)
+	assert.True(t, os.IsNotExist(err))
+
+	// a missing upload source is an error
+	_, _, err = listLocal(missing, true)
+	assert.Error(t, err)
+}
+
+func TestRemoveEmptyDirs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "uplink-sync")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "a", "b", "c"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "d"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "d", "kept"), nil, 0644))
+
+	removeEmptyDirs(dir, []string{"a/b/c/deleted", "d/deleted"})
+
+	_, err = os.Stat(filepath.Join(dir, "a"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "d", "kept"))
+	assert.NoError(t, err)
+	_, err = os.Stat(dir)
+	assert.NoError(t, err)
+}
+
+// listMetainfo lists objects from a sorted list, two per page
+type listMetainfo struct {
+	storj.Metainfo
+	objects []storj.Object
+}
+
+func (m *listMetainfo) ListObjects(ctx context.Context, bucket string, options storj.ListOptions) (storj.ObjectList, error) {
+	list := storj.ObjectList{Bucket: bucket, Prefix: options.Prefix}
+	for _, object := range m.objects {
+		if !strings.HasPrefix(object.Path, options.Prefix) {
+			continue
+		}
+		object.Path = strings.TrimPrefix(object.Path, options.Prefix)
+		if object.Path <= options.Cursor {
+			continue
+		}
+		if len(list.Items) == 2 {
+			list.More = true
+			break
+		}
+		list.Items = append(list.Items, object)
+	}
+	return list, nil
+}
+
+func TestListRemote(t *testing.T) {
+	modified := time.Now().Add(-time.Hour)
+	synced := time.Now().Add(-2 * time.Hour).Round(0)
+
+	metainfo := &listMetainfo{objects: []storj.Object{
+		{Path: "dir/a", Size: 1, Modified: modified},
+		{Path: "dir/b", Size: 2, Modified: modified, Metadata: map[string]string{syncMtimeKey: synced.Format(time.RFC3339Nano)}},
+		{Path: "dir/sub/c", Size: 3, Modified: modified},
+		{Path: "other/d", Size: 4, Modified: modified},
+	}}
+	sort.Slice(metainfo.objects, func(i, k int) bool { return metainfo.objects[i].Path < metainfo.objects[k].Path })
+
+	remote, err := fpath.New("sj://bucket/dir")
+	require.NoError(t, err)
+
+	entries, err := listRemote(context.Background(), metainfo, remote)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+	for name, expected := range map[string]syncEntry{
+		"a":     {size: 1, mtime: modified},
+		"b":     {size: 2, mtime: synced},
+		"sub/c": {size: 3, mtime: modified},
+	} {
+		assert.Equal(t, expected.size, entries[name].size, name)
+		assert.True(t, expected.mtime.Equal(entries[name].mtime), name)
+	}
+}
+
+func TestRunParallel(t *testing.T) {
+	var running, maxRunning, done int32
+	var jobs []func() error
+	for i := 0; i < 10; i++ {
+		i := i
+		jobs = append(jobs, func() error {
+			current := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(&done, 1)
+			if i%5 == 0 {
+				return errors.New("failed")
+			}
+			return nil
+		})
+	}
+
+	err := runParallel(3, jobs)
+	assert.Error(t, err)
+	assert.Equal(t, 2, strings.Count(err.Error(), "failed"))
+	assert.EqualValues(t, 10, done)
+	assert.True(t, maxRunning <= 3)
+
+	assert.NoError(t, runParallel(3, nil))
+}
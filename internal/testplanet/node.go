@@ -180,7 +180,9 @@ func (node *Node) initOverlay(planet *Planet) error {
 	node.Kademlia = kad
 	node.StatDB = node.Database.StatDB()
 	node.Overlay = overlay.NewCache(teststore.New(), node.StatDB)
-	node.Discovery = discovery.NewDiscovery(node.Log.Named("discovery"), node.Overlay, node.Kademlia, node.StatDB)
+	node.Discovery = discovery.NewDiscovery(node.Log.Named("discovery"), node.Overlay, node.Kademlia, node.StatDB, statdb.Config{}, discovery.Config{
+		CrawlConcurrency: 5,
+	})
 
 	return nil
 }
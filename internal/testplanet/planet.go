@@ -26,6 +26,7 @@ import (
 	"storj.io/storj/pkg/piecestore/psserver/psdb"
 	"storj.io/storj/pkg/pointerdb"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/statdb"
 	"storj.io/storj/pkg/utils"
 	"storj.io/storj/storage/teststore"
 )
@@ -43,6 +44,7 @@ type Planet struct {
 	Satellites   []*Node
 	StorageNodes []*Node
 	Uplinks      []*Node
+	Adversaries  []*Node
 
 	identities *Identities
 }
@@ -95,7 +97,7 @@ func NewWithLogger(log *zap.Logger, satelliteCount, storageNodeCount, uplinkCoun
 	}
 
 	for _, n := range planet.nodes {
-		server := node.NewServer(n.Log.Named("node"), n.Kademlia)
+		server := node.NewServer(n.Log.Named("node"), n.Kademlia, n.Identity)
 		pb.RegisterNodesServer(n.Provider.GRPC(), server)
 		// TODO: shutdown
 	}
@@ -120,14 +122,7 @@ func NewWithLogger(log *zap.Logger, satelliteCount, storageNodeCount, uplinkCoun
 			}
 		}(node)
 
-		ns := &pb.NodeStats{
-			UptimeCount:       0,
-			UptimeRatio:       0,
-			AuditSuccessRatio: 0,
-			AuditCount:        0,
-		}
-
-		overlayServer := overlay.NewServer(node.Log.Named("overlay"), node.Overlay, ns)
+		overlayServer := overlay.NewServer(node.Log.Named("overlay"), node.Overlay, overlay.NodeSelectionConfig{LocalNetwork: true}, statdb.Config{})
 		pb.RegisterOverlayServer(node.Provider.GRPC(), overlayServer)
 
 		node.Dependencies = append(node.Dependencies,
@@ -190,7 +185,9 @@ func NewWithLogger(log *zap.Logger, satelliteCount, storageNodeCount, uplinkCoun
 func (planet *Planet) Start(ctx context.Context) {
 	for _, node := range planet.nodes {
 		go func(node *Node) {
-			node.Kademlia.StartRefresh(ctx)
+			if node.Kademlia != nil {
+				node.Kademlia.StartRefresh(ctx)
+			}
 			err := node.Provider.Run(ctx)
 			if err == grpc.ErrServerStopped {
 				err = nil
@@ -204,6 +201,23 @@ func (planet *Planet) Start(ctx context.Context) {
 	planet.started = true
 }
 
+// NewAdversary adds a storage node whose kademlia endpoint is served by server
+// instead of a routing table, for testing how the network copes with malicious
+// nodes. Other nodes only learn about it when they contact it. It must be
+// called before Start.
+func (planet *Planet) NewAdversary(server pb.NodesServer) (*Node, error) {
+	if planet.started {
+		return nil, errors.New("adversaries must be added before Start")
+	}
+	node, err := planet.newNode("adversary"+strconv.Itoa(len(planet.Adversaries)), pb.NodeType_STORAGE)
+	if err != nil {
+		return nil, err
+	}
+	pb.RegisterNodesServer(node.Provider.GRPC(), server)
+	planet.Adversaries = append(planet.Adversaries, node)
+	return node, nil
+}
+
 // Size returns number of nodes in the network
 func (planet *Planet) Size() int { return len(planet.nodes) }
 
@@ -0,0 +1,80 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zeebo/errs"
+)
+
+// Build is the version of this build. Release builds set it with
+// -ldflags "-X storj.io/storj/internal/version.Build=v1.2.3"
+var Build = "v0.0.0"
+
+// Error is the errs class for version errors
+var Error = errs.Class("version error")
+
+// SemVer is a semantic version
+type SemVer struct {
+	Major, Minor, Patch int64
+}
+
+// Parse parses a version of the form v1.2.3, the leading v and
+// anything after a - or + are optional
+func Parse(s string) (SemVer, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(trimmed, "-+"); i >= 0 {
+		trimmed = trimmed[:i]
+	}
+
+	parts := strings.Split(trimmed, ".")
+	if len(parts) != 3 {
+		return SemVer{}, Error.New("invalid version %q", s)
+	}
+
+	var numbers [3]int64
+	for i, part := range parts {
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || n < 0 {
+			return SemVer{}, Error.New("invalid version %q", s)
+		}
+		numbers[i] = n
+	}
+
+	return SemVer{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// Compare returns -1, 0 or 1 depending on whether v is older, equal or newer than other
+func (v SemVer) Compare(other SemVer) int {
+	switch {
+	case v.Major != other.Major:
+		return compare(v.Major, other.Major)
+	case v.Minor != other.Minor:
+		return compare(v.Minor, other.Minor)
+	default:
+		return compare(v.Patch, other.Patch)
+	}
+}
+
+// Less returns whether v is older than other
+func (v SemVer) Less(other SemVer) bool { return v.Compare(other) < 0 }
+
+// String returns the version in the v1.2.3 form
+func (v SemVer) String() string {
+	return fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+func compare(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
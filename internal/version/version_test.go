@@ -0,0 +1,55 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package version_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/internal/version"
+)
+
+func TestParse(t *testing.T) {
+	for _, tt := range []struct {
+		in       string
+		expected version.SemVer
+	}{
+		{"v1.2.3", version.SemVer{Major: 1, Minor: 2, Patch: 3}},
+		{"0.10.0", version.SemVer{Major: 0, Minor: 10, Patch: 0}},
+		{"v2.0.1-rc.1", version.SemVer{Major: 2, Minor: 0, Patch: 1}},
+		{"v2.0.1+build", version.SemVer{Major: 2, Minor: 0, Patch: 1}},
+	} {
+		v, err := version.Parse(tt.in)
+		if assert.NoError(t, err, tt.in) {
+			assert.Equal(t, tt.expected, v, tt.in)
+		}
+	}
+
+	for _, in := range []string{"", "v1", "v1.2", "v1.2.x", "v1.2.3.4", "v1.-2.3"} {
+		_, err := version.Parse(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	for _, tt := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"v1.2.3", "v1.2.3", 0},
+		{"v1.2.3", "v1.2.4", -1},
+		{"v1.3.0", "v1.2.9", 1},
+		{"v0.9.9", "v1.0.0", -1},
+		{"v1.10.0", "v1.9.0", 1},
+	} {
+		a, err := version.Parse(tt.a)
+		assert.NoError(t, err)
+		b, err := version.Parse(tt.b)
+		assert.NoError(t, err)
+
+		assert.Equal(t, tt.expected, a.Compare(b), tt.a+" vs "+tt.b)
+		assert.Equal(t, tt.expected < 0, a.Less(b))
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/storj"
+)
+
+var (
+	// ContainError is the containment errs class
+	ContainError = errs.Class("containment error")
+
+	// ErrContainedNotFound is the errs class for when a pending audit isn't found
+	ErrContainedNotFound = errs.Class("pending audit not found")
+)
+
+// PendingAudit contains the info needed to re-ask a contained node for a share
+type PendingAudit struct {
+	NodeID            storj.NodeID
+	PieceID           string
+	PieceNum          int
+	PieceSize         int64
+	StripeIndex       int
+	ShareSize         int
+	ExpectedShareHash []byte
+	ReverifyCount     int
+	Path              storj.Path
+	CreatedAt         time.Time
+}
+
+// Containment stores pending audits for nodes that didn't respond to an audit
+type Containment interface {
+	// Get returns the pending audit for a contained node
+	Get(ctx context.Context, nodeID storj.NodeID) (*PendingAudit, error)
+	// IncrementPending creates a pending audit or increments the reverify count of an existing one
+	IncrementPending(ctx context.Context, pendingAudit *PendingAudit) error
+	// Delete removes a node from containment
+	Delete(ctx context.Context, nodeID storj.NodeID) (bool, error)
+	// List returns all pending audits
+	List(ctx context.Context) ([]*PendingAudit, error)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/audit"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestContainment(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testContainment(ctx, t, db.Containment())
+	})
+}
+
+func testContainment(ctx context.Context, t *testing.T, containment audit.Containment) {
+	nodeID := teststorj.NodeIDFromString("contained")
+	pending := &audit.PendingAudit{
+		NodeID:            nodeID,
+		PieceID:           "pieceid",
+		PieceNum:          3,
+		PieceSize:         1024,
+		StripeIndex:       5,
+		ShareSize:         256,
+		ExpectedShareHash: []byte("expectedsharehash"),
+		Path:              "path/to/segment",
+	}
+
+	{ // Get missing entry
+		_, err := containment.Get(ctx, nodeID)
+		assert.True(t, audit.ErrContainedNotFound.Has(err))
+	}
+
+	{ // New entry
+		err := containment.IncrementPending(ctx, pending)
+		assert.NoError(t, err)
+
+		got, err := containment.Get(ctx, nodeID)
+		assert.NoError(t, err)
+		pending.CreatedAt = got.CreatedAt
+		assert.Equal(t, pending, got)
+	}
+
+	{ // Increment existing entry
+		err := containment.IncrementPending(ctx, pending)
+		assert.NoError(t, err)
+
+		got, err := containment.Get(ctx, nodeID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, got.ReverifyCount)
+	}
+
+	{ // List entries
+		list, err := containment.List(ctx)
+		assert.NoError(t, err)
+		if assert.Len(t, list, 1) {
+			assert.Equal(t, nodeID, list[0].NodeID)
+		}
+	}
+
+	{ // Delete entry
+		deleted, err := containment.Delete(ctx, nodeID)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+
+		deleted, err = containment.Delete(ctx, nodeID)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+
+		_, err = containment.Get(ctx, nodeID)
+		assert.True(t, audit.ErrContainedNotFound.Has(err))
+	}
+}
@@ -16,11 +16,13 @@ import (
 	"storj.io/storj/pkg/pointerdb/pdbclient"
 	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 )
 
 // Stripe keeps track of a stripe's index and its parent segment
 type Stripe struct {
 	Index         int
+	Path          storj.Path
 	Segment       *pb.Pointer
 	PBA           *pb.PayerBandwidthAllocation
 	Authorization *pb.SignedMessage
@@ -31,6 +33,8 @@ type Cursor struct {
 	pointers pdbclient.Client
 	lastPath storj.Path
 	mutex    sync.Mutex
+
+	index *SegmentIndex
 }
 
 // NewCursor creates a Cursor which iterates over pointer db
@@ -38,11 +42,22 @@ func NewCursor(pointers pdbclient.Client) *Cursor {
 	return &Cursor{pointers: pointers}
 }
 
+// NewWeightedCursor creates a Cursor which picks a node to audit first using the
+// segment index, and then one of the segments it holds. The index is built
+// separately, see SegmentIndex.Run.
+func NewWeightedCursor(pointers pdbclient.Client, index *SegmentIndex) *Cursor {
+	return &Cursor{pointers: pointers, index: index}
+}
+
 // NextStripe returns a random stripe to be audited
 func (cursor *Cursor) NextStripe(ctx context.Context) (stripe *Stripe, err error) {
 	cursor.mutex.Lock()
 	defer cursor.mutex.Unlock()
 
+	if cursor.index != nil {
+		return cursor.nextWeightedStripe(ctx)
+	}
+
 	var pointerItems []pdbclient.ListItem
 	var path storj.Path
 	var more bool
@@ -74,6 +89,30 @@ func (cursor *Cursor) NextStripe(ctx context.Context) (stripe *Stripe, err error
 		cursor.lastPath = pointerItems[len(pointerItems)-1].Path
 	}
 
+	return cursor.getStripe(ctx, path)
+}
+
+// nextWeightedStripe picks a node weighted by its audit history and returns a random stripe
+// of a random segment it holds
+func (cursor *Cursor) nextWeightedStripe(ctx context.Context) (stripe *Stripe, err error) {
+	_, path, err := cursor.index.RandomSegment(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return nil, nil
+	}
+
+	stripe, err = cursor.getStripe(ctx, path)
+	if storage.ErrKeyNotFound.Has(err) {
+		// the segment was deleted since the index was built
+		return nil, nil
+	}
+	return stripe, err
+}
+
+// getStripe returns a random stripe of the segment at path
+func (cursor *Cursor) getStripe(ctx context.Context, path storj.Path) (stripe *Stripe, err error) {
 	// get pointer info
 	pointer, _, pba, err := cursor.pointers.Get(ctx, path)
 	if err != nil {
@@ -100,7 +139,7 @@ func (cursor *Cursor) NextStripe(ctx context.Context) (stripe *Stripe, err error
 	}
 
 	authorization := cursor.pointers.SignedMessage()
-	return &Stripe{Index: index, Segment: pointer, PBA: pba, Authorization: authorization}, nil
+	return &Stripe{Index: index, Path: path, Segment: pointer, PBA: pba, Authorization: authorization}, nil
 }
 
 func makeErasureScheme(rs *pb.RedundancyScheme) (eestream.ErasureScheme, error) {
@@ -7,7 +7,9 @@ import (
 	"context"
 
 	"github.com/zeebo/errs"
+	"go.uber.org/zap"
 
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/statdb"
 	"storj.io/storj/pkg/storj"
 )
@@ -19,6 +21,8 @@ type reporter interface {
 // Reporter records audit reports in statdb and implements the reporter interface
 type Reporter struct {
 	statdb     statdb.DB
+	cache      *overlay.Cache
+	states     statdb.Config
 	maxRetries int
 }
 
@@ -37,7 +41,12 @@ func NewReporter(ctx context.Context, statDBPort string, maxRetries int, apiKey
 	if !ok {
 		return nil, errs.New("unable to get master db instance")
 	}
-	return &Reporter{statdb: sdb.StatDB(), maxRetries: maxRetries}, nil
+	return &Reporter{
+		statdb:     sdb.StatDB(),
+		cache:      overlay.LoadFromContext(ctx),
+		states:     statdb.LoadConfigFromContext(ctx),
+		maxRetries: maxRetries,
+	}, nil
 }
 
 // RecordAudits saves failed audit details to statdb
@@ -92,14 +101,16 @@ func (reporter *Reporter) recordAuditFailStatus(ctx context.Context, failedAudit
 	failedIDs := storj.NodeIDList{}
 
 	for _, nodeID := range failedAuditNodeIDs {
-		_, err := reporter.statdb.Update(ctx, &statdb.UpdateRequest{
+		stats, err := reporter.statdb.Update(ctx, &statdb.UpdateRequest{
 			NodeID:       nodeID,
 			IsUp:         true,
 			AuditSuccess: false,
 		})
 		if err != nil {
 			failedIDs = append(failedIDs, nodeID)
+			continue
 		}
+		reporter.updateState(ctx, stats)
 	}
 	if len(failedIDs) > 0 {
 		return failedIDs, Error.New("failed to record some audit fail statuses in statdb")
@@ -113,10 +124,12 @@ func (reporter *Reporter) recordOfflineStatus(ctx context.Context, offlineNodeID
 	failedIDs := storj.NodeIDList{}
 
 	for _, nodeID := range offlineNodeIDs {
-		_, err := reporter.statdb.UpdateUptime(ctx, nodeID, false)
+		stats, err := reporter.statdb.UpdateUptime(ctx, nodeID, false)
 		if err != nil {
 			failedIDs = append(failedIDs, nodeID)
+			continue
 		}
+		reporter.updateState(ctx, stats)
 	}
 	if len(failedIDs) > 0 {
 		return failedIDs, Error.New("failed to record some audit offline statuses in statdb")
@@ -129,17 +142,32 @@ func (reporter *Reporter) recordAuditSuccessStatus(ctx context.Context, successN
 	failedIDs := storj.NodeIDList{}
 
 	for _, nodeID := range successNodeIDs {
-		_, err := reporter.statdb.Update(ctx, &statdb.UpdateRequest{
+		stats, err := reporter.statdb.Update(ctx, &statdb.UpdateRequest{
 			NodeID:       nodeID,
 			IsUp:         true,
 			AuditSuccess: true,
 		})
 		if err != nil {
 			failedIDs = append(failedIDs, nodeID)
+			continue
 		}
+		reporter.updateState(ctx, stats)
 	}
 	if len(failedIDs) > 0 {
 		return failedIDs, Error.New("failed to record some audit success statuses in statdb")
 	}
 	return nil, nil
 }
+
+// updateState moves a node to the state its new stats call for, through the overlay cache
+// when there is one so that node selection sees the new state. Failures are only logged,
+// retrying would record the audit twice.
+func (reporter *Reporter) updateState(ctx context.Context, stats *statdb.NodeStats) {
+	var updater statdb.StateUpdater = reporter.statdb
+	if reporter.cache != nil {
+		updater = reporter.cache
+	}
+	if _, err := reporter.states.Apply(ctx, updater, stats); err != nil {
+		zap.L().Error("failed to update node state", zap.String("Node ID", stats.NodeID.String()), zap.Error(err))
+	}
+}
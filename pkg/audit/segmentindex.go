@@ -0,0 +1,229 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	mathrand "math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+// unvettedBoost is how much more likely a node that hasn't reached the vetting audit count is to be audited
+const unvettedBoost = 2
+
+// maxNodeSegments is the most segments the index keeps per node
+const maxNodeSegments = 1000
+
+// pointerIterator iterates over the pointers in pointerdb
+type pointerIterator interface {
+	Iterate(ctx context.Context, req *pb.IterateRequest, f func(it storage.Iterator) error) error
+}
+
+// SegmentIndex is a reverse index from storage nodes to the segments they hold pieces of.
+// It is used to pick a node to audit first, and then one of its segments. Each node keeps
+// a uniform sample of at most maxNodeSegments of its segments, so the index grows with the
+// number of nodes rather than the number of segments.
+type SegmentIndex struct {
+	pointers          pointerIterator
+	statdb            statdb.DB
+	vettingAuditCount int64
+	maxSegments       int
+
+	mu    sync.Mutex
+	built *builtIndex
+}
+
+// builtIndex is an immutable snapshot of the index, replaced as a whole on every build
+type builtIndex struct {
+	builtAt  time.Time
+	nodes    storj.NodeIDList
+	weights  []float64
+	total    float64
+	segments map[storj.NodeID][]storj.Path
+}
+
+// NewSegmentIndex creates an empty SegmentIndex, call Build or Run to populate it
+func NewSegmentIndex(pointers pointerIterator, sdb statdb.DB, vettingAuditCount int64) *SegmentIndex {
+	return &SegmentIndex{
+		pointers:          pointers,
+		statdb:            sdb,
+		vettingAuditCount: vettingAuditCount,
+		maxSegments:       maxNodeSegments,
+		built:             &builtIndex{},
+	}
+}
+
+// Run rebuilds the index every interval until ctx is canceled. Readers keep using the
+// previous index while a new one is built.
+func (index *SegmentIndex) Run(ctx context.Context, interval time.Duration) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := index.Build(ctx); err != nil {
+			zap.L().Error("failed to build segment index", zap.Error(err))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Build rebuilds the index by iterating over every remote segment in pointerdb and swaps
+// it in once it is complete. The previous index is kept if building fails.
+func (index *SegmentIndex) Build(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	segments := make(map[storj.NodeID][]storj.Path)
+	seen := make(map[storj.NodeID]int)
+	err = index.pointers.Iterate(ctx, &pb.IterateRequest{Recurse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				pointer := &pb.Pointer{}
+				if err := proto.Unmarshal(item.Value, pointer); err != nil {
+					return Error.New("error unmarshalling pointer %s", err)
+				}
+
+				if pointer.GetType() != pb.Pointer_REMOTE || pointer.GetSegmentSize() == 0 {
+					continue
+				}
+
+				path := storj.Path(item.Key.String())
+				for _, piece := range pointer.GetRemote().GetRemotePieces() {
+					nodeID := piece.NodeId
+					seen[nodeID]++
+
+					// reservoir sampling keeps every segment of the node with the same probability
+					if len(segments[nodeID]) < index.maxSegments {
+						segments[nodeID] = append(segments[nodeID], path)
+					} else if i := mathrand.Intn(seen[nodeID]); i < index.maxSegments {
+						segments[nodeID][i] = path
+					}
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	candidates := make(storj.NodeIDList, 0, len(segments))
+	for nodeID := range segments {
+		candidates = append(candidates, nodeID)
+	}
+	sort.Sort(candidates)
+
+	built := &builtIndex{segments: segments}
+	for _, nodeID := range candidates {
+		weight, err := index.weight(ctx, nodeID)
+		if err != nil {
+			return err
+		}
+		if weight <= 0 {
+			delete(segments, nodeID)
+			continue
+		}
+		built.total += weight
+		built.nodes = append(built.nodes, nodeID)
+		built.weights = append(built.weights, built.total)
+	}
+	built.builtAt = time.Now()
+
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	index.built = built
+	return nil
+}
+
+// weight returns how likely a node is to be picked for an audit relative to other nodes.
+// Nodes with few audits get audited more often and unvetted nodes are boosted further.
+// Disqualified nodes aren't audited at all.
+func (index *SegmentIndex) weight(ctx context.Context, nodeID storj.NodeID) (float64, error) {
+	var auditCount int64
+	stats, err := index.statdb.Get(ctx, nodeID)
+	switch {
+	case statdb.ErrNodeNotFound.Has(err):
+		// nodes without stats haven't been audited yet
+	case err != nil:
+		return 0, err
+	case stats.State == statdb.StateDisqualified:
+		return 0, nil
+	default:
+		auditCount = stats.AuditCount
+	}
+
+	weight := 1 / float64(1+auditCount)
+	if auditCount < index.vettingAuditCount {
+		weight *= unvettedBoost
+	}
+	return weight, nil
+}
+
+// current returns the most recently built index
+func (index *SegmentIndex) current() *builtIndex {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	return index.built
+}
+
+// BuiltAt returns when the index was last built
+func (index *SegmentIndex) BuiltAt() time.Time {
+	return index.current().builtAt
+}
+
+// Segments returns the paths of the indexed segments a node holds pieces of
+func (index *SegmentIndex) Segments(nodeID storj.NodeID) []storj.Path {
+	return append([]storj.Path(nil), index.current().segments[nodeID]...)
+}
+
+// RandomSegment picks a node weighted towards unvetted nodes and nodes with few audits,
+// and then a random segment held by that node. It returns an empty path if the index is empty.
+func (index *SegmentIndex) RandomSegment(ctx context.Context) (nodeID storj.NodeID, path storj.Path, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	built := index.current()
+	if len(built.nodes) == 0 || built.total <= 0 {
+		return nodeID, "", nil
+	}
+
+	// pick a point in [0, total) with 53 bits of precision
+	const precision = 1 << 53
+	n, err := rand.Int(rand.Reader, big.NewInt(precision))
+	if err != nil {
+		return nodeID, "", err
+	}
+	point := float64(n.Int64()) / precision * built.total
+
+	i := sort.SearchFloat64s(built.weights, point)
+	if i >= len(built.nodes) {
+		i = len(built.nodes) - 1
+	}
+	nodeID = built.nodes[i]
+
+	segments := built.segments[nodeID]
+	n, err = rand.Int(rand.Reader, big.NewInt(int64(len(segments))))
+	if err != nil {
+		return nodeID, "", err
+	}
+	return nodeID, segments[n.Int64()], nil
+}
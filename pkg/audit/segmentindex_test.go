@@ -0,0 +1,130 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage/teststore"
+)
+
+// mockStatDB returns stats for known nodes and a not found error for unknown ones,
+// or err for every node when it is set
+type mockStatDB struct {
+	statdb.DB
+	stats map[storj.NodeID]*statdb.NodeStats
+	err   error
+}
+
+func (m *mockStatDB) Get(ctx context.Context, nodeID storj.NodeID) (*statdb.NodeStats, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	stats, ok := m.stats[nodeID]
+	if !ok {
+		return nil, statdb.ErrNodeNotFound.New("%s", nodeID)
+	}
+	return stats, nil
+}
+
+func TestSegmentIndex(t *testing.T) {
+	ctx := auth.WithAPIKey(context.Background(), nil)
+
+	ca, err := testidentity.NewTestCA(ctx)
+	require.NoError(t, err)
+	identity, err := ca.NewIdentity()
+	require.NoError(t, err)
+
+	pdb := pointerdb.NewServer(teststore.New(), overlay.NewCache(teststore.New(), nil), zap.NewNop(), pointerdb.Config{MaxInlineSegmentSize: 8000}, identity)
+
+	unvetted := teststorj.NodeIDFromString("unvetted")
+	vetted := teststorj.NodeIDFromString("vetted")
+	disqualified := teststorj.NodeIDFromString("disqualified")
+
+	segments := map[storj.Path]storj.NodeIDList{
+		"a/1": {unvetted, vetted},
+		"a/2": {unvetted, disqualified},
+		"b/1": {vetted},
+		"b/2": {vetted},
+	}
+	for path, nodeIDs := range segments {
+		req := makePutRequest(path)
+		req.Pointer.Remote.RemotePieces = nil
+		for i, nodeID := range nodeIDs {
+			req.Pointer.Remote.RemotePieces = append(req.Pointer.Remote.RemotePieces, &pb.RemotePiece{
+				PieceNum: int32(i),
+				NodeId:   nodeID,
+			})
+		}
+		_, err := pdb.Put(ctx, &req)
+		require.NoError(t, err)
+	}
+
+	sdb := &mockStatDB{stats: map[storj.NodeID]*statdb.NodeStats{
+		vetted:       {NodeID: vetted, AuditCount: 1000},
+		disqualified: {NodeID: disqualified, State: statdb.StateDisqualified},
+	}}
+
+	index := NewSegmentIndex(pdb, sdb, 100)
+	assert.True(t, index.BuiltAt().IsZero())
+	require.NoError(t, index.Build(ctx))
+	assert.False(t, index.BuiltAt().IsZero())
+
+	assert.ElementsMatch(t, []storj.Path{"a/1", "a/2"}, index.Segments(unvetted))
+	assert.ElementsMatch(t, []storj.Path{"a/1", "b/1", "b/2"}, index.Segments(vetted))
+	assert.Empty(t, index.Segments(teststorj.NodeIDFromString("unknown")))
+
+	// the unvetted node without audits is weighted thousands of times higher
+	picked := 0
+	for i := 0; i < 100; i++ {
+		nodeID, path, err := index.RandomSegment(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, index.Segments(nodeID), path)
+		assert.NotEqual(t, disqualified, nodeID)
+		if nodeID == unvetted {
+			picked++
+		}
+	}
+	assert.True(t, picked > 90, "unvetted node picked %d times out of 100", picked)
+
+	// a failed rebuild keeps the previous index instead of treating nodes as unaudited
+	builtAt := index.BuiltAt()
+	sdb.err = statdb.Error.New("unavailable")
+	assert.Error(t, index.Build(ctx))
+	assert.Equal(t, builtAt, index.BuiltAt())
+	assert.ElementsMatch(t, []storj.Path{"a/1", "b/1", "b/2"}, index.Segments(vetted))
+	assert.Empty(t, index.Segments(disqualified))
+
+	// nodes keep a bounded sample of their segments
+	sdb.err = nil
+	index.maxSegments = 2
+	require.NoError(t, index.Build(ctx))
+	assert.Len(t, index.Segments(vetted), 2)
+	assert.Subset(t, []storj.Path{"a/1", "b/1", "b/2"}, index.Segments(vetted))
+}
+
+func TestSegmentIndexEmpty(t *testing.T) {
+	ctx := context.Background()
+
+	pdb := pointerdb.NewServer(teststore.New(), overlay.NewCache(teststore.New(), nil), zap.NewNop(), pointerdb.Config{}, nil)
+	index := NewSegmentIndex(pdb, &mockStatDB{}, 100)
+	require.NoError(t, index.Build(ctx))
+
+	_, path, err := index.RandomSegment(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, storj.Path(""), path)
+}
@@ -10,8 +10,10 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pointerdb"
 	"storj.io/storj/pkg/pointerdb/pdbclient"
 	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/statdb"
 	"storj.io/storj/pkg/transport"
 )
 
@@ -25,10 +27,13 @@ type Service struct {
 
 // Config contains configurable values for audit service
 type Config struct {
-	APIKey           string        `help:"APIKey to access the statdb" default:""`
-	SatelliteAddr    string        `help:"address to contact services on the satellite"`
-	MaxRetriesStatDB int           `help:"max number of times to attempt updating a statdb batch" default:"3"`
-	Interval         time.Duration `help:"how frequently segments are audited" default:"30s"`
+	APIKey            string        `help:"APIKey to access the statdb" default:""`
+	SatelliteAddr     string        `help:"address to contact services on the satellite"`
+	MaxRetriesStatDB  int           `help:"max number of times to attempt updating a statdb batch" default:"3"`
+	Interval          time.Duration `help:"how frequently segments are audited" default:"30s"`
+	MaxReverifyCount  int           `help:"max number of times a contained node is re-asked for a pending audit before it fails" default:"3"`
+	VettingAuditCount int64         `help:"number of audits before a node is no longer audited more often as unvetted" default:"100"`
+	IndexRefresh      time.Duration `help:"how frequently the node to segment index used to pick audits is rebuilt" default:"10m"`
 }
 
 // Run runs the repairer with the configured values
@@ -43,10 +48,28 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 		return err
 	}
 	transport := transport.NewClient(identity)
-	service, err := NewService(ctx, c.SatelliteAddr, c.Interval, c.MaxRetriesStatDB, pointers, transport, overlay, *identity, c.APIKey)
+	service, err := NewService(ctx, c.SatelliteAddr, c.Interval, c.MaxRetriesStatDB, c.MaxReverifyCount, pointers, transport, overlay, *identity, c.APIKey)
 	if err != nil {
 		return err
 	}
+
+	// when pointerdb runs in this process, pick nodes to audit first instead of segments
+	if pdb := pointerdb.LoadFromContext(ctx); pdb != nil {
+		sdb, ok := ctx.Value("masterdb").(interface {
+			StatDB() statdb.DB
+		})
+		if !ok {
+			return Error.New("unable to get master db instance")
+		}
+		index := NewSegmentIndex(pdb, sdb.StatDB(), c.VettingAuditCount)
+		service.Cursor = NewWeightedCursor(pointers, index)
+
+		go func() {
+			err := index.Run(ctx, c.IndexRefresh)
+			zap.S().Error("audit segment index failed to run:", zap.Error(err))
+		}()
+	}
+
 	go func() {
 		err := service.Run(ctx)
 		zap.S().Error("audit service failed to run:", zap.Error(err))
@@ -55,10 +78,17 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 }
 
 // NewService instantiates a Service with access to a Cursor and Verifier
-func NewService(ctx context.Context, statDBPort string, interval time.Duration, maxRetries int, pointers pdbclient.Client, transport transport.Client, overlay overlay.Client,
+func NewService(ctx context.Context, statDBPort string, interval time.Duration, maxRetries, maxReverifyCount int, pointers pdbclient.Client, transport transport.Client, overlay overlay.Client,
 	identity provider.FullIdentity, apiKey string) (service *Service, err error) {
+	db, ok := ctx.Value("masterdb").(interface {
+		Containment() Containment
+	})
+	if !ok {
+		return nil, Error.New("unable to get master db instance")
+	}
+
 	cursor := NewCursor(pointers)
-	verifier := NewVerifier(transport, overlay, identity)
+	verifier := NewVerifier(transport, overlay, identity, pointers, db.Containment(), maxReverifyCount)
 	reporter, err := NewReporter(ctx, statDBPort, maxRetries, apiKey)
 	if err != nil {
 		return nil, err
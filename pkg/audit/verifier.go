@@ -6,6 +6,7 @@ package audit
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"io"
 
 	"github.com/vivint/infectious"
@@ -14,10 +15,12 @@ import (
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/piecestore/psclient"
+	"storj.io/storj/pkg/pointerdb/pdbclient"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/transport"
 	"storj.io/storj/pkg/utils"
+	"storj.io/storj/storage"
 )
 
 var mon = monkit.Package()
@@ -30,14 +33,29 @@ type share struct {
 
 // Verifier helps verify the correctness of a given stripe
 type Verifier struct {
-	downloader downloader
+	downloader       downloader
+	containment      Containment
+	pointers         pdbclient.Client
+	maxReverifyCount int
 }
 
 type downloader interface {
 	DownloadShares(ctx context.Context, pointer *pb.Pointer, stripeIndex int, pba *pb.PayerBandwidthAllocation,
 		authorization *pb.SignedMessage) (shares map[int]share, nodes map[int]*pb.Node, err error)
+	DownloadShare(ctx context.Context, pending *PendingAudit, pba *pb.PayerBandwidthAllocation,
+		authorization *pb.SignedMessage) (s share, err error)
 }
 
+// reverifyOutcome is the result of re-asking a contained node for its pending share
+type reverifyOutcome int
+
+const (
+	reverifySuccess reverifyOutcome = iota
+	reverifyFail
+	reverifyOffline
+	reverifySkipped
+)
+
 // defaultDownloader downloads shares from networked storage nodes
 type defaultDownloader struct {
 	transport transport.Client
@@ -52,8 +70,14 @@ func newDefaultDownloader(transport transport.Client, overlay overlay.Client, id
 }
 
 // NewVerifier creates a Verifier
-func NewVerifier(transport transport.Client, overlay overlay.Client, id provider.FullIdentity) *Verifier {
-	return &Verifier{downloader: newDefaultDownloader(transport, overlay, id)}
+func NewVerifier(transport transport.Client, overlay overlay.Client, id provider.FullIdentity,
+	pointers pdbclient.Client, containment Containment, maxReverifyCount int) *Verifier {
+	return &Verifier{
+		downloader:       newDefaultDownloader(transport, overlay, id),
+		containment:      containment,
+		pointers:         pointers,
+		maxReverifyCount: maxReverifyCount,
+	}
 }
 
 // getShare use piece store clients to download shares from a given node
@@ -143,6 +167,20 @@ func (d *defaultDownloader) DownloadShares(ctx context.Context, pointer *pb.Poin
 	return shares, nodes, nil
 }
 
+// DownloadShare downloads the share that a contained node was asked for in its pending audit
+func (d *defaultDownloader) DownloadShare(ctx context.Context, pending *PendingAudit,
+	pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (s share, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	node, err := d.overlay.Lookup(ctx, pending.NodeID)
+	if err != nil {
+		return s, err
+	}
+
+	return d.getShare(ctx, pending.StripeIndex, pending.ShareSize, pending.PieceNum,
+		psclient.PieceID(pending.PieceID), pending.PieceSize, node, pba, authorization)
+}
+
 func makeCopies(ctx context.Context, originals map[int]share) (copies []infectious.Share, err error) {
 	defer mon.Task()(&ctx)(&err)
 	copies = make([]infectious.Share, 0, len(originals))
@@ -183,6 +221,41 @@ func auditShares(ctx context.Context, required, total int, originals map[int]sha
 	return pieceNums, nil
 }
 
+// recoverShares rebuilds the expected contents of the given pieces from the downloaded shares
+func recoverShares(ctx context.Context, required, total int, originals map[int]share, pieceNums []int) (recovered map[int][]byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+	f, err := infectious.NewFEC(required, total)
+	if err != nil {
+		return nil, err
+	}
+
+	copies, err := makeCopies(ctx, originals)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := f.Decode(nil, copies)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[int]bool, len(pieceNums))
+	for _, pieceNum := range pieceNums {
+		wanted[pieceNum] = true
+	}
+
+	recovered = make(map[int][]byte, len(pieceNums))
+	err = f.Encode(data, func(s infectious.Share) {
+		if wanted[s.Number] {
+			recovered[s.Number] = append([]byte{}, s.Data...)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recovered, nil
+}
+
 func calcPadded(size int64, blockSize int) int64 {
 	mod := size % int64(blockSize)
 	if mod == 0 {
@@ -222,11 +295,146 @@ func (verifier *Verifier) verify(ctx context.Context, stripe *Stripe) (verifiedN
 
 	successNodes := getSuccessNodes(ctx, nodes, failedNodes, offlineNodes)
 
-	return &RecordAuditsInfo{
+	verifiedNodes = &RecordAuditsInfo{
 		SuccessNodeIDs: successNodes,
 		FailNodeIDs:    failedNodes,
 		OfflineNodeIDs: offlineNodes,
-	}, nil
+	}
+
+	if verifier.containment == nil {
+		return verifiedNodes, nil
+	}
+	return verifier.contain(ctx, stripe, shares, nodes, verifiedNodes)
+}
+
+// contain re-asks contained nodes for their pending shares instead of auditing them on the
+// current stripe, and places nodes that didn't respond to the current stripe in containment
+func (verifier *Verifier) contain(ctx context.Context, stripe *Stripe, shares map[int]share, nodes map[int]*pb.Node,
+	verifiedNodes *RecordAuditsInfo) (_ *RecordAuditsInfo, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	offline := make(map[storj.NodeID]bool, len(verifiedNodes.OfflineNodeIDs))
+	for _, nodeID := range verifiedNodes.OfflineNodeIDs {
+		offline[nodeID] = true
+	}
+
+	report := &RecordAuditsInfo{}
+	contained := make(map[storj.NodeID]bool)
+	var missingPieceNums []int
+
+	for pieceNum, node := range nodes {
+		pending, err := verifier.containment.Get(ctx, node.Id)
+		if ErrContainedNotFound.Has(err) {
+			if offline[node.Id] {
+				missingPieceNums = append(missingPieceNums, pieceNum)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		contained[node.Id] = true
+
+		outcome, err := verifier.reverify(ctx, stripe, pending)
+		if err != nil {
+			return nil, err
+		}
+		switch outcome {
+		case reverifySuccess:
+			report.SuccessNodeIDs = append(report.SuccessNodeIDs, node.Id)
+		case reverifyFail:
+			report.FailNodeIDs = append(report.FailNodeIDs, node.Id)
+		case reverifyOffline:
+			report.OfflineNodeIDs = append(report.OfflineNodeIDs, node.Id)
+		}
+	}
+
+	report.SuccessNodeIDs = append(report.SuccessNodeIDs, withoutContained(verifiedNodes.SuccessNodeIDs, contained)...)
+	report.FailNodeIDs = append(report.FailNodeIDs, withoutContained(verifiedNodes.FailNodeIDs, contained)...)
+	report.OfflineNodeIDs = append(report.OfflineNodeIDs, withoutContained(verifiedNodes.OfflineNodeIDs, contained)...)
+
+	if len(missingPieceNums) == 0 {
+		return report, nil
+	}
+
+	pointer := stripe.Segment
+	required := int(pointer.Remote.Redundancy.GetMinReq())
+	total := int(pointer.Remote.Redundancy.GetTotal())
+	shareSize := int(pointer.Remote.Redundancy.GetErasureShareSize())
+	pieceSize := calcPadded(pointer.GetSegmentSize(), shareSize) / int64(required)
+
+	expected, err := recoverShares(ctx, required, total, shares, missingPieceNums)
+	if err != nil {
+		return nil, err
+	}
+
+	for pieceNum, data := range expected {
+		hash := sha256.Sum256(data)
+		err := verifier.containment.IncrementPending(ctx, &PendingAudit{
+			NodeID:            nodes[pieceNum].Id,
+			PieceID:           pointer.Remote.GetPieceId(),
+			PieceNum:          pieceNum,
+			PieceSize:         pieceSize,
+			StripeIndex:       stripe.Index,
+			ShareSize:         shareSize,
+			ExpectedShareHash: hash[:],
+			Path:              stripe.Path,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// reverify re-asks a contained node for the share of its pending audit. The node is released from
+// containment once it returns the share, or failed once it has missed maxReverifyCount re-asks.
+func (verifier *Verifier) reverify(ctx context.Context, stripe *Stripe, pending *PendingAudit) (outcome reverifyOutcome, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if verifier.pointers != nil && pending.Path != "" {
+		// a node can't be held to a share of a segment that was deleted or replaced
+		pointer, _, _, err := verifier.pointers.Get(ctx, pending.Path)
+		if storage.ErrKeyNotFound.Has(err) || (err == nil && pointer.GetRemote().GetPieceId() != pending.PieceID) {
+			_, err = verifier.containment.Delete(ctx, pending.NodeID)
+			return reverifySkipped, err
+		}
+		if err != nil {
+			return reverifySkipped, err
+		}
+	}
+
+	s, err := verifier.downloader.DownloadShare(ctx, pending, stripe.PBA, stripe.Authorization)
+	if err != nil {
+		if pending.ReverifyCount+1 >= verifier.maxReverifyCount {
+			_, err = verifier.containment.Delete(ctx, pending.NodeID)
+			return reverifyFail, err
+		}
+		return reverifyOffline, verifier.containment.IncrementPending(ctx, pending)
+	}
+
+	_, err = verifier.containment.Delete(ctx, pending.NodeID)
+	if err != nil {
+		return reverifySkipped, err
+	}
+
+	hash := sha256.Sum256(s.Data)
+	if !bytes.Equal(hash[:], pending.ExpectedShareHash) {
+		return reverifyFail, nil
+	}
+	return reverifySuccess, nil
+}
+
+// withoutContained filters contained nodes out of nodeIDs
+func withoutContained(nodeIDs storj.NodeIDList, contained map[storj.NodeID]bool) (filtered storj.NodeIDList) {
+	for _, nodeID := range nodeIDs {
+		if !contained[nodeID] {
+			filtered = append(filtered, nodeID)
+		}
+	}
+	return filtered
 }
 
 // getSuccessNodes uses the failed nodes and offline nodes arrays to determine which nodes passed the audit
@@ -6,6 +6,7 @@ package audit
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"strconv"
 	"testing"
 
@@ -14,10 +15,13 @@ import (
 
 	"storj.io/storj/internal/teststorj"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
 )
 
 type mockDownloader struct {
 	shares map[int]share
+	// pending is returned by DownloadShare, keyed by node id
+	pending map[storj.NodeID]share
 }
 
 func TestPassingAudit(t *testing.T) {
@@ -186,6 +190,122 @@ func TestNotEnoughShares(t *testing.T) {
 	assert.Contains(t, err.Error(), "infectious: must specify at least the number of required shares")
 }
 
+func TestContainment(t *testing.T) {
+	const (
+		required  = 4
+		total     = 8
+		shareSize = 16
+	)
+	ctx := context.Background()
+
+	f, err := infectious.NewFEC(required, total)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded := make(map[int]share, total)
+	err = f.Encode(randData(required*shareSize), func(s infectious.Share) {
+		encoded[s.Number] = share{PieceNumber: s.Number, Data: append([]byte{}, s.Data...)}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodeID := func(pieceNum int) storj.NodeID {
+		return teststorj.NodeIDFromString(strconv.Itoa(pieceNum))
+	}
+
+	// pieces 5, 6 and 7 don't respond to the first audit
+	shares := make(map[int]share, total)
+	for pieceNum, s := range encoded {
+		shares[pieceNum] = s
+		if pieceNum >= 5 {
+			shares[pieceNum] = share{PieceNumber: pieceNum, Error: Error.New("offline")}
+		}
+	}
+
+	md := &mockDownloader{shares: shares, pending: map[storj.NodeID]share{}}
+	containment := &mockContainment{pending: map[storj.NodeID]*PendingAudit{}}
+	verifier := &Verifier{downloader: md, containment: containment, maxReverifyCount: 2}
+
+	pointer := &pb.Pointer{
+		Type: pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{
+			Redundancy: &pb.RedundancyScheme{
+				Type:             pb.RedundancyScheme_RS,
+				MinReq:           required,
+				Total:            total,
+				ErasureShareSize: shareSize,
+			},
+			PieceId: "testId",
+		},
+		SegmentSize: required * shareSize,
+	}
+	stripe := &Stripe{Index: 0, Path: "testPath", Segment: pointer}
+
+	verifiedNodes, err := verifier.verify(ctx, stripe)
+	assert.NoError(t, err)
+	for pieceNum := 0; pieceNum < 5; pieceNum++ {
+		assert.Contains(t, verifiedNodes.SuccessNodeIDs, nodeID(pieceNum))
+	}
+	assert.Len(t, verifiedNodes.OfflineNodeIDs, 3)
+	assert.Len(t, containment.pending, 3)
+	for pieceNum := 5; pieceNum < total; pieceNum++ {
+		pending := containment.pending[nodeID(pieceNum)]
+		if assert.NotNil(t, pending) {
+			expected := sha256.Sum256(encoded[pieceNum].Data)
+			assert.Equal(t, expected[:], pending.ExpectedShareHash)
+			assert.Equal(t, pieceNum, pending.PieceNum)
+			assert.Equal(t, "testPath", pending.Path)
+		}
+	}
+
+	// on the next audit every node answers the new stripe, but contained nodes are
+	// re-asked for their pending shares: 5 returns it, 6 returns bad data, 7 stays offline
+	md.shares = encoded
+	md.pending[nodeID(5)] = encoded[5]
+	md.pending[nodeID(6)] = share{PieceNumber: 6, Data: make([]byte, shareSize)}
+
+	verifiedNodes, err = verifier.verify(ctx, stripe)
+	assert.NoError(t, err)
+	assert.Contains(t, verifiedNodes.SuccessNodeIDs, nodeID(5))
+	assert.Equal(t, storj.NodeIDList{nodeID(6)}, verifiedNodes.FailNodeIDs)
+	assert.Equal(t, storj.NodeIDList{nodeID(7)}, verifiedNodes.OfflineNodeIDs)
+	assert.Len(t, containment.pending, 1)
+	assert.Equal(t, 1, containment.pending[nodeID(7)].ReverifyCount)
+
+	// reconnecting doesn't release 7 from containment, missing the pending share again fails it
+	verifiedNodes, err = verifier.verify(ctx, stripe)
+	assert.NoError(t, err)
+	assert.Equal(t, storj.NodeIDList{nodeID(7)}, verifiedNodes.FailNodeIDs)
+	assert.Empty(t, verifiedNodes.OfflineNodeIDs)
+	assert.Empty(t, containment.pending)
+}
+
+func TestReverifyCount(t *testing.T) {
+	ctx := context.Background()
+	nodeID := teststorj.NodeIDFromString("contained")
+
+	for i, tt := range []struct {
+		reverifyCount int
+		outcome       reverifyOutcome
+	}{
+		{0, reverifyOffline},
+		{1, reverifyOffline},
+		{2, reverifyFail},
+	} {
+		pending := &PendingAudit{NodeID: nodeID, ReverifyCount: tt.reverifyCount}
+		containment := &mockContainment{pending: map[storj.NodeID]*PendingAudit{nodeID: pending}}
+		md := &mockDownloader{pending: map[storj.NodeID]share{}}
+		verifier := &Verifier{downloader: md, containment: containment, maxReverifyCount: 3}
+
+		outcome, err := verifier.reverify(ctx, &Stripe{}, pending)
+		assert.NoError(t, err, i)
+		assert.Equal(t, tt.outcome, outcome, i)
+		assert.Equal(t, tt.outcome == reverifyOffline, containment.pending[nodeID] != nil, i)
+	}
+}
+
 func TestCalcPadded(t *testing.T) {
 	for _, tt := range []struct {
 		segSize    int64
@@ -217,6 +337,51 @@ func (m *mockDownloader) DownloadShares(ctx context.Context, pointer *pb.Pointer
 	return m.shares, nodes, nil
 }
 
+func (m *mockDownloader) DownloadShare(ctx context.Context, pending *PendingAudit,
+	pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (s share, err error) {
+	s, ok := m.pending[pending.NodeID]
+	if !ok {
+		return s, Error.New("node %s is offline", pending.NodeID)
+	}
+	return s, nil
+}
+
+type mockContainment struct {
+	pending map[storj.NodeID]*PendingAudit
+}
+
+func (m *mockContainment) Get(ctx context.Context, nodeID storj.NodeID) (*PendingAudit, error) {
+	pending, ok := m.pending[nodeID]
+	if !ok {
+		return nil, ErrContainedNotFound.New("%v", nodeID)
+	}
+	copied := *pending
+	return &copied, nil
+}
+
+func (m *mockContainment) IncrementPending(ctx context.Context, pendingAudit *PendingAudit) error {
+	if existing, ok := m.pending[pendingAudit.NodeID]; ok {
+		existing.ReverifyCount++
+		return nil
+	}
+	copied := *pendingAudit
+	m.pending[pendingAudit.NodeID] = &copied
+	return nil
+}
+
+func (m *mockContainment) Delete(ctx context.Context, nodeID storj.NodeID) (bool, error) {
+	_, ok := m.pending[nodeID]
+	delete(m.pending, nodeID)
+	return ok, nil
+}
+
+func (m *mockContainment) List(ctx context.Context) (pending []*PendingAudit, err error) {
+	for _, p := range m.pending {
+		pending = append(pending, p)
+	}
+	return pending, nil
+}
+
 func makePointer(nodeAmt int) *pb.Pointer {
 	var rps []*pb.RemotePiece
 	for i := 0; i < nodeAmt; i++ {
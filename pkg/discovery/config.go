@@ -25,7 +25,10 @@ var (
 
 // Config loads on the configuration values from run flags
 type Config struct {
-	RefreshInterval time.Duration `help:"the interval at which the cache refreshes itself in seconds" default:"1s"`
+	RefreshInterval  time.Duration `help:"the interval at which the cache refreshes itself in seconds" default:"1s"`
+	WalkInterval     time.Duration `help:"the interval at which every node in the cache is pinged" default:"1h"`
+	CrawlConcurrency int           `help:"the number of nodes contacted in parallel while crawling the network" default:"5"`
+	CrawlRate        int           `help:"the maximum number of nodes contacted per second while crawling the network" default:"100"`
 }
 
 // Run runs the Discovery boot up and initialization
@@ -42,16 +45,30 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 		return Error.New("unable to get master db instance")
 	}
 
-	discovery := NewDiscovery(zap.L().Named("discovery"), ol, kad, stat.StatDB())
+	discovery := NewDiscovery(zap.L().Named("discovery"), ol, kad, stat.StatDB(), statdb.LoadConfigFromContext(ctx), c)
 
 	zap.L().Debug("Starting discovery")
 
 	ticker := time.NewTicker(c.RefreshInterval)
 	defer ticker.Stop()
+	walkTicker := time.NewTicker(c.WalkInterval)
+	defer walkTicker.Stop()
+
+	// the crawl can take a long time on a large network, the cache is refreshed meanwhile
+	go func() {
+		if err := discovery.Bootstrap(ctx); err != nil {
+			discovery.log.Error("Error with network crawl: ", zap.Error(err))
+		}
+	}()
 
 	go func() {
 		for {
 			select {
+			case <-walkTicker.C:
+				err := discovery.Walk(ctx)
+				if err != nil {
+					discovery.log.Error("Error with cache walk: ", zap.Error(err))
+				}
 			case <-ticker.C:
 				err := discovery.Refresh(ctx)
 				if err != nil {
@@ -0,0 +1,29 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package discovery
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/pkg/pb"
+)
+
+func TestCrawlRate(t *testing.T) {
+	nodes := []*pb.Node{{}, {}, {}}
+
+	for _, rate := range []int{0, 1000, 2e9} {
+		d := &Discovery{config: Config{CrawlConcurrency: 2, CrawlRate: rate}}
+
+		var visited int32
+		err := d.crawl(context.Background(), nodes, func(context.Context, *pb.Node) {
+			atomic.AddInt32(&visited, 1)
+		})
+		assert.NoError(t, err, rate)
+		assert.Equal(t, int32(len(nodes)), atomic.LoadInt32(&visited), rate)
+	}
+}
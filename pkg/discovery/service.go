@@ -6,14 +6,18 @@ package discovery
 import (
 	"context"
 	"crypto/rand"
+	"sync"
+	"time"
 
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 
 	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/statdb"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
 )
 
 var (
@@ -27,15 +31,19 @@ type Discovery struct {
 	cache  *overlay.Cache
 	kad    *kademlia.Kademlia
 	statdb statdb.DB
+	states statdb.Config
+	config Config
 }
 
 // NewDiscovery Returns a new Discovery instance with cache, kad, and statdb loaded on
-func NewDiscovery(logger *zap.Logger, ol *overlay.Cache, kad *kademlia.Kademlia, stat statdb.DB) *Discovery {
+func NewDiscovery(logger *zap.Logger, ol *overlay.Cache, kad *kademlia.Kademlia, stat statdb.DB, states statdb.Config, config Config) *Discovery {
 	return &Discovery{
 		log:    logger,
 		cache:  ol,
 		kad:    kad,
 		statdb: stat,
+		states: states,
+		config: config,
 	}
 }
 
@@ -55,16 +63,67 @@ func (d *Discovery) Refresh(ctx context.Context) error {
 	return nil
 }
 
-// Bootstrap walks the initialized network and populates the cache
-func (d *Discovery) Bootstrap(ctx context.Context) error {
-	// o := overlay.LoadFromContext(ctx)
-	// kad := kademlia.LoadFromContext(ctx)
-	// TODO(coyle): make Bootstrap work
-	// look in our routing table
-	// get every node we know about
-	// ask every node for every node they know about
-	// for each newly known node, ask those nodes for every node they know about
-	// continue until no new nodes are found
+// Bootstrap walks the initialized network and populates the cache.
+// Starting from our routing table and the bootstrap nodes, every node is asked for the nodes it knows
+// about and each newly found node is asked in turn, until no new nodes are found.
+// Nodes that respond are added to the cache, nodes that don't are marked offline.
+func (d *Discovery) Bootstrap(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rt, err := d.kad.GetRoutingTable(ctx)
+	if err != nil {
+		return DiscoveryError.Wrap(err)
+	}
+	known, err := d.kad.GetNodes(ctx, storj.NodeID{}, 0)
+	if err != nil {
+		return DiscoveryError.Wrap(err)
+	}
+	known = append(known, d.kad.Seen()...)
+
+	var mu sync.Mutex
+	visited := map[storj.NodeID]bool{rt.Local().Id: true}
+
+	// bootstrap nodes may not have a known ID yet, so they are always asked
+	var queue []*pb.Node
+	for _, node := range d.kad.GetBootstrapNodes() {
+		node := node
+		if node.Id.IsZero() {
+			queue = append(queue, &node)
+		} else {
+			known = append(known, &node)
+		}
+	}
+	for _, node := range known {
+		if !visited[node.Id] {
+			visited[node.Id] = true
+			queue = append(queue, node)
+		}
+	}
+
+	for len(queue) > 0 {
+		var next []*pb.Node
+		err = d.crawl(ctx, queue, func(ctx context.Context, node *pb.Node) {
+			neighbors, err := d.kad.FetchNeighbors(ctx, *node)
+			d.record(ctx, node, err)
+			if err != nil {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for _, neighbor := range neighbors {
+				if !visited[neighbor.Id] {
+					visited[neighbor.Id] = true
+					next = append(next, neighbor)
+				}
+			}
+		})
+		if err != nil {
+			return DiscoveryError.Wrap(err)
+		}
+		queue = next
+	}
+
 	return nil
 }
 
@@ -81,9 +140,100 @@ func (d *Discovery) Discovery(ctx context.Context) error {
 	return nil
 }
 
-// Walk iterates over each node in each bucket to traverse the network
-func (d *Discovery) Walk(ctx context.Context) error {
-	// TODO: This should walk the cache, rather than be a duplicate of refresh
+// Walk pings every node in the cache, recording which ones are still online
+func (d *Discovery) Walk(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var cursor storj.NodeID
+	for {
+		nodes, err := d.cache.List(ctx, cursor, storage.LookupLimit)
+		if err != nil {
+			return DiscoveryError.Wrap(err)
+		}
+		if len(nodes) == 0 {
+			return nil
+		}
+		cursor = nodes[len(nodes)-1].Id
+
+		err = d.crawl(ctx, nodes, func(ctx context.Context, node *pb.Node) {
+			_, err := d.kad.Ping(ctx, *node)
+			d.record(ctx, node, err)
+		})
+		if err != nil {
+			return DiscoveryError.Wrap(err)
+		}
+	}
+}
+
+// record marks node as online in the cache and statdb when err is nil, offline otherwise,
+// and moves it to the state its uptime calls for
+func (d *Discovery) record(ctx context.Context, node *pb.Node, err error) {
+	if node.Id.IsZero() {
+		return
+	}
+
+	var stats *statdb.NodeStats
+	if err != nil {
+		d.log.Debug("node is offline", zap.String("nodeID", node.Id.String()), zap.Error(err))
+		stats, err = d.statdb.UpdateUptime(ctx, node.Id, false)
+	} else {
+		d.cache.ConnSuccess(ctx, node)
+		stats, err = d.statdb.Get(ctx, node.Id)
+	}
+	if err != nil {
+		d.log.Debug("error updating node uptime", zap.String("nodeID", node.Id.String()), zap.Error(err))
+		return
+	}
+
+	if _, err := d.states.Apply(ctx, d.cache, stats); err != nil {
+		d.log.Error("error updating node state", zap.String("nodeID", node.Id.String()), zap.Error(err))
+	}
+}
+
+// crawl calls visit for every node, running at most config.CrawlConcurrency
+// visits at a time and starting at most config.CrawlRate visits per second
+func (d *Discovery) crawl(ctx context.Context, nodes []*pb.Node, visit func(context.Context, *pb.Node)) error {
+	concurrency := d.config.CrawlConcurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	var limiter <-chan time.Time
+	if d.config.CrawlRate > 0 {
+		interval := time.Second / time.Duration(d.config.CrawlRate)
+		if interval <= 0 {
+			// rates above one visit per nanosecond aren't limited any further
+			interval = time.Nanosecond
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	sem := make(chan struct{}, concurrency)
+	for _, node := range nodes {
+		if limiter != nil {
+			select {
+			case <-limiter:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		wg.Add(1)
+		go func(node *pb.Node) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			visit(ctx, node)
+		}(node)
+	}
 	return nil
 }
 
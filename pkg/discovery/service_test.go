@@ -7,9 +7,15 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"storj.io/storj/internal/testcontext"
 	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/discovery"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/statdb"
 )
 
 func TestCache_Refresh(t *testing.T) {
@@ -27,3 +33,78 @@ func TestCache_Refresh(t *testing.T) {
 	err = planet.Satellites[0].Discovery.Refresh(ctx)
 	assert.NoError(t, err)
 }
+
+func TestBootstrap(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	satellite := planet.Satellites[0]
+	err = satellite.Discovery.Bootstrap(ctx)
+	require.NoError(t, err)
+
+	for _, storageNode := range planet.StorageNodes {
+		node, err := satellite.Overlay.Get(ctx, storageNode.ID())
+		if assert.NoError(t, err) {
+			assert.Equal(t, storageNode.Addr(), node.Address.Address)
+		}
+	}
+}
+
+func TestWalk(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 5, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	satellite := planet.Satellites[0]
+	offline := &pb.Node{
+		Id:      teststorj.NodeIDFromString("offline"),
+		Type:    pb.NodeType_STORAGE,
+		Address: &pb.NodeAddress{Address: "127.0.0.1:1"},
+	}
+	require.NoError(t, satellite.Overlay.Put(ctx, offline.Id, *offline))
+	for _, storageNode := range planet.StorageNodes {
+		require.NoError(t, satellite.Overlay.Put(ctx, storageNode.ID(), storageNode.Info))
+	}
+
+	before, err := satellite.StatDB.Get(ctx, offline.Id)
+	require.NoError(t, err)
+
+	// a single failed check suspends nodes that were never online
+	states := statdb.Config{SuspensionUptimeRatio: 0.5, SuspensionMinUptimeCount: 1}
+	walker := discovery.NewDiscovery(zap.NewNop(), satellite.Overlay, satellite.Kademlia, satellite.StatDB, states, discovery.Config{CrawlConcurrency: 5})
+	err = walker.Walk(ctx)
+	require.NoError(t, err)
+
+	after, err := satellite.StatDB.Get(ctx, offline.Id)
+	require.NoError(t, err)
+	assert.Equal(t, before.UptimeCount+1, after.UptimeCount)
+	assert.Equal(t, before.UptimeSuccessCount, after.UptimeSuccessCount)
+	assert.Equal(t, statdb.StateSuspended, after.State)
+
+	// the cached node is suspended too, so it isn't selected anymore
+	cached, err := satellite.Overlay.Get(ctx, offline.Id)
+	require.NoError(t, err)
+	assert.Equal(t, pb.NodeState_SUSPENDED, cached.GetReputation().GetState())
+
+	for _, storageNode := range planet.StorageNodes {
+		stats, err := satellite.StatDB.Get(ctx, storageNode.ID())
+		require.NoError(t, err)
+		assert.True(t, stats.UptimeSuccessCount >= 1)
+		assert.NotEqual(t, statdb.StateSuspended, stats.State)
+	}
+}
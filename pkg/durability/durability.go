@@ -0,0 +1,151 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package durability estimates how likely erasure coded segments are to be
+// lost when storage nodes leave the network.
+package durability
+
+import (
+	"math"
+	"time"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/storj"
+)
+
+// Error is the default durability errs class
+var Error = errs.Class("durability error")
+
+// MaxShares is the largest number of shares supported by the erasure code
+const MaxShares = 256
+
+const year = 365 * 24 * time.Hour
+
+// Model describes how storage nodes leave the network and how quickly the
+// satellite repairs the pieces lost with them
+type Model struct {
+	ChurnRate      float64       `help:"fraction of the storage nodes leaving the network per day" default:"0.01"`
+	RepairInterval time.Duration `help:"how long a segment below the repair threshold can wait to be repaired" default:"6h"`
+}
+
+// Config is the minimum durability required of the redundancy schemes of
+// remote segments
+type Config struct {
+	MinDurability float64 `default:"0" help:"minimum annual durability, in nines, of the redundancy scheme of remote segments, 0 to accept any scheme"`
+	Durability    Model
+}
+
+// Estimate is the estimated durability of a redundancy scheme
+type Estimate struct {
+	// LossProbability is the probability of losing a segment waiting for
+	// repair at the repair threshold during one repair interval
+	LossProbability float64
+	// AnnualLossProbability is the probability of losing a segment within
+	// a year of repair intervals
+	AnnualLossProbability float64
+	// ExpansionFactor is the size stored on the network for each byte of
+	// the segment
+	ExpansionFactor float64
+}
+
+// Nines returns the annual durability as a number of nines, e.g. 11 for
+// 99.999999999%
+func (estimate Estimate) Nines() float64 {
+	if estimate.AnnualLossProbability <= 0 {
+		return math.Inf(1)
+	}
+	return -math.Log10(estimate.AnnualLossProbability)
+}
+
+// Validate checks that scheme can be used to erasure code segments
+func Validate(scheme storj.RedundancyScheme) error {
+	if scheme.Algorithm != storj.ReedSolomon {
+		return Error.New("unsupported redundancy algorithm %d", scheme.Algorithm)
+	}
+	if scheme.ShareSize <= 0 {
+		return Error.New("share size %d must be positive", scheme.ShareSize)
+	}
+	if scheme.RequiredShares < 1 {
+		return Error.New("required shares %d must be positive", scheme.RequiredShares)
+	}
+	if scheme.RepairShares < scheme.RequiredShares {
+		return Error.New("repair threshold %d less than required shares %d", scheme.RepairShares, scheme.RequiredShares)
+	}
+	if scheme.OptimalShares < scheme.RepairShares {
+		return Error.New("success threshold %d less than repair threshold %d", scheme.OptimalShares, scheme.RepairShares)
+	}
+	if scheme.TotalShares < scheme.OptimalShares {
+		return Error.New("total shares %d less than success threshold %d", scheme.TotalShares, scheme.OptimalShares)
+	}
+	if scheme.TotalShares > MaxShares {
+		return Error.New("total shares %d greater than maximum %d", scheme.TotalShares, MaxShares)
+	}
+	return nil
+}
+
+// Estimate estimates the durability of scheme. A segment waiting for repair
+// has only the repair threshold of pieces left, and it is lost if more than
+// repair threshold - required shares of them leave before the repair.
+func (model Model) Estimate(scheme storj.RedundancyScheme) (Estimate, error) {
+	if err := Validate(scheme); err != nil {
+		return Estimate{}, err
+	}
+	if model.ChurnRate < 0 || model.ChurnRate > 1 {
+		return Estimate{}, Error.New("churn rate %v must be between 0 and 1", model.ChurnRate)
+	}
+	if model.RepairInterval <= 0 {
+		return Estimate{}, Error.New("repair interval %v must be positive", model.RepairInterval)
+	}
+
+	// probability that a single node leaves during a repair interval
+	days := model.RepairInterval.Hours() / 24
+	nodeLoss := -math.Expm1(days * math.Log1p(-model.ChurnRate))
+
+	pieces := int(scheme.RepairShares)
+	loss := binomialTail(pieces, pieces-int(scheme.RequiredShares)+1, nodeLoss)
+
+	intervals := float64(year) / float64(model.RepairInterval)
+	return Estimate{
+		LossProbability:       loss,
+		AnnualLossProbability: -math.Expm1(intervals * math.Log1p(-loss)),
+		ExpansionFactor:       float64(scheme.OptimalShares) / float64(scheme.RequiredShares),
+	}, nil
+}
+
+// binomialTail returns the probability of at least k successes in n trials
+// with success probability p. The terms are summed in log space as the
+// interesting probabilities are far below the float64 precision of 1 - p.
+func binomialTail(n, k int, p float64) float64 {
+	switch {
+	case k <= 0:
+		return 1
+	case k > n || p <= 0:
+		return 0
+	case p >= 1:
+		return 1
+	}
+
+	logP, logQ := math.Log(p), math.Log1p(-p)
+	logTerms := make([]float64, 0, n-k+1)
+	max := math.Inf(-1)
+	for i := k; i <= n; i++ {
+		logTerm := logChoose(n, i) + float64(i)*logP + float64(n-i)*logQ
+		logTerms = append(logTerms, logTerm)
+		max = math.Max(max, logTerm)
+	}
+
+	var sum float64
+	for _, logTerm := range logTerms {
+		sum += math.Exp(logTerm - max)
+	}
+	return math.Min(1, math.Exp(max+math.Log(sum)))
+}
+
+// logChoose returns the natural logarithm of n choose k
+func logChoose(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package durability
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/pkg/storj"
+)
+
+func rs(required, repair, optimal, total int16) storj.RedundancyScheme {
+	return storj.RedundancyScheme{
+		Algorithm:      storj.ReedSolomon,
+		ShareSize:      1024,
+		RequiredShares: required,
+		RepairShares:   repair,
+		OptimalShares:  optimal,
+		TotalShares:    total,
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for i, tt := range []struct {
+		scheme storj.RedundancyScheme
+		valid  bool
+	}{
+		{rs(29, 35, 80, 95), true},
+		{rs(1, 1, 1, 1), true},
+		{rs(0, 35, 80, 95), false},
+		{rs(29, 20, 80, 95), false},
+		{rs(29, 35, 30, 95), false},
+		{rs(29, 35, 80, 70), false},
+		{rs(29, 35, 80, 300), false},
+		{storj.RedundancyScheme{RequiredShares: 1, RepairShares: 1, OptimalShares: 1, TotalShares: 1}, false},
+	} {
+		err := Validate(tt.scheme)
+		if tt.valid {
+			assert.NoError(t, err, i)
+		} else {
+			assert.True(t, Error.Has(err), i)
+		}
+	}
+}
+
+func TestBinomialTail(t *testing.T) {
+	for i, tt := range []struct {
+		n, k     int
+		p        float64
+		expected float64
+	}{
+		{4, 0, 0.5, 1},
+		{4, 5, 0.5, 0},
+		{4, 2, 0.5, 11.0 / 16},
+		{4, 4, 0.5, 1.0 / 16},
+		{3, 1, 0.1, 1 - 0.9*0.9*0.9},
+		{10, 1, 0, 0},
+		{10, 10, 1, 1},
+	} {
+		assert.InDelta(t, tt.expected, binomialTail(tt.n, tt.k, tt.p), 1e-12, i)
+	}
+
+	// far below the precision of 1 - p
+	assert.InEpsilon(t, math.Pow(1e-3, 20), binomialTail(20, 20, 1e-3), 1e-9)
+}
+
+func TestEstimate(t *testing.T) {
+	model := Model{ChurnRate: 0.01, RepairInterval: 6 * time.Hour}
+
+	estimate, err := model.Estimate(rs(29, 35, 80, 95))
+	if assert.NoError(t, err) {
+		assert.InDelta(t, 80.0/29, estimate.ExpansionFactor, 1e-12)
+		assert.True(t, estimate.LossProbability > 0)
+		assert.True(t, estimate.AnnualLossProbability > estimate.LossProbability)
+		assert.InDelta(t, 8.2, estimate.Nines(), 0.1)
+	}
+
+	// a smaller repair margin is less durable
+	weaker, err := model.Estimate(rs(29, 31, 80, 95))
+	if assert.NoError(t, err) {
+		assert.True(t, weaker.Nines() < estimate.Nines())
+	}
+
+	// so is a slower repair
+	slower, err := Model{ChurnRate: 0.01, RepairInterval: 24 * time.Hour}.Estimate(rs(29, 35, 80, 95))
+	if assert.NoError(t, err) {
+		assert.True(t, slower.Nines() < estimate.Nines())
+	}
+
+	// without churn nothing is lost
+	perfect, err := Model{RepairInterval: time.Hour}.Estimate(rs(2, 2, 3, 4))
+	if assert.NoError(t, err) {
+		assert.Zero(t, perfect.AnnualLossProbability)
+		assert.True(t, math.IsInf(perfect.Nines(), 1))
+	}
+
+	_, err = Model{ChurnRate: 2, RepairInterval: time.Hour}.Estimate(rs(2, 2, 3, 4))
+	assert.True(t, Error.Has(err))
+	_, err = Model{ChurnRate: 0.01}.Estimate(rs(2, 2, 3, 4))
+	assert.True(t, Error.Has(err))
+	_, err = model.Estimate(rs(3, 2, 3, 4))
+	assert.True(t, Error.Has(err))
+}
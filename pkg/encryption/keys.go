@@ -0,0 +1,178 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package encryption
+
+import (
+	"storj.io/storj/pkg/storj"
+)
+
+// PathKeys derives the keys of paths either from the root key or, for
+// restricted access, from the key of a path prefix. Keys of paths outside of
+// the prefix can't be derived from the key of a prefix.
+type PathKeys struct {
+	key storj.Key
+
+	// prefix and encryptedPrefix are the components of the path prefix,
+	// starting with the bucket; both are empty for the root key
+	prefix          []string
+	encryptedPrefix []string
+	// bucketContentKey is the content key of the bucket of the prefix
+	bucketContentKey *storj.Key
+}
+
+// NewRootKeys returns the path keys derived from the root key
+func NewRootKeys(root *storj.Key) *PathKeys {
+	return &PathKeys{key: *root}
+}
+
+// NewPrefixKeys returns the path keys derived from the key of a path prefix.
+// encryptedPrefix is the prefix encrypted after the bucket. bucketContentKey
+// is the content key of the bucket, so that its metadata can be read; it may
+// be nil.
+func NewPrefixKeys(prefix, encryptedPrefix storj.Path, key, bucketContentKey *storj.Key) (*PathKeys, error) {
+	comps := storj.SplitPath(prefix)
+	encryptedComps := storj.SplitPath(encryptedPrefix)
+	if prefix == "" || len(comps) != len(encryptedComps) || comps[0] != encryptedComps[0] {
+		return nil, Error.New("invalid path prefix %q for encrypted path prefix %q", prefix, encryptedPrefix)
+	}
+	return &PathKeys{
+		key:              *key,
+		prefix:           comps,
+		encryptedPrefix:  encryptedComps,
+		bucketContentKey: bucketContentKey,
+	}, nil
+}
+
+// Prefix returns the path prefix the keys are restricted to, or an empty path
+// for the root key
+func (keys *PathKeys) Prefix() storj.Path {
+	return storj.JoinPaths(keys.prefix...)
+}
+
+// relative returns the components of path after the prefix
+func (keys *PathKeys) relative(path storj.Path) ([]string, error) {
+	comps := storj.SplitPath(path)
+	if len(comps) < len(keys.prefix) {
+		return nil, Error.New("path %q is outside of the accessible prefix", path)
+	}
+	for i, comp := range keys.prefix {
+		if comps[i] != comp {
+			return nil, Error.New("path %q is outside of the accessible prefix", path)
+		}
+	}
+	return comps[len(keys.prefix):], nil
+}
+
+// DerivePathKey derives the key of path at depth. This method must be called
+// on an unencrypted path.
+func (keys *PathKeys) DerivePathKey(path storj.Path, depth int) (*storj.Key, error) {
+	if len(keys.prefix) == 0 {
+		return DerivePathKey(path, &keys.key, depth)
+	}
+
+	if depth < len(keys.prefix) {
+		return nil, Error.New("path %q is outside of the accessible prefix", path)
+	}
+	rest, err := keys.relative(path)
+	if err != nil {
+		return nil, err
+	}
+	key := keys.key
+	if depth == len(keys.prefix) {
+		return &key, nil
+	}
+	return DerivePathKey(storj.JoinPaths(rest...), &key, depth-len(keys.prefix))
+}
+
+// DeriveContentKey derives the key for the encrypted data of path. This
+// method must be called on an unencrypted path.
+func (keys *PathKeys) DeriveContentKey(path storj.Path) (*storj.Key, error) {
+	if len(keys.prefix) == 0 {
+		return DeriveContentKey(path, &keys.key)
+	}
+
+	comps := storj.SplitPath(path)
+	if len(comps) == 1 && comps[0] == keys.prefix[0] && len(keys.prefix) > 1 {
+		if keys.bucketContentKey == nil {
+			return nil, Error.New("bucket %q is not accessible", path)
+		}
+		key := *keys.bucketContentKey
+		return &key, nil
+	}
+
+	derivedKey, err := keys.DerivePathKey(path, len(comps))
+	if err != nil {
+		return nil, err
+	}
+	return DeriveKey(derivedKey, "content")
+}
+
+// EncryptAfterBucket encrypts path without encrypting its first element, the bucket
+func (keys *PathKeys) EncryptAfterBucket(path storj.Path, cipher storj.Cipher) (encrypted storj.Path, err error) {
+	comps := storj.SplitPath(path)
+	if len(comps) <= 1 {
+		return path, nil
+	}
+
+	if len(keys.prefix) == 0 {
+		encrypted, err = EncryptPath(path, cipher, &keys.key)
+		if err != nil {
+			return "", err
+		}
+		// replace the first path component with the unencrypted bucket name
+		return storj.JoinPaths(comps[0], storj.JoinPaths(storj.SplitPath(encrypted)[1:]...)), nil
+	}
+
+	rest, err := keys.relative(path)
+	if err != nil {
+		return "", err
+	}
+	encryptedRest, err := EncryptPath(storj.JoinPaths(rest...), cipher, &keys.key)
+	if err != nil {
+		return "", err
+	}
+	return joinNonEmpty(storj.JoinPaths(keys.encryptedPrefix...), encryptedRest), nil
+}
+
+// DecryptAfterBucket decrypts path without decrypting its first element, the bucket
+func (keys *PathKeys) DecryptAfterBucket(path storj.Path, cipher storj.Cipher) (decrypted storj.Path, err error) {
+	comps := storj.SplitPath(path)
+	if len(comps) <= 1 {
+		return path, nil
+	}
+
+	if len(keys.prefix) == 0 {
+		bucketKey, err := DerivePathKey(path, &keys.key, 1)
+		if err != nil {
+			return "", err
+		}
+		decrypted, err = DecryptPath(storj.JoinPaths(comps[1:]...), cipher, bucketKey)
+		if err != nil {
+			return "", err
+		}
+		return storj.JoinPaths(comps[0], decrypted), nil
+	}
+
+	if len(comps) < len(keys.encryptedPrefix) {
+		return "", Error.New("path %q is outside of the accessible prefix", path)
+	}
+	for i, comp := range keys.encryptedPrefix {
+		if comps[i] != comp {
+			return "", Error.New("path %q is outside of the accessible prefix", path)
+		}
+	}
+	decrypted, err = DecryptPath(storj.JoinPaths(comps[len(keys.encryptedPrefix):]...), cipher, &keys.key)
+	if err != nil {
+		return "", err
+	}
+	return joinNonEmpty(storj.JoinPaths(keys.prefix...), decrypted), nil
+}
+
+// joinNonEmpty joins path to prefix, unless path is empty
+func joinNonEmpty(prefix, path storj.Path) storj.Path {
+	if path == "" {
+		return prefix
+	}
+	return storj.JoinPaths(prefix, path)
+}
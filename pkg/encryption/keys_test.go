@@ -0,0 +1,92 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package encryption
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/storj"
+)
+
+func TestPrefixKeys(t *testing.T) {
+	forAllCiphers(func(cipher storj.Cipher) {
+		root := new(storj.Key)
+		copy(root[:], randData(storj.KeySize))
+		rootKeys := NewRootKeys(root)
+
+		prefix := "bucket/fold1/fold2"
+		encryptedPrefix, err := rootKeys.EncryptAfterBucket(prefix, cipher)
+		require.NoError(t, err)
+		prefixKey, err := DerivePathKey(prefix, root, 3)
+		require.NoError(t, err)
+		bucketContentKey, err := DeriveContentKey("bucket", root)
+		require.NoError(t, err)
+
+		keys, err := NewPrefixKeys(prefix, encryptedPrefix, prefixKey, bucketContentKey)
+		require.NoError(t, err)
+		assert.Equal(t, prefix, keys.Prefix())
+
+		for i, path := range []storj.Path{
+			"bucket/fold1/fold2",
+			"bucket/fold1/fold2/file.txt",
+			"bucket/fold1/fold2/fold3/file.txt",
+		} {
+			errTag := fmt.Sprintf("%d. %+v", i, path)
+
+			expected, err := rootKeys.EncryptAfterBucket(path, cipher)
+			require.NoError(t, err, errTag)
+			encrypted, err := keys.EncryptAfterBucket(path, cipher)
+			require.NoError(t, err, errTag)
+			assert.Equal(t, expected, encrypted, errTag)
+
+			decrypted, err := keys.DecryptAfterBucket(encrypted, cipher)
+			require.NoError(t, err, errTag)
+			assert.Equal(t, path, decrypted, errTag)
+
+			expectedKey, err := rootKeys.DeriveContentKey(path)
+			require.NoError(t, err, errTag)
+			contentKey, err := keys.DeriveContentKey(path)
+			require.NoError(t, err, errTag)
+			assert.Equal(t, expectedKey, contentKey, errTag)
+		}
+
+		// the bucket is readable, but not the paths next to the prefix
+		contentKey, err := keys.DeriveContentKey("bucket")
+		require.NoError(t, err)
+		assert.Equal(t, bucketContentKey, contentKey)
+
+		for i, path := range []storj.Path{
+			"bucket/fold1",
+			"bucket/fold1/other/file.txt",
+			"other/fold1/fold2/file.txt",
+		} {
+			errTag := fmt.Sprintf("%d. %+v", i, path)
+
+			_, err := keys.EncryptAfterBucket(path, cipher)
+			assert.Error(t, err, errTag)
+			_, err = keys.DeriveContentKey(path)
+			assert.Error(t, err, errTag)
+			_, err = keys.DerivePathKey(path, len(storj.SplitPath(path)))
+			assert.Error(t, err, errTag)
+		}
+	})
+}
+
+func TestNewPrefixKeysInvalid(t *testing.T) {
+	key := new(storj.Key)
+	for i, tt := range []struct {
+		prefix, encryptedPrefix storj.Path
+	}{
+		{"", ""},
+		{"bucket/fold1", "bucket"},
+		{"bucket/fold1", "other/fold1"},
+	} {
+		_, err := NewPrefixKeys(tt.prefix, tt.encryptedPrefix, key, nil)
+		assert.Error(t, err, fmt.Sprintf("%d. %+v", i, tt))
+	}
+}
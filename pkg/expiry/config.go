@@ -0,0 +1,75 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package expiry
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/pointerdb/pdbclient"
+	"storj.io/storj/pkg/provider"
+	ecclient "storj.io/storj/pkg/storage/ec"
+	"storj.io/storj/pkg/storage/segments"
+)
+
+// Config contains configurable values for the expiry sweeper
+type Config struct {
+	Interval      time.Duration `help:"how frequently expired segments are deleted" default:"1h"`
+	OverlayAddr   string        `help:"Address to contact overlay server through"`
+	PointerDBAddr string        `help:"Address to contact pointerdb server through"`
+	MaxBufferMem  int           `help:"maximum buffer memory (in bytes) to be allocated for read buffers" default:"0x400000"`
+	APIKey        string        `help:"expiry-specific pointerdb access credential"`
+}
+
+// Run runs the expiry sweeper with configured values
+func (c Config) Run(ctx context.Context, server *provider.Provider) (err error) {
+	pdb := pointerdb.LoadFromContext(ctx)
+	if pdb == nil {
+		return Error.New("failed to load pointerdb from context")
+	}
+
+	store, err := c.getSegmentStore(ctx, server.Identity())
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	sweeper := NewSweeper(pdb, store, 0, zap.L(), c.Interval)
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		if err := sweeper.Run(ctx); err != nil {
+			defer cancel()
+			zap.L().Error("Error running expiry sweeper", zap.Error(err))
+		}
+	}()
+
+	return server.Run(ctx)
+}
+
+// getSegmentStore creates a segment store for deleting segments together
+// with their pieces
+func (c Config) getSegmentStore(ctx context.Context, identity *provider.FullIdentity) (store segments.Store, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	oc, err := overlay.NewClient(identity, c.OverlayAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	pdb, err := pdbclient.NewClient(identity, c.PointerDBAddr, c.APIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ec := ecclient.NewClient(identity, c.MaxBufferMem)
+
+	// the redundancy strategy is only used for uploads
+	return segments.NewSegmentStore(oc, ec, pdb, eestream.RedundancyStrategy{}, 0), nil
+}
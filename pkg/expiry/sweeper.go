@@ -0,0 +1,133 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package expiry
+
+import (
+	"context"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+// Deleter deletes a segment together with its pieces
+type Deleter interface {
+	Delete(ctx context.Context, path storj.Path) error
+}
+
+// Sweeper periodically deletes the segments whose expiration date has passed
+type Sweeper struct {
+	logger    *zap.Logger
+	pointerdb *pointerdb.Server
+	segments  Deleter
+	limit     int
+	ticker    *time.Ticker
+}
+
+// NewSweeper creates a new expiry sweeper. At most limit expired segments
+// are collected before they are deleted.
+func NewSweeper(pointerdb *pointerdb.Server, segments Deleter, limit int, logger *zap.Logger, interval time.Duration) *Sweeper {
+	if limit <= 0 || limit > storage.LookupLimit {
+		limit = storage.LookupLimit
+	}
+	return &Sweeper{
+		logger:    logger,
+		pointerdb: pointerdb,
+		segments:  segments,
+		limit:     limit,
+		ticker:    time.NewTicker(interval),
+	}
+}
+
+// Run the sweeper loop
+func (sweeper *Sweeper) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		err = sweeper.Sweep(ctx, time.Now())
+		if err != nil {
+			sweeper.logger.Error("Expiry sweep failed", zap.Error(err))
+		}
+
+		select {
+		case <-sweeper.ticker.C: // wait for the next interval to happen
+		case <-ctx.Done(): // or the sweeper is canceled via context
+			return ctx.Err()
+		}
+	}
+}
+
+// Sweep deletes all the segments that expired before now. Segments failing
+// to be deleted are left for the next sweep.
+func (sweeper *Sweeper) Sweep(ctx context.Context, now time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	first := ""
+	for {
+		paths, next, err := sweeper.expired(ctx, first, now)
+		if err != nil {
+			return err
+		}
+
+		// the pointers are deleted outside of the iteration, as some
+		// databases can't write while iterating
+		for _, path := range paths {
+			if err := sweeper.segments.Delete(ctx, path); err != nil {
+				sweeper.logger.Warn("failed to delete expired segment", zap.String("path", path), zap.Error(err))
+				continue
+			}
+			mon.Meter("expired_segments_deleted").Mark(1)
+		}
+
+		if next == "" {
+			return nil
+		}
+		first = next
+	}
+}
+
+// expired returns up to limit expired segments starting from first and the
+// path to continue from, which is empty when all segments were checked
+func (sweeper *Sweeper) expired(ctx context.Context, first string, now time.Time) (paths []storj.Path, next string, err error) {
+	err = sweeper.pointerdb.Iterate(ctx, &pb.IterateRequest{First: first, Recurse: true},
+		func(it storage.Iterator) error {
+			var item storage.ListItem
+			for it.Next(&item) {
+				if len(paths) >= sweeper.limit {
+					next = item.Key.String()
+					return nil
+				}
+
+				pointer := &pb.Pointer{}
+				if err := proto.Unmarshal(item.Value, pointer); err != nil {
+					return Error.New("error unmarshalling pointer %s", err)
+				}
+				if isExpired(pointer, now) {
+					paths = append(paths, item.Key.String())
+				}
+			}
+			return nil
+		},
+	)
+	return paths, next, err
+}
+
+// isExpired checks whether the pointer expired before now. Pointers without
+// expiration have the zero time as expiration date.
+func isExpired(pointer *pb.Pointer, now time.Time) bool {
+	if pointer.GetExpirationDate() == nil {
+		return false
+	}
+	expiration, err := ptypes.Timestamp(pointer.GetExpirationDate())
+	if err != nil {
+		return false
+	}
+	return !expiration.IsZero() && expiration.Before(now)
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package expiry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/pointerdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/teststore"
+)
+
+// testDeleter deletes pointers without pieces and fails for the paths in fail
+type testDeleter struct {
+	pointerdb *pointerdb.Server
+	deleted   []storj.Path
+	fail      map[storj.Path]bool
+}
+
+func (deleter *testDeleter) Delete(ctx context.Context, path storj.Path) error {
+	if deleter.fail[path] {
+		return errors.New("delete failed")
+	}
+	deleter.deleted = append(deleter.deleted, path)
+	_, err := deleter.pointerdb.Delete(ctx, &pb.DeleteRequest{Path: path})
+	return err
+}
+
+func TestSweep(t *testing.T) {
+	ctx := auth.WithAPIKey(context.Background(), nil)
+	db := teststore.New()
+	pdb := pointerdb.NewServer(db, &overlay.Cache{}, zap.NewNop(), pointerdb.Config{MaxInlineSegmentSize: 8000}, nil)
+
+	now := time.Now()
+	for path, expiration := range map[storj.Path]time.Time{
+		"l/bucket/a":     now.Add(-time.Hour),
+		"l/bucket/b":     {},
+		"l/bucket/c":     now.Add(time.Hour),
+		"l/bucket/d":     now.Add(-time.Minute),
+		"s0/bucket/d":    now.Add(-time.Minute),
+		"l/bucket/e":     {},
+		"l/other/f":      now.Add(-24 * time.Hour),
+		"l/other/failed": now.Add(-time.Hour),
+	} {
+		timestamp, err := ptypes.TimestampProto(expiration)
+		require.NoError(t, err)
+
+		_, err = pdb.Put(ctx, &pb.PutRequest{
+			Path: path,
+			Pointer: &pb.Pointer{
+				Type:           pb.Pointer_INLINE,
+				InlineSegment:  []byte(path),
+				ExpirationDate: timestamp,
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	deleter := &testDeleter{pointerdb: pdb, fail: map[storj.Path]bool{"l/other/failed": true}}
+	sweeper := NewSweeper(pdb, deleter, 2, zap.NewNop(), time.Hour)
+
+	err := sweeper.Sweep(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, []storj.Path{"l/bucket/a", "l/bucket/d", "l/other/f", "s0/bucket/d"}, deleter.deleted)
+
+	for _, path := range []storj.Path{"l/bucket/b", "l/bucket/c", "l/bucket/e", "l/other/failed"} {
+		_, err := db.Get(storage.Key(path))
+		assert.NoError(t, err, path)
+	}
+
+	// later sweeps delete the segments expiring in between
+	deleter.deleted = nil
+	err = sweeper.Sweep(ctx, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []storj.Path{"l/bucket/c"}, deleter.deleted)
+}
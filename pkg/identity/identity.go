@@ -311,15 +311,33 @@ func (fi *FullIdentity) DialOption(id storj.NodeID) (grpc.DialOption, error) {
 	tlsConfig := &tls.Config{
 		Certificates:       []tls.Certificate{*c},
 		InsecureSkipVerify: true,
-		VerifyPeerCertificate: peertls.VerifyPeerFunc(
+		VerifyPeerCertificate: permanent(peertls.VerifyPeerFunc(
 			peertls.VerifyPeerCertChains,
 			verifyIdentity(id),
-		),
+		)),
 	}
 
 	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)), nil
 }
 
+// permanentError is a peer verification failure that retrying won't fix
+type permanentError struct{ error }
+
+// Temporary tells grpc not to retry the dial
+func (permanentError) Temporary() bool { return false }
+
+// permanent marks the errors of verify as permanent, so dials with
+// grpc.FailOnNonTempDialError give up on peers with the wrong identity
+// instead of retrying until they time out
+func permanent(verify peertls.PeerCertVerificationFunc) peertls.PeerCertVerificationFunc {
+	return func(raw [][]byte, parsed [][]*x509.Certificate) error {
+		if err := verify(raw, parsed); err != nil {
+			return permanentError{err}
+		}
+		return nil
+	}
+}
+
 func verifyIdentity(id storj.NodeID) peertls.PeerCertVerificationFunc {
 	return func(_ [][]byte, parsedChains [][]*x509.Certificate) (err error) {
 		defer mon.TaskNamed("verifyIdentity")(nil)(&err)
@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
+	"storj.io/storj/pkg/audit"
 	"storj.io/storj/pkg/kademlia"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
@@ -44,18 +45,20 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 
 	sdb, ok := ctx.Value("masterdb").(interface {
 		StatDB() statdb.DB
+		Containment() audit.Containment
 	})
 	if !ok {
 		return Error.New("unable to get master db instance")
 	}
 
 	srv := &Server{
-		dht:      kad,
-		identity: server.Identity(),
-		cache:    ol,
-		statdb:   sdb.StatDB(),
-		logger:   zap.L(),
-		metrics:  monkit.Default,
+		dht:         kad,
+		identity:    server.Identity(),
+		cache:       ol,
+		statdb:      sdb.StatDB(),
+		containment: sdb.Containment(),
+		logger:      zap.L(),
+		metrics:     monkit.Default,
 	}
 
 	pb.RegisterInspectorServer(server.GRPC(), srv)
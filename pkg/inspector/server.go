@@ -6,10 +6,12 @@ package inspector
 import (
 	"context"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
+	"storj.io/storj/pkg/audit"
 	"storj.io/storj/pkg/dht"
 	"storj.io/storj/pkg/node"
 	"storj.io/storj/pkg/overlay"
@@ -26,12 +28,13 @@ var (
 
 // Server holds references to cache and kad
 type Server struct {
-	dht      dht.DHT
-	cache    *overlay.Cache
-	statdb   statdb.DB
-	logger   *zap.Logger
-	metrics  *monkit.Registry
-	identity *provider.FullIdentity
+	dht         dht.DHT
+	cache       *overlay.Cache
+	statdb      statdb.DB
+	containment audit.Containment
+	logger      *zap.Logger
+	metrics     *monkit.Registry
+	identity    *provider.FullIdentity
 }
 
 // ---------------------
@@ -44,7 +47,7 @@ func (srv *Server) CountNodes(ctx context.Context, req *pb.CountNodesRequest) (*
 	if err != nil {
 		return nil, err
 	}
-	kadNodes, err := srv.dht.GetNodes(ctx, srv.identity.ID, 0)
+	kadNodes, err := srv.dht.GetNodes(ctx, storj.NodeID{}, 0)
 	if err != nil {
 		return nil, err
 	}
@@ -141,6 +144,31 @@ func (srv *Server) LookupNode(ctx context.Context, req *pb.LookupNodeRequest) (*
 	}, nil
 }
 
+// ExportRoutingTable returns every node in the routing table
+func (srv *Server) ExportRoutingTable(ctx context.Context, req *pb.ExportRoutingTableRequest) (*pb.ExportRoutingTableResponse, error) {
+	nodes, err := srv.dht.GetNodes(ctx, storj.NodeID{}, 0)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+	return &pb.ExportRoutingTableResponse{Nodes: nodes}, nil
+}
+
+// ImportRoutingTable pings the given nodes, so that those that respond are added to the routing table
+func (srv *Server) ImportRoutingTable(ctx context.Context, req *pb.ImportRoutingTableRequest) (*pb.ImportRoutingTableResponse, error) {
+	var responding int64
+	for _, node := range req.Nodes {
+		if node.Id == srv.identity.ID {
+			continue
+		}
+		if _, err := srv.dht.Ping(ctx, *node); err != nil {
+			srv.logger.Debug("imported node did not respond", zap.String("nodeID", node.Id.String()), zap.Error(err))
+			continue
+		}
+		responding++
+	}
+	return &pb.ImportRoutingTableResponse{Responding: responding}, nil
+}
+
 // ---------------------
 // StatDB commands:
 // ---------------------
@@ -152,11 +180,19 @@ func (srv *Server) GetStats(ctx context.Context, req *pb.GetStatsRequest) (*pb.G
 		return nil, err
 	}
 
+	stateUpdatedAt, err := ptypes.TimestampProto(stats.StateUpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
 	return &pb.GetStatsResponse{
-		AuditCount:  stats.AuditCount,
-		AuditRatio:  stats.AuditSuccessRatio,
-		UptimeCount: stats.UptimeCount,
-		UptimeRatio: stats.UptimeRatio,
+		AuditCount:     stats.AuditCount,
+		AuditRatio:     stats.AuditSuccessRatio,
+		UptimeCount:    stats.UptimeCount,
+		UptimeRatio:    stats.UptimeRatio,
+		State:          pb.NodeState(stats.State),
+		StateUpdatedAt: stateUpdatedAt,
+		StateOverride:  stats.StateOverride,
 	}, nil
 }
 
@@ -176,3 +212,72 @@ func (srv *Server) CreateStats(ctx context.Context, req *pb.CreateStatsRequest)
 
 	return &pb.CreateStatsResponse{}, nil
 }
+
+// UpdateNodeState overrides the state of a node until it is released. Released nodes
+// are moved to the state their stats call for on their next audit or uptime check.
+func (srv *Server) UpdateNodeState(ctx context.Context, req *pb.UpdateNodeStateRequest) (_ *pb.UpdateNodeStateResponse, err error) {
+	if req.Release {
+		_, err = srv.cache.ReleaseState(ctx, req.NodeId)
+	} else {
+		_, err = srv.cache.OverrideState(ctx, req.NodeId, statdb.NodeState(req.State))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateNodeStateResponse{}, nil
+}
+
+// ---------------------
+// Audit commands:
+// ---------------------
+
+// ListPendingAudits returns the pending audits of all contained nodes
+func (srv *Server) ListPendingAudits(ctx context.Context, req *pb.ListPendingAuditsRequest) (*pb.ListPendingAuditsResponse, error) {
+	pendingAudits, err := srv.containment.List(ctx)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
+	res := &pb.ListPendingAuditsResponse{}
+	for _, pending := range pendingAudits {
+		converted, err := convertPendingAudit(pending)
+		if err != nil {
+			return nil, ServerError.Wrap(err)
+		}
+		res.PendingAudits = append(res.PendingAudits, converted)
+	}
+	return res, nil
+}
+
+// GetPendingAudit returns the pending audit of a contained node
+func (srv *Server) GetPendingAudit(ctx context.Context, req *pb.GetPendingAuditRequest) (*pb.GetPendingAuditResponse, error) {
+	pending, err := srv.containment.Get(ctx, req.NodeId)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+
+	converted, err := convertPendingAudit(pending)
+	if err != nil {
+		return nil, ServerError.Wrap(err)
+	}
+	return &pb.GetPendingAuditResponse{PendingAudit: converted}, nil
+}
+
+func convertPendingAudit(pending *audit.PendingAudit) (*pb.PendingAudit, error) {
+	createdAt, err := ptypes.TimestampProto(pending.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.PendingAudit{
+		NodeId:        pending.NodeID,
+		PieceId:       pending.PieceID,
+		PieceNum:      int64(pending.PieceNum),
+		StripeIndex:   int64(pending.StripeIndex),
+		ShareSize:     int64(pending.ShareSize),
+		ReverifyCount: int64(pending.ReverifyCount),
+		Path:          pending.Path,
+		CreatedAt:     createdAt,
+	}, nil
+}
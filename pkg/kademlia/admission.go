@@ -0,0 +1,65 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"net"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// hasDifficulty returns whether id has at least the minimum number of trailing zero
+// bits. Dialing a node verifies that its id was derived from its key with
+// identity.NodeIDFromKey, so the difficulty of admitted nodes can't be faked
+func hasDifficulty(id storj.NodeID, minimum uint16) bool {
+	if minimum == 0 {
+		return true
+	}
+	difficulty, err := id.Difficulty()
+	return err == nil && difficulty >= minimum
+}
+
+// ipPrefix returns the /24 (IPv4) or /64 (IPv6) network of address, the host name
+// when it isn't an IP, or "" for loopback addresses which aren't limited
+func ipPrefix(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	ip := net.ParseIP(host)
+	switch {
+	case ip == nil:
+		return host
+	case ip.IsLoopback():
+		return ""
+	case ip.To4() != nil:
+		return ip.Mask(net.CIDRMask(24, 32)).String()
+	default:
+		return ip.Mask(net.CIDRMask(64, 128)).String()
+	}
+}
+
+// ipPrefixFull returns whether the bucket already holds as many nodes from the
+// IP prefix of node as the limit allows
+func (rt *RoutingTable) ipPrefixFull(bID bucketID, node *pb.Node) (bool, error) {
+	if rt.bucketPrefixLimit <= 0 {
+		return false, nil
+	}
+	prefix := ipPrefix(node.GetAddress().GetAddress())
+	if prefix == "" {
+		return false, nil
+	}
+	nodes, err := rt.getUnmarshaledNodesFromBucket(bID)
+	if err != nil {
+		return false, err
+	}
+	count := 0
+	for _, n := range nodes {
+		if n.Id != node.Id && ipPrefix(n.GetAddress().GetAddress()) == prefix {
+			count++
+		}
+	}
+	return count >= rt.bucketPrefixLimit, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestIPPrefix(t *testing.T) {
+	for _, tt := range []struct {
+		address  string
+		expected string
+	}{
+		{"1.2.3.4:7777", "1.2.3.0"},
+		{"1.2.3.200:1", "1.2.3.0"},
+		{"1.2.4.4:7777", "1.2.4.0"},
+		{"[2001:db8:1:2:3:4:5:6]:7777", "2001:db8:1:2::"},
+		{"127.0.0.1:7777", ""},
+		{"[::1]:7777", ""},
+		{"storj.io:7777", "storj.io"},
+	} {
+		assert.Equal(t, tt.expected, ipPrefix(tt.address), tt.address)
+	}
+}
+
+func TestMinimumDifficulty(t *testing.T) {
+	rt, cleanup := createRoutingTable(t, teststorj.NodeIDFromString("OO"))
+	defer cleanup()
+	rt.minDifficulty = 8
+
+	easy := teststorj.MockNode("PO") // trailing 0xff bytes, difficulty 0
+	hard := &pb.Node{Id: storj.NodeID{'Q', 'O'}, Type: pb.NodeType_STORAGE}
+
+	assert.NoError(t, rt.ConnectionSuccess(easy))
+	assert.NoError(t, rt.ConnectionSuccess(hard))
+
+	v, _ := rt.nodeBucketDB.Get(easy.Id.Bytes())
+	assert.Nil(t, v)
+	v, _ = rt.nodeBucketDB.Get(hard.Id.Bytes())
+	assert.NotNil(t, v)
+
+	assert.NotContains(t, rt.seen, easy.Id)
+}
+
+func TestBucketPrefixLimit(t *testing.T) {
+	rt, cleanup := createRoutingTable(t, teststorj.NodeIDFromString("OO"))
+	defer cleanup()
+	rt.bucketPrefixLimit = 2
+
+	node := func(id, address string) *pb.Node {
+		n := teststorj.MockNode(id)
+		n.Address = &pb.NodeAddress{Address: address}
+		return n
+	}
+
+	for _, tt := range []struct {
+		node  *pb.Node
+		added bool
+	}{
+		{node("PO", "1.2.3.4:7777"), true},
+		{node("NO", "1.2.3.5:7777"), true},
+		{node("MO", "1.2.3.6:7777"), false}, // third node from 1.2.3.0/24
+		{node("LO", "1.2.4.4:7777"), true},
+		{node("QO", "127.0.0.1:7777"), true},
+		{node("SO", "127.0.0.1:7778"), true}, // loopback isn't limited
+	} {
+		added, err := rt.addNode(tt.node)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.added, added, string(tt.node.Id.Bytes()[:2]))
+	}
+
+	for _, nodes := range rt.replacementCache {
+		for _, n := range nodes {
+			assert.NotEqual(t, teststorj.NodeIDFromString("MO"), n.Id)
+		}
+	}
+}
@@ -26,6 +26,10 @@ var (
 var (
 	flagBucketSize           = flag.Int("kademlia.bucket-size", 20, "Size of each Kademlia bucket")
 	flagReplacementCacheSize = flag.Int("kademlia.replacement-cache-size", 5, "Size of Kademlia replacement cache")
+	flagMinimumDifficulty    = flag.Int("kademlia.minimum-difficulty", 0, "Minimum identity difficulty of nodes admitted to the routing table; 0 admits any identity")
+	flagBucketPrefixLimit    = flag.Int("kademlia.bucket-prefix-limit", 3, "Max number of nodes from the same IP prefix (/24 or /64) in a Kademlia bucket; 0 disables the limit")
+	flagDisjointPaths        = flag.Int("kademlia.disjoint-paths", 3, "Number of disjoint paths used for each Kademlia lookup")
+	flagMaxFailures          = flag.Int("kademlia.max-failures", 5, "Consecutive connection failures before a node is evicted from the routing table")
 )
 
 //CtxKey Used as kademlia key
@@ -44,7 +48,8 @@ type OperatorConfig struct {
 // Config defines all of the things that are needed to start up Kademlia
 // server endpoints (and not necessarily client code).
 type Config struct {
-	BootstrapAddr   string `help:"the kademlia node to bootstrap against" default:"127.0.0.1:7778"`
+	BootstrapAddr   string `help:"comma-separated list of kademlia nodes to bootstrap against" default:"127.0.0.1:7778"`
+	RestoreMinNodes int    `help:"how many nodes of the persisted routing table must respond on startup to skip bootstrapping" default:"5"`
 	DBPath          string `help:"the path for our db services to be created on" default:"$CONFDIR/kademlia"`
 	Alpha           int    `help:"alpha is a system wide concurrency parameter." default:"5"`
 	ExternalAddress string `help:"the public address of the kademlia node; defaults to the gRPC server address." default:""`
@@ -84,8 +89,7 @@ func (c Config) Run(ctx context.Context, server *provider.Provider,
 	nodeType pb.NodeType) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	// TODO(coyle): I'm thinking we just remove this function and grab from the config.
-	in, err := GetIntroNode(c.BootstrapAddr)
+	bootstrapNodes, err := GetIntroNodes(c.BootstrapAddr)
 	if err != nil {
 		return err
 	}
@@ -101,18 +105,18 @@ func (c Config) Run(ctx context.Context, server *provider.Provider,
 	}
 
 	logger := zap.L()
-	kad, err := NewKademlia(logger, nodeType, []pb.Node{*in}, addr, metadata, server.Identity(), c.DBPath, c.Alpha)
+	kad, err := NewKademlia(logger, nodeType, bootstrapNodes, addr, metadata, server.Identity(), c.DBPath, c.Alpha)
 	if err != nil {
 		return err
 	}
 	kad.StartRefresh(ctx)
 	defer func() { err = utils.CombineErrors(err, kad.Disconnect()) }()
 
-	pb.RegisterNodesServer(server.GRPC(), node.NewServer(logger, kad))
+	pb.RegisterNodesServer(server.GRPC(), node.NewServer(logger, kad, server.Identity()))
 
 	go func() {
-		if err = kad.Bootstrap(ctx); err != nil {
-			logger.Error("Failed to bootstrap Kademlia", zap.Any("ID", server.Identity().ID))
+		if _, err := kad.Restore(ctx, c.RestoreMinNodes); err != nil {
+			logger.Error("Failed to bootstrap Kademlia", zap.Any("ID", server.Identity().ID), zap.Error(err))
 		}
 	}()
 
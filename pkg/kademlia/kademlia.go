@@ -9,6 +9,8 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 	"unsafe"
@@ -22,6 +24,7 @@ import (
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
 	"storj.io/storj/storage"
 	"storj.io/storj/storage/boltdb"
 )
@@ -43,12 +46,14 @@ type discoveryOptions struct {
 	retries        int
 	bootstrap      bool
 	bootstrapNodes []pb.Node
+	minDifficulty  uint16
 }
 
 // Kademlia is an implementation of kademlia adhering to the DHT interface.
 type Kademlia struct {
 	log             *zap.Logger
 	alpha           int // alpha is a system wide concurrency parameter
+	disjointPaths   int // number of disjoint paths of a lookup
 	routingTable    *RoutingTable
 	bootstrapNodes  []pb.Node
 	nodeClient      node.Client
@@ -92,6 +97,7 @@ func NewKademliaWithRoutingTable(log *zap.Logger, self pb.Node, bootstrapNodes [
 	k := &Kademlia{
 		log:            log,
 		alpha:          alpha,
+		disjointPaths:  *flagDisjointPaths,
 		routingTable:   rt,
 		bootstrapNodes: bootstrapNodes,
 		identity:       identity,
@@ -103,6 +109,7 @@ func NewKademliaWithRoutingTable(log *zap.Logger, self pb.Node, bootstrapNodes [
 		return nil, BootstrapErr.Wrap(err)
 	}
 	k.nodeClient = nc
+	rt.SetPinger(k)
 	return k, nil
 }
 
@@ -162,6 +169,29 @@ func (k *Kademlia) GetNodes(ctx context.Context, start storj.NodeID, limit int,
 	return nodes, nil
 }
 
+// AddObserver registers obs to be notified of every node that contacts us or that we contact
+func (k *Kademlia) AddObserver(obs transport.Observer) {
+	k.routingTable.AddObserver(obs)
+}
+
+// GetBootstrapNodes returns the trusted nodes used to join the network
+func (k *Kademlia) GetBootstrapNodes() []pb.Node {
+	return k.bootstrapNodes
+}
+
+// UpdateAddress changes the address we advertise to other nodes
+func (k *Kademlia) UpdateAddress(address string) error {
+	self := k.routingTable.Local()
+	if self.Address != nil && self.Address.Address == address {
+		return nil
+	}
+	self.Address = &pb.NodeAddress{
+		Transport: defaultTransport,
+		Address:   address,
+	}
+	return k.routingTable.UpdateSelf(&self)
+}
+
 // GetRoutingTable provides the routing table for the Kademlia DHT
 func (k *Kademlia) GetRoutingTable(ctx context.Context) (dht.RoutingTable, error) {
 	return k.routingTable, nil
@@ -184,6 +214,58 @@ func (k *Kademlia) Bootstrap(ctx context.Context) error {
 	return err
 }
 
+// Restore checks the nodes persisted in the routing table by pinging them in
+// parallel, and only bootstraps when fewer than minResponding of them respond.
+// It returns how many persisted nodes responded
+func (k *Kademlia) Restore(ctx context.Context, minResponding int) (responding int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	self := k.routingTable.Local()
+	persisted, err := k.GetNodes(ctx, storj.NodeID{}, 0)
+	if err != nil {
+		return 0, err
+	}
+	nodes := make([]pb.Node, 0, len(persisted))
+	for _, node := range persisted {
+		if node.Id != self.Id {
+			nodes = append(nodes, *node)
+		}
+	}
+
+	responding = k.PingNodes(ctx, nodes)
+	if responding >= minResponding {
+		k.log.Info("restored routing table", zap.Int("responding", responding), zap.Int("persisted", len(nodes)))
+		return responding, nil
+	}
+
+	k.log.Info("too few persisted nodes responded, bootstrapping",
+		zap.Int("responding", responding), zap.Int("persisted", len(nodes)))
+	return responding, k.Bootstrap(ctx)
+}
+
+// PingNodes pings nodes with up to alpha pings at a time and returns how many
+// responded. The routing table is updated with the outcome of each ping
+func (k *Kademlia) PingNodes(ctx context.Context, nodes []pb.Node) int {
+	limiter := make(chan struct{}, k.alpha)
+	var responding int64
+	var wg sync.WaitGroup
+	for _, node := range nodes {
+		wg.Add(1)
+		limiter <- struct{}{}
+		go func(node pb.Node) {
+			defer wg.Done()
+			defer func() { <-limiter }()
+			if _, err := k.Ping(ctx, node); err != nil {
+				k.log.Debug("ping failed", zap.String("nodeID", node.Id.String()), zap.Error(err))
+				return
+			}
+			atomic.AddInt64(&responding, 1)
+		}(node)
+	}
+	wg.Wait()
+	return int(responding)
+}
+
 // Ping checks that the provided node is still accessible on the network
 func (k *Kademlia) Ping(ctx context.Context, node pb.Node) (pb.Node, error) {
 	ok, err := k.nodeClient.Ping(ctx, node)
@@ -196,13 +278,24 @@ func (k *Kademlia) Ping(ctx context.Context, node pb.Node) (pb.Node, error) {
 	return node, nil
 }
 
+// FetchNeighbors asks node for the nodes closest to it in its own routing table
+func (k *Kademlia) FetchNeighbors(ctx context.Context, node pb.Node) ([]*pb.Node, error) {
+	neighbors, err := k.nodeClient.Lookup(ctx, node, pb.Node{Id: node.Id, Type: node.Type})
+	if err != nil {
+		return nil, NodeErr.Wrap(err)
+	}
+	return neighbors, nil
+}
+
 // FindNode looks up the provided NodeID first in the local Node, and if it is not found
 // begins searching the network for the NodeID. Returns and error if node was not found
 func (k *Kademlia) FindNode(ctx context.Context, ID storj.NodeID) (pb.Node, error) {
 	return k.lookup(ctx, ID, false)
 }
 
-//lookup initiates a kadmelia node lookup
+// lookup initiates a kadmelia node lookup over disjoint paths, in the manner
+// of S/Kademlia, so a single malicious node can't steer the whole lookup.
+// Every path runs to the end and the paths that found the target have to agree on it.
 func (k *Kademlia) lookup(ctx context.Context, ID storj.NodeID, isBootstrap bool) (pb.Node, error) {
 	kb := k.routingTable.K()
 	var nodes []*pb.Node
@@ -217,13 +310,56 @@ func (k *Kademlia) lookup(ctx context.Context, ID storj.NodeID, isBootstrap bool
 			return pb.Node{}, err
 		}
 	}
-	lookup := newPeerDiscovery(k.log, nodes, k.nodeClient, ID, discoveryOptions{
-		concurrency: k.alpha, retries: defaultRetries, bootstrap: isBootstrap, bootstrapNodes: k.bootstrapNodes,
-	})
-	target, err := lookup.Run(ctx)
+
+	paths := k.disjointPaths
+	if paths < 1 {
+		paths = 1
+	}
+	seeds := make([][]*pb.Node, paths)
+	for i, node := range nodes {
+		seeds[i%paths] = append(seeds[i%paths], node)
+	}
+
+	var mu sync.Mutex
+	var found []*pb.Node
+	var errlist []error
+
+	visited := newVisitedNodes()
+	var wg sync.WaitGroup
+	for _, seed := range seeds {
+		lookup := newPeerDiscovery(k.log, seed, k.nodeClient, ID, discoveryOptions{
+			concurrency: k.alpha, retries: defaultRetries, bootstrap: isBootstrap, bootstrapNodes: k.bootstrapNodes,
+			minDifficulty: k.routingTable.minDifficulty,
+		}, visited)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			node, err := lookup.Run(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			// a path can only vote for the node that was looked up
+			if node != nil && node.Id == ID {
+				found = append(found, node)
+			}
+			if err != nil {
+				errlist = append(errlist, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	target, err := agree(found)
 	if err != nil {
 		return pb.Node{}, err
 	}
+	if target == nil {
+		if err := errs.Combine(errlist...); err != nil {
+			return pb.Node{}, err
+		}
+	}
+
 	bucket, err := k.routingTable.getKBucketID(ID)
 	if err != nil {
 		k.log.Warn("Error getting getKBucketID in kad lookup")
@@ -242,6 +378,28 @@ func (k *Kademlia) lookup(ctx context.Context, ID storj.NodeID, isBootstrap bool
 	return *target, nil
 }
 
+// agree returns the target most lookup paths found. Paths that found different addresses for
+// the target need a majority, otherwise a malicious node could redirect the lookup to itself.
+func agree(found []*pb.Node) (*pb.Node, error) {
+	if len(found) == 0 {
+		return nil, nil
+	}
+
+	votes := make(map[string]int)
+	var target *pb.Node
+	for _, node := range found {
+		address := node.GetAddress().GetAddress()
+		votes[address]++
+		if target == nil || votes[address] > votes[target.GetAddress().GetAddress()] {
+			target = node
+		}
+	}
+	if votes[target.GetAddress().GetAddress()]*2 <= len(found) {
+		return nil, NodeErr.New("lookup paths disagree on the address of %s", target.Id)
+	}
+	return target, nil
+}
+
 // Seen returns all nodes that this kademlia instance has successfully communicated with
 func (k *Kademlia) Seen() []*pb.Node {
 	nodes := []*pb.Node{}
@@ -253,20 +411,29 @@ func (k *Kademlia) Seen() []*pb.Node {
 	return nodes
 }
 
-// GetIntroNode determines the best node to bootstrap a new node onto the network
-func GetIntroNode(addr string) (*pb.Node, error) {
-	if addr == "" {
-		addr = "bootstrap.storj.io:8080"
+// GetIntroNodes returns the nodes at a comma-separated list of addresses
+// to bootstrap a new node onto the network
+func GetIntroNodes(addrs string) ([]pb.Node, error) {
+	var nodes []pb.Node
+	for _, addr := range strings.Split(addrs, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		nodes = append(nodes, pb.Node{
+			Address: &pb.NodeAddress{
+				Transport: defaultTransport,
+				Address:   addr,
+			},
+			// TODO: nodetype is an assumption for now, but we shouldn't need to know
+			// or care for bootstrapping
+			Type: pb.NodeType_SATELLITE,
+		})
 	}
-	return &pb.Node{
-		Address: &pb.NodeAddress{
-			Transport: defaultTransport,
-			Address:   addr,
-		},
-		// TODO: nodetype is an assumption for now, but we shouldn't need to know
-		// or care for bootstrapping
-		Type: pb.NodeType_SATELLITE,
-	}, nil
+	if len(nodes) == 0 {
+		return nil, BootstrapErr.New("no bootstrap addresses provided")
+	}
+	return nodes, nil
 }
 
 //StartRefresh occasionally refreshes stale kad buckets
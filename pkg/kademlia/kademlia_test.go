@@ -176,7 +176,7 @@ func testNode(t *testing.T, bn []pb.Node) (*Kademlia, *grpc.Server, func()) {
 	logger := zaptest.NewLogger(t)
 	k, err := NewKademlia(logger, pb.NodeType_STORAGE, bn, lis.Addr().String(), nil, fid, dir, defaultAlpha)
 	assert.NoError(t, err)
-	s := node.NewServer(logger, k)
+	s := node.NewServer(logger, k, fid)
 	// new ident opts
 	identOpt, err := fid.ServerOption()
 	assert.NoError(t, err)
@@ -532,3 +532,7 @@ func (mn *mockNodesServer) Ping(ctx context.Context, req *pb.PingRequest) (*pb.P
 	atomic.AddInt32(&mn.pingCalled, 1)
 	return &pb.PingResponse{}, nil
 }
+
+func (mn *mockNodesServer) DialBack(ctx context.Context, req *pb.DialBackRequest) (*pb.DialBackResponse, error) {
+	return &pb.DialBackResponse{}, nil
+}
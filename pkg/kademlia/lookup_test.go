@@ -4,12 +4,15 @@
 package kademlia_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"storj.io/storj/internal/testcontext"
 	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
 )
 
 func TestLookupNodes(t *testing.T) {
@@ -37,3 +40,80 @@ func TestLookupNodes(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, target.Id, found.Id)
 }
+
+// eclipser answers every lookup with made up nodes close to the target
+type eclipser struct {
+	address string
+}
+
+func (e *eclipser) Query(ctx context.Context, req *pb.QueryRequest) (*pb.QueryResponse, error) {
+	var fakes []*pb.Node
+	for i := byte(1); i <= 5; i++ {
+		id := req.Target.Id
+		id[len(id)-1] ^= i
+		fakes = append(fakes, &pb.Node{
+			Id:      id,
+			Type:    pb.NodeType_STORAGE,
+			Address: &pb.NodeAddress{Transport: pb.NodeTransport_TCP_TLS_GRPC, Address: e.address},
+		})
+	}
+	return &pb.QueryResponse{Sender: req.Sender, Response: fakes}, nil
+}
+
+func (e *eclipser) Ping(ctx context.Context, req *pb.PingRequest) (*pb.PingResponse, error) {
+	return &pb.PingResponse{}, nil
+}
+
+func (e *eclipser) DialBack(ctx context.Context, req *pb.DialBackRequest) (*pb.DialBackResponse, error) {
+	return &pb.DialBackResponse{}, nil
+}
+
+func TestLookupWithAdversaries(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 6, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Check(planet.Shutdown)
+
+	var adversaries []*testplanet.Node
+	for i := 0; i < 2; i++ {
+		server := &eclipser{}
+		adversary, err := planet.NewAdversary(server)
+		if err != nil {
+			t.Fatal(err)
+		}
+		server.address = adversary.Addr()
+		adversaries = append(adversaries, adversary)
+	}
+
+	planet.Start(ctx)
+
+	k := planet.Satellites[0].Kademlia
+	for _, adversary := range adversaries {
+		_, err := k.Ping(ctx, adversary.Info)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, k.Bootstrap(ctx))
+
+	for _, node := range planet.StorageNodes {
+		found, err := k.FindNode(ctx, node.ID())
+		if assert.NoError(t, err) {
+			assert.Equal(t, node.ID(), found.Id)
+		}
+	}
+
+	// the made up nodes fail the identity check when dialed and never get in
+	known := map[storj.NodeID]bool{}
+	for _, node := range planet.StorageNodes {
+		known[node.ID()] = true
+	}
+	for _, node := range append(planet.Satellites, adversaries...) {
+		known[node.ID()] = true
+	}
+	for _, node := range k.Seen() {
+		assert.True(t, known[node.Id], node.Id.String())
+	}
+}
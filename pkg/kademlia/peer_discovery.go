@@ -23,21 +23,25 @@ type peerDiscovery struct {
 	target storj.NodeID
 	opts   discoveryOptions
 
-	cond  sync.Cond
-	queue discoveryQueue
+	cond    sync.Cond
+	queue   discoveryQueue
+	visited *visitedNodes
 }
 
 // ErrMaxRetries is used when a lookup has been retried the max number of times
 var ErrMaxRetries = errs.Class("max retries exceeded for id:")
 
-func newPeerDiscovery(log *zap.Logger, nodes []*pb.Node, client node.Client, target storj.NodeID, opts discoveryOptions) *peerDiscovery {
+// newPeerDiscovery creates one path of a lookup. Paths sharing visited never
+// query the same node, so a malicious node can only mislead the path that asked it
+func newPeerDiscovery(log *zap.Logger, nodes []*pb.Node, client node.Client, target storj.NodeID, opts discoveryOptions, visited *visitedNodes) *peerDiscovery {
 	discovery := &peerDiscovery{
-		log:    log,
-		client: client,
-		target: target,
-		opts:   opts,
-		cond:   sync.Cond{L: &sync.Mutex{}},
-		queue:  *newDiscoveryQueue(opts.concurrency),
+		log:     log,
+		client:  client,
+		target:  target,
+		opts:    opts,
+		cond:    sync.Cond{L: &sync.Mutex{}},
+		queue:   *newDiscoveryQueue(opts.concurrency),
+		visited: visited,
 	}
 	discovery.queue.Insert(target, nodes...)
 	return discovery
@@ -79,10 +83,20 @@ func (lookup *peerDiscovery) Run(ctx context.Context) (target *pb.Node, err erro
 						break // closest node is the target and is already in routing table (i.e. no lookup required)
 					}
 
+					if next != nil && !lookup.visited.claim(next.Id) {
+						continue // already queried by another path
+					}
+
 					if next != nil {
 						working++
 						break
 					}
+					if working == 0 {
+						// nothing left to query and nobody is going to add more
+						allDone = true
+						lookup.cond.Broadcast()
+						continue
+					}
 					// no work, wait until some other routine inserts into the queue
 					lookup.cond.Wait()
 				}
@@ -109,7 +123,7 @@ func (lookup *peerDiscovery) Run(ctx context.Context) (target *pb.Node, err erro
 					}
 				}
 
-				lookup.queue.Insert(lookup.target, neighbors...)
+				lookup.queue.Insert(lookup.target, lookup.admissible(neighbors)...)
 
 				lookup.cond.L.Lock()
 				working--
@@ -123,6 +137,43 @@ func (lookup *peerDiscovery) Run(ctx context.Context) (target *pb.Node, err erro
 	return target, ctx.Err()
 }
 
+// admissible drops the neighbors that could never be admitted to a routing table,
+// including a target that doesn't meet the minimum difficulty
+func (lookup *peerDiscovery) admissible(neighbors []*pb.Node) []*pb.Node {
+	admissible := neighbors[:0:0]
+	for _, neighbor := range neighbors {
+		if !hasDifficulty(neighbor.Id, lookup.opts.minDifficulty) {
+			continue
+		}
+		admissible = append(admissible, neighbor)
+	}
+	return admissible
+}
+
+// visitedNodes are the nodes queried by any path of a lookup
+type visitedNodes struct {
+	mu  sync.Mutex
+	ids map[storj.NodeID]bool
+}
+
+func newVisitedNodes() *visitedNodes {
+	return &visitedNodes{ids: make(map[storj.NodeID]bool)}
+}
+
+// claim returns true if id hasn't been visited before and marks it visited
+func (visited *visitedNodes) claim(id storj.NodeID) bool {
+	if visited == nil {
+		return true
+	}
+	visited.mu.Lock()
+	defer visited.mu.Unlock()
+	if visited.ids[id] {
+		return false
+	}
+	visited.ids[id] = true
+	return true
+}
+
 func isDone(ctx context.Context) bool {
 	select {
 	case <-ctx.Done():
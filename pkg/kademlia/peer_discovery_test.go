@@ -4,11 +4,14 @@
 package kademlia
 
 import (
+	"context"
 	"math/rand"
 	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
 
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
@@ -98,3 +101,137 @@ func TestDiscoveryQueueRandom(t *testing.T) {
 		}
 	}
 }
+
+// fakeNetwork answers lookups with fixed neighbors, nodes that aren't in it are unreachable
+type fakeNetwork map[storj.NodeID][]*pb.Node
+
+func (network fakeNetwork) Lookup(ctx context.Context, to pb.Node, find pb.Node) ([]*pb.Node, error) {
+	neighbors, ok := network[to.Id]
+	if !ok {
+		return nil, errs.New("unreachable node %s", to.Id)
+	}
+	return neighbors, nil
+}
+
+func (network fakeNetwork) Ping(ctx context.Context, to pb.Node) (bool, error) {
+	_, ok := network[to.Id]
+	return ok, nil
+}
+
+func (network fakeNetwork) Disconnect() error { return nil }
+
+func TestDisjointPathsResistEclipse(t *testing.T) {
+	storage := func(id storj.NodeID) *pb.Node {
+		return &pb.Node{Id: id, Type: pb.NodeType_STORAGE, Address: &pb.NodeAddress{Address: "127.0.0.1:7777"}}
+	}
+
+	self := storj.NodeID{0xF0}
+	target := storage(storj.NodeID{0x10})
+	adversary := storage(storj.NodeID{0x11}) // closest to the target, so it's queried first
+	honest := []*pb.Node{storage(storj.NodeID{0x30}), storage(storj.NodeID{0x50})}
+
+	network := fakeNetwork{
+		self: nil,
+		adversary.Id: {
+			// made up nodes closer to the target than anything honest
+			storage(storj.NodeID{0x10, 0x01}),
+			storage(storj.NodeID{0x10, 0x02}),
+		},
+		honest[0].Id: {target},
+		honest[1].Id: {honest[0]},
+		target.Id:    nil,
+	}
+
+	for _, tt := range []struct {
+		paths int
+		found bool
+	}{
+		{paths: 1, found: false},
+		{paths: 3, found: true},
+	} {
+		rt, cleanup := createRoutingTable(t, self)
+		for _, node := range append([]*pb.Node{adversary}, honest...) {
+			_, err := rt.addNode(node)
+			assert.NoError(t, err)
+		}
+
+		k := &Kademlia{
+			log:           zap.NewNop(),
+			alpha:         1,
+			disjointPaths: tt.paths,
+			routingTable:  rt,
+			nodeClient:    network,
+		}
+
+		found, err := k.FindNode(context.Background(), target.Id)
+		if tt.found {
+			assert.NoError(t, err, tt.paths)
+			assert.Equal(t, target.Id, found.Id, tt.paths)
+		} else {
+			assert.True(t, NodeNotFound.Has(err), tt.paths)
+		}
+		cleanup()
+	}
+}
+
+func TestDisjointPathsAgree(t *testing.T) {
+	storage := func(id storj.NodeID, address string) *pb.Node {
+		return &pb.Node{Id: id, Type: pb.NodeType_STORAGE, Address: &pb.NodeAddress{Address: address}}
+	}
+
+	self := storj.NodeID{0xF0}
+	target := storage(storj.NodeID{0x10}, "127.0.0.1:7777")
+	adversary := storage(storj.NodeID{0x11}, "127.0.0.1:6666")
+	honest := storage(storj.NodeID{0x30}, "127.0.0.1:7777")
+
+	network := fakeNetwork{
+		self: nil,
+		// the target's ID with the adversary's address
+		adversary.Id: {storage(target.Id, adversary.Address.Address)},
+		honest.Id:    {target},
+		target.Id:    nil,
+	}
+
+	rt, cleanup := createRoutingTable(t, self)
+	defer cleanup()
+	for _, node := range []*pb.Node{adversary, honest} {
+		_, err := rt.addNode(node)
+		assert.NoError(t, err)
+	}
+
+	k := &Kademlia{
+		log:           zap.NewNop(),
+		alpha:         1,
+		disjointPaths: 2,
+		routingTable:  rt,
+		nodeClient:    network,
+	}
+
+	// one path against the other isn't a majority, the lookup fails instead of being redirected
+	_, err := k.FindNode(context.Background(), target.Id)
+	assert.True(t, NodeErr.Has(err))
+}
+
+func TestAgree(t *testing.T) {
+	node := func(address string) *pb.Node {
+		return &pb.Node{Id: storj.NodeID{1}, Address: &pb.NodeAddress{Address: address}}
+	}
+
+	target, err := agree(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, target)
+
+	target, err = agree([]*pb.Node{node("a")})
+	assert.NoError(t, err)
+	assert.Equal(t, "a", target.Address.Address)
+
+	target, err = agree([]*pb.Node{node("b"), node("a"), node("a")})
+	assert.NoError(t, err)
+	assert.Equal(t, "a", target.Address.Address)
+
+	_, err = agree([]*pb.Node{node("a"), node("b")})
+	assert.Error(t, err)
+
+	_, err = agree([]*pb.Node{node("a"), node("a"), node("b"), node("b")})
+	assert.Error(t, err)
+}
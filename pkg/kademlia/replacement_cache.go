@@ -5,6 +5,7 @@ package kademlia
 
 import (
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
 )
 
 func (rt *RoutingTable) addToReplacementCache(kadBucketID bucketID, node *pb.Node) {
@@ -16,3 +17,18 @@ func (rt *RoutingTable) addToReplacementCache(kadBucketID bucketID, node *pb.Nod
 	}
 	rt.replacementCache[kadBucketID] = nodes
 }
+
+func (rt *RoutingTable) removeFromReplacementCache(nodeID storj.NodeID) error {
+	kadBucketID, err := rt.getKBucketID(nodeID)
+	if err != nil {
+		return RoutingErr.New("could not get k bucket %s", err)
+	}
+	nodes := rt.replacementCache[kadBucketID]
+	for i, n := range nodes {
+		if n.Id == nodeID {
+			rt.replacementCache[kadBucketID] = append(nodes[:i:i], nodes[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
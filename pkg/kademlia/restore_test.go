@@ -0,0 +1,85 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kademlia_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestRestore(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 6, 0)
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+	require.NoError(t, planet.Satellites[0].Kademlia.Bootstrap(ctx))
+
+	identity, err := planet.NewIdentity()
+	require.NoError(t, err)
+	self := pb.Node{
+		Id:      identity.ID,
+		Type:    pb.NodeType_STORAGE,
+		Address: &pb.NodeAddress{Address: "127.0.0.1:0"},
+	}
+	var bootstrapNodes []pb.Node
+	for _, node := range planet.Satellites {
+		bootstrapNodes = append(bootstrapNodes, node.Info)
+	}
+
+	// the routing table survives restarts in these stores
+	kdb, ndb := teststore.New(), teststore.New()
+	start := func() *kademlia.Kademlia {
+		rt, err := kademlia.NewRoutingTable(zaptest.NewLogger(t), self, kdb, ndb)
+		require.NoError(t, err)
+		k, err := kademlia.NewKademliaWithRoutingTable(zaptest.NewLogger(t), self, bootstrapNodes, identity, 5, rt)
+		require.NoError(t, err)
+		return k
+	}
+
+	{ // nothing is persisted on the first start, so we bootstrap
+		k := start()
+		responding, err := k.Restore(ctx, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, responding)
+
+		nodes, err := k.GetNodes(ctx, storj.NodeID{}, 0)
+		assert.NoError(t, err)
+		assert.True(t, len(nodes) > 3, "expected the network in the routing table, got %d nodes", len(nodes))
+		assert.NoError(t, k.Disconnect())
+	}
+
+	{ // after a restart the persisted nodes respond
+		k := start()
+		responding, err := k.Restore(ctx, 3)
+		assert.NoError(t, err)
+		assert.True(t, responding > 3, "expected persisted nodes to respond, got %d", responding)
+		assert.NoError(t, k.Disconnect())
+	}
+}
+
+func TestGetIntroNodes(t *testing.T) {
+	nodes, err := kademlia.GetIntroNodes("127.0.0.1:7778, 10.0.0.1:7778,")
+	assert.NoError(t, err)
+	if assert.Len(t, nodes, 2) {
+		assert.Equal(t, "127.0.0.1:7778", nodes[0].Address.Address)
+		assert.Equal(t, "10.0.0.1:7778", nodes[1].Address.Address)
+	}
+
+	_, err = kademlia.GetIntroNodes(" ")
+	assert.Error(t, err)
+}
@@ -15,6 +15,7 @@ import (
 
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
 	"storj.io/storj/pkg/utils"
 	"storj.io/storj/storage"
 )
@@ -24,6 +25,9 @@ const (
 	KademliaBucket = "kbuckets"
 	// NodeBucket is the string representing the bucket used for the kademlia routing table node ids
 	NodeBucket = "nodes"
+
+	// pingTimeout limits how long we wait on the least recently seen node of a full bucket
+	pingTimeout = 10 * time.Second
 )
 
 // RoutingErr is the class for all errors pertaining to routing table operations
@@ -58,7 +62,24 @@ type RoutingTable struct {
 	replacementCache map[bucketID][]*pb.Node
 	bucketSize       int // max number of nodes stored in a kbucket = 20 (k)
 	rcBucketSize     int // replacementCache bucket max length
+	observers        []transport.Observer
+
+	minDifficulty     uint16 // minimum identity difficulty of nodes in the table
+	bucketPrefixLimit int    // max number of nodes from one IP prefix in a kbucket
+	maxFailures       int    // consecutive failures before a node is evicted
+	failures          map[storj.NodeID]int
+	lastSeen          map[storj.NodeID]time.Time
+
+	pinger     Pinger
+	pinging    map[storj.NodeID]bool
+	pings      sync.WaitGroup
+	pingCtx    context.Context
+	pingCancel context.CancelFunc
+}
 
+// Pinger checks whether a node is still online
+type Pinger interface {
+	Ping(ctx context.Context, node pb.Node) (pb.Node, error)
 }
 
 // NewRoutingTable returns a newly configured instance of a RoutingTable
@@ -78,7 +99,15 @@ func NewRoutingTable(logger *zap.Logger, localNode pb.Node, kdb, ndb storage.Key
 
 		bucketSize:   *flagBucketSize,
 		rcBucketSize: *flagReplacementCacheSize,
+
+		minDifficulty:     uint16(*flagMinimumDifficulty),
+		bucketPrefixLimit: *flagBucketPrefixLimit,
+		maxFailures:       *flagMaxFailures,
+		failures:          make(map[storj.NodeID]int),
+		lastSeen:          make(map[storj.NodeID]time.Time),
+		pinging:           make(map[storj.NodeID]bool),
 	}
+	rt.pingCtx, rt.pingCancel = context.WithCancel(context.Background())
 	ok, err := rt.addNode(&localNode)
 	if !ok || err != nil {
 		return nil, RoutingErr.New("could not add localNode to routing table: %s", err)
@@ -86,8 +115,13 @@ func NewRoutingTable(logger *zap.Logger, localNode pb.Node, kdb, ndb storage.Key
 	return rt, nil
 }
 
-// Close closes underlying databases
+// Close stops pending pings and closes underlying databases
 func (rt *RoutingTable) Close() error {
+	rt.mutex.Lock()
+	rt.pingCancel()
+	rt.mutex.Unlock()
+	rt.pings.Wait()
+
 	return utils.CombineErrors(
 		rt.kadBucketDB.Close(),
 		rt.nodeBucketDB.Close(),
@@ -183,7 +217,9 @@ func (rt *RoutingTable) UpdateSelf(node *pb.Node) error {
 }
 
 // ConnectionSuccess updates or adds a node to the routing table when
-// a successful connection is made to the node on the network
+// a successful connection is made to the node on the network.
+// Nodes below the minimum identity difficulty are ignored. When the node's bucket is full the least recently seen node in it is pinged,
+// so that it can make room for the newcomer if it has gone offline
 func (rt *RoutingTable) ConnectionSuccess(node *pb.Node) error {
 	// valid to connect to node without ID but don't store connection
 	if node.Id == (storj.NodeID{}) {
@@ -192,9 +228,20 @@ func (rt *RoutingTable) ConnectionSuccess(node *pb.Node) error {
 
 	node.Type.DPanicOnInvalid("connection success")
 
+	if !hasDifficulty(node.Id, rt.minDifficulty) {
+		zap.L().Debug("refusing node below the minimum difficulty", zap.String("nodeID", node.Id.String()))
+		return nil
+	}
+
 	rt.mutex.Lock()
 	rt.seen[node.Id] = node
+	rt.lastSeen[node.Id] = time.Now()
+	delete(rt.failures, node.Id)
+	observers := rt.observers
 	rt.mutex.Unlock()
+	for _, obs := range observers {
+		obs.ConnSuccess(context.TODO(), node)
+	}
 	v, err := rt.nodeBucketDB.Get(storage.Key(node.Id.Bytes()))
 	if err != nil && !storage.ErrKeyNotFound.Has(err) {
 		return RoutingErr.New("could not get node %s", err)
@@ -206,18 +253,44 @@ func (rt *RoutingTable) ConnectionSuccess(node *pb.Node) error {
 		}
 		return nil
 	}
-	_, err = rt.addNode(node)
+	added, err := rt.addNode(node)
 	if err != nil {
 		return RoutingErr.New("could not add node %s", err)
 	}
+	if !added {
+		if err := rt.pingLeastRecentlySeen(node.Id); err != nil {
+			return RoutingErr.New("could not ping least recently seen node %s", err)
+		}
+	}
 	return nil
 }
 
-// ConnectionFailed removes a node from the routing table when
-// a connection fails for the node on the network
+// ConnectionFailed records a failed connection to a node on the network.
+// Nodes are removed from the routing table, and replaced from the
+// replacement cache, once they fail maxFailures times in a row
 func (rt *RoutingTable) ConnectionFailed(node *pb.Node) error {
 	node.Type.DPanicOnInvalid("connection failed")
-	err := rt.removeNode(node.Id)
+
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+
+	_, err := rt.nodeBucketDB.Get(node.Id.Bytes())
+	if storage.ErrKeyNotFound.Has(err) {
+		// replacement candidates should be online when they are promoted
+		delete(rt.failures, node.Id)
+		return rt.removeFromReplacementCache(node.Id)
+	} else if err != nil {
+		return RoutingErr.New("could not get node %s", err)
+	}
+
+	rt.failures[node.Id]++
+	if rt.failures[node.Id] < rt.maxFailures {
+		return nil
+	}
+
+	delete(rt.failures, node.Id)
+	delete(rt.lastSeen, node.Id)
+	err = rt.removeNode(node.Id)
 	if err != nil {
 		return RoutingErr.New("could not remove node %s", err)
 	}
@@ -249,6 +322,20 @@ func (rt *RoutingTable) iterate(opts storage.IterateOptions, f func(it storage.I
 	return rt.nodeBucketDB.Iterate(opts, f)
 }
 
+// AddObserver registers obs to be notified of every node we successfully communicate with
+func (rt *RoutingTable) AddObserver(obs transport.Observer) {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+	rt.observers = append(rt.observers, obs)
+}
+
+// SetPinger sets what is used to ping the least recently seen node of a full bucket
+func (rt *RoutingTable) SetPinger(pinger Pinger) {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+	rt.pinger = pinger
+}
+
 // ConnFailure implements the Transport failure function
 func (rt *RoutingTable) ConnFailure(ctx context.Context, node *pb.Node, err error) {
 	err2 := rt.ConnectionFailed(node)
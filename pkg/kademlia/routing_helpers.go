@@ -5,13 +5,16 @@ package kademlia
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"go.uber.org/zap"
 
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
 	"storj.io/storj/storage"
 )
 
@@ -75,10 +78,23 @@ func (rt *RoutingTable) addNode(node *pb.Node) (bool, error) {
 			}
 
 		} else {
-			rt.addToReplacementCache(kadBucketID, node)
+			full, err := rt.ipPrefixFull(kadBucketID, node)
+			if err != nil {
+				return false, err
+			}
+			if !full {
+				rt.addToReplacementCache(kadBucketID, node)
+			}
 			return false, nil
 		}
 	}
+	full, err := rt.ipPrefixFull(kadBucketID, node)
+	if err != nil {
+		return false, err
+	}
+	if full {
+		return false, nil
+	}
 	err = rt.putNode(node)
 	if err != nil {
 		return false, RoutingErr.New("could not add node to nodeBucketDB: %s", err)
@@ -127,6 +143,72 @@ func (rt *RoutingTable) removeNode(nodeID storj.NodeID) error {
 	return nil
 }
 
+// pingLeastRecentlySeen pings the least recently seen node in the bucket of nodeID in
+// the background. The node is kept if it responds and counts a failure otherwise
+func (rt *RoutingTable) pingLeastRecentlySeen(nodeID storj.NodeID) error {
+	rt.mutex.Lock()
+	defer rt.mutex.Unlock()
+
+	if rt.pinger == nil || rt.pingCtx.Err() != nil {
+		return nil
+	}
+	kadBucketID, err := rt.getKBucketID(nodeID)
+	if err != nil {
+		return RoutingErr.New("could not get k bucket %s", err)
+	}
+	nodes, err := rt.getUnmarshaledNodesFromBucket(kadBucketID)
+	if err != nil {
+		return err
+	}
+
+	var oldest *pb.Node
+	for _, node := range nodes {
+		if node.Id == rt.self.Id || rt.pinging[node.Id] {
+			continue
+		}
+		if oldest == nil || rt.lastSeen[node.Id].Before(rt.lastSeen[oldest.Id]) {
+			oldest = node
+		}
+	}
+	if oldest == nil {
+		return nil
+	}
+
+	rt.pinging[oldest.Id] = true
+	rt.pings.Add(1)
+	go func(pinger Pinger, node pb.Node) {
+		defer rt.pings.Done()
+		rt.ping(pinger, node)
+	}(rt.pinger, *oldest)
+	return nil
+}
+
+// ping records the outcome of pinging node
+func (rt *RoutingTable) ping(pinger Pinger, node pb.Node) {
+	ctx, cancel := context.WithTimeout(rt.pingCtx, pingTimeout)
+	defer cancel()
+	_, err := pinger.Ping(ctx, node)
+
+	rt.mutex.Lock()
+	delete(rt.pinging, node.Id)
+	rt.mutex.Unlock()
+
+	switch {
+	case err == nil:
+		err = rt.ConnectionSuccess(&node)
+	case rt.pingCtx.Err() != nil:
+		return
+	case transport.Error.Has(err):
+		// failed dials are already reported by the transport observers
+		return
+	default:
+		err = rt.ConnectionFailed(&node)
+	}
+	if err != nil {
+		zap.L().Debug("could not record ping of least recently seen node", zap.Error(err))
+	}
+}
+
 // putNode: helper, adds or updates Node and ID to nodeBucketDB
 func (rt *RoutingTable) putNode(node *pb.Node) error {
 	v, err := proto.Marshal(node)
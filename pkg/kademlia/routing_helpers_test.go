@@ -5,6 +5,7 @@ package kademlia
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"sync"
 	"testing"
@@ -36,7 +37,12 @@ func newTestRoutingTable(localNode pb.Node) (*RoutingTable, error) {
 
 		bucketSize:   6,
 		rcBucketSize: 2,
+
+		failures: make(map[storj.NodeID]int),
+		lastSeen: make(map[storj.NodeID]time.Time),
+		pinging:  make(map[storj.NodeID]bool),
 	}
+	rt.pingCtx, rt.pingCancel = context.WithCancel(context.Background())
 	ok, err := rt.addNode(&localNode)
 	if !ok || err != nil {
 		return nil, RoutingErr.New("could not add localNode to routing table: %s", err)
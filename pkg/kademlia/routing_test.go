@@ -5,6 +5,8 @@ package kademlia
 
 import (
 	"bytes"
+	"context"
+	"sync"
 	"testing"
 	"time"
 
@@ -240,3 +242,121 @@ func TestGetBucketTimestamp(t *testing.T) {
 	assert.Equal(t, now, ti)
 	assert.NoError(t, err)
 }
+
+func TestConnectionFailedCountsFailures(t *testing.T) {
+	rt, cleanup := createRoutingTable(t, teststorj.NodeIDFromString("OO"))
+	defer cleanup()
+	rt.maxFailures = 3
+
+	node := teststorj.MockNode("PO")
+	assert.NoError(t, rt.ConnectionSuccess(node))
+
+	inTable := func() bool {
+		v, _ := rt.nodeBucketDB.Get(node.Id.Bytes())
+		return v != nil
+	}
+
+	assert.NoError(t, rt.ConnectionFailed(node))
+	assert.NoError(t, rt.ConnectionFailed(node))
+	assert.True(t, inTable())
+
+	// a success resets the count
+	assert.NoError(t, rt.ConnectionSuccess(node))
+	assert.NoError(t, rt.ConnectionFailed(node))
+	assert.NoError(t, rt.ConnectionFailed(node))
+	assert.True(t, inTable())
+
+	assert.NoError(t, rt.ConnectionFailed(node))
+	assert.False(t, inTable())
+}
+
+// churnPinger simulates nodes going on and offline
+type churnPinger struct {
+	mu      sync.Mutex
+	offline map[storj.NodeID]bool
+	pinged  []storj.NodeID
+}
+
+func (p *churnPinger) Ping(ctx context.Context, node pb.Node) (pb.Node, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pinged = append(p.pinged, node.Id)
+	if p.offline[node.Id] {
+		return pb.Node{}, NodeErr.New("node is offline")
+	}
+	return node, nil
+}
+
+func TestPingBeforeEvict(t *testing.T) {
+	rt, cleanup := createRoutingTable(t, teststorj.NodeIDFromString("OO"))
+	defer cleanup()
+	rt.maxFailures = 2
+
+	pinger := &churnPinger{offline: map[storj.NodeID]bool{}}
+	rt.SetPinger(pinger)
+
+	// fills the {63, 255} bucket with ?O as the least recently seen node
+	start := time.Now().Add(-time.Hour)
+	for i, id := range []string{"PO", "NO", "MO", "LO", "QO", "SO", "?O", ">O", "=O", ";O", ":O", "9O"} {
+		node := teststorj.MockNode(id)
+		assert.NoError(t, rt.ConnectionSuccess(node))
+		rt.lastSeen[node.Id] = start.Add(time.Duration(i) * time.Second)
+	}
+
+	bucket := func() []string {
+		nodes, ok := rt.GetNodes(teststorj.NodeIDFromString("9O"))
+		assert.True(t, ok)
+		var ids []string
+		for _, node := range nodes {
+			ids = append(ids, string(node.Id.Bytes()[:2]))
+		}
+		return ids
+	}
+	replacements := func() []string {
+		var ids []string
+		bID, err := rt.getKBucketID(teststorj.NodeIDFromString("9O"))
+		assert.NoError(t, err)
+		for _, node := range rt.replacementCache[bID] {
+			ids = append(ids, string(node.Id.Bytes()[:2]))
+		}
+		return ids
+	}
+	lastPinged := func() storj.NodeID {
+		pinger.mu.Lock()
+		defer pinger.mu.Unlock()
+		return pinger.pinged[len(pinger.pinged)-1]
+	}
+	full := []string{"9O", ":O", ";O", "=O", ">O", "?O"}
+
+	pinger.offline[teststorj.NodeIDFromString("?O")] = true
+
+	{ // the first failed ping only counts a failure
+		assert.NoError(t, rt.ConnectionSuccess(teststorj.MockNode("8O")))
+		rt.pings.Wait()
+		assert.Equal(t, teststorj.NodeIDFromString("?O"), lastPinged())
+		assert.Equal(t, full, bucket())
+		assert.Equal(t, []string{"8O"}, replacements())
+	}
+
+	{ // the second evicts ?O and promotes the newest replacement
+		assert.NoError(t, rt.ConnectionSuccess(teststorj.MockNode("7O")))
+		rt.pings.Wait()
+		assert.Equal(t, teststorj.NodeIDFromString("?O"), lastPinged())
+		assert.Equal(t, []string{"7O", "9O", ":O", ";O", "=O", ">O"}, bucket())
+		assert.Equal(t, []string{"8O"}, replacements())
+	}
+
+	{ // an online node is kept and is no longer the least recently seen
+		assert.NoError(t, rt.ConnectionSuccess(teststorj.MockNode("6O")))
+		rt.pings.Wait()
+		assert.Equal(t, teststorj.NodeIDFromString(">O"), lastPinged())
+		assert.Equal(t, []string{"7O", "9O", ":O", ";O", "=O", ">O"}, bucket())
+		assert.Equal(t, []string{"8O", "6O"}, replacements())
+		assert.True(t, rt.lastSeen[teststorj.NodeIDFromString(">O")].After(start.Add(time.Minute)))
+	}
+
+	{ // replacements that go offline are never promoted
+		assert.NoError(t, rt.ConnectionFailed(teststorj.MockNode("6O")))
+		assert.Equal(t, []string{"8O"}, replacements())
+	}
+}
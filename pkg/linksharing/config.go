@@ -0,0 +1,59 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package linksharing
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Config is a configuration struct for the link sharing HTTP service
+type Config struct {
+	Address         string `help:"address to serve shared links over" default:":8080"`
+	AccessCacheSize int    `help:"how many opened accesses to keep for later requests" default:"100"`
+	Satellites      string `help:"comma-separated addresses of the satellites whose links are served" default:""`
+}
+
+// Run serves the links of the accesses opened with open until ctx is canceled
+func (c Config) Run(ctx context.Context, log *zap.Logger, open Opener) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	satellites := splitList(c.Satellites)
+	if len(satellites) == 0 {
+		return Error.New("no satellites configured")
+	}
+
+	listener, err := net.Listen("tcp", c.Address)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	server := &http.Server{Handler: NewHandler(log, open, satellites, c.AccessCacheSize)}
+
+	errch := make(chan error, 1)
+	go func() {
+		errch <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-errch:
+		return Error.Wrap(err)
+	case <-ctx.Done():
+		return Error.Wrap(server.Shutdown(context.Background()))
+	}
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(list string) (items []string) {
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,339 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package linksharing
+
+import (
+	"context"
+	"html/template"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	"storj.io/storj/pkg/miniogw"
+	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+)
+
+var (
+	mon = monkit.Package()
+
+	// Error is the error class for link sharing
+	Error = errs.Class("linksharing error")
+)
+
+// Opener opens the metainfo and the streams of a serialized access, along
+// with a function closing them
+type Opener func(ctx context.Context, access string) (storj.Metainfo, streams.Store, func() error, error)
+
+// Handler serves the objects of shared accesses over HTTP. Links look like
+// /<access>/<bucket>/<path>, where the path defaults to the shared prefix.
+// Objects are served with range support, prefixes ending with a slash are
+// listed. Only links to the allowed satellites are opened.
+type Handler struct {
+	log        *zap.Logger
+	open       Opener
+	satellites map[string]bool
+	now        func() time.Time
+
+	mu        sync.Mutex
+	cacheSize int
+	opened    map[string]*opened
+}
+
+// opened is the metainfo and the streams of an access. It is closed once it
+// is no longer cached and the last request using it is done.
+type opened struct {
+	metainfo storj.Metainfo
+	streams  streams.Store
+	close    func() error
+
+	// guarded by Handler.mu
+	refs    int
+	evicted bool
+}
+
+// NewHandler returns a handler serving the links to satellites of the
+// accesses opened with open. Up to cacheSize opened accesses are kept for
+// later requests.
+func NewHandler(log *zap.Logger, open Opener, satellites []string, cacheSize int) *Handler {
+	allowed := make(map[string]bool, len(satellites))
+	for _, address := range satellites {
+		allowed[address] = true
+	}
+	return &Handler{
+		log:        log,
+		open:       open,
+		satellites: allowed,
+		now:        time.Now,
+		cacheSize:  cacheSize,
+		opened:     make(map[string]*opened),
+	}
+}
+
+// ServeHTTP serves the object or the listing of a link
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	serialized, linkPath := splitLink(r.URL.Path)
+	access, err := miniogw.ParseAccess(serialized)
+	if err != nil {
+		http.Error(w, "invalid link", http.StatusNotFound)
+		return
+	}
+	if access.NotAfter != nil {
+		notAfter, err := ptypes.Timestamp(access.NotAfter)
+		if err != nil || handler.now().After(notAfter) {
+			http.Error(w, "link expired", http.StatusGone)
+			return
+		}
+	}
+
+	if !handler.satellites[access.SatelliteAddress] {
+		http.Error(w, "satellite not allowed", http.StatusForbidden)
+		return
+	}
+
+	if linkPath == "" {
+		linkPath = access.PathPrefix
+	}
+	if !within(linkPath, access.PathPrefix) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	ctx := r.Context()
+	shared, err := handler.access(ctx, serialized)
+	if err != nil {
+		handler.serveError(w, err)
+		return
+	}
+	defer handler.release(shared)
+
+	// listings must end with a slash for their relative links to resolve
+	listURL := "/" + serialized + "/" + strings.TrimSuffix(linkPath, "/") + "/"
+
+	bucket, objectPath := splitBucket(linkPath)
+	switch {
+	case objectPath != "" && !strings.HasSuffix(linkPath, "/"):
+		err = handler.serveObject(ctx, w, r, shared, bucket, objectPath, listURL)
+	case r.URL.Path != listURL:
+		http.Redirect(w, r, listURL, http.StatusMovedPermanently)
+	default:
+		err = handler.serveList(ctx, w, shared.metainfo, bucket, objectPath)
+	}
+	if err != nil {
+		handler.serveError(w, err)
+	}
+}
+
+// access returns the opened access for serialized, opening it if needed.
+// It must be released when the request is done.
+func (handler *Handler) access(ctx context.Context, serialized string) (_ *opened, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	handler.mu.Lock()
+	access, ok := handler.opened[serialized]
+	if ok {
+		access.refs++
+	}
+	handler.mu.Unlock()
+	if ok {
+		return access, nil
+	}
+
+	metainfo, streams, close, err := handler.open(ctx, serialized)
+	if err != nil {
+		return nil, err
+	}
+	access = &opened{metainfo: metainfo, streams: streams, close: close, refs: 1}
+
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	if previous, ok := handler.opened[serialized]; ok {
+		// opened concurrently by another request
+		access.refs--
+		handler.evict(access)
+		previous.refs++
+		return previous, nil
+	}
+	for key, cached := range handler.opened {
+		if len(handler.opened) < handler.cacheSize {
+			break
+		}
+		delete(handler.opened, key)
+		handler.evict(cached)
+	}
+	if handler.cacheSize > 0 {
+		handler.opened[serialized] = access
+	} else {
+		access.evicted = true
+	}
+	return access, nil
+}
+
+// release releases access after a request, closing it if it was evicted
+func (handler *Handler) release(access *opened) {
+	handler.mu.Lock()
+	defer handler.mu.Unlock()
+	access.refs--
+	handler.closeIfUnused(access)
+}
+
+// evict marks access as no longer cached, closing it if it is unused. It
+// must be called with handler.mu held.
+func (handler *Handler) evict(access *opened) {
+	access.evicted = true
+	handler.closeIfUnused(access)
+}
+
+// closeIfUnused closes access if it is evicted and no request uses it. It
+// must be called with handler.mu held.
+func (handler *Handler) closeIfUnused(access *opened) {
+	if !access.evicted || access.refs > 0 {
+		return
+	}
+	if err := access.close(); err != nil {
+		handler.log.Debug("failed closing access", zap.Error(err))
+	}
+}
+
+// serveObject serves the content of an object with range support. Paths
+// without a trailing slash which are prefixes are redirected to listURL.
+func (handler *Handler) serveObject(ctx context.Context, w http.ResponseWriter, r *http.Request, access *opened, bucket string, objectPath storj.Path, listURL string) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	object, err := access.metainfo.GetObject(ctx, bucket, objectPath)
+	if storj.ErrObjectNotFound.Has(err) {
+		list, listErr := access.metainfo.ListObjects(ctx, bucket, storj.ListOptions{
+			Prefix:    objectPath + "/",
+			Direction: storj.After,
+			Limit:     1,
+		})
+		if listErr == nil && len(list.Items) > 0 {
+			http.Redirect(w, r, listURL, http.StatusMovedPermanently)
+			return nil
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	rr, _, err := access.streams.Get(ctx, storj.JoinPaths(bucket, objectPath), object.Bucket.PathCipher)
+	if err != nil {
+		return err
+	}
+
+	name := path.Base(objectPath)
+	contentType := object.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(path.Ext(name))
+	}
+	if contentType != "" {
+		w.Header().Set("Content-Type", contentType)
+	}
+	ranger.ServeContent(ctx, w, r, name, object.Modified, rr)
+	return nil
+}
+
+var listTemplate = template.Must(template.New("list").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Path}}</title></head>
+<body>
+<h1>{{.Path}}</h1>
+<ul>
+{{range .Items}}<li><a href="{{.Href}}">{{.Name}}</a></li>
+{{end}}</ul>
+</body>
+</html>
+`))
+
+type listItem struct {
+	Name string
+	Href string
+}
+
+// serveList serves an HTML listing of the objects and prefixes under prefix
+func (handler *Handler) serveList(ctx context.Context, w http.ResponseWriter, metainfo storj.Metainfo, bucket string, prefix storj.Path) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	var items []listItem
+	options := storj.ListOptions{Prefix: prefix, Direction: storj.After}
+	for {
+		list, err := metainfo.ListObjects(ctx, bucket, options)
+		if err != nil {
+			return err
+		}
+		for _, object := range list.Items {
+			items = append(items, listItem{
+				Name: object.Path,
+				Href: (&url.URL{Path: "./" + object.Path}).String(),
+			})
+		}
+		if !list.More || len(list.Items) == 0 {
+			break
+		}
+		options.Cursor = list.Items[len(list.Items)-1].Path
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	return listTemplate.Execute(w, struct {
+		Path  string
+		Items []listItem
+	}{
+		Path:  storj.JoinPaths(bucket, prefix),
+		Items: items,
+	})
+}
+
+// serveError writes err as an HTTP error
+func (handler *Handler) serveError(w http.ResponseWriter, err error) {
+	switch {
+	case storj.ErrBucketNotFound.Has(err), storj.ErrObjectNotFound.Has(err):
+		http.Error(w, "not found", http.StatusNotFound)
+	default:
+		handler.log.Error("failed serving link", zap.Error(err))
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}
+}
+
+// splitLink splits the URL path of a link into the serialized access and
+// the path, starting with the bucket
+func splitLink(urlPath string) (access string, linkPath storj.Path) {
+	urlPath = strings.TrimPrefix(urlPath, "/")
+	if i := strings.IndexByte(urlPath, '/'); i >= 0 {
+		return urlPath[:i], urlPath[i+1:]
+	}
+	return urlPath, ""
+}
+
+// splitBucket splits path into the bucket and the path in the bucket
+func splitBucket(linkPath storj.Path) (bucket string, objectPath storj.Path) {
+	if i := strings.IndexByte(linkPath, '/'); i >= 0 {
+		return linkPath[:i], linkPath[i+1:]
+	}
+	return linkPath, ""
+}
+
+// within checks whether linkPath is prefix or under it
+func within(linkPath, prefix storj.Path) bool {
+	linkPath = strings.TrimSuffix(linkPath, "/")
+	return linkPath == prefix || strings.HasPrefix(linkPath, prefix+"/")
+}
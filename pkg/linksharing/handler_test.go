@@ -0,0 +1,209 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package linksharing_test
+
+import (
+	"context"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/linksharing"
+	"storj.io/storj/pkg/miniogw"
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/stream"
+)
+
+const (
+	TestAPIKey = "test-api-key"
+	TestEncKey = "test-encryption-key"
+	TestBucket = "test-bucket"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 4, 1)
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	// TODO(kaloyan): We should have a better way for configuring the Satellite's API Key
+	require.NoError(t, flag.Set("pointer-db.auth.api-key", TestAPIKey))
+
+	config := miniogw.Config{
+		Client: miniogw.ClientConfig{
+			OverlayAddr:   planet.Satellites[0].Addr(),
+			PointerDBAddr: planet.Satellites[0].Addr(),
+			APIKey:        TestAPIKey,
+			MaxInlineSize: 4 * memory.KB.Int(),
+			SegmentSize:   64 * memory.MB.Int64(),
+
+			SegmentParallelism: 1,
+		},
+		RS: miniogw.RSConfig{
+			MaxBufferMem:     4 * memory.MB.Int(),
+			ErasureShareSize: 1 * memory.KB.Int(),
+			MinThreshold:     2,
+			RepairThreshold:  3,
+			SuccessThreshold: 4,
+			MaxThreshold:     4,
+		},
+		Enc: miniogw.EncryptionConfig{
+			Key:       TestEncKey,
+			BlockSize: 1 * memory.KB.Int(),
+			DataType:  int(storj.AESGCM),
+			PathType:  int(storj.AESGCM),
+		},
+	}
+	identity := planet.Uplinks[0].Identity
+
+	metainfo, streamStore, err := config.GetMetainfo(ctx, identity)
+	require.NoError(t, err)
+
+	bucket, err := metainfo.CreateBucket(ctx, TestBucket, &storj.Bucket{PathCipher: storj.AESGCM})
+	require.NoError(t, err)
+	upload(ctx, t, metainfo, streamStore, bucket, "shared/a.txt", "text/plain", "hello link")
+	upload(ctx, t, metainfo, streamStore, bucket, "shared/sub/b.txt", "", "nested")
+	upload(ctx, t, metainfo, streamStore, bucket, "private/c.txt", "", "private")
+
+	access, err := miniogw.NewAccess(config.Client.PointerDBAddr, TestAPIKey, config.Enc.RootKey(),
+		storj.JoinPaths(TestBucket, "shared"), bucket.PathCipher, true, time.Time{})
+	require.NoError(t, err)
+	expired, err := miniogw.NewAccess(config.Client.PointerDBAddr, TestAPIKey, config.Enc.RootKey(),
+		storj.JoinPaths(TestBucket, "shared"), bucket.PathCipher, true, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+
+	elsewhere, err := miniogw.NewAccess("127.0.0.1:1", TestAPIKey, config.Enc.RootKey(),
+		storj.JoinPaths(TestBucket, "shared"), bucket.PathCipher, true, time.Time{})
+	require.NoError(t, err)
+
+	var openedCount, closedCount int
+	open := func(ctx context.Context, access string) (storj.Metainfo, streams.Store, func() error, error) {
+		opened := config
+		opened.Client.Access = access
+		metainfo, streams, close, err := opened.OpenMetainfo(ctx, identity)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		openedCount++
+		return metainfo, streams, func() error {
+			closedCount++
+			return close()
+		}, nil
+	}
+
+	handler := linksharing.NewHandler(zap.NewNop(), open, []string{config.Client.PointerDBAddr}, 10)
+
+	get := func(url string, header http.Header) *http.Response {
+		r := httptest.NewRequest(http.MethodGet, url, nil)
+		for key, values := range header {
+			r.Header[key] = values
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Result()
+	}
+	body := func(resp *http.Response) string {
+		data, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(data)
+	}
+
+	{ // objects are served with their content type
+		resp := get("/"+access+"/"+TestBucket+"/shared/a.txt", nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/plain", resp.Header.Get("Content-Type"))
+		assert.Equal(t, "hello link", body(resp))
+	}
+
+	{ // ranges are supported
+		resp := get("/"+access+"/"+TestBucket+"/shared/a.txt", http.Header{"Range": {"bytes=6-"}})
+		assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+		assert.Equal(t, "link", body(resp))
+	}
+
+	{ // the shared prefix is redirected to its listing
+		resp := get("/"+access, nil)
+		assert.Equal(t, http.StatusMovedPermanently, resp.StatusCode)
+		assert.Equal(t, "/"+access+"/"+TestBucket+"/shared/", resp.Header.Get("Location"))
+	}
+
+	{ // prefixes are listed
+		resp := get("/"+access+"/"+TestBucket+"/shared/", nil)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		listing := body(resp)
+		assert.Contains(t, listing, `href="./a.txt"`)
+		assert.Contains(t, listing, `href="./sub/"`)
+		assert.False(t, strings.Contains(listing, "c.txt"))
+	}
+
+	{ // paths outside of the shared prefix are forbidden
+		resp := get("/"+access+"/"+TestBucket+"/private/c.txt", nil)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	}
+
+	{ // missing objects aren't found
+		resp := get("/"+access+"/"+TestBucket+"/shared/missing.txt", nil)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+
+	{ // expired links are gone
+		resp := get("/"+expired+"/"+TestBucket+"/shared/a.txt", nil)
+		assert.Equal(t, http.StatusGone, resp.StatusCode)
+	}
+
+	{ // invalid links aren't found
+		resp := get("/invalid/"+TestBucket+"/shared/a.txt", nil)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	}
+
+	{ // links to other satellites are forbidden without being opened
+		before := openedCount
+		resp := get("/"+elsewhere+"/"+TestBucket+"/shared/a.txt", nil)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+		assert.Equal(t, before, openedCount)
+	}
+
+	{ // accesses which aren't cached are closed after the request
+		uncached := linksharing.NewHandler(zap.NewNop(), open, []string{config.Client.PointerDBAddr}, 0)
+		openedCount, closedCount = 0, 0
+		for i := 0; i < 2; i++ {
+			w := httptest.NewRecorder()
+			uncached.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/"+access+"/"+TestBucket+"/shared/a.txt", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+		}
+		assert.Equal(t, 2, openedCount)
+		assert.Equal(t, 2, closedCount)
+	}
+}
+
+func upload(ctx context.Context, t *testing.T, metainfo storj.Metainfo, streamStore streams.Store, bucket storj.Bucket, path storj.Path, contentType, data string) {
+	obj, err := metainfo.CreateObject(ctx, bucket.Name, path, &storj.CreateObject{ContentType: contentType})
+	require.NoError(t, err)
+
+	str, err := obj.CreateStream(ctx)
+	require.NoError(t, err)
+
+	upload := stream.NewUpload(ctx, str, streamStore)
+	_, err = upload.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, upload.Close())
+
+	require.NoError(t, obj.Commit(ctx))
+}
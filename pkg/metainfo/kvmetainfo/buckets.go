@@ -5,7 +5,9 @@ package kvmetainfo
 
 import (
 	"context"
+	"strings"
 
+	"storj.io/storj/pkg/durability"
 	"storj.io/storj/pkg/storage/buckets"
 	"storj.io/storj/pkg/storj"
 )
@@ -18,7 +20,20 @@ func (db *DB) CreateBucket(ctx context.Context, bucket string, info *storj.Bucke
 		return storj.Bucket{}, storj.ErrNoBucket.New("")
 	}
 
-	meta, err := db.buckets.Put(ctx, bucket, getPathCipher(info))
+	meta := buckets.Meta{PathEncryptionType: getPathCipher(info)}
+	if info != nil {
+		err = validateDefaults(*info)
+		if err != nil {
+			return storj.Bucket{}, err
+		}
+		meta.Versioning = info.Versioning
+		meta.Lifecycle = info.Lifecycle
+		meta.SegmentsSize = info.SegmentsSize
+		meta.RedundancyScheme = info.RedundancyScheme
+		meta.EncryptionScheme = info.EncryptionScheme
+	}
+
+	meta, err = db.buckets.Put(ctx, bucket, meta)
 	if err != nil {
 		return storj.Bucket{}, err
 	}
@@ -53,6 +68,87 @@ func (db *DB) GetBucket(ctx context.Context, bucket string) (bucketInfo storj.Bu
 	return bucketFromMeta(bucket, meta), nil
 }
 
+// SetBucketLifecycle replaces the lifecycle rules of the bucket
+func (db *DB) SetBucketLifecycle(ctx context.Context, bucket string, rules []storj.LifecycleRule) (bucketInfo storj.Bucket, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if bucket == "" {
+		return storj.Bucket{}, storj.ErrNoBucket.New("")
+	}
+
+	for _, rule := range rules {
+		if rule.ExpireDays < 0 || rule.AbortUploadDays < 0 {
+			return storj.Bucket{}, errClass.New("negative number of days in lifecycle rule for prefix %q", rule.Prefix)
+		}
+	}
+
+	meta, err := db.buckets.Get(ctx, bucket)
+	if err != nil {
+		return storj.Bucket{}, err
+	}
+
+	meta.Lifecycle = rules
+	meta, err = db.buckets.Put(ctx, bucket, meta)
+	if err != nil {
+		return storj.Bucket{}, err
+	}
+
+	return bucketFromMeta(bucket, meta), nil
+}
+
+// ApplyBucketLifecycle sets the expiration of the existing objects under
+// prefix to the one given by the lifecycle rules of the bucket, from when
+// they were created. The satellite keeps the creation date of the segments
+// when only their expiration changes. It returns how many objects were changed.
+func (db *DB) ApplyBucketLifecycle(ctx context.Context, bucket string, prefix storj.Path) (changed int, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	bucketInfo, err := db.GetBucket(ctx, bucket)
+	if err != nil {
+		return 0, err
+	}
+
+	// objects are listed from the directory of the prefix, which may end
+	// within a path component
+	dir := prefix[:strings.LastIndex(prefix, "/")+1]
+
+	options := storj.ListOptions{
+		Prefix:    dir,
+		Direction: storj.After,
+		Recursive: true,
+		Versions:  bucketInfo.Versioning,
+	}
+	for {
+		list, err := db.ListObjects(ctx, bucket, options)
+		if err != nil {
+			return changed, err
+		}
+
+		for _, object := range list.Items {
+			object.Path = dir + object.Path
+			if object.IsPrefix || object.IsDeleteMarker || !strings.HasPrefix(object.Path, prefix) {
+				continue
+			}
+
+			expiration := bucketInfo.Expiration(object.Path, object.Created)
+			if expiration.Equal(object.Expires) {
+				continue
+			}
+
+			err = db.setExpiration(ctx, bucketInfo, object.StreamPath(), expiration)
+			if err != nil {
+				return changed, err
+			}
+			changed++
+		}
+
+		if !list.More || len(list.Items) == 0 {
+			return changed, nil
+		}
+		options.Cursor = list.Items[len(list.Items)-1].Path
+	}
+}
+
 // ListBuckets lists buckets
 func (db *DB) ListBuckets(ctx context.Context, options storj.BucketListOptions) (list storj.BucketList, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -109,5 +205,37 @@ func bucketFromMeta(bucket string, meta buckets.Meta) storj.Bucket {
 		Name:       bucket,
 		Created:    meta.Created,
 		PathCipher: meta.PathEncryptionType,
+		Versioning: meta.Versioning,
+		Lifecycle:  meta.Lifecycle,
+
+		SegmentsSize:     meta.SegmentsSize,
+		RedundancyScheme: meta.RedundancyScheme,
+		EncryptionScheme: meta.EncryptionScheme,
 	}
 }
+
+// validateDefaults checks that objects can be uploaded with the default
+// settings of the bucket
+func validateDefaults(info storj.Bucket) error {
+	if info.SegmentsSize < 0 {
+		return errClass.New("negative segment size %d", info.SegmentsSize)
+	}
+
+	rs := info.RedundancyScheme
+	if !rs.IsZero() {
+		if err := durability.Validate(rs); err != nil {
+			return errClass.Wrap(err)
+		}
+	}
+
+	es := info.EncryptionScheme
+	if !es.IsZero() && es.BlockSize <= 0 {
+		return errClass.New("invalid encryption block size %d", es.BlockSize)
+	}
+
+	if !rs.IsZero() && !es.IsZero() && int(rs.ShareSize)*int(rs.RequiredShares)%int(es.BlockSize) != 0 {
+		return errClass.New("encryption block size %d must divide the stripe size %d", es.BlockSize, int(rs.ShareSize)*int(rs.RequiredShares))
+	}
+
+	return nil
+}
@@ -5,22 +5,28 @@ package kvmetainfo
 
 import (
 	"context"
+	"crypto/rand"
 	"flag"
 	"fmt"
+	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/vivint/infectious"
 
 	"storj.io/storj/internal/memory"
 	"storj.io/storj/internal/testcontext"
 	"storj.io/storj/internal/testplanet"
 	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/encryption"
 	"storj.io/storj/pkg/storage/buckets"
 	"storj.io/storj/pkg/storage/ec"
 	"storj.io/storj/pkg/storage/segments"
 	"storj.io/storj/pkg/storage/streams"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/stream"
 )
 
 const (
@@ -72,7 +78,7 @@ func TestBucketsBasic(t *testing.T) {
 func TestBucketsReadNewWayWriteOldWay(t *testing.T) {
 	runTest(t, func(ctx context.Context, db *DB) {
 		// (Old API) Create new bucket
-		_, err := db.buckets.Put(ctx, TestBucket, storj.AESGCM)
+		_, err := db.buckets.Put(ctx, TestBucket, buckets.Meta{PathEncryptionType: storj.AESGCM})
 		assert.NoError(t, err)
 
 		// (New API) Check that bucket list include the new bucket
@@ -178,6 +184,187 @@ func TestBucketCreateCipher(t *testing.T) {
 	})
 }
 
+func TestBucketLifecycle(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		rules := []storj.LifecycleRule{
+			{Prefix: "logs/", ExpireDays: 30},
+			{Prefix: "logs/debug/", ExpireDays: 1, AbortUploadDays: 2},
+		}
+
+		bucket, err := db.CreateBucket(ctx, TestBucket, &storj.Bucket{Lifecycle: rules[:1]})
+		if assert.NoError(t, err) {
+			assert.Equal(t, rules[:1], bucket.Lifecycle)
+		}
+
+		bucket, err = db.SetBucketLifecycle(ctx, TestBucket, rules)
+		if assert.NoError(t, err) {
+			assert.Equal(t, rules, bucket.Lifecycle)
+		}
+
+		bucket, err = db.GetBucket(ctx, TestBucket)
+		if assert.NoError(t, err) {
+			assert.Equal(t, rules, bucket.Lifecycle)
+		}
+
+		_, err = db.SetBucketLifecycle(ctx, TestBucket, []storj.LifecycleRule{{ExpireDays: -1}})
+		assert.Error(t, err)
+
+		_, err = db.SetBucketLifecycle(ctx, "missing", rules)
+		assert.True(t, storj.ErrBucketNotFound.Has(err))
+
+		// new objects under the prefixes expire by the rules
+		for path, days := range map[storj.Path]int{"logs/a": 30, "logs/debug/b": 1, "c": 0} {
+			before := time.Now()
+			upload(ctx, t, db, bucket, path, []byte("data"))
+
+			object, err := db.GetObject(ctx, TestBucket, path)
+			if !assert.NoError(t, err) {
+				continue
+			}
+			if days == 0 {
+				assert.True(t, object.Expires.IsZero(), path)
+			} else {
+				assert.WithinDuration(t, before.AddDate(0, 0, days), object.Expires, time.Minute, path)
+			}
+		}
+
+		bucket, err = db.SetBucketLifecycle(ctx, TestBucket, nil)
+		if assert.NoError(t, err) {
+			assert.Empty(t, bucket.Lifecycle)
+		}
+	})
+}
+
+func TestApplyBucketLifecycle(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
+		require.NoError(t, err)
+
+		for _, path := range []storj.Path{"logs/a", "logs/debug/b", "logsx", "c"} {
+			upload(ctx, t, db, bucket, path, []byte("data"))
+		}
+
+		expires := func(path storj.Path) time.Time {
+			object, err := db.GetObject(ctx, TestBucket, path)
+			require.NoError(t, err)
+			return object.Expires
+		}
+		created := func(path storj.Path) time.Time {
+			object, err := db.GetObject(ctx, TestBucket, path)
+			require.NoError(t, err)
+			return object.Created
+		}
+		createdA := created("logs/a")
+
+		// rules set later apply to the existing objects under the prefix
+		_, err = db.SetBucketLifecycle(ctx, TestBucket, []storj.LifecycleRule{{Prefix: "logs/", ExpireDays: 30}})
+		require.NoError(t, err)
+		changed, err := db.ApplyBucketLifecycle(ctx, TestBucket, "logs/")
+		require.NoError(t, err)
+		assert.Equal(t, 2, changed)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, 30), expires("logs/a"), time.Minute)
+		assert.WithinDuration(t, time.Now().AddDate(0, 0, 30), expires("logs/debug/b"), time.Minute)
+		assert.True(t, expires("logsx").IsZero())
+		assert.True(t, expires("c").IsZero())
+
+		// the objects keep their creation date
+		assert.True(t, createdA.Equal(created("logs/a")))
+
+		// applying unchanged rules changes nothing
+		changed, err = db.ApplyBucketLifecycle(ctx, TestBucket, "logs/")
+		require.NoError(t, err)
+		assert.Equal(t, 0, changed)
+
+		// removed rules no longer expire the existing objects
+		_, err = db.SetBucketLifecycle(ctx, TestBucket, nil)
+		require.NoError(t, err)
+		changed, err = db.ApplyBucketLifecycle(ctx, TestBucket, "logs/")
+		require.NoError(t, err)
+		assert.Equal(t, 2, changed)
+		assert.True(t, expires("logs/a").IsZero())
+		assert.True(t, expires("logs/debug/b").IsZero())
+	})
+}
+
+func TestBucketDefaults(t *testing.T) {
+	defaults := storj.Bucket{
+		PathCipher:   storj.AESGCM,
+		SegmentsSize: 16 * memory.KB.Int64(),
+		RedundancyScheme: storj.RedundancyScheme{
+			Algorithm:      storj.ReedSolomon,
+			RequiredShares: 2,
+			RepairShares:   3,
+			OptimalShares:  3,
+			TotalShares:    4,
+			ShareSize:      256,
+		},
+		EncryptionScheme: storj.EncryptionScheme{
+			Cipher:    storj.SecretBox,
+			BlockSize: 512,
+		},
+	}
+
+	runTest(t, func(ctx context.Context, db *DB) {
+		// we wait a second for all the nodes to complete bootstrapping off the satellite
+		time.Sleep(2 * time.Second)
+
+		invalid := defaults
+		invalid.EncryptionScheme.BlockSize = 384
+		_, err := db.CreateBucket(ctx, TestBucket, &invalid)
+		assert.Error(t, err)
+
+		invalid = defaults
+		invalid.RedundancyScheme.RequiredShares = 5
+		_, err = db.CreateBucket(ctx, TestBucket, &invalid)
+		assert.Error(t, err)
+
+		bucket, err := db.CreateBucket(ctx, TestBucket, &defaults)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		bucket, err = db.GetBucket(ctx, TestBucket)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, defaults.SegmentsSize, bucket.SegmentsSize)
+		assert.Equal(t, defaults.RedundancyScheme, bucket.RedundancyScheme)
+		assert.Equal(t, defaults.EncryptionScheme, bucket.EncryptionScheme)
+
+		// objects are uploaded with the defaults of the bucket
+		data := make([]byte, 41*memory.KB)
+		_, err = rand.Read(data)
+		if !assert.NoError(t, err) {
+			return
+		}
+		upload(ctx, t, db, bucket, TestFile, data)
+
+		readOnly, err := db.GetObjectStream(ctx, TestBucket, TestFile)
+		if !assert.NoError(t, err) {
+			return
+		}
+		info := readOnly.Info()
+		assert.EqualValues(t, 3, info.SegmentCount)
+		assert.Equal(t, defaults.SegmentsSize, info.FixedSegmentSize)
+		assert.Equal(t, defaults.RedundancyScheme, info.RedundancyScheme)
+		assert.Equal(t, defaults.EncryptionScheme, info.EncryptionScheme)
+
+		download := stream.NewDownload(ctx, readOnly, db.streams)
+		downloaded, err := ioutil.ReadAll(download)
+		assert.NoError(t, err)
+		assert.NoError(t, download.Close())
+		assert.Equal(t, data, downloaded)
+
+		// the settings chosen for the object take precedence
+		es := storj.EncryptionScheme{Cipher: storj.AESGCM, BlockSize: 256}
+		obj, err := db.CreateObject(ctx, TestBucket, TestFile, &storj.CreateObject{EncryptionScheme: es})
+		if assert.NoError(t, err) {
+			assert.Equal(t, defaults.RedundancyScheme, obj.Info().RedundancyScheme)
+			assert.Equal(t, es, obj.Info().EncryptionScheme)
+		}
+	})
+}
+
 func TestListBucketsEmpty(t *testing.T) {
 	runTest(t, func(ctx context.Context, db *DB) {
 		_, err := db.ListBuckets(ctx, storj.BucketListOptions{})
@@ -329,6 +516,13 @@ func runTest(t *testing.T, test func(context.Context, *DB)) {
 }
 
 func newDB(planet *testplanet.Planet) (*DB, error) {
+	key := new(storj.Key)
+	copy(key[:], TestEncKey)
+
+	return newDBWithKeys(planet, TestAPIKey, encryption.NewRootKeys(key))
+}
+
+func newDBWithKeys(planet *testplanet.Planet, apiKey string, keys *encryption.PathKeys) (*DB, error) {
 	// TODO(kaloyan): We should have a better way for configuring the Satellite's API Key
 	err := flag.Set("pointer-db.auth.api-key", TestAPIKey)
 	if err != nil {
@@ -340,7 +534,7 @@ func newDB(planet *testplanet.Planet) (*DB, error) {
 		return nil, err
 	}
 
-	pdb, err := planet.Uplinks[0].DialPointerDB(planet.Satellites[0], TestAPIKey)
+	pdb, err := planet.Uplinks[0].DialPointerDB(planet.Satellites[0], apiKey)
 	if err != nil {
 		return nil, err
 	}
@@ -358,17 +552,14 @@ func newDB(planet *testplanet.Planet) (*DB, error) {
 
 	segments := segments.NewSegmentStore(oc, ec, pdb, rs, int(8*memory.KB))
 
-	key := new(storj.Key)
-	copy(key[:], TestEncKey)
-
-	streams, err := streams.NewStreamStore(segments, int64(64*memory.MB), key, int(1*memory.KB), storj.AESGCM)
+	streams, err := streams.NewStreamStoreWithKeys(segments, int64(64*memory.MB), keys, int(1*memory.KB), storj.AESGCM, storj.SHA256, 1)
 	if err != nil {
 		return nil, err
 	}
 
 	buckets := buckets.NewStore(streams)
 
-	return New(buckets, streams, segments, pdb, key), nil
+	return NewWithKeys(buckets, streams, segments, pdb, keys), nil
 }
 
 func forAllCiphers(test func(cipher storj.Cipher)) {
@@ -0,0 +1,77 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kvmetainfo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/pb"
+	pointerdbAuth "storj.io/storj/pkg/pointerdb/auth"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestSharedPrefix(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 4, 1)
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	db, err := newDB(planet)
+	require.NoError(t, err)
+
+	bucket, err := db.CreateBucket(ctx, TestBucket, nil)
+	require.NoError(t, err)
+	upload(ctx, t, db, bucket, "shared/a.txt", []byte("shared"))
+	upload(ctx, t, db, bucket, "private/b.txt", []byte("private"))
+
+	// share the prefix like the owner of the root key would
+	root := new(storj.Key)
+	copy(root[:], TestEncKey)
+	prefix := storj.JoinPaths(TestBucket, "shared")
+	encryptedPrefix, err := encryption.NewRootKeys(root).EncryptAfterBucket(prefix, bucket.PathCipher)
+	require.NoError(t, err)
+	prefixKey, err := encryption.DerivePathKey(prefix, root, 2)
+	require.NoError(t, err)
+	bucketContentKey, err := encryption.DeriveContentKey(TestBucket, root)
+	require.NoError(t, err)
+
+	keys, err := encryption.NewPrefixKeys(prefix, encryptedPrefix, prefixKey, bucketContentKey)
+	require.NoError(t, err)
+	apiKey, err := pointerdbAuth.Restrict(TestAPIKey, &pb.Caveat{PathPrefix: encryptedPrefix, ReadOnly: true})
+	require.NoError(t, err)
+
+	shared, err := newDBWithKeys(planet, apiKey, keys)
+	require.NoError(t, err)
+
+	object, err := shared.GetObject(ctx, TestBucket, "shared/a.txt")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "shared/a.txt", object.Path)
+	}
+	assertStream(ctx, t, shared, bucket, "shared/a.txt", 6, []byte("shared"))
+
+	list, err := shared.ListObjects(ctx, TestBucket, storj.ListOptions{Prefix: "shared/", Direction: storj.After})
+	if assert.NoError(t, err) && assert.Len(t, list.Items, 1) {
+		assert.Equal(t, "a.txt", list.Items[0].Path)
+	}
+
+	// paths outside of the prefix aren't accessible
+	_, err = shared.GetObject(ctx, TestBucket, "private/b.txt")
+	assert.Error(t, err)
+	_, err = shared.ListObjects(ctx, TestBucket, storj.ListOptions{Direction: storj.After})
+	assert.Error(t, err)
+
+	// read only accesses can't write
+	err = shared.DeleteObject(ctx, TestBucket, "shared/a.txt")
+	assert.Error(t, err)
+}
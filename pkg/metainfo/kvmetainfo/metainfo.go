@@ -8,6 +8,7 @@ import (
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/encryption"
 	"storj.io/storj/pkg/pointerdb/pdbclient"
 	"storj.io/storj/pkg/storage/buckets"
 	"storj.io/storj/pkg/storage/segments"
@@ -31,17 +32,23 @@ type DB struct {
 	segments segments.Store
 	pointers pdbclient.Client
 
-	rootKey *storj.Key
+	keys *encryption.PathKeys
 }
 
 // New creates a new metainfo database
 func New(buckets buckets.Store, streams streams.Store, segments segments.Store, pointers pdbclient.Client, rootKey *storj.Key) *DB {
+	return NewWithKeys(buckets, streams, segments, pointers, encryption.NewRootKeys(rootKey))
+}
+
+// NewWithKeys creates a new metainfo database deriving the path keys from
+// keys, which may be restricted to a path prefix
+func NewWithKeys(buckets buckets.Store, streams streams.Store, segments segments.Store, pointers pdbclient.Client, keys *encryption.PathKeys) *DB {
 	return &DB{
 		buckets:  buckets,
 		streams:  streams,
 		segments: segments,
 		pointers: pointers,
-		rootKey:  rootKey,
+		keys:     keys,
 	}
 }
 
@@ -5,6 +5,8 @@ package kvmetainfo
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"time"
 
@@ -14,7 +16,6 @@ import (
 	"go.uber.org/zap"
 
 	"storj.io/storj/internal/memory"
-	"storj.io/storj/pkg/encryption"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/pkg/storage/objects"
@@ -47,21 +48,35 @@ var defaultES = storj.EncryptionScheme{
 func (db *DB) GetObject(ctx context.Context, bucket string, path storj.Path) (info storj.Object, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	_, info, err = db.getInfo(ctx, committedPrefix, bucket, path)
+	_, info, err = db.getCurrentInfo(ctx, bucket, path)
+	if err != nil {
+		return storj.Object{}, err
+	}
+	if info.IsDeleteMarker {
+		return storj.Object{}, storj.ErrObjectNotFound.New("%q", path)
+	}
 
-	return info, err
+	return info, nil
 }
 
 // GetObjectStream returns interface for reading the object stream
 func (db *DB) GetObjectStream(ctx context.Context, bucket string, path storj.Path) (stream storj.ReadOnlyStream, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	meta, info, err := db.getInfo(ctx, committedPrefix, bucket, path)
+	meta, info, err := db.getCurrentInfo(ctx, bucket, path)
 	if err != nil {
 		return nil, err
 	}
+	if info.IsDeleteMarker {
+		return nil, storj.ErrObjectNotFound.New("%q", path)
+	}
+
+	return db.openStream(meta, info)
+}
 
-	streamKey, err := encryption.DeriveContentKey(meta.fullpath, db.rootKey)
+// openStream returns the stream of the object version read by getInfo
+func (db *DB) openStream(meta object, info storj.Object) (storj.ReadOnlyStream, error) {
+	streamKey, err := db.keys.DeriveContentKey(meta.fullpath)
 	if err != nil {
 		return nil, err
 	}
@@ -87,19 +102,70 @@ func (db *DB) CreateObject(ctx context.Context, bucket string, path storj.Path,
 		return nil, storj.ErrNoPath.New("")
 	}
 
+	if storj.IsReservedPath(path) {
+		return nil, errClass.New("path %q is reserved for object versions", path)
+	}
+
 	info := storj.Object{
 		Bucket: bucketInfo,
 		Path:   path,
 	}
 
+	if bucketInfo.Versioning {
+		// the new version is uploaded apart from the other uploads of the
+		// object and replaces the current version when committed, unless
+		// another version was committed in the meantime
+		store, err := db.buckets.GetObjectStore(ctx, bucket)
+		if err != nil {
+			return nil, err
+		}
+		_, err = db.resumeCommit(ctx, bucketInfo, store, path)
+		if err != nil {
+			return nil, err
+		}
+		_, current, err := db.getInfo(ctx, committedPrefix, bucket, path)
+		if err != nil && !storj.ErrObjectNotFound.Has(err) {
+			return nil, err
+		}
+		info.Version = current.Version + 1
+		info.IsNoncurrent = true
+		info.UploadID, err = newUploadID()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if createInfo != nil {
 		info.Metadata = createInfo.Metadata
 		info.ContentType = createInfo.ContentType
 		info.Expires = createInfo.Expires
+		info.PendingExpires = createInfo.PendingExpires
 		info.RedundancyScheme = createInfo.RedundancyScheme
 		info.EncryptionScheme = createInfo.EncryptionScheme
+		info.ChecksumAlgorithm = createInfo.ChecksumAlgorithm
 	}
 
+	if info.Expires.IsZero() {
+		// the satellite can't read the encrypted bucket metadata, so the
+		// expiration rules are applied when the object is created, and to
+		// the existing objects by ApplyBucketLifecycle
+		info.Expires = bucketInfo.Expiration(path, time.Now())
+	}
+
+	// the defaults of the bucket apply to the settings not chosen for the
+	// object
+	if info.RedundancyScheme.IsZero() {
+		info.RedundancyScheme = bucketInfo.RedundancyScheme
+	}
+	if info.EncryptionScheme.IsZero() {
+		info.EncryptionScheme = bucketInfo.EncryptionScheme
+	}
+	info.FixedSegmentSize = bucketInfo.SegmentsSize
+
+	// the stream is uploaded with the settings chosen so far, the unset ones
+	// are left to the stream store
+	streamInfo := info
+
 	// TODO: autodetect content type from the path extension
 	// if info.ContentType == "" {}
 
@@ -115,8 +181,9 @@ func (db *DB) CreateObject(ctx context.Context, bucket string, path storj.Path,
 	}
 
 	return &mutableObject{
-		db:   db,
-		info: info,
+		db:         db,
+		info:       info,
+		streamInfo: streamInfo,
 	}, nil
 }
 
@@ -126,10 +193,20 @@ func (db *DB) ModifyObject(ctx context.Context, bucket string, path storj.Path)
 	return nil, errors.New("not implemented")
 }
 
-// DeleteObject deletes an object from database
+// DeleteObject deletes an object from database. In a versioned bucket, the
+// object is kept as a noncurrent version and replaced by a delete marker.
 func (db *DB) DeleteObject(ctx context.Context, bucket string, path storj.Path) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	bucketInfo, err := db.GetBucket(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	if bucketInfo.Versioning {
+		return db.deleteVersioned(ctx, bucketInfo, path)
+	}
+
 	store, err := db.buckets.GetObjectStore(ctx, bucket)
 	if err != nil {
 		return err
@@ -164,6 +241,10 @@ func (db *DB) ListObjects(ctx context.Context, bucket string, options storj.List
 		return storj.ObjectList{}, err
 	}
 
+	if options.Versions {
+		return db.listVersions(ctx, bucketInfo, objects, options)
+	}
+
 	var startAfter, endBefore string
 	switch options.Direction {
 	case storj.Before:
@@ -187,23 +268,39 @@ func (db *DB) ListObjects(ctx context.Context, bucket string, options storj.List
 		endBefore = "\x7f\x7f\x7f\x7f\x7f\x7f\x7f"
 	}
 
-	items, more, err := objects.List(ctx, options.Prefix, startAfter, endBefore, options.Recursive, options.Limit, meta.All)
-	if err != nil {
-		return storj.ObjectList{}, err
-	}
-
 	list = storj.ObjectList{
 		Bucket: bucket,
 		Prefix: options.Prefix,
-		More:   more,
-		Items:  make([]storj.Object, 0, len(items)),
 	}
 
-	for _, item := range items {
-		list.Items = append(list.Items, objectFromMeta(bucketInfo, item.Path, item.IsPrefix, item.Meta))
-	}
+	for {
+		items, more, err := objects.List(ctx, options.Prefix, startAfter, endBefore, options.Recursive, options.Limit, meta.All)
+		if err != nil {
+			return storj.ObjectList{}, err
+		}
+
+		list.More = more
+		for _, item := range items {
+			// noncurrent versions and delete markers are only listed with the
+			// versions, the prefixes holding noncurrent versions never
+			if storj.IsReservedPath(item.Path) || (!item.IsPrefix && item.Meta.DeleteMarker) {
+				continue
+			}
+			list.Items = append(list.Items, objectFromMeta(bucketInfo, item.Path, item.IsPrefix, item.Meta))
+		}
 
-	return list, nil
+		// pages holding only hidden items are skipped, as an empty page
+		// can't be continued from
+		if len(list.Items) > 0 || !more || len(items) == 0 {
+			return list, nil
+		}
+
+		if options.Direction == storj.Before || options.Direction == storj.Backward {
+			endBefore = items[0].Path
+		} else {
+			startAfter = items[len(items)-1].Path
+		}
+	}
 }
 
 type object struct {
@@ -228,7 +325,7 @@ func (db *DB) getInfo(ctx context.Context, prefix string, bucket string, path st
 
 	fullpath := bucket + "/" + path
 
-	encryptedPath, err := streams.EncryptAfterBucket(fullpath, bucketInfo.PathCipher, db.rootKey)
+	encryptedPath, err := db.keys.EncryptAfterBucket(fullpath, bucketInfo.PathCipher)
 	if err != nil {
 		return object{}, storj.Object{}, err
 	}
@@ -263,7 +360,7 @@ func (db *DB) getInfo(ctx context.Context, prefix string, bucket string, path st
 		Data:       pointer.GetMetadata(),
 	}
 
-	streamInfoData, err := streams.DecryptStreamInfo(ctx, lastSegmentMeta, fullpath, db.rootKey)
+	streamInfoData, err := streams.DecryptStreamInfo(ctx, lastSegmentMeta, fullpath, db.keys)
 	if err != nil {
 		return object{}, storj.Object{}, err
 	}
@@ -296,11 +393,13 @@ func (db *DB) getInfo(ctx context.Context, prefix string, bucket string, path st
 
 func objectFromMeta(bucket storj.Bucket, path storj.Path, isPrefix bool, meta objects.Meta) storj.Object {
 	return storj.Object{
-		Version:  0, // TODO:
+		Version:  meta.Version,
 		Bucket:   bucket,
 		Path:     path,
 		IsPrefix: isPrefix,
 
+		IsDeleteMarker: meta.DeleteMarker,
+
 		Metadata: meta.UserDefined,
 
 		ContentType: meta.ContentType,
@@ -309,8 +408,9 @@ func objectFromMeta(bucket storj.Bucket, path storj.Path, isPrefix bool, meta ob
 		Expires:     meta.Expiration,
 
 		Stream: storj.Stream{
-			Size:     meta.Size,
-			Checksum: []byte(meta.Checksum),
+			Size:              meta.Size,
+			Checksum:          []byte(meta.Checksum),
+			ChecksumAlgorithm: meta.ChecksumAlgorithm,
 		},
 	}
 }
@@ -326,11 +426,13 @@ func objectStreamFromMeta(bucket storj.Bucket, path storj.Path, lastSegment segm
 	}
 
 	return storj.Object{
-		Version:  0, // TODO:
+		Version:  serMetaInfo.Version,
 		Bucket:   bucket,
 		Path:     path,
 		IsPrefix: false,
 
+		IsDeleteMarker: serMetaInfo.DeleteMarker,
+
 		Metadata: serMetaInfo.UserDefined,
 
 		ContentType: serMetaInfo.ContentType,
@@ -339,8 +441,9 @@ func objectStreamFromMeta(bucket storj.Bucket, path storj.Path, lastSegment segm
 		Expires:     lastSegment.Expiration, // TODO: use correct field
 
 		Stream: storj.Stream{
-			Size: stream.SegmentsSize*(stream.NumberOfSegments-1) + stream.LastSegmentSize,
-			// Checksum: []byte(object.Checksum),
+			Size:              stream.SegmentsSize*(stream.NumberOfSegments-1) + stream.LastSegmentSize,
+			Checksum:          stream.Checksum,
+			ChecksumAlgorithm: storj.ChecksumAlgorithm(stream.ChecksumAlgorithm),
 
 			SegmentCount:     stream.NumberOfSegments,
 			FixedSegmentSize: stream.SegmentsSize,
@@ -366,6 +469,67 @@ func objectStreamFromMeta(bucket storj.Bucket, path storj.Path, lastSegment segm
 	}, nil
 }
 
+// setExpiration sets when the segments of the stream stored at path, relative
+// to the bucket, are removed by the satellite. Its last segment is updated
+// last. The pieces keep the expiration they were uploaded with.
+func (db *DB) setExpiration(ctx context.Context, bucket storj.Bucket, path storj.Path, expiration time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	fullpath := storj.JoinPaths(bucket.Name, path)
+	encPath, err := db.keys.EncryptAfterBucket(fullpath, bucket.PathCipher)
+	if err != nil {
+		return err
+	}
+
+	exp, err := ptypes.TimestampProto(expiration)
+	if err != nil {
+		return err
+	}
+
+	lastSegment, _, _, err := db.pointers.Get(ctx, committedPrefix+encPath)
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			err = storj.ErrObjectNotFound.Wrap(err)
+		}
+		return err
+	}
+
+	streamInfoData, err := streams.DecryptStreamInfo(ctx, segments.Meta{Data: lastSegment.GetMetadata()}, fullpath, db.keys)
+	if err != nil {
+		return err
+	}
+	streamInfo := pb.StreamInfo{}
+	err = proto.Unmarshal(streamInfoData, &streamInfo)
+	if err != nil {
+		return err
+	}
+
+	for index := int64(0); index < streamInfo.NumberOfSegments-1; index++ {
+		pointer, _, _, err := db.pointers.Get(ctx, getSegmentPath(encPath, index))
+		if err != nil {
+			return err
+		}
+		pointer.ExpirationDate = exp
+		err = db.pointers.Put(ctx, getSegmentPath(encPath, index), pointer)
+		if err != nil {
+			return err
+		}
+	}
+
+	lastSegment.ExpirationDate = exp
+	return db.pointers.Put(ctx, committedPrefix+encPath, lastSegment)
+}
+
+// newUploadID returns a random ID for the pending upload of a new version
+func newUploadID() (string, error) {
+	var id [8]byte
+	_, err := rand.Read(id[:])
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id[:]), nil
+}
+
 // convertTime converts gRPC timestamp to Go time
 func convertTime(ts *timestamp.Timestamp) time.Time {
 	if ts == nil {
@@ -381,6 +545,8 @@ func convertTime(ts *timestamp.Timestamp) time.Time {
 type mutableObject struct {
 	db   *DB
 	info storj.Object
+	// streamInfo is info without the default schemes
+	streamInfo storj.Object
 }
 
 func (object *mutableObject) Info() storj.Object { return object.info }
@@ -388,7 +554,7 @@ func (object *mutableObject) Info() storj.Object { return object.info }
 func (object *mutableObject) CreateStream(ctx context.Context) (storj.MutableStream, error) {
 	return &mutableStream{
 		db:   object.db,
-		info: object.info,
+		info: object.streamInfo,
 	}, nil
 }
 
@@ -401,6 +567,25 @@ func (object *mutableObject) DeleteStream(ctx context.Context) error {
 }
 
 func (object *mutableObject) Commit(ctx context.Context) error {
+	if !object.info.PendingExpires.IsZero() {
+		// the satellite may already have removed the segments of an upload
+		// committed too late
+		if time.Now().After(object.info.PendingExpires) {
+			return errClass.New("pending upload of %q expired", object.info.Path)
+		}
+		err := object.db.setExpiration(ctx, object.info.Bucket, object.info.StreamPath(), object.info.Expires)
+		if err != nil {
+			return err
+		}
+	}
+
+	if object.info.UploadID != "" {
+		err := object.db.commitVersion(ctx, object.info)
+		if err != nil {
+			return err
+		}
+	}
+
 	_, info, err := object.db.getInfo(ctx, committedPrefix, object.info.Bucket.Name, object.info.Path)
 	object.info = info
 	return err
@@ -8,10 +8,12 @@ import (
 	"crypto/rand"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"storj.io/storj/internal/memory"
 	"storj.io/storj/pkg/storj"
@@ -252,6 +254,72 @@ func assertRemoteSegment(t *testing.T, segment storj.Segment) {
 	}
 }
 
+func TestPendingExpiration(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, &storj.Bucket{PathCipher: storj.AESGCM, SegmentsSize: 4 * memory.KB.Int64()})
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		data := make([]byte, 10*memory.KB)
+		_, err = rand.Read(data)
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		encPath, err := db.keys.EncryptAfterBucket(storj.JoinPaths(TestBucket, TestFile), storj.AESGCM)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assertExpiration := func(expected time.Time) {
+			for _, path := range []storj.Path{getSegmentPath(encPath, 0), getSegmentPath(encPath, 1), committedPrefix + encPath} {
+				pointer, _, _, err := db.pointers.Get(ctx, path)
+				if assert.NoError(t, err) {
+					assert.True(t, expected.Equal(convertTime(pointer.GetExpirationDate())), path)
+				}
+			}
+		}
+
+		// the segments of a pending upload expire at its deadline until it
+		// is committed
+		pending := time.Now().Add(time.Hour)
+		mutable := createPending(ctx, t, db, pending, data)
+		assertExpiration(pending)
+
+		assert.NoError(t, mutable.Commit(ctx))
+		assertExpiration(time.Time{})
+
+		readOnly, err := db.GetObjectStream(ctx, bucket.Name, TestFile)
+		if assert.NoError(t, err) {
+			assert.True(t, readOnly.Info().Expires.IsZero())
+			download := stream.NewDownload(ctx, readOnly, db.streams)
+			downloaded, err := ioutil.ReadAll(download)
+			assert.NoError(t, err)
+			assert.NoError(t, download.Close())
+			assert.Equal(t, data, downloaded)
+		}
+
+		// an upload committed after its deadline may have been removed
+		mutable = createPending(ctx, t, db, time.Now().Add(-time.Minute), data)
+		assert.Error(t, mutable.Commit(ctx))
+	})
+}
+
+func createPending(ctx context.Context, t *testing.T, db *DB, pending time.Time, data []byte) storj.MutableObject {
+	mutable, err := db.CreateObject(ctx, TestBucket, TestFile, &storj.CreateObject{PendingExpires: pending})
+	require.NoError(t, err)
+
+	str, err := mutable.CreateStream(ctx)
+	require.NoError(t, err)
+
+	upload := stream.NewUpload(ctx, str, db.streams)
+	_, err = upload.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, upload.Close())
+
+	return mutable
+}
+
 func TestDeleteObject(t *testing.T) {
 	runTest(t, func(ctx context.Context, db *DB) {
 		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
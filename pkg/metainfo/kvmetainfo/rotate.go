@@ -0,0 +1,287 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kvmetainfo
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gogo/protobuf/proto"
+
+	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storage/meta"
+	"storj.io/storj/pkg/storage/segments"
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+// RotateKey encrypts the paths and the content keys of all buckets and
+// objects with keys derived from newKey instead of the root key of db. The
+// pieces on the storage nodes aren't touched, only the pointers are replaced.
+//
+// The objects of a bucket are rotated before the bucket itself. An
+// interrupted rotation is resumed by rotating again with the same keys: the
+// buckets and objects already encrypted with newKey are recognized as their
+// paths and keys can't be decrypted with the old root key. The objects of
+// every bucket are listed again, so that those missed by a previous rotation
+// are rotated too. No other uplink may write to the buckets meanwhile.
+// Segments of abandoned uploads without last segment can't be read and
+// aren't rotated.
+func (db *DB) RotateKey(ctx context.Context, newKey *storj.Key) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if db.keys.Prefix() != "" {
+		return errClass.New("rotating the key requires the root key, not the key of %q", db.keys.Prefix())
+	}
+	newKeys := encryption.NewRootKeys(newKey)
+
+	// bucket paths aren't encrypted
+	return db.listPointers(ctx, "l", false, func(item segments.ListItem) error {
+		if item.IsPrefix {
+			return nil
+		}
+		return db.rotateBucket(ctx, item.Path, item.Meta, newKeys)
+	})
+}
+
+// rotateBucket rotates the keys of the objects of bucket, then of bucket. The
+// objects are rotated even if bucket was rotated already, as some of them may
+// have been missed.
+func (db *DB) rotateBucket(ctx context.Context, bucket string, lastSegment segments.Meta, newKeys *encryption.PathKeys) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	keys := db.keys
+	if rotated(ctx, lastSegment, bucket, newKeys) {
+		keys = newKeys
+	}
+	pathCipher, err := bucketPathCipher(ctx, bucket, lastSegment, keys)
+	if err != nil {
+		return err
+	}
+
+	err = db.listPointers(ctx, storj.JoinPaths("l", bucket), true, func(item segments.ListItem) error {
+		encPath := storj.JoinPaths(bucket, item.Path)
+
+		path, err := db.keys.DecryptAfterBucket(encPath, pathCipher)
+		if err != nil {
+			if _, newErr := newKeys.DecryptAfterBucket(encPath, pathCipher); newErr == nil {
+				// the object was rotated already
+				return nil
+			}
+			return err
+		}
+
+		return db.rotateStream(ctx, path, pathCipher, newKeys)
+	})
+	if err != nil {
+		return err
+	}
+
+	return db.rotateStream(ctx, bucket, storj.Unencrypted, newKeys)
+}
+
+// rotateStream rotates the keys of the stream at path. When the encrypted path
+// changes, the stream is copied to its new path before it's deleted from the
+// old one, its last segment last, so that an interrupted rotation finds it
+// again. Otherwise its pointers are updated in place, its last segment last.
+func (db *DB) rotateStream(ctx context.Context, path storj.Path, pathCipher storj.Cipher, newKeys *encryption.PathKeys) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	encFrom, err := db.keys.EncryptAfterBucket(path, pathCipher)
+	if err != nil {
+		return err
+	}
+	encTo, err := newKeys.EncryptAfterBucket(path, pathCipher)
+	if err != nil {
+		return err
+	}
+	inPlace := encFrom == encTo
+
+	fromKey, err := db.keys.DeriveContentKey(path)
+	if err != nil {
+		return err
+	}
+	toKey, err := newKeys.DeriveContentKey(path)
+	if err != nil {
+		return err
+	}
+
+	lastSegment, _, _, err := db.pointers.Get(ctx, committedPrefix+encFrom)
+	if err != nil {
+		return err
+	}
+	if inPlace && rotated(ctx, segments.Meta{Data: lastSegment.GetMetadata()}, path, newKeys) {
+		return nil
+	}
+
+	streamMeta := pb.StreamMeta{}
+	err = proto.Unmarshal(lastSegment.GetMetadata(), &streamMeta)
+	if err != nil {
+		return err
+	}
+	cipher := storj.Cipher(streamMeta.EncryptionType)
+
+	streamInfoData, err := streams.DecryptStreamInfo(ctx, segments.Meta{Data: lastSegment.GetMetadata()}, path, db.keys)
+	if err != nil {
+		return err
+	}
+	streamInfo := pb.StreamInfo{}
+	err = proto.Unmarshal(streamInfoData, &streamInfo)
+	if err != nil {
+		return err
+	}
+
+	copied := false
+	if !inPlace {
+		_, _, _, err = db.pointers.Get(ctx, committedPrefix+encTo)
+		if err != nil && !storage.ErrKeyNotFound.Has(err) {
+			return err
+		}
+		copied = err == nil
+	}
+
+	if !copied {
+		for index := int64(0); index < streamInfo.NumberOfSegments-1; index++ {
+			pointer, _, _, err := db.pointers.Get(ctx, getSegmentPath(encFrom, index))
+			if err != nil {
+				return err
+			}
+
+			if len(pointer.GetMetadata()) > 0 {
+				segmentMeta := pb.SegmentMeta{}
+				err = proto.Unmarshal(pointer.GetMetadata(), &segmentMeta)
+				if err != nil {
+					return err
+				}
+				err = rotateKey(&segmentMeta, cipher, fromKey, toKey)
+				if err != nil {
+					return err
+				}
+				pointer.Metadata, err = proto.Marshal(&segmentMeta)
+				if err != nil {
+					return err
+				}
+			}
+
+			err = db.pointers.Put(ctx, getSegmentPath(encTo, index), pointer)
+			if err != nil {
+				return err
+			}
+		}
+
+		if streamMeta.LastSegmentMeta != nil {
+			err = reencryptKey(streamMeta.LastSegmentMeta, cipher, fromKey, toKey)
+			if err != nil {
+				return err
+			}
+			lastSegment.Metadata, err = proto.Marshal(&streamMeta)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = db.pointers.Put(ctx, committedPrefix+encTo, lastSegment)
+		if err != nil {
+			return err
+		}
+	}
+
+	if inPlace {
+		return nil
+	}
+
+	for index := int64(0); index < streamInfo.NumberOfSegments-1; index++ {
+		err = db.pointers.Delete(ctx, getSegmentPath(encFrom, index))
+		if err != nil && !storage.ErrKeyNotFound.Has(err) {
+			return err
+		}
+	}
+	return db.pointers.Delete(ctx, committedPrefix+encFrom)
+}
+
+// rotateKey encrypts the content key of a segment with to instead of from,
+// unless it was encrypted with to already by an interrupted rotation
+func rotateKey(segmentMeta *pb.SegmentMeta, cipher storj.Cipher, from, to *storj.Key) error {
+	err := reencryptKey(segmentMeta, cipher, from, to)
+	if err == nil {
+		return nil
+	}
+
+	var nonce storj.Nonce
+	copy(nonce[:], segmentMeta.KeyNonce)
+	if _, toErr := encryption.DecryptKey(segmentMeta.EncryptedKey, cipher, to, &nonce); toErr == nil {
+		return nil
+	}
+	return err
+}
+
+// bucketPathCipher returns the path cipher of bucket, read from the last
+// segment of its pointer with keys
+func bucketPathCipher(ctx context.Context, bucket string, lastSegment segments.Meta, keys *encryption.PathKeys) (storj.Cipher, error) {
+	streamInfoData, err := streams.DecryptStreamInfo(ctx, lastSegment, bucket, keys)
+	if err != nil {
+		return storj.Unencrypted, err
+	}
+	streamInfo := pb.StreamInfo{}
+	err = proto.Unmarshal(streamInfoData, &streamInfo)
+	if err != nil {
+		return storj.Unencrypted, err
+	}
+	serMeta := pb.SerializableMeta{}
+	err = proto.Unmarshal(streamInfo.Metadata, &serMeta)
+	if err != nil {
+		return storj.Unencrypted, err
+	}
+
+	pathEncType := serMeta.UserDefined["path-enc-type"]
+	if pathEncType == "" {
+		// buckets created before the setting use AES-GCM
+		return storj.AESGCM, nil
+	}
+	cipher, err := strconv.Atoi(pathEncType)
+	if err != nil {
+		return storj.Unencrypted, err
+	}
+	return storj.Cipher(cipher), nil
+}
+
+// rotated returns whether the stream info in the last segment of the stream
+// at path is encrypted with keys. Unencrypted stream infos are never
+// considered rotated, rotating them again is harmless.
+func rotated(ctx context.Context, lastSegment segments.Meta, path storj.Path, keys *encryption.PathKeys) bool {
+	streamMeta := pb.StreamMeta{}
+	err := proto.Unmarshal(lastSegment.Data, &streamMeta)
+	if err != nil || storj.Cipher(streamMeta.EncryptionType) == storj.Unencrypted {
+		return false
+	}
+
+	_, err = streams.DecryptStreamInfo(ctx, lastSegment, path, keys)
+	return err == nil
+}
+
+// listPointers calls fn with every pointer under prefix, with its path
+// relative to prefix
+func (db *DB) listPointers(ctx context.Context, prefix storj.Path, recursive bool, fn func(item segments.ListItem) error) error {
+	startAfter := ""
+	for {
+		items, more, err := db.segments.List(ctx, prefix, startAfter, "", recursive, 0, meta.All)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			err = fn(item)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !more || len(items) == 0 {
+			return nil
+		}
+		startAfter = items[len(items)-1].Path
+	}
+}
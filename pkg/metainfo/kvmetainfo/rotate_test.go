@@ -0,0 +1,192 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kvmetainfo
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/stream"
+	"storj.io/storj/storage"
+)
+
+func TestRotateKey(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 4, 1)
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	// we wait a second for all the nodes to complete bootstrapping off the satellite
+	time.Sleep(2 * time.Second)
+
+	db, err := newDB(planet)
+	require.NoError(t, err)
+
+	newKey := new(storj.Key)
+	copy(newKey[:], "new-test-encryption-key")
+	newDB, err := newDBWithKeys(planet, TestAPIKey, encryption.NewRootKeys(newKey))
+	require.NoError(t, err)
+
+	large := make([]byte, 41*memory.KB)
+	_, err = rand.Read(large)
+	require.NoError(t, err)
+
+	contents := map[string]map[storj.Path][]byte{
+		"encrypted": {"dir/large": large, "dir/small": []byte("small"), "other": []byte("other")},
+		"plain":     {"dir/small": []byte("small")},
+	}
+
+	encrypted, err := db.CreateBucket(ctx, "encrypted", &storj.Bucket{PathCipher: storj.AESGCM, SegmentsSize: 16 * memory.KB.Int64()})
+	require.NoError(t, err)
+	plain, err := db.CreateBucket(ctx, "plain", &storj.Bucket{PathCipher: storj.Unencrypted})
+	require.NoError(t, err)
+	for path, content := range contents["encrypted"] {
+		upload(ctx, t, db, encrypted, path, content)
+	}
+	for path, content := range contents["plain"] {
+		upload(ctx, t, db, plain, path, content)
+	}
+
+	// simulate a rotation interrupted after copying the large object to its
+	// new path, before deleting it from the old one
+	encLarge, err := db.keys.EncryptAfterBucket("encrypted/dir/large", storj.AESGCM)
+	require.NoError(t, err)
+	oldPointers := map[storj.Path]*pb.Pointer{}
+	for _, path := range []storj.Path{
+		getSegmentPath(encLarge, 0),
+		getSegmentPath(encLarge, 1),
+		committedPrefix + encLarge,
+	} {
+		pointer, _, _, err := db.pointers.Get(ctx, path)
+		require.NoError(t, err)
+		oldPointers[path] = pointer
+	}
+	require.NoError(t, db.rotateStream(ctx, "encrypted/dir/large", storj.AESGCM, encryption.NewRootKeys(newKey)))
+	for path, pointer := range oldPointers {
+		require.NoError(t, db.pointers.Put(ctx, path, pointer))
+	}
+
+	require.NoError(t, db.RotateKey(ctx, newKey))
+	// rotating again finds nothing left to rotate
+	require.NoError(t, db.RotateKey(ctx, newKey))
+
+	for path := range oldPointers {
+		_, _, _, err := db.pointers.Get(ctx, path)
+		assert.True(t, storage.ErrKeyNotFound.Has(err), path)
+	}
+
+	list, err := newDB.ListBuckets(ctx, storj.BucketListOptions{Direction: storj.After})
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 2)
+
+	for bucket, objects := range contents {
+		info, err := newDB.GetBucket(ctx, bucket)
+		require.NoError(t, err)
+		assert.Equal(t, bucket == "encrypted", info.PathCipher == storj.AESGCM)
+
+		objectList, err := newDB.ListObjects(ctx, bucket, storj.ListOptions{Direction: storj.After, Recursive: true})
+		require.NoError(t, err)
+		assert.Len(t, objectList.Items, len(objects), bucket)
+
+		for path, content := range objects {
+			readOnly, err := newDB.GetObjectStream(ctx, bucket, path)
+			if !assert.NoError(t, err, path) {
+				continue
+			}
+			download := stream.NewDownload(ctx, readOnly, newDB.streams)
+			data, err := ioutil.ReadAll(download)
+			assert.NoError(t, err)
+			assert.NoError(t, download.Close())
+			assert.Equal(t, content, data, path)
+
+			_, err = db.GetObjectStream(ctx, bucket, path)
+			assert.Error(t, err, path)
+		}
+	}
+}
+
+func TestRotateKeyInPlace(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 4, 1)
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	// we wait a second for all the nodes to complete bootstrapping off the satellite
+	time.Sleep(2 * time.Second)
+
+	db, err := newDB(planet)
+	require.NoError(t, err)
+
+	newKey := new(storj.Key)
+	copy(newKey[:], "new-test-encryption-key")
+	newKeys := encryption.NewRootKeys(newKey)
+	newDB, err := newDBWithKeys(planet, TestAPIKey, newKeys)
+	require.NoError(t, err)
+
+	large := make([]byte, 41*memory.KB)
+	_, err = rand.Read(large)
+	require.NoError(t, err)
+
+	contents := map[storj.Path][]byte{"dir/large": large, "late": []byte("late")}
+
+	plain, err := db.CreateBucket(ctx, "plain", &storj.Bucket{PathCipher: storj.Unencrypted, SegmentsSize: 16 * memory.KB.Int64()})
+	require.NoError(t, err)
+	for path, content := range contents {
+		upload(ctx, t, db, plain, path, content)
+	}
+
+	// simulate a rotation interrupted after updating the first segments of
+	// the large object in place, before its last segment
+	lastSegmentPath := committedPrefix + "plain/dir/large"
+	lastSegment, _, _, err := db.pointers.Get(ctx, lastSegmentPath)
+	require.NoError(t, err)
+	require.NoError(t, db.rotateStream(ctx, "plain/dir/large", storj.Unencrypted, newKeys))
+	require.NoError(t, db.pointers.Put(ctx, lastSegmentPath, lastSegment))
+
+	// simulate an object missed by a rotation of its bucket, as if it was
+	// written by another uplink meanwhile
+	latePath := committedPrefix + "plain/late"
+	late, _, _, err := db.pointers.Get(ctx, latePath)
+	require.NoError(t, err)
+
+	require.NoError(t, db.RotateKey(ctx, newKey))
+	require.NoError(t, db.pointers.Put(ctx, latePath, late))
+
+	_, err = newDB.GetObjectStream(ctx, "plain", "late")
+	require.Error(t, err)
+
+	// rotating again picks up the missed object
+	require.NoError(t, db.RotateKey(ctx, newKey))
+
+	for path, content := range contents {
+		readOnly, err := newDB.GetObjectStream(ctx, "plain", path)
+		if !assert.NoError(t, err, path) {
+			continue
+		}
+		download := stream.NewDownload(ctx, readOnly, newDB.streams)
+		data, err := ioutil.ReadAll(download)
+		assert.NoError(t, err)
+		assert.NoError(t, download.Close())
+		assert.Equal(t, content, data, path)
+	}
+}
@@ -0,0 +1,596 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kvmetainfo
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storage/meta"
+	"storj.io/storj/pkg/storage/objects"
+	"storj.io/storj/pkg/storage/segments"
+	"storj.io/storj/pkg/storage/streams"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+// In a versioned bucket, the current version of an object is stored at the
+// path of the object, like in any bucket, and the noncurrent versions at
+// storj.VersionPath, under a prefix next to it listing all of them. The number
+// of every version is kept in its serialized metadata.
+
+// GetObjectVersion returns information about a version of an object
+func (db *DB) GetObjectVersion(ctx context.Context, bucket string, path storj.Path, version uint32) (info storj.Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, info, err = db.getVersionInfo(ctx, bucket, path, version)
+
+	return info, err
+}
+
+// GetObjectVersionStream returns interface for reading the stream of a version of an object
+func (db *DB) GetObjectVersionStream(ctx context.Context, bucket string, path storj.Path, version uint32) (stream storj.ReadOnlyStream, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	meta, info, err := db.getVersionInfo(ctx, bucket, path, version)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDeleteMarker {
+		return nil, storj.ErrObjectNotFound.New("%q is a delete marker", path)
+	}
+
+	return db.openStream(meta, info)
+}
+
+// DeleteObjectVersion deletes a version of an object, making the previous
+// version current when deleting the current one
+func (db *DB) DeleteObjectVersion(ctx context.Context, bucket string, path storj.Path, version uint32) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	bucketInfo, err := db.GetBucket(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	store, err := db.buckets.GetObjectStore(ctx, bucket)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.resumeCommit(ctx, bucketInfo, store, path)
+	if err != nil {
+		return err
+	}
+
+	// an object without current version has no noncurrent ones either
+	_, current, err := db.getInfo(ctx, committedPrefix, bucket, path)
+	if err != nil {
+		return err
+	}
+
+	if current.Version != version {
+		return store.Delete(ctx, storj.VersionPath(path, version))
+	}
+
+	previous, err := db.noncurrentVersions(ctx, bucketInfo, store, path, current.Version)
+	if err != nil {
+		return err
+	}
+
+	if len(previous) == 0 {
+		return store.Delete(ctx, path)
+	}
+
+	// the previous version replaces the deleted one like a committed upload
+	err = db.moveStream(ctx, bucketInfo, storj.VersionPath(path, previous[0].Version), storj.CommitPath(path))
+	if err != nil {
+		return err
+	}
+
+	_, err = db.resumeCommit(ctx, bucketInfo, store, path)
+	return err
+}
+
+// getVersionInfo returns the information of a version of the object at path,
+// which may be the current one
+func (db *DB) getVersionInfo(ctx context.Context, bucket string, path storj.Path, version uint32) (obj object, info storj.Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	obj, info, err = db.getCurrentInfo(ctx, bucket, path)
+	if err == nil && info.Version == version {
+		return obj, info, nil
+	}
+	if err != nil && !storj.ErrObjectNotFound.Has(err) {
+		return object{}, storj.Object{}, err
+	}
+
+	obj, info, err = db.getInfo(ctx, committedPrefix, bucket, storj.VersionPath(path, version))
+	if err != nil {
+		return object{}, storj.Object{}, err
+	}
+
+	info.Path = path
+	info.IsNoncurrent = true
+
+	return obj, info, nil
+}
+
+// getCurrentInfo returns the information of the current version of the object
+// at path. An object found without current version in a versioned bucket may
+// have been left so by an interrupted commit, which is resumed.
+func (db *DB) getCurrentInfo(ctx context.Context, bucket string, path storj.Path) (obj object, info storj.Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	obj, info, err = db.getInfo(ctx, committedPrefix, bucket, path)
+	if !storj.ErrObjectNotFound.Has(err) {
+		return obj, info, err
+	}
+
+	bucketInfo, bucketErr := db.GetBucket(ctx, bucket)
+	if bucketErr != nil || !bucketInfo.Versioning {
+		return obj, info, err
+	}
+
+	store, storeErr := db.buckets.GetObjectStore(ctx, bucket)
+	if storeErr != nil {
+		return object{}, storj.Object{}, storeErr
+	}
+
+	resumed, resumeErr := db.resumeCommit(ctx, bucketInfo, store, path)
+	if resumeErr != nil {
+		return object{}, storj.Object{}, resumeErr
+	}
+	if !resumed {
+		return obj, info, err
+	}
+
+	return db.getInfo(ctx, committedPrefix, bucket, path)
+}
+
+// commitVersion makes the uploaded version of info the current one, keeping
+// the current one as a noncurrent version. The upload is first moved to the
+// commit path of the object, from where it is made current, so that an
+// interrupted commit is resumed by the next access to the object.
+func (db *DB) commitVersion(ctx context.Context, info storj.Object) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store, err := db.buckets.GetObjectStore(ctx, info.Bucket.Name)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.resumeCommit(ctx, info.Bucket, store, info.Path)
+	if err != nil {
+		return err
+	}
+
+	_, current, err := db.getInfo(ctx, committedPrefix, info.Bucket.Name, info.Path)
+	if err != nil && !storj.ErrObjectNotFound.Has(err) {
+		return err
+	}
+	if err == nil && current.Version >= info.Version {
+		err = store.Delete(ctx, info.StreamPath())
+		if err != nil {
+			return err
+		}
+		return errClass.New("version %d of %q was committed concurrently", current.Version, info.Path)
+	}
+
+	return db.makeCurrent(ctx, info, store)
+}
+
+// makeCurrent moves the upload of info to the commit path of the object and
+// resumes the commit from there. The satellite can't compare and swap pointers,
+// so another upload may have been committed with the same version meanwhile.
+// The upload that was made current first keeps the version and the other one
+// fails.
+func (db *DB) makeCurrent(ctx context.Context, info storj.Object, store objects.Store) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	upload, err := db.lastSegment(ctx, info.Bucket, info.StreamPath())
+	if err != nil {
+		return err
+	}
+
+	err = db.moveStream(ctx, info.Bucket, info.StreamPath(), storj.CommitPath(info.Path))
+	if err != nil {
+		return err
+	}
+
+	_, err = db.resumeCommit(ctx, info.Bucket, store, info.Path)
+	if err != nil {
+		return err
+	}
+
+	// a later version may already have been committed on top of this one
+	at := info.Path
+	_, current, err := db.getInfo(ctx, committedPrefix, info.Bucket.Name, info.Path)
+	if err == nil && current.Version != info.Version {
+		at = storj.VersionPath(info.Path, info.Version)
+	}
+
+	committed, err := db.lastSegment(ctx, info.Bucket, at)
+	if err != nil && !storj.ErrObjectNotFound.Has(err) {
+		return err
+	}
+	if err != nil || !sameStream(upload, committed) {
+		return errClass.New("version %d of %q was committed concurrently", info.Version, info.Path)
+	}
+	return nil
+}
+
+// deleteVersioned keeps the current version of the object at path as a
+// noncurrent version and replaces it with a delete marker
+func (db *DB) deleteVersioned(ctx context.Context, bucket storj.Bucket, path storj.Path) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	store, err := db.buckets.GetObjectStore(ctx, bucket.Name)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.resumeCommit(ctx, bucket, store, path)
+	if err != nil {
+		return err
+	}
+
+	_, current, err := db.getInfo(ctx, committedPrefix, bucket.Name, path)
+	if err != nil {
+		return err
+	}
+	if current.IsDeleteMarker {
+		return storj.ErrObjectNotFound.New("%q", path)
+	}
+
+	metadata, err := proto.Marshal(&pb.SerializableMeta{
+		Version:      current.Version + 1,
+		DeleteMarker: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.streams.Put(ctx, storj.JoinPaths(bucket.Name, storj.CommitPath(path)), bucket.PathCipher, bytes.NewReader(nil), metadata, time.Time{}, streams.Settings{})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.resumeCommit(ctx, bucket, store, path)
+	return err
+}
+
+// resumeCommit makes the version stored at the commit path of the object at
+// path, if any, the current one. A current version older than it is kept as
+// a noncurrent version, a newer one is the version being deleted. Every step
+// can be repeated, so that a commit interrupted at any point is completed by
+// resuming it.
+func (db *DB) resumeCommit(ctx context.Context, bucket storj.Bucket, store objects.Store, path storj.Path) (resumed bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	committing, err := store.Meta(ctx, storj.CommitPath(path))
+	if storj.ErrObjectNotFound.Has(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	current, err := store.Meta(ctx, path)
+	switch {
+	case storj.ErrObjectNotFound.Has(err):
+		err = nil
+	case err != nil:
+		return false, err
+	case current.Version < committing.Version:
+		err = db.moveStream(ctx, bucket, path, storj.VersionPath(path, current.Version))
+	case current.Version > committing.Version:
+		err = store.Delete(ctx, path)
+	default:
+		// the same version committed twice at once, the upload that was made
+		// current first keeps it
+		var same bool
+		same, err = db.sameStreamAt(ctx, bucket, path, storj.CommitPath(path))
+		if err == nil && !same {
+			return false, store.Delete(ctx, storj.CommitPath(path))
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+
+	err = db.moveStream(ctx, bucket, storj.CommitPath(path), path)
+	if err != nil {
+		return false, err
+	}
+
+	// a previous version made current again may still be at its noncurrent
+	// path if its move to the commit path was interrupted
+	err = store.Delete(ctx, storj.VersionPath(path, committing.Version))
+	if err != nil && !storj.ErrObjectNotFound.Has(err) {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// noncurrentVersions returns the noncurrent versions of the object at path
+// older than its current version, from the latest. They are listed from the
+// versions prefix of the object, as their encrypted paths aren't sorted by
+// number.
+func (db *DB) noncurrentVersions(ctx context.Context, bucket storj.Bucket, store objects.Store, path storj.Path, current uint32) (versions []storj.Object, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	prefix := storj.VersionsPrefix(path)
+
+	var startAfter string
+	for {
+		items, more, err := store.List(ctx, prefix, startAfter, "", true, 0, meta.All)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			if item.IsPrefix || item.Meta.Version >= current {
+				continue
+			}
+			object := objectFromMeta(bucket, path, false, item.Meta)
+			object.IsNoncurrent = true
+			versions = append(versions, object)
+		}
+
+		if !more || len(items) == 0 {
+			break
+		}
+		startAfter = items[len(items)-1].Path
+	}
+
+	sort.Slice(versions, func(i, k int) bool { return versions[i].Version > versions[k].Version })
+	return versions, nil
+}
+
+// listVersions lists every version of the objects, each current version
+// followed by the noncurrent ones from the latest. The current versions are
+// listed from the cursor like any object, and their noncurrent versions
+// looked up next to them.
+func (db *DB) listVersions(ctx context.Context, bucket storj.Bucket, store objects.Store, options storj.ListOptions) (list storj.ObjectList, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var startAfter string
+	switch options.Direction {
+	case storj.Forward:
+		startAfter = keyBefore(options.Cursor)
+	case storj.After:
+		startAfter = options.Cursor
+	default:
+		return storj.ObjectList{}, errClass.New("versions can only be listed forwards")
+	}
+
+	list = storj.ObjectList{
+		Bucket: bucket.Name,
+		Prefix: options.Prefix,
+	}
+
+	for {
+		items, more, err := store.List(ctx, options.Prefix, startAfter, "", options.Recursive, options.Limit, meta.All)
+		if err != nil {
+			return storj.ObjectList{}, err
+		}
+
+		list.More = more
+		for _, item := range items {
+			// noncurrent versions are listed after their current version,
+			// the prefixes holding them never
+			if storj.IsReservedPath(item.Path) {
+				continue
+			}
+			if item.IsPrefix {
+				list.Items = append(list.Items, objectFromMeta(bucket, item.Path, true, item.Meta))
+				continue
+			}
+
+			list.Items = append(list.Items, objectFromMeta(bucket, item.Path, false, item.Meta))
+
+			path := item.Path
+			if prefix := strings.TrimSuffix(options.Prefix, "/"); prefix != "" {
+				path = storj.JoinPaths(prefix, item.Path)
+			}
+			versions, err := db.noncurrentVersions(ctx, bucket, store, path, item.Meta.Version)
+			if err != nil {
+				return storj.ObjectList{}, err
+			}
+			for _, version := range versions {
+				version.Path = item.Path
+				list.Items = append(list.Items, version)
+			}
+		}
+
+		// pages holding only hidden items are skipped, as an empty page
+		// can't be continued from
+		if len(list.Items) > 0 || !more || len(items) == 0 {
+			return list, nil
+		}
+		startAfter = items[len(items)-1].Path
+	}
+}
+
+// moveStream moves the stream stored at from to the path to, both relative to
+// the bucket. The segments aren't downloaded: only their pointers are moved,
+// with their content keys encrypted again with the key derived from the new
+// path. The stream is copied before being deleted, its last segment last, so
+// that an interrupted move can be repeated.
+func (db *DB) moveStream(ctx context.Context, bucket storj.Bucket, from, to storj.Path) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	fromPath := storj.JoinPaths(bucket.Name, from)
+	toPath := storj.JoinPaths(bucket.Name, to)
+
+	encFrom, err := db.keys.EncryptAfterBucket(fromPath, bucket.PathCipher)
+	if err != nil {
+		return err
+	}
+	encTo, err := db.keys.EncryptAfterBucket(toPath, bucket.PathCipher)
+	if err != nil {
+		return err
+	}
+
+	fromKey, err := db.keys.DeriveContentKey(fromPath)
+	if err != nil {
+		return err
+	}
+	toKey, err := db.keys.DeriveContentKey(toPath)
+	if err != nil {
+		return err
+	}
+
+	lastSegment, _, _, err := db.pointers.Get(ctx, committedPrefix+encFrom)
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			err = storj.ErrObjectNotFound.Wrap(err)
+		}
+		return err
+	}
+
+	streamMeta := pb.StreamMeta{}
+	err = proto.Unmarshal(lastSegment.GetMetadata(), &streamMeta)
+	if err != nil {
+		return err
+	}
+	cipher := storj.Cipher(streamMeta.EncryptionType)
+
+	streamInfoData, err := streams.DecryptStreamInfo(ctx, segments.Meta{Data: lastSegment.GetMetadata()}, fromPath, db.keys)
+	if err != nil {
+		return err
+	}
+	streamInfo := pb.StreamInfo{}
+	err = proto.Unmarshal(streamInfoData, &streamInfo)
+	if err != nil {
+		return err
+	}
+
+	for index := int64(0); index < streamInfo.NumberOfSegments-1; index++ {
+		pointer, _, _, err := db.pointers.Get(ctx, getSegmentPath(encFrom, index))
+		if err != nil {
+			return err
+		}
+
+		if len(pointer.GetMetadata()) > 0 {
+			segmentMeta := pb.SegmentMeta{}
+			err = proto.Unmarshal(pointer.GetMetadata(), &segmentMeta)
+			if err != nil {
+				return err
+			}
+			err = reencryptKey(&segmentMeta, cipher, fromKey, toKey)
+			if err != nil {
+				return err
+			}
+			pointer.Metadata, err = proto.Marshal(&segmentMeta)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = db.pointers.Put(ctx, getSegmentPath(encTo, index), pointer)
+		if err != nil {
+			return err
+		}
+	}
+
+	if streamMeta.LastSegmentMeta != nil {
+		err = reencryptKey(streamMeta.LastSegmentMeta, cipher, fromKey, toKey)
+		if err != nil {
+			return err
+		}
+		lastSegment.Metadata, err = proto.Marshal(&streamMeta)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the stream appears at its new path and disappears from its old one
+	// with its last segment
+	err = db.pointers.Put(ctx, committedPrefix+encTo, lastSegment)
+	if err != nil {
+		return err
+	}
+	err = db.pointers.Delete(ctx, committedPrefix+encFrom)
+	if err != nil {
+		return err
+	}
+
+	for index := int64(0); index < streamInfo.NumberOfSegments-1; index++ {
+		err = db.pointers.Delete(ctx, getSegmentPath(encFrom, index))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// lastSegment returns the pointer of the last segment of the stream at path,
+// relative to the bucket
+func (db *DB) lastSegment(ctx context.Context, bucket storj.Bucket, path storj.Path) (*pb.Pointer, error) {
+	encPath, err := db.keys.EncryptAfterBucket(storj.JoinPaths(bucket.Name, path), bucket.PathCipher)
+	if err != nil {
+		return nil, err
+	}
+
+	pointer, _, _, err := db.pointers.Get(ctx, committedPrefix+encPath)
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			err = storj.ErrObjectNotFound.Wrap(err)
+		}
+		return nil, err
+	}
+	return pointer, nil
+}
+
+// sameStreamAt is whether the streams at a and b, relative to the bucket, are
+// the same one, which happens while it's moved from one to the other
+func (db *DB) sameStreamAt(ctx context.Context, bucket storj.Bucket, a, b storj.Path) (bool, error) {
+	pointerA, err := db.lastSegment(ctx, bucket, a)
+	if err != nil {
+		return false, err
+	}
+	pointerB, err := db.lastSegment(ctx, bucket, b)
+	if err != nil {
+		return false, err
+	}
+	return sameStream(pointerA, pointerB), nil
+}
+
+// sameStream is whether the last segments a and b belong to the same stream.
+// Moving a stream keeps the pieces, or the inline data, of its segments.
+func sameStream(a, b *pb.Pointer) bool {
+	if a.GetType() != b.GetType() {
+		return false
+	}
+	if a.GetType() == pb.Pointer_INLINE {
+		return bytes.Equal(a.GetInlineSegment(), b.GetInlineSegment())
+	}
+	return a.GetRemote().GetPieceId() == b.GetRemote().GetPieceId()
+}
+
+// reencryptKey encrypts the content key of a segment, encrypted with from,
+// with to instead
+func reencryptKey(segmentMeta *pb.SegmentMeta, cipher storj.Cipher, from, to *storj.Key) error {
+	var nonce storj.Nonce
+	copy(nonce[:], segmentMeta.KeyNonce)
+
+	contentKey, err := encryption.DecryptKey(segmentMeta.EncryptedKey, cipher, from, &nonce)
+	if err != nil {
+		return err
+	}
+
+	segmentMeta.EncryptedKey, err = encryption.EncryptKey(contentKey, cipher, to, &nonce)
+	return err
+}
@@ -0,0 +1,318 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package kvmetainfo
+
+import (
+	"context"
+	"crypto/rand"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/memory"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/stream"
+)
+
+func TestVersioning(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		// we wait a second for all the nodes to complete bootstrapping off the satellite
+		time.Sleep(2 * time.Second)
+
+		large := make([]byte, 32*memory.KB)
+		_, err := rand.Read(large)
+		require.NoError(t, err)
+
+		bucket, err := db.CreateBucket(ctx, TestBucket, &storj.Bucket{PathCipher: storj.AESGCM, Versioning: true})
+		require.NoError(t, err)
+		assert.True(t, bucket.Versioning)
+
+		bucket, err = db.GetBucket(ctx, TestBucket)
+		require.NoError(t, err)
+		assert.True(t, bucket.Versioning)
+
+		const path = "dir/file"
+		upload(ctx, t, db, bucket, path, []byte("one"))
+		upload(ctx, t, db, bucket, path, large)
+		upload(ctx, t, db, bucket, path, []byte("three"))
+
+		object, err := db.GetObject(ctx, bucket.Name, path)
+		require.NoError(t, err)
+		assert.EqualValues(t, 3, object.Version)
+		assert.False(t, object.IsNoncurrent)
+		assertVersion(ctx, t, db, path, 3, []byte("three"))
+		assertVersion(ctx, t, db, path, 2, large)
+		assertVersion(ctx, t, db, path, 1, []byte("one"))
+
+		list, err := db.ListObjects(ctx, bucket.Name, options("dir", "", storj.After, 0))
+		require.NoError(t, err)
+		if assert.Len(t, list.Items, 1) {
+			assert.Equal(t, "file", list.Items[0].Path)
+			assert.EqualValues(t, 3, list.Items[0].Version)
+		}
+		assertVersions(ctx, t, db, []uint32{3, 2, 1}, []bool{false, false, false})
+
+		// deleting keeps every version behind a delete marker
+		err = db.DeleteObject(ctx, bucket.Name, path)
+		require.NoError(t, err)
+
+		_, err = db.GetObject(ctx, bucket.Name, path)
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+		_, err = db.GetObjectStream(ctx, bucket.Name, path)
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+		err = db.DeleteObject(ctx, bucket.Name, path)
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+
+		list, err = db.ListObjects(ctx, bucket.Name, optionsRecursive("", "", storj.After, 0))
+		require.NoError(t, err)
+		assert.Empty(t, list.Items)
+		assertVersions(ctx, t, db, []uint32{4, 3, 2, 1}, []bool{true, false, false, false})
+
+		// deleting the delete marker restores the object
+		err = db.DeleteObjectVersion(ctx, bucket.Name, path, 4)
+		require.NoError(t, err)
+		assertVersion(ctx, t, db, path, 3, []byte("three"))
+
+		// deleting the current version undoes the last overwrite
+		err = db.DeleteObjectVersion(ctx, bucket.Name, path, 3)
+		require.NoError(t, err)
+		object, err = db.GetObject(ctx, bucket.Name, path)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, object.Version)
+		assertVersion(ctx, t, db, path, 2, large)
+
+		err = db.DeleteObjectVersion(ctx, bucket.Name, path, 1)
+		require.NoError(t, err)
+		err = db.DeleteObjectVersion(ctx, bucket.Name, path, 1)
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+		assertVersions(ctx, t, db, []uint32{2}, []bool{false})
+
+		// the next version follows the current one
+		upload(ctx, t, db, bucket, path, []byte("three again"))
+		assertVersions(ctx, t, db, []uint32{3, 2}, []bool{false, false})
+		assertVersion(ctx, t, db, path, 3, []byte("three again"))
+
+		_, err = db.CreateObject(ctx, bucket.Name, storj.VersionPath(path, 1), nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestUnversionedBucket(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, nil)
+		require.NoError(t, err)
+		assert.False(t, bucket.Versioning)
+
+		upload(ctx, t, db, bucket, TestFile, []byte("one"))
+		upload(ctx, t, db, bucket, TestFile, []byte("two"))
+
+		object, err := db.GetObject(ctx, bucket.Name, TestFile)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, object.Version)
+
+		list, err := db.ListObjects(ctx, bucket.Name, storj.ListOptions{Direction: storj.After, Recursive: true, Versions: true})
+		require.NoError(t, err)
+		assert.Len(t, list.Items, 1)
+
+		err = db.DeleteObject(ctx, bucket.Name, TestFile)
+		require.NoError(t, err)
+
+		list, err = db.ListObjects(ctx, bucket.Name, storj.ListOptions{Direction: storj.After, Recursive: true, Versions: true})
+		require.NoError(t, err)
+		assert.Empty(t, list.Items)
+	})
+}
+
+func TestConcurrentVersions(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, &storj.Bucket{PathCipher: storj.AESGCM, Versioning: true})
+		require.NoError(t, err)
+
+		const path = "dir/file"
+		upload(ctx, t, db, bucket, path, []byte("one"))
+
+		// both uploads get the next version, but are stored apart
+		first := createVersion(ctx, t, db, path, []byte("first"))
+		second := createVersion(ctx, t, db, path, []byte("second"))
+		assert.Equal(t, first.Info().Version, second.Info().Version)
+		assert.NotEqual(t, first.Info().StreamPath(), second.Info().StreamPath())
+
+		require.NoError(t, first.Commit(ctx))
+		assert.Error(t, second.Commit(ctx))
+
+		assertVersion(ctx, t, db, path, 2, []byte("first"))
+		assertVersions(ctx, t, db, []uint32{2, 1}, []bool{false, false})
+
+		// the upload that lost is removed
+		_, err = db.GetObject(ctx, bucket.Name, second.Info().StreamPath())
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+	})
+}
+
+func TestSimultaneousVersions(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, &storj.Bucket{PathCipher: storj.AESGCM, Versioning: true})
+		require.NoError(t, err)
+
+		const path = "dir/file"
+		upload(ctx, t, db, bucket, path, []byte("one"))
+
+		first := createVersion(ctx, t, db, path, []byte("first"))
+		second := createVersion(ctx, t, db, path, []byte("second"))
+
+		// the first upload passed the version check before the second one
+		// was committed, and is made current only afterwards
+		require.NoError(t, second.Commit(ctx))
+
+		store, err := db.buckets.GetObjectStore(ctx, bucket.Name)
+		require.NoError(t, err)
+		assert.Error(t, db.makeCurrent(ctx, first.Info(), store))
+
+		assertVersion(ctx, t, db, path, 2, []byte("second"))
+		assertVersions(ctx, t, db, []uint32{2, 1}, []bool{false, false})
+
+		// the upload that lost is removed
+		_, err = db.GetObject(ctx, bucket.Name, storj.CommitPath(path))
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+		_, err = db.GetObject(ctx, bucket.Name, first.Info().StreamPath())
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+	})
+}
+
+func TestResumeCommit(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, &storj.Bucket{PathCipher: storj.AESGCM, Versioning: true})
+		require.NoError(t, err)
+
+		const path = "dir/file"
+		upload(ctx, t, db, bucket, path, []byte("one"))
+
+		// a commit interrupted after moving the current version away leaves
+		// the object without current version until it is accessed
+		mutable := createVersion(ctx, t, db, path, []byte("two"))
+		require.NoError(t, db.moveStream(ctx, bucket, mutable.Info().StreamPath(), storj.CommitPath(path)))
+		require.NoError(t, db.moveStream(ctx, bucket, path, storj.VersionPath(path, 1)))
+
+		object, err := db.GetObject(ctx, bucket.Name, path)
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, object.Version)
+		assertVersion(ctx, t, db, path, 2, []byte("two"))
+		assertVersions(ctx, t, db, []uint32{2, 1}, []bool{false, false})
+
+		// a commit interrupted before moving the current version away is
+		// completed before the next one
+		mutable = createVersion(ctx, t, db, path, []byte("three"))
+		require.NoError(t, db.moveStream(ctx, bucket, mutable.Info().StreamPath(), storj.CommitPath(path)))
+
+		upload(ctx, t, db, bucket, path, []byte("four"))
+		assertVersions(ctx, t, db, []uint32{4, 3, 2, 1}, []bool{false, false, false, false})
+		assertVersion(ctx, t, db, path, 3, []byte("three"))
+
+		// deleting the current version interrupted after copying the previous
+		// one to the commit path
+		previous, _, _, err := db.pointers.Get(ctx, committedPrefix+mustEncrypt(t, db, storj.VersionPath(path, 3)))
+		require.NoError(t, err)
+		require.NoError(t, db.moveStream(ctx, bucket, storj.VersionPath(path, 3), storj.CommitPath(path)))
+		require.NoError(t, db.pointers.Put(ctx, committedPrefix+mustEncrypt(t, db, storj.VersionPath(path, 3)), previous))
+
+		object, err = db.GetObject(ctx, bucket.Name, path)
+		require.NoError(t, err)
+		assert.EqualValues(t, 4, object.Version)
+
+		require.NoError(t, db.DeleteObjectVersion(ctx, bucket.Name, path, 1))
+		assertVersions(ctx, t, db, []uint32{3, 2}, []bool{false, false})
+		assertVersion(ctx, t, db, path, 3, []byte("three"))
+	})
+}
+
+func TestListVersionsPages(t *testing.T) {
+	runTest(t, func(ctx context.Context, db *DB) {
+		bucket, err := db.CreateBucket(ctx, TestBucket, &storj.Bucket{PathCipher: storj.AESGCM, Versioning: true})
+		require.NoError(t, err)
+
+		for _, path := range []storj.Path{"a", "b", "c"} {
+			upload(ctx, t, db, bucket, path, []byte("one"))
+			upload(ctx, t, db, bucket, path, []byte("two"))
+		}
+
+		var paths []storj.Path
+		cursor := ""
+		for {
+			list, err := db.ListObjects(ctx, bucket.Name, storj.ListOptions{Cursor: cursor, Direction: storj.After, Limit: 1, Versions: true})
+			require.NoError(t, err)
+			// the prefixes holding noncurrent versions may follow the last
+			// object, leaving the last page empty
+			if len(list.Items) == 0 && !list.More {
+				break
+			}
+			// the versions of an object are listed on the same page
+			if assert.Len(t, list.Items, 2) {
+				assert.EqualValues(t, 2, list.Items[0].Version)
+				assert.EqualValues(t, 1, list.Items[1].Version)
+				assert.Equal(t, list.Items[0].Path, list.Items[1].Path)
+				paths = append(paths, list.Items[0].Path)
+				cursor = list.Items[0].Path
+			}
+			if !list.More {
+				break
+			}
+		}
+		assert.ElementsMatch(t, []storj.Path{"a", "b", "c"}, paths)
+	})
+}
+
+func createVersion(ctx context.Context, t *testing.T, db *DB, path storj.Path, data []byte) storj.MutableObject {
+	obj, err := db.CreateObject(ctx, TestBucket, path, nil)
+	require.NoError(t, err)
+
+	str, err := obj.CreateStream(ctx)
+	require.NoError(t, err)
+
+	upload := stream.NewUpload(ctx, str, db.streams)
+	_, err = upload.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, upload.Close())
+
+	return obj
+}
+
+func mustEncrypt(t *testing.T, db *DB, path storj.Path) storj.Path {
+	encPath, err := db.keys.EncryptAfterBucket(storj.JoinPaths(TestBucket, path), storj.AESGCM)
+	require.NoError(t, err)
+	return encPath
+}
+
+func assertVersion(ctx context.Context, t *testing.T, db *DB, path storj.Path, version uint32, content []byte) {
+	readOnly, err := db.GetObjectVersionStream(ctx, TestBucket, path, version)
+	require.NoError(t, err)
+
+	assert.Equal(t, path, readOnly.Info().Path)
+	assert.Equal(t, version, readOnly.Info().Version)
+	assert.EqualValues(t, len(content), readOnly.Info().Size)
+
+	download := stream.NewDownload(ctx, readOnly, db.streams)
+	data, err := ioutil.ReadAll(download)
+	assert.NoError(t, err)
+	assert.NoError(t, download.Close())
+	assert.Equal(t, content, data)
+}
+
+func assertVersions(ctx context.Context, t *testing.T, db *DB, versions []uint32, deleteMarkers []bool) {
+	list, err := db.ListObjects(ctx, TestBucket, storj.ListOptions{Direction: storj.After, Recursive: true, Versions: true})
+	require.NoError(t, err)
+	assert.False(t, list.More)
+
+	if !assert.Len(t, list.Items, len(versions)) {
+		return
+	}
+	for i, item := range list.Items {
+		assert.Equal(t, "dir/file", item.Path)
+		assert.Equal(t, versions[i], item.Version)
+		assert.Equal(t, i > 0, item.IsNoncurrent)
+		assert.Equal(t, deleteMarkers[i], item.IsDeleteMarker)
+	}
+}
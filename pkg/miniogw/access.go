@@ -0,0 +1,112 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+
+	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/pb"
+	pointerdbAuth "storj.io/storj/pkg/pointerdb/auth"
+	"storj.io/storj/pkg/storj"
+)
+
+// accessVersion is the version byte of serialized accesses
+const accessVersion byte = 1
+
+// NewAccess returns a serialized access to the paths under prefix, which
+// starts with the bucket. It holds the satellite address, an API key
+// restricted to prefix and the key of prefix, so the root key isn't shared.
+// A zero notAfter never expires the access.
+func NewAccess(satelliteAddr, apiKey string, rootKey *storj.Key, prefix storj.Path, pathCipher storj.Cipher, readOnly bool, notAfter time.Time) (string, error) {
+	prefix = strings.Trim(prefix, "/")
+	comps := storj.SplitPath(prefix)
+	if prefix == "" {
+		return "", Error.New("no bucket specified")
+	}
+
+	encryptedPrefix, err := encryption.NewRootKeys(rootKey).EncryptAfterBucket(prefix, pathCipher)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	pathKey, err := encryption.DerivePathKey(prefix, rootKey, len(comps))
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	bucketContentKey, err := encryption.DeriveContentKey(comps[0], rootKey)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	caveat := &pb.Caveat{
+		PathPrefix: encryptedPrefix,
+		ReadOnly:   readOnly,
+	}
+	if !notAfter.IsZero() {
+		caveat.NotAfter, err = ptypes.TimestampProto(notAfter)
+		if err != nil {
+			return "", Error.Wrap(err)
+		}
+	}
+	restricted, err := pointerdbAuth.Restrict(apiKey, caveat)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+
+	data, err := proto.Marshal(&pb.Access{
+		SatelliteAddress:    satelliteAddr,
+		ApiKey:              restricted,
+		PathPrefix:          prefix,
+		EncryptedPathPrefix: encryptedPrefix,
+		PathKey:             pathKey[:],
+		BucketContentKey:    bucketContentKey[:],
+		NotAfter:            caveat.NotAfter,
+	})
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	return base58.CheckEncode(data, accessVersion), nil
+}
+
+// ParseAccess parses an access serialized by NewAccess
+func ParseAccess(serialized string) (*pb.Access, error) {
+	data, version, err := base58.CheckDecode(serialized)
+	if err != nil {
+		return nil, Error.New("invalid access: %v", err)
+	}
+	if version != accessVersion {
+		return nil, Error.New("unsupported access version %d", version)
+	}
+
+	access := &pb.Access{}
+	if err := proto.Unmarshal(data, access); err != nil {
+		return nil, Error.New("invalid access: %v", err)
+	}
+	if access.SatelliteAddress == "" || access.ApiKey == "" {
+		return nil, Error.New("invalid access: missing satellite address or API key")
+	}
+	return access, nil
+}
+
+// accessKeys returns the path keys of access
+func accessKeys(access *pb.Access) (*encryption.PathKeys, error) {
+	if len(access.PathKey) != len(storj.Key{}) || len(access.BucketContentKey) != len(storj.Key{}) {
+		return nil, Error.New("invalid access: bad key length")
+	}
+
+	pathKey, bucketContentKey := new(storj.Key), new(storj.Key)
+	copy(pathKey[:], access.PathKey)
+	copy(bucketContentKey[:], access.BucketContentKey)
+
+	keys, err := encryption.NewPrefixKeys(access.PathPrefix, access.EncryptedPathPrefix, pathKey, bucketContentKey)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return keys, nil
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package miniogw
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestAccess(t *testing.T) {
+	root := new(storj.Key)
+	copy(root[:], "root key")
+
+	serialized, err := NewAccess("satellite:7777", "api key", root, "bucket/photos/", storj.AESGCM, true, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	access, err := ParseAccess(serialized)
+	require.NoError(t, err)
+	assert.Equal(t, "satellite:7777", access.SatelliteAddress)
+	assert.Equal(t, "bucket/photos", access.PathPrefix)
+	assert.NotEqual(t, "api key", access.ApiKey)
+
+	keys, err := accessKeys(access)
+	require.NoError(t, err)
+
+	// the shared keys encrypt like the root key under the prefix
+	expected, err := encryption.NewRootKeys(root).EncryptAfterBucket("bucket/photos/a.jpg", storj.AESGCM)
+	require.NoError(t, err)
+	encrypted, err := keys.EncryptAfterBucket("bucket/photos/a.jpg", storj.AESGCM)
+	require.NoError(t, err)
+	assert.Equal(t, expected, encrypted)
+
+	_, err = keys.EncryptAfterBucket("bucket/videos/a.mp4", storj.AESGCM)
+	assert.Error(t, err)
+
+	_, err = NewAccess("satellite:7777", "api key", root, "", storj.AESGCM, true, time.Time{})
+	assert.Error(t, err)
+
+	_, err = ParseAccess(serialized[:len(serialized)-1])
+	assert.Error(t, err)
+}
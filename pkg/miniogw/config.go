@@ -7,17 +7,24 @@ import (
 	"context"
 	"errors"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/minio/cli"
 	minio "github.com/minio/minio/cmd"
 	"github.com/vivint/infectious"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
+	"storj.io/storj/pkg/auth/grpcauth"
+	"storj.io/storj/pkg/durability"
 	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/encryption"
 	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/metainfo/kvmetainfo"
 	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/pointerdb/pdbclient"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/storage/buckets"
@@ -25,6 +32,7 @@ import (
 	"storj.io/storj/pkg/storage/segments"
 	"storj.io/storj/pkg/storage/streams"
 	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
 )
 
 // RSConfig is a configuration struct that keeps details about default
@@ -47,6 +55,13 @@ type EncryptionConfig struct {
 	PathType  int    `help:"Type of encryption to use for paths (0=Unencrypted, 1=AES-GCM, 2=SecretBox)" default:"1"`
 }
 
+// RootKey returns the root key for encrypting the data
+func (c EncryptionConfig) RootKey() *storj.Key {
+	key := new(storj.Key)
+	copy(key[:], c.Key)
+	return key
+}
+
 // MinioConfig is a configuration struct that keeps details about starting
 // Minio
 type MinioConfig struct {
@@ -65,6 +80,18 @@ type ClientConfig struct {
 	APIKey        string `help:"API Key (TODO: this needs to change to macaroons somehow)"`
 	MaxInlineSize int    `help:"max inline segment size in bytes" default:"4096"`
 	SegmentSize   int64  `help:"the size of a segment in bytes" default:"64000000"`
+
+	SegmentParallelism int `help:"how many segments of an object to upload or download at the same time, each buffered in memory if more than 1" default:"1"`
+	Checksum           int `help:"algorithm of the checksums of uploaded content (0=None, 1=SHA-256, 2=MD5)" default:"1"`
+
+	Regions         string `help:"comma-separated countries or regions (e.g. US or US-CA) to store pieces in, empty for anywhere" default:""`
+	ExcludedRegions string `help:"comma-separated countries or regions to never store pieces in" default:""`
+
+	MaxLatency   time.Duration `help:"only store pieces on nodes with a 90th percentile latency below this, 0 for any" default:"0s"`
+	MinSpeedKbps int64         `help:"only store pieces on nodes uploading at least this many kilobits per second, 0 for any" default:"0"`
+	RankBySpeed  bool          `help:"prefer the fastest nodes for storing pieces" default:"false"`
+
+	Access string `help:"access shared by 'uplink share', replacing the satellite addresses, API key and encryption key" default:""`
 }
 
 // ServerConfig determines how minio listens for requests
@@ -135,6 +162,32 @@ func (c Config) action(ctx context.Context, cliCtx *cli.Context, identity *provi
 func (c Config) GetMetainfo(ctx context.Context, identity *provider.FullIdentity) (db storj.Metainfo, ss streams.Store, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	db, ss, _, err = c.OpenMetainfo(ctx, identity)
+	return db, ss, err
+}
+
+// OpenMetainfo is like GetMetainfo, but also returns a function closing the
+// connections to the satellite, for callers opening many short-lived clients
+func (c Config) OpenMetainfo(ctx context.Context, identity *provider.FullIdentity) (db storj.Metainfo, ss streams.Store, close func() error, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var keys *encryption.PathKeys
+	if c.Client.Access != "" {
+		access, err := ParseAccess(c.Client.Access)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		keys, err = accessKeys(access)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		c.Client.OverlayAddr = access.SatelliteAddress
+		c.Client.PointerDBAddr = access.SatelliteAddress
+		c.Client.APIKey = access.ApiKey
+	} else {
+		keys = encryption.NewRootKeys(c.Enc.RootKey())
+	}
+
 	if c.Client.OverlayAddr == "" || c.Client.PointerDBAddr == "" {
 		var errlist errs.Group
 		if c.Client.OverlayAddr == "" {
@@ -143,47 +196,78 @@ func (c Config) GetMetainfo(ctx context.Context, identity *provider.FullIdentity
 		if c.Client.PointerDBAddr == "" {
 			errlist.Add(errors.New("pointerdb address not specified"))
 		}
-		return nil, nil, errlist.Err()
+		return nil, nil, nil, errlist.Err()
 	}
 
-	oc, err := overlay.NewClient(identity, c.Client.OverlayAddr)
+	var conns []*grpc.ClientConn
+	close = func() error {
+		var group errs.Group
+		for _, conn := range conns {
+			group.Add(conn.Close())
+		}
+		return group.Err()
+	}
+	defer func() {
+		if err != nil {
+			err = errs.Combine(err, close())
+		}
+	}()
+
+	tc := transport.NewClient(identity)
+	apiKeyInjector := grpc.WithUnaryInterceptor(grpcauth.NewAPIKeyInjector(c.Client.APIKey))
+
+	overlayConn, err := tc.DialAddress(context.Background(), c.Client.OverlayAddr, apiKeyInjector)
+	if err != nil {
+		return nil, nil, nil, Error.New("failed to connect to overlay: %v", err)
+	}
+	conns = append(conns, overlayConn)
+
+	oc := overlay.NewClientFrom(pb.NewOverlayClient(overlayConn))
+	if c.Client.Regions != "" || c.Client.ExcludedRegions != "" {
+		oc = overlay.NewPlacementClient(oc, splitList(c.Client.Regions), splitList(c.Client.ExcludedRegions))
+	}
+	if c.Client.MaxLatency > 0 || c.Client.MinSpeedKbps > 0 || c.Client.RankBySpeed {
+		oc = overlay.NewPerformanceClient(oc, c.Client.MaxLatency, c.Client.MinSpeedKbps, c.Client.RankBySpeed)
+	}
+
+	pdbConn, err := tc.DialAddress(context.Background(), c.Client.PointerDBAddr, apiKeyInjector)
 	if err != nil {
-		return nil, nil, Error.New("failed to connect to overlay: %v", err)
+		return nil, nil, nil, Error.New("failed to connect to pointer DB: %v", err)
 	}
+	conns = append(conns, pdbConn)
+
+	pdb := pdbclient.New(pb.NewPointerDBClient(pdbConn))
 
-	pdb, err := pdbclient.NewClient(identity, c.Client.PointerDBAddr, c.Client.APIKey)
+	err = durability.Validate(c.GetRedundancyScheme())
 	if err != nil {
-		return nil, nil, Error.New("failed to connect to pointer DB: %v", err)
+		return nil, nil, nil, Error.New("invalid redundancy scheme: %v", err)
 	}
 
-	ec := ecclient.NewClient(identity, c.RS.MaxBufferMem)
+	ec := ecclient.NewClientWithReporter(identity, c.RS.MaxBufferMem, oc)
 	fc, err := infectious.NewFEC(c.RS.MinThreshold, c.RS.MaxThreshold)
 	if err != nil {
-		return nil, nil, Error.New("failed to create erasure coding client: %v", err)
+		return nil, nil, nil, Error.New("failed to create erasure coding client: %v", err)
 	}
 	rs, err := eestream.NewRedundancyStrategy(eestream.NewRSScheme(fc, c.RS.ErasureShareSize), c.RS.RepairThreshold, c.RS.SuccessThreshold)
 	if err != nil {
-		return nil, nil, Error.New("failed to create redundancy strategy: %v", err)
+		return nil, nil, nil, Error.New("failed to create redundancy strategy: %v", err)
 	}
 
 	segments := segments.NewSegmentStore(oc, ec, pdb, rs, c.Client.MaxInlineSize)
 
 	if c.RS.ErasureShareSize*c.RS.MinThreshold%c.Enc.BlockSize != 0 {
 		err = Error.New("EncryptionBlockSize must be a multiple of ErasureShareSize * RS MinThreshold")
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	key := new(storj.Key)
-	copy(key[:], c.Enc.Key)
-
-	streams, err := streams.NewStreamStore(segments, c.Client.SegmentSize, key, c.Enc.BlockSize, storj.Cipher(c.Enc.DataType))
+	streams, err := streams.NewStreamStoreWithKeys(segments, c.Client.SegmentSize, keys, c.Enc.BlockSize, storj.Cipher(c.Enc.DataType), storj.ChecksumAlgorithm(c.Client.Checksum), c.Client.SegmentParallelism)
 	if err != nil {
-		return nil, nil, Error.New("failed to create stream store: %v", err)
+		return nil, nil, nil, Error.New("failed to create stream store: %v", err)
 	}
 
 	buckets := buckets.NewStore(streams)
 
-	return kvmetainfo.New(buckets, streams, segments, pdb, key), streams, nil
+	return kvmetainfo.NewWithKeys(buckets, streams, segments, pdb, keys), streams, close, nil
 }
 
 // GetRedundancyScheme returns the configured redundancy scheme for new uploads
@@ -194,6 +278,7 @@ func (c Config) GetRedundancyScheme() storj.RedundancyScheme {
 		RepairShares:   int16(c.RS.RepairThreshold),
 		OptimalShares:  int16(c.RS.SuccessThreshold),
 		TotalShares:    int16(c.RS.MaxThreshold),
+		ShareSize:      int32(c.RS.ErasureShareSize),
 	}
 }
 
@@ -214,5 +299,15 @@ func (c Config) NewGateway(ctx context.Context, identity *provider.FullIdentity)
 		return nil, err
 	}
 
-	return NewStorjGateway(metainfo, streams, storj.Cipher(c.Enc.PathType), c.GetEncryptionScheme(), c.GetRedundancyScheme()), nil
+	return NewStorjGateway(metainfo, streams, storj.Cipher(c.Enc.PathType)), nil
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(list string) (items []string) {
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
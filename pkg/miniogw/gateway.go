@@ -29,13 +29,11 @@ var (
 )
 
 // NewStorjGateway creates a *Storj object from an existing ObjectStore
-func NewStorjGateway(metainfo storj.Metainfo, streams streams.Store, pathCipher storj.Cipher, encryption storj.EncryptionScheme, redundancy storj.RedundancyScheme) *Gateway {
+func NewStorjGateway(metainfo storj.Metainfo, streams streams.Store, pathCipher storj.Cipher) *Gateway {
 	return &Gateway{
 		metainfo:   metainfo,
 		streams:    streams,
 		pathCipher: pathCipher,
-		encryption: encryption,
-		redundancy: redundancy,
 		multipart:  NewMultipartUploads(),
 	}
 }
@@ -45,8 +43,6 @@ type Gateway struct {
 	metainfo   storj.Metainfo
 	streams    streams.Store
 	pathCipher storj.Cipher
-	encryption storj.EncryptionScheme
-	redundancy storj.RedundancyScheme
 	multipart  *MultipartUploads
 }
 
@@ -73,7 +69,13 @@ type gatewayLayer struct {
 func (layer *gatewayLayer) DeleteBucket(ctx context.Context, bucket string) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	list, err := layer.gateway.metainfo.ListObjects(ctx, bucket, storj.ListOptions{Direction: storj.After, Recursive: true, Limit: 1})
+	info, err := layer.gateway.metainfo.GetBucket(ctx, bucket)
+	if err != nil {
+		return convertError(err, bucket, "")
+	}
+
+	// noncurrent versions and delete markers count as objects too
+	list, err := layer.gateway.metainfo.ListObjects(ctx, bucket, storj.ListOptions{Direction: storj.After, Recursive: true, Limit: 1, Versions: info.Versioning})
 	if err != nil {
 		return convertError(err, bucket, "")
 	}
@@ -87,6 +89,8 @@ func (layer *gatewayLayer) DeleteBucket(ctx context.Context, bucket string) (err
 	return convertError(err, bucket, "")
 }
 
+// DeleteObject deletes the object, or replaces it with a delete marker in a
+// versioned bucket.
 func (layer *gatewayLayer) DeleteObject(ctx context.Context, bucket, object string) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -354,9 +358,12 @@ func (layer *gatewayLayer) CopyObject(ctx context.Context, srcBucket, srcObject,
 		ContentType:      info.ContentType,
 		Expires:          info.Expires,
 		Metadata:         info.Metadata,
-		RedundancyScheme: info.RedundancyScheme,
 		EncryptionScheme: info.EncryptionScheme,
 	}
+	// inline objects have no redundancy scheme to copy
+	if info.RedundancyScheme.ShareSize > 0 {
+		createInfo.RedundancyScheme = info.RedundancyScheme
+	}
 
 	return layer.putObject(ctx, destBucket, destObject, download, &createInfo)
 }
@@ -364,6 +371,10 @@ func (layer *gatewayLayer) CopyObject(ctx context.Context, srcBucket, srcObject,
 func (layer *gatewayLayer) putObject(ctx context.Context, bucket, object string, reader io.Reader, createInfo *storj.CreateObject) (objInfo minio.ObjectInfo, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	// S3 clients compare the ETag with the MD5 of the content, so the
+	// gateway always uses MD5 whatever the checksum of the uplink is
+	createInfo.ChecksumAlgorithm = storj.MD5
+
 	mutableObject, err := layer.gateway.metainfo.CreateObject(ctx, bucket, object, createInfo)
 	if err != nil {
 		return minio.ObjectInfo{}, convertError(err, bucket, object)
@@ -398,11 +409,21 @@ func upload(ctx context.Context, streams streams.Store, mutableObject storj.Muta
 		return err
 	}
 
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	upload := stream.NewUpload(ctx, mutableStream, streams)
 
 	_, err = io.Copy(upload, reader)
+	if err != nil {
+		// abort the upload instead of storing the content read so far,
+		// e.g. when it doesn't match its Content-MD5
+		cancel()
+		utils.LogClose(upload)
+		return err
+	}
 
-	return utils.CombineErrors(err, upload.Close())
+	return upload.Close()
 }
 
 func (layer *gatewayLayer) PutObject(ctx context.Context, bucket, object string, data *hash.Reader, metadata map[string]string) (objInfo minio.ObjectInfo, err error) {
@@ -411,11 +432,11 @@ func (layer *gatewayLayer) PutObject(ctx context.Context, bucket, object string,
 	contentType := metadata["content-type"]
 	delete(metadata, "content-type")
 
+	// the content is verified against its Content-MD5, if any, while it's
+	// read
 	createInfo := storj.CreateObject{
-		ContentType:      contentType,
-		Metadata:         metadata,
-		RedundancyScheme: layer.gateway.redundancy,
-		EncryptionScheme: layer.gateway.encryption,
+		ContentType: contentType,
+		Metadata:    metadata,
 	}
 
 	return layer.putObject(ctx, bucket, object, data, &createInfo)
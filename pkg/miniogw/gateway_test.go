@@ -198,7 +198,7 @@ func TestPutObject(t *testing.T) {
 			assert.False(t, info.IsDir)
 			assert.True(t, time.Since(info.ModTime) < 1*time.Second)
 			assert.Equal(t, data.Size(), info.Size)
-			// assert.Equal(t, data.SHA256HexString(), info.ETag) TODO: when we start calculating checksums
+			assert.Equal(t, data.MD5HexString(), info.ETag)
 			assert.Equal(t, serMetaInfo.ContentType, info.ContentType)
 			assert.Equal(t, serMetaInfo.UserDefined, info.UserDefined)
 		}
@@ -212,9 +212,31 @@ func TestPutObject(t *testing.T) {
 			assert.Equal(t, info.ModTime, obj.Modified)
 			assert.Equal(t, info.Size, obj.Size)
 			assert.Equal(t, info.ETag, hex.EncodeToString(obj.Checksum))
+			assert.Equal(t, storj.MD5, obj.ChecksumAlgorithm)
 			assert.Equal(t, info.ContentType, obj.ContentType)
 			assert.Equal(t, info.UserDefined, obj.Metadata)
 		}
+
+		// Check that content not matching its Content-MD5 isn't stored
+		badData, err := hash.NewReader(bytes.NewReader([]byte("bad test")), int64(len("bad test")), "098f6bcd4621d373cade4e832627b4f6", "")
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, err = layer.PutObject(ctx, TestBucket, "bad-"+TestFile, badData, nil)
+		assert.IsType(t, hash.BadDigest{}, err)
+
+		_, err = metainfo.GetObject(ctx, TestBucket, "bad-"+TestFile)
+		assert.True(t, storj.ErrObjectNotFound.Has(err))
+
+		// Check that the ETag is the MD5 of the content without Content-MD5
+		noMD5Data, err := hash.NewReader(bytes.NewReader([]byte("test")), int64(len("test")), "", "")
+		if !assert.NoError(t, err) {
+			return
+		}
+		info, err = layer.PutObject(ctx, TestBucket, "no-md5-"+TestFile, noMD5Data, nil)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "098f6bcd4621d373cade4e832627b4f6", info.ETag)
+		}
 	})
 }
 
@@ -376,7 +398,8 @@ func TestCopyObject(t *testing.T) {
 			assert.False(t, info.IsDir)
 			assert.True(t, info.ModTime.Sub(obj.Modified) < 1*time.Second)
 			assert.Equal(t, obj.Size, info.Size)
-			assert.Equal(t, hex.EncodeToString(obj.Checksum), info.ETag)
+			// the copy has the MD5 ETag of the gateway uploads
+			assert.Equal(t, "098f6bcd4621d373cade4e832627b4f6", info.ETag)
 			assert.Equal(t, createInfo.ContentType, info.ContentType)
 			assert.Equal(t, createInfo.Metadata, info.UserDefined)
 		}
@@ -696,23 +719,7 @@ func initEnv(planet *testplanet.Planet) (minio.ObjectLayer, storj.Metainfo, stre
 
 	metainfo := kvmetainfo.New(buckets, streams, segments, pdb, key)
 
-	gateway := NewStorjGateway(
-		metainfo,
-		streams,
-		storj.AESGCM,
-		storj.EncryptionScheme{
-			Cipher:    storj.AESGCM,
-			BlockSize: 1 * memory.KB.Int32(),
-		},
-		storj.RedundancyScheme{
-			Algorithm:      storj.ReedSolomon,
-			RequiredShares: int16(rs.RequiredCount()),
-			RepairShares:   int16(rs.RepairThreshold()),
-			OptimalShares:  int16(rs.OptimalThreshold()),
-			TotalShares:    int16(rs.TotalCount()),
-			ShareSize:      int32(rs.ErasureShareSize()),
-		},
-	)
+	gateway := NewStorjGateway(metainfo, streams, storj.AESGCM)
 
 	layer, err := gateway.NewGatewayLayer(auth.Credentials{})
 
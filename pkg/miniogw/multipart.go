@@ -22,7 +22,7 @@ func (layer *gatewayLayer) NewMultipartUpload(ctx context.Context, bucket, objec
 	defer mon.Task()(&ctx)(&err)
 
 	// Check that the bucket exists
-	_, err = layer.gateway.metainfo.GetBucket(ctx, bucket)
+	bucketInfo, err := layer.gateway.metainfo.GetBucket(ctx, bucket)
 	if err != nil {
 		return "", convertError(err, bucket, "")
 	}
@@ -34,15 +34,31 @@ func (layer *gatewayLayer) NewMultipartUpload(ctx context.Context, bucket, objec
 		return "", err
 	}
 
+	// pending uploads are aborted when not completed in the time allowed by
+	// the lifecycle rules of the bucket. The segments uploaded so far expire
+	// then, so that the satellite removes them even if this gateway stops.
+	var abort *time.Timer
+	var pendingExpires time.Time
+	if timeout := bucketInfo.AbortUploadAfter(object); timeout > 0 {
+		pendingExpires = time.Now().Add(timeout)
+		abort = time.AfterFunc(timeout, func() {
+			uploads.RemoveByID(upload.ID)
+			upload.Stream.Abort(Error.New("aborted by the lifecycle rules of the bucket"))
+		})
+	}
+
 	go func() {
+		if abort != nil {
+			defer abort.Stop()
+		}
+
 		contentType := metadata["content-type"]
 		delete(metadata, "content-type")
 
 		createInfo := storj.CreateObject{
-			ContentType:      contentType,
-			Metadata:         metadata,
-			RedundancyScheme: layer.gateway.redundancy,
-			EncryptionScheme: layer.gateway.encryption,
+			ContentType:    contentType,
+			Metadata:       metadata,
+			PendingExpires: pendingExpires,
 		}
 		objInfo, err := layer.putObject(ctx, bucket, object, upload.Stream, &createInfo)
 
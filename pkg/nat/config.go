@@ -0,0 +1,84 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nat
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/provider"
+)
+
+var (
+	mon = monkit.Package()
+	// Error is the main nat error class for this package
+	Error = errs.Class("nat error")
+)
+
+// Mapper is a router protocol for mapping a public port to a local one
+type Mapper interface {
+	// ExternalIP returns the public address of the router
+	ExternalIP(ctx context.Context) (net.IP, error)
+	// AddPortMapping forwards externalPort on the router to internalPort on
+	// this machine for lifetime and returns the external port actually mapped
+	AddPortMapping(ctx context.Context, internalPort, externalPort int, lifetime time.Duration) (int, error)
+	// DeletePortMapping removes a mapping made by AddPortMapping
+	DeletePortMapping(ctx context.Context, internalPort, externalPort int) error
+	String() string
+}
+
+// Config is a configuration struct for NAT traversal of storage nodes
+type Config struct {
+	Enabled  bool          `help:"check whether the node can be reached from the network and advertise its external address" default:"true"`
+	Mapping  bool          `help:"map the node's port on the router with UPnP or NAT-PMP" default:"true"`
+	Gateway  string        `help:"address of the router for NAT-PMP; detected when empty" default:""`
+	Interval time.Duration `help:"how often to check reachability and renew port mappings" default:"15m"`
+}
+
+// Run implements provider.Responsibility
+func (c Config) Run(ctx context.Context, server *provider.Provider) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if !c.Enabled {
+		return server.Run(ctx)
+	}
+
+	kad := kademlia.LoadFromContext(ctx)
+	if kad == nil {
+		return Error.New("programmer error: kademlia responsibility unstarted")
+	}
+
+	_, port, err := net.SplitHostPort(server.Addr().String())
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	localPort, err := strconv.Atoi(port)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	rt, err := kad.GetRoutingTable(ctx)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	// an operator who configured an external address knows better than us
+	self := rt.Local()
+	updateAddress := self.GetAddress().GetAddress() == server.Addr().String()
+
+	service := NewService(zap.L().Named("nat"), c, kad, server.Identity(), localPort, updateAddress)
+	go func() {
+		if err := service.Run(ctx); err != nil {
+			zap.L().Debug("nat service stopped", zap.Error(err))
+		}
+	}()
+
+	return server.Run(ctx)
+}
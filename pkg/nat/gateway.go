@@ -0,0 +1,49 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nat
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net"
+	"os"
+	"strings"
+)
+
+// defaultGateway returns the IPv4 default gateway from the kernel routing
+// table. Only Linux is supported; elsewhere the gateway must be configured.
+func defaultGateway() (net.IP, error) {
+	f, err := os.Open("/proc/net/route")
+	if err != nil {
+		return nil, Error.New("could not detect default gateway: %v", err)
+	}
+	defer func() { _ = f.Close() }()
+	return parseRoutes(f)
+}
+
+// parseRoutes finds the default route in the contents of /proc/net/route
+func parseRoutes(r io.Reader) (net.IP, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// Iface Destination Gateway ...
+		if len(fields) < 3 || fields[1] != "00000000" {
+			continue
+		}
+		gateway, err := hex.DecodeString(fields[2])
+		if err != nil || len(gateway) != 4 {
+			continue
+		}
+		// the kernel writes addresses in host byte order
+		ip := make(net.IP, 4)
+		binary.BigEndian.PutUint32(ip, binary.LittleEndian.Uint32(gateway))
+		return ip, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return nil, Error.New("no default gateway")
+}
@@ -0,0 +1,133 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nat
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"time"
+)
+
+const (
+	natpmpPort = 5351
+
+	natpmpOpExternalAddress = 0
+	natpmpOpMapTCP          = 2
+
+	// natpmpRetries is how many times a request is sent before giving up,
+	// doubling the wait after each attempt as RFC 6886 asks
+	natpmpRetries = 4
+	natpmpWait    = 250 * time.Millisecond
+)
+
+// natpmpResultCodes are the descriptions of the NAT-PMP result codes
+var natpmpResultCodes = map[uint16]string{
+	1: "unsupported version",
+	2: "not authorized or refused",
+	3: "network failure",
+	4: "out of resources",
+	5: "unsupported opcode",
+}
+
+// NATPMP maps ports using the NAT Port Mapping Protocol (RFC 6886)
+type NATPMP struct {
+	gateway string
+}
+
+// NewNATPMP returns a NAT-PMP client talking to the gateway at address,
+// which may omit the port
+func NewNATPMP(address string) *NATPMP {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "5351")
+	}
+	return &NATPMP{gateway: address}
+}
+
+// String implements Mapper
+func (n *NATPMP) String() string { return "natpmp" }
+
+// ExternalIP implements Mapper
+func (n *NATPMP) ExternalIP(ctx context.Context) (net.IP, error) {
+	resp, err := n.request(ctx, []byte{0, natpmpOpExternalAddress}, 12)
+	if err != nil {
+		return nil, err
+	}
+	return net.IPv4(resp[8], resp[9], resp[10], resp[11]), nil
+}
+
+// AddPortMapping implements Mapper
+func (n *NATPMP) AddPortMapping(ctx context.Context, internalPort, externalPort int, lifetime time.Duration) (int, error) {
+	req := make([]byte, 12)
+	req[1] = natpmpOpMapTCP
+	binary.BigEndian.PutUint16(req[4:], uint16(internalPort))
+	binary.BigEndian.PutUint16(req[6:], uint16(externalPort))
+	binary.BigEndian.PutUint32(req[8:], uint32(lifetime/time.Second))
+
+	resp, err := n.request(ctx, req, 16)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.BigEndian.Uint16(resp[10:])), nil
+}
+
+// DeletePortMapping implements Mapper
+func (n *NATPMP) DeletePortMapping(ctx context.Context, internalPort, externalPort int) error {
+	// a mapping with a zero lifetime and external port is deleted
+	req := make([]byte, 12)
+	req[1] = natpmpOpMapTCP
+	binary.BigEndian.PutUint16(req[4:], uint16(internalPort))
+	_, err := n.request(ctx, req, 16)
+	return err
+}
+
+// request sends req to the gateway and waits for a successful response of
+// at least size bytes
+func (n *NATPMP) request(ctx context.Context, req []byte, size int) (_ []byte, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", n.gateway)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	buf := make([]byte, 16)
+	wait := natpmpWait
+	for i := 0; i < natpmpRetries; i++ {
+		if _, err := conn.Write(req); err != nil {
+			return nil, Error.Wrap(err)
+		}
+
+		deadline := time.Now().Add(wait)
+		if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+			deadline = d
+		}
+		if err := conn.SetReadDeadline(deadline); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		wait *= 2
+
+		read, err := conn.Read(buf)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() && ctx.Err() == nil {
+				continue
+			}
+			return nil, Error.Wrap(err)
+		}
+		if read < size || buf[0] != 0 || buf[1] != req[1]|0x80 {
+			// not the answer to our request
+			continue
+		}
+		if code := binary.BigEndian.Uint16(buf[2:]); code != 0 {
+			if msg, ok := natpmpResultCodes[code]; ok {
+				return nil, Error.New("natpmp: %s", msg)
+			}
+			return nil, Error.New("natpmp: result code %d", code)
+		}
+		return buf[:read], nil
+	}
+	return nil, Error.New("natpmp: no response from %s", n.gateway)
+}
@@ -0,0 +1,98 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nat
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+)
+
+// fakeNATPMP answers NAT-PMP requests like a router with the given external
+// address would, failing mappings of port 1
+func fakeNATPMP(t *testing.T, ctx *testcontext.Context, external net.IP) net.PacketConn {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx.Go(func() error {
+		buf := make([]byte, 64)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return nil
+			}
+			if n < 2 {
+				continue
+			}
+
+			var resp []byte
+			switch buf[1] {
+			case natpmpOpExternalAddress:
+				resp = make([]byte, 12)
+				copy(resp[8:], external.To4())
+			case natpmpOpMapTCP:
+				resp = make([]byte, 16)
+				internal := binary.BigEndian.Uint16(buf[4:])
+				if internal == 1 {
+					binary.BigEndian.PutUint16(resp[2:], 2)
+				}
+				copy(resp[8:10], buf[4:6])
+				// the router hands out a different external port
+				binary.BigEndian.PutUint16(resp[10:], internal+1000)
+				copy(resp[12:], buf[8:12])
+			default:
+				continue
+			}
+			resp[1] = buf[1] | 0x80
+			if _, err := conn.WriteTo(resp, addr); err != nil {
+				return err
+			}
+		}
+	})
+
+	return conn
+}
+
+func TestNATPMP(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	external := net.ParseIP("203.0.113.7")
+	gateway := fakeNATPMP(t, ctx, external)
+	defer ctx.Check(gateway.Close)
+
+	natpmp := NewNATPMP(gateway.LocalAddr().String())
+
+	ip, err := natpmp.ExternalIP(ctx)
+	require.NoError(t, err)
+	assert.True(t, external.Equal(ip))
+
+	port, err := natpmp.AddPortMapping(ctx, 28967, 28967, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 28967+1000, port)
+
+	_, err = natpmp.AddPortMapping(ctx, 1, 1, time.Hour)
+	assert.EqualError(t, err, "nat error: natpmp: not authorized or refused")
+
+	assert.NoError(t, natpmp.DeletePortMapping(ctx, 28967, port))
+}
+
+func TestNATPMPNoResponse(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	// nothing answers on this socket
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ctx.Check(conn.Close)
+
+	_, err = NewNATPMP(conn.LocalAddr().String()).ExternalIP(ctx)
+	assert.Error(t, err)
+}
@@ -0,0 +1,250 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nat
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/utils"
+)
+
+// Status is the outcome of the latest reachability check
+type Status struct {
+	// Reachable is whether a bootstrap node could dial us back
+	Reachable bool
+	// Address is the address we were dialed back on
+	Address string
+	// Mapping is the port mapping protocol in use, if any
+	Mapping string
+	// Err is why the check failed
+	Err     error
+	Checked time.Time
+}
+
+// Service periodically maps the node's port on the router, checks that
+// the node can be reached from the network and advertises the address it
+// was reached on
+type Service struct {
+	log           *zap.Logger
+	config        Config
+	kad           *kademlia.Kademlia
+	transport     transport.Client
+	localPort     int
+	updateAddress bool
+
+	// discover finds a port mapper on the local network
+	discover func(ctx context.Context) (Mapper, error)
+
+	mu         sync.Mutex
+	status     Status
+	mapper     Mapper
+	mappedPort int
+}
+
+// NewService creates a new nat service for a node listening on localPort.
+// The advertised address is only changed if updateAddress is set.
+func NewService(log *zap.Logger, config Config, kad *kademlia.Kademlia, identity *provider.FullIdentity, localPort int, updateAddress bool) *Service {
+	service := &Service{
+		log:           log,
+		config:        config,
+		kad:           kad,
+		transport:     transport.NewClient(identity),
+		localPort:     localPort,
+		updateAddress: updateAddress,
+	}
+	service.discover = service.discoverMapper
+	return service
+}
+
+// Run checks reachability every interval until ctx is canceled, then
+// removes any port mapping it made
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+	defer service.unmap()
+
+	ticker := time.NewTicker(service.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		service.Check(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Status returns the outcome of the latest reachability check
+func (service *Service) Status() Status {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	return service.status
+}
+
+// Check renews the port mapping, asks a bootstrap node to dial us back and
+// advertises the address it reached us on. The round trips don't hold the lock,
+// so the status can be read meanwhile.
+func (service *Service) Check(ctx context.Context) Status {
+	defer mon.Task()(&ctx)(nil)
+
+	port := service.localPort
+	status := Status{Checked: time.Now()}
+	if service.config.Mapping {
+		if mapped, mapper, ok := service.mapPort(ctx); ok {
+			port = mapped
+			status.Mapping = mapper.String()
+		}
+	}
+
+	resp, err := service.dialBack(ctx, port)
+	switch {
+	case err != nil:
+		status.Err = err
+	case !resp.Reachable:
+		status.Err = Error.New("dial back to %s failed: %s", resp.DialedAddress, resp.Error)
+	default:
+		status.Reachable = true
+		status.Address = resp.DialedAddress
+	}
+
+	if status.Reachable && service.updateAddress {
+		if err := service.kad.UpdateAddress(status.Address); err != nil {
+			service.log.Error("could not update advertised address", zap.Error(err))
+		}
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	service.report(status)
+	service.status = status
+	return status
+}
+
+// report tells the operator when reachability changes, it must be called with mu held
+func (service *Service) report(status Status) {
+	previous := service.status
+	if !previous.Checked.IsZero() && previous.Reachable == status.Reachable && previous.Address == status.Address {
+		return
+	}
+	if status.Reachable {
+		service.log.Info("node is reachable from the network",
+			zap.String("address", status.Address), zap.String("mapping", status.Mapping))
+		return
+	}
+	service.log.Warn("node is not reachable from the network; forward its port on your router or enable UPnP/NAT-PMP",
+		zap.Int("port", service.localPort), zap.Error(status.Err))
+}
+
+// mapPort makes or renews the port mapping, returning the external port and the mapper
+func (service *Service) mapPort(ctx context.Context) (int, Mapper, bool) {
+	service.mu.Lock()
+	mapper, external := service.mapper, service.mappedPort
+	service.mu.Unlock()
+
+	if mapper == nil {
+		var err error
+		mapper, err = service.discover(ctx)
+		if err != nil {
+			service.log.Debug("no port mapping available", zap.Error(err))
+			return 0, nil, false
+		}
+	}
+	if external == 0 {
+		external = service.localPort
+	}
+
+	// renewing before the mapping expires keeps it alive between checks
+	lifetime := 2 * service.config.Interval
+	mapped, err := mapper.AddPortMapping(ctx, service.localPort, external, lifetime)
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	if err != nil {
+		service.log.Warn("port mapping failed", zap.Stringer("mapping", mapper), zap.Error(err))
+		service.mapper, service.mappedPort = nil, 0
+		return 0, nil, false
+	}
+	service.mapper, service.mappedPort = mapper, mapped
+	return mapped, mapper, true
+}
+
+// unmap removes the port mapping, if any
+func (service *Service) unmap() {
+	service.mu.Lock()
+	mapper, mapped := service.mapper, service.mappedPort
+	service.mapper, service.mappedPort = nil, 0
+	service.mu.Unlock()
+
+	if mapper == nil || mapped == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := mapper.DeletePortMapping(ctx, service.localPort, mapped); err != nil {
+		service.log.Debug("could not remove port mapping", zap.Error(err))
+	}
+}
+
+// discoverMapper looks for a UPnP gateway, then falls back to NAT-PMP
+func (service *Service) discoverMapper(ctx context.Context) (Mapper, error) {
+	upnp, upnpErr := DiscoverUPnP(ctx)
+	if upnpErr == nil {
+		return upnp, nil
+	}
+
+	gateway := service.config.Gateway
+	if gateway == "" {
+		ip, err := defaultGateway()
+		if err != nil {
+			return nil, utils.CombineErrors(upnpErr, err)
+		}
+		gateway = ip.String()
+	}
+	natpmp := NewNATPMP(gateway)
+	if _, err := natpmp.ExternalIP(ctx); err != nil {
+		return nil, utils.CombineErrors(upnpErr, err)
+	}
+	return natpmp, nil
+}
+
+// dialBack asks the bootstrap nodes, in order, to dial us back on port
+func (service *Service) dialBack(ctx context.Context, port int) (*pb.DialBackResponse, error) {
+	var errs []error
+	for _, node := range service.kad.GetBootstrapNodes() {
+		node := node
+		resp, err := service.askDialBack(ctx, &node, port)
+		if err == nil {
+			return resp, nil
+		}
+		errs = append(errs, err)
+	}
+	if len(errs) == 0 {
+		return nil, Error.New("no bootstrap nodes to dial us back")
+	}
+	return nil, utils.CombineErrors(errs...)
+}
+
+func (service *Service) askDialBack(ctx context.Context, node *pb.Node, port int) (_ *pb.DialBackResponse, err error) {
+	conn, err := service.transport.DialNode(ctx, node)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { err = utils.CombineErrors(err, conn.Close()) }()
+
+	resp, err := pb.NewNodesClient(conn).DialBack(ctx, &pb.DialBackRequest{Port: int32(port)})
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return resp, nil
+}
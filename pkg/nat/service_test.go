@@ -0,0 +1,171 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nat
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+)
+
+// fakeMapper maps every port to itself, after release is closed if it's set
+type fakeMapper struct {
+	fail     bool
+	release  chan struct{}
+	mapped   []time.Duration
+	unmapped []int
+}
+
+func (m *fakeMapper) String() string { return "fake" }
+
+func (m *fakeMapper) ExternalIP(ctx context.Context) (net.IP, error) {
+	return net.IPv4(127, 0, 0, 1), nil
+}
+
+func (m *fakeMapper) AddPortMapping(ctx context.Context, internalPort, externalPort int, lifetime time.Duration) (int, error) {
+	if m.release != nil {
+		<-m.release
+	}
+	if m.fail {
+		return 0, Error.New("mapping refused")
+	}
+	m.mapped = append(m.mapped, lifetime)
+	return externalPort, nil
+}
+
+func (m *fakeMapper) DeletePortMapping(ctx context.Context, internalPort, externalPort int) error {
+	m.unmapped = append(m.unmapped, externalPort)
+	return nil
+}
+
+func port(t *testing.T, address string) int {
+	_, p, err := net.SplitHostPort(address)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(p)
+	require.NoError(t, err)
+	return port
+}
+
+func TestServiceDialBack(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 1, 0)
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	node := planet.StorageNodes[0]
+	config := Config{Interval: time.Hour}
+
+	{ // the node is dialed back on its listening port, which is advertised
+		require.NoError(t, node.Kademlia.UpdateAddress("127.0.0.1:1"))
+
+		service := NewService(zaptest.NewLogger(t), config, node.Kademlia, node.Identity, port(t, node.Addr()), true)
+		status := service.Check(ctx)
+		assert.NoError(t, status.Err)
+		assert.True(t, status.Reachable)
+		assert.Equal(t, node.Addr(), status.Address)
+		assert.Equal(t, status, service.Status())
+
+		rt, err := node.Kademlia.GetRoutingTable(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, node.Addr(), rt.Local().Address.Address)
+	}
+
+	{ // nothing is listening on a closed port
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		closed := port(t, lis.Addr().String())
+		require.NoError(t, lis.Close())
+
+		service := NewService(zaptest.NewLogger(t), config, node.Kademlia, node.Identity, closed, true)
+		status := service.Check(ctx)
+		assert.Error(t, status.Err)
+		assert.False(t, status.Reachable)
+
+		// an unreachable address is not advertised
+		rt, err := node.Kademlia.GetRoutingTable(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, node.Addr(), rt.Local().Address.Address)
+	}
+}
+
+func TestServiceMapping(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 1, 0)
+	require.NoError(t, err)
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	node := planet.StorageNodes[0]
+	localPort := port(t, node.Addr())
+	config := Config{Mapping: true, Interval: time.Hour}
+
+	{ // the mapping is renewed for twice the check interval and removed on shutdown
+		mapper := &fakeMapper{}
+		service := NewService(zaptest.NewLogger(t), config, node.Kademlia, node.Identity, localPort, true)
+		service.discover = func(context.Context) (Mapper, error) { return mapper, nil }
+
+		status := service.Check(ctx)
+		assert.True(t, status.Reachable)
+		assert.Equal(t, "fake", status.Mapping)
+		service.Check(ctx)
+		assert.Equal(t, []time.Duration{2 * time.Hour, 2 * time.Hour}, mapper.mapped)
+
+		service.unmap()
+		assert.Equal(t, []int{localPort}, mapper.unmapped)
+	}
+
+	{ // a failed mapping falls back to the local port
+		service := NewService(zaptest.NewLogger(t), config, node.Kademlia, node.Identity, localPort, true)
+		service.discover = func(context.Context) (Mapper, error) { return &fakeMapper{fail: true}, nil }
+
+		status := service.Check(ctx)
+		assert.True(t, status.Reachable)
+		assert.Equal(t, "", status.Mapping)
+		assert.Equal(t, node.Addr(), status.Address)
+	}
+
+	{ // the status can be read while the mapping is made
+		discovered := make(chan struct{})
+		mapper := &fakeMapper{release: make(chan struct{})}
+		service := NewService(zaptest.NewLogger(t), config, node.Kademlia, node.Identity, localPort, true)
+		service.discover = func(context.Context) (Mapper, error) {
+			close(discovered)
+			return mapper, nil
+		}
+
+		done := make(chan Status)
+		go func() { done <- service.Check(ctx) }()
+		<-discovered
+
+		read := make(chan Status)
+		go func() { read <- service.Status() }()
+		select {
+		case status := <-read:
+			assert.True(t, status.Checked.IsZero())
+		case <-time.After(5 * time.Second):
+			t.Fatal("status blocked by the port mapping")
+		}
+
+		close(mapper.release)
+		status := <-done
+		assert.True(t, status.Reachable)
+		assert.Equal(t, "fake", status.Mapping)
+	}
+}
@@ -0,0 +1,320 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nat
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ssdpAddress = "239.255.255.250:1900"
+	ssdpSearch  = "urn:schemas-upnp-org:device:InternetGatewayDevice:1"
+
+	// maxResponseSize bounds how much of a gateway response is read
+	maxResponseSize = 1 << 20
+)
+
+// UPnP maps ports using a UPnP Internet Gateway Device
+type UPnP struct {
+	client      *http.Client
+	controlURL  string
+	serviceType string
+	// internalIP is the address the gateway sees us on
+	internalIP string
+}
+
+// DiscoverUPnP searches the local network for a UPnP Internet Gateway Device
+// and returns a client for the first one found
+func DiscoverUPnP(ctx context.Context) (_ *UPnP, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	conn, err := net.ListenPacket("udp4", ":0")
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	dst, err := net.ResolveUDPAddr("udp4", ssdpAddress)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	search := "M-SEARCH * HTTP/1.1\r\n" +
+		"HOST: " + ssdpAddress + "\r\n" +
+		"ST: " + ssdpSearch + "\r\n" +
+		"MAN: \"ssdp:discover\"\r\n" +
+		"MX: 2\r\n\r\n"
+	if _, err := conn.WriteTo([]byte(search), dst); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetReadDeadline(deadline); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	buf := make([]byte, 2048)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			return nil, Error.New("upnp: no gateway found: %v", err)
+		}
+		location, ok := parseSearchResponse(buf[:n])
+		if !ok {
+			continue
+		}
+		gateway, err := NewUPnP(ctx, location)
+		if err != nil {
+			continue
+		}
+		return gateway, nil
+	}
+}
+
+// parseSearchResponse returns the device description location of an SSDP
+// response to our search
+func parseSearchResponse(data []byte) (location string, ok bool) {
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), nil)
+	if err != nil {
+		return "", false
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("St") != ssdpSearch {
+		return "", false
+	}
+	location = resp.Header.Get("Location")
+	return location, location != ""
+}
+
+// upnpDevice is a device in a UPnP device description
+type upnpDevice struct {
+	DeviceType string        `xml:"deviceType"`
+	Services   []upnpService `xml:"serviceList>service"`
+	Devices    []upnpDevice  `xml:"deviceList>device"`
+}
+
+// upnpService is a service in a UPnP device description
+type upnpService struct {
+	ServiceType string `xml:"serviceType"`
+	ControlURL  string `xml:"controlURL"`
+}
+
+// findService returns the first WAN connection service of the device tree
+func (device *upnpDevice) findService() (upnpService, bool) {
+	for _, service := range device.Services {
+		if strings.Contains(service.ServiceType, ":WANIPConnection:") ||
+			strings.Contains(service.ServiceType, ":WANPPPConnection:") {
+			return service, true
+		}
+	}
+	for i := range device.Devices {
+		if service, ok := device.Devices[i].findService(); ok {
+			return service, true
+		}
+	}
+	return upnpService{}, false
+}
+
+// NewUPnP returns a client for the gateway whose device description is at
+// location
+func NewUPnP(ctx context.Context, location string) (_ *UPnP, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	base, err := url.Parse(location)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	req, err := http.NewRequest(http.MethodGet, location, nil)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, Error.New("upnp: fetching %s: %s", location, resp.Status)
+	}
+
+	var root struct {
+		URLBase string     `xml:"URLBase"`
+		Device  upnpDevice `xml:"device"`
+	}
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&root); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	service, ok := root.Device.findService()
+	if !ok {
+		return nil, Error.New("upnp: %s is not an internet gateway", location)
+	}
+	if root.URLBase != "" {
+		if base, err = url.Parse(root.URLBase); err != nil {
+			return nil, Error.Wrap(err)
+		}
+	}
+	control, err := base.Parse(service.ControlURL)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	internalIP, err := localIPFor(base.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UPnP{
+		client:      client,
+		controlURL:  control.String(),
+		serviceType: service.ServiceType,
+		internalIP:  internalIP,
+	}, nil
+}
+
+// String implements Mapper
+func (u *UPnP) String() string { return "upnp" }
+
+// ExternalIP implements Mapper
+func (u *UPnP) ExternalIP(ctx context.Context) (net.IP, error) {
+	resp, err := u.call(ctx, "GetExternalIPAddress", nil)
+	if err != nil {
+		return nil, err
+	}
+	ip := net.ParseIP(resp["NewExternalIPAddress"])
+	if ip == nil {
+		return nil, Error.New("upnp: invalid external address %q", resp["NewExternalIPAddress"])
+	}
+	return ip, nil
+}
+
+// AddPortMapping implements Mapper
+func (u *UPnP) AddPortMapping(ctx context.Context, internalPort, externalPort int, lifetime time.Duration) (int, error) {
+	_, err := u.call(ctx, "AddPortMapping", [][2]string{
+		{"NewRemoteHost", ""},
+		{"NewExternalPort", strconv.Itoa(externalPort)},
+		{"NewProtocol", "TCP"},
+		{"NewInternalPort", strconv.Itoa(internalPort)},
+		{"NewInternalClient", u.internalIP},
+		{"NewEnabled", "1"},
+		{"NewPortMappingDescription", "storj storage node"},
+		{"NewLeaseDuration", strconv.Itoa(int(lifetime / time.Second))},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return externalPort, nil
+}
+
+// DeletePortMapping implements Mapper
+func (u *UPnP) DeletePortMapping(ctx context.Context, internalPort, externalPort int) error {
+	_, err := u.call(ctx, "DeletePortMapping", [][2]string{
+		{"NewRemoteHost", ""},
+		{"NewExternalPort", strconv.Itoa(externalPort)},
+		{"NewProtocol", "TCP"},
+	})
+	return err
+}
+
+// call invokes a SOAP action on the gateway and returns the response arguments
+func (u *UPnP) call(ctx context.Context, action string, args [][2]string) (_ map[string]string, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var body bytes.Buffer
+	body.WriteString(`<?xml version="1.0"?>` +
+		`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/" s:encodingStyle="http://schemas.xmlsoap.org/soap/encoding/">` +
+		`<s:Body>`)
+	fmt.Fprintf(&body, `<u:%s xmlns:u="%s">`, action, u.serviceType)
+	for _, arg := range args {
+		fmt.Fprintf(&body, "<%s>", arg[0])
+		if err := xml.EscapeText(&body, []byte(arg[1])); err != nil {
+			return nil, Error.Wrap(err)
+		}
+		fmt.Fprintf(&body, "</%s>", arg[0])
+	}
+	fmt.Fprintf(&body, `</u:%s></s:Body></s:Envelope>`, action)
+
+	req, err := http.NewRequest(http.MethodPost, u.controlURL, &body)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	req.Header.Set("Content-Type", `text/xml; charset="utf-8"`)
+	req.Header.Set("SOAPAction", fmt.Sprintf(`"%s#%s"`, u.serviceType, action))
+
+	resp, err := u.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	values, err := parseSOAPResponse(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		if desc := values["errorDescription"]; desc != "" {
+			return nil, Error.New("upnp: %s: %s (%s)", action, desc, values["errorCode"])
+		}
+		return nil, Error.New("upnp: %s: %s", action, resp.Status)
+	}
+	return values, nil
+}
+
+// parseSOAPResponse collects the text of every leaf element of a SOAP
+// response by its local name
+func parseSOAPResponse(r io.Reader) (map[string]string, error) {
+	values := make(map[string]string)
+	decoder := xml.NewDecoder(r)
+	var name string
+	var text []byte
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return nil, Error.Wrap(err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			name, text = t.Name.Local, nil
+		case xml.CharData:
+			text = append(text, t...)
+		case xml.EndElement:
+			if name == t.Name.Local {
+				values[name] = strings.TrimSpace(string(text))
+			}
+			name = ""
+		}
+	}
+}
+
+// localIPFor returns the local address used to reach host
+func localIPFor(host string) (string, error) {
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(host, "80")
+	}
+	conn, err := net.Dial("udp4", host)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	defer func() { _ = conn.Close() }()
+	return conn.LocalAddr().(*net.UDPAddr).IP.String(), nil
+}
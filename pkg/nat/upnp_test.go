@@ -0,0 +1,157 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package nat
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+)
+
+const (
+	testServiceType = "urn:schemas-upnp-org:service:WANIPConnection:1"
+
+	testDescription = `<?xml version="1.0"?>
+<root xmlns="urn:schemas-upnp-org:device-1-0">
+  <device>
+    <deviceType>urn:schemas-upnp-org:device:InternetGatewayDevice:1</deviceType>
+    <serviceList>
+      <service>
+        <serviceType>urn:schemas-upnp-org:service:Layer3Forwarding:1</serviceType>
+        <controlURL>/l3f</controlURL>
+      </service>
+    </serviceList>
+    <deviceList>
+      <device>
+        <deviceType>urn:schemas-upnp-org:device:WANDevice:1</deviceType>
+        <deviceList>
+          <device>
+            <deviceType>urn:schemas-upnp-org:device:WANConnectionDevice:1</deviceType>
+            <serviceList>
+              <service>
+                <serviceType>` + testServiceType + `</serviceType>
+                <controlURL>/ctl/IPConn</controlURL>
+              </service>
+            </serviceList>
+          </device>
+        </deviceList>
+      </device>
+    </deviceList>
+  </device>
+</root>`
+
+	testFault = `<?xml version="1.0"?>
+<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/">
+  <s:Body>
+    <s:Fault>
+      <faultcode>s:Client</faultcode>
+      <faultstring>UPnPError</faultstring>
+      <detail>
+        <UPnPError xmlns="urn:schemas-upnp-org:control-1-0">
+          <errorCode>718</errorCode>
+          <errorDescription>ConflictInMappingEntry</errorDescription>
+        </UPnPError>
+      </detail>
+    </s:Fault>
+  </s:Body>
+</s:Envelope>`
+)
+
+// fakeGateway serves a UPnP internet gateway, refusing to map port 1
+func fakeGateway(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/desc.xml", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testDescription))
+	})
+	mux.HandleFunc("/ctl/IPConn", func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		switch r.Header.Get("SOAPAction") {
+		case `"` + testServiceType + `#GetExternalIPAddress"`:
+			_, _ = w.Write([]byte(`<?xml version="1.0"?>
+<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body>
+<u:GetExternalIPAddressResponse xmlns:u="` + testServiceType + `">
+<NewExternalIPAddress>203.0.113.7</NewExternalIPAddress>
+</u:GetExternalIPAddressResponse></s:Body></s:Envelope>`))
+		case `"` + testServiceType + `#AddPortMapping"`:
+			if strings.Contains(string(body), "<NewExternalPort>1</NewExternalPort>") {
+				w.WriteHeader(http.StatusInternalServerError)
+				_, _ = w.Write([]byte(testFault))
+				return
+			}
+			assert.Contains(t, string(body), "<NewInternalClient>127.0.0.1</NewInternalClient>")
+			assert.Contains(t, string(body), "<NewLeaseDuration>3600</NewLeaseDuration>")
+			_, _ = w.Write([]byte(`<s:Envelope xmlns:s="http://schemas.xmlsoap.org/soap/envelope/"><s:Body>` +
+				`<u:AddPortMappingResponse xmlns:u="` + testServiceType + `"/></s:Body></s:Envelope>`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestUPnP(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	server := fakeGateway(t)
+	defer server.Close()
+
+	upnp, err := NewUPnP(ctx, server.URL+"/desc.xml")
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/ctl/IPConn", upnp.controlURL)
+	assert.Equal(t, testServiceType, upnp.serviceType)
+
+	ip, err := upnp.ExternalIP(ctx)
+	require.NoError(t, err)
+	assert.True(t, net.ParseIP("203.0.113.7").Equal(ip))
+
+	port, err := upnp.AddPortMapping(ctx, 28967, 28967, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 28967, port)
+
+	_, err = upnp.AddPortMapping(ctx, 1, 1, time.Hour)
+	assert.EqualError(t, err, "nat error: upnp: AddPortMapping: ConflictInMappingEntry (718)")
+}
+
+func TestUPnPNotGateway(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<root><device><deviceType>urn:schemas-upnp-org:device:MediaServer:1</deviceType></device></root>`))
+	}))
+	defer server.Close()
+
+	_, err := NewUPnP(ctx, server.URL)
+	assert.Error(t, err)
+}
+
+func TestParseSearchResponse(t *testing.T) {
+	location, ok := parseSearchResponse([]byte("HTTP/1.1 200 OK\r\n" +
+		"CACHE-CONTROL: max-age=120\r\n" +
+		"ST: " + ssdpSearch + "\r\n" +
+		"LOCATION: http://192.168.1.1:5000/rootDesc.xml\r\n\r\n"))
+	assert.True(t, ok)
+	assert.Equal(t, "http://192.168.1.1:5000/rootDesc.xml", location)
+
+	// other devices answering the search are ignored
+	_, ok = parseSearchResponse([]byte("HTTP/1.1 200 OK\r\n" +
+		"ST: urn:schemas-upnp-org:device:MediaServer:1\r\n" +
+		"LOCATION: http://192.168.1.2/desc.xml\r\n\r\n"))
+	assert.False(t, ok)
+
+	_, ok = parseSearchResponse([]byte("garbage"))
+	assert.False(t, ok)
+}
@@ -106,7 +106,7 @@ func (pool *ConnectionPool) Dial(ctx context.Context, n *pb.Node) (pb.NodesClien
 	}
 
 	conn.dial.Do(func() {
-		grpc, err := pool.tc.DialNode(ctx, n, grpc.WithBlock())
+		grpc, err := pool.tc.DialNode(ctx, n, grpc.WithBlock(), grpc.FailOnNonTempDialError(true))
 		conn.err = err
 		if conn.err != nil {
 			return
@@ -118,6 +118,12 @@ func (pool *ConnectionPool) Dial(ctx context.Context, n *pb.Node) (pb.NodesClien
 	})
 
 	if conn.err != nil {
+		// forget failed dials, so the node is dialed again next time
+		pool.mu.Lock()
+		if pool.items[id] == conn {
+			delete(pool.items, id)
+		}
+		pool.mu.Unlock()
 		return nil, conn.err
 	}
 
@@ -25,19 +25,22 @@ func (node *Node) Lookup(ctx context.Context, to pb.Node, find pb.Node) ([]*pb.N
 	if err != nil {
 		return nil, NodeClientErr.Wrap(err)
 	}
+	rt, err := node.dht.GetRoutingTable(ctx)
+	if err != nil {
+		return nil, NodeClientErr.Wrap(err)
+	}
+	// the routing table holds our latest address, which may have changed
+	// since the client was created
+	self := rt.Local()
 	resp, err := conn.Query(ctx, &pb.QueryRequest{
 		Limit:    20,
-		Sender:   &node.self,
+		Sender:   &self,
 		Target:   &find,
 		Pingback: true,
 	})
 	if err != nil {
 		return nil, NodeClientErr.Wrap(err)
 	}
-	rt, err := node.dht.GetRoutingTable(ctx)
-	if err != nil {
-		return nil, NodeClientErr.Wrap(err)
-	}
 	if err := rt.ConnectionSuccess(&to); err != nil {
 		return nil, NodeClientErr.Wrap(err)
 	}
@@ -5,24 +5,37 @@ package node
 
 import (
 	"context"
+	"net"
+	"strconv"
+	"time"
 
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
 
 	"storj.io/storj/pkg/dht"
+	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/transport"
 )
 
+// dialBackTimeout bounds how long a dial back may take
+const dialBackTimeout = 10 * time.Second
+
 // Server implements the grpc Node Server
 type Server struct {
-	dht dht.DHT
-	log *zap.Logger
+	dht       dht.DHT
+	log       *zap.Logger
+	transport transport.Client
 }
 
 // NewServer returns a newly instantiated Node Server
-func NewServer(log *zap.Logger, dht dht.DHT) *Server {
+func NewServer(log *zap.Logger, dht dht.DHT, identity *provider.FullIdentity) *Server {
 	return &Server{
-		dht: dht,
-		log: log,
+		dht:       dht,
+		log:       log,
+		transport: transport.NewClient(identity),
 	}
 }
 
@@ -41,11 +54,12 @@ func (server *Server) Query(ctx context.Context, req *pb.QueryRequest) (*pb.Quer
 				server.log.Error("could not respond to connection failed", zap.Error(err))
 			}
 			server.log.Error("connection to node failed", zap.Error(err), zap.String("nodeID", req.Sender.Id.String()))
-		}
-
-		err = rt.ConnectionSuccess(req.Sender)
-		if err != nil {
-			server.log.Error("could not respond to connection success", zap.Error(err))
+		} else {
+			// only senders that answered our ping are trusted
+			err = rt.ConnectionSuccess(req.Sender)
+			if err != nil {
+				server.log.Error("could not respond to connection success", zap.Error(err))
+			}
 		}
 	}
 
@@ -62,3 +76,62 @@ func (server *Server) Ping(ctx context.Context, req *pb.PingRequest) (*pb.PingRe
 	//TODO
 	return &pb.PingResponse{}, nil
 }
+
+// DialBack dials the requesting node back on the requested port at the
+// address its request came from, so that nodes behind a NAT can tell
+// whether they are reachable from the outside
+func (server *Server) DialBack(ctx context.Context, req *pb.DialBackRequest) (*pb.DialBackResponse, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, NodeClientErr.New("unable to get peer from context")
+	}
+	pi, err := identity.PeerIdentityFromPeer(p)
+	if err != nil {
+		return nil, NodeClientErr.Wrap(err)
+	}
+	if req.Port <= 0 || req.Port > 65535 {
+		return nil, NodeClientErr.New("invalid port %d", req.Port)
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return nil, NodeClientErr.Wrap(err)
+	}
+
+	// only the address the request came from is dialed, so this can't be
+	// used to make us connect to arbitrary hosts
+	resp := &pb.DialBackResponse{
+		ObservedAddress: p.Addr.String(),
+		DialedAddress:   net.JoinHostPort(host, strconv.Itoa(int(req.Port))),
+	}
+
+	if err := server.dialBack(ctx, pb.Node{
+		Id: pi.ID,
+		Address: &pb.NodeAddress{
+			Transport: pb.NodeTransport_TCP_TLS_GRPC,
+			Address:   resp.DialedAddress,
+		},
+		Type: pb.NodeType_STORAGE,
+	}); err != nil {
+		resp.Error = err.Error()
+		return resp, nil
+	}
+	resp.Reachable = true
+	return resp, nil
+}
+
+// dialBack opens a fresh connection to node and pings it. The connection
+// pool is bypassed so an existing connection can't hide an unreachable node.
+func (server *Server) dialBack(ctx context.Context, node pb.Node) error {
+	ctx, cancel := context.WithTimeout(ctx, dialBackTimeout)
+	defer cancel()
+
+	conn, err := server.transport.DialNode(ctx, &node, grpc.WithBlock(), grpc.FailOnNonTempDialError(true))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	_, err = pb.NewNodesClient(conn).Ping(ctx, &pb.PingRequest{})
+	return err
+}
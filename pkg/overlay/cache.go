@@ -5,6 +5,7 @@ package overlay
 
 import (
 	"context"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/zeebo/errs"
@@ -40,6 +41,7 @@ var OverlayError = errs.Class("Overlay Error")
 type Cache struct {
 	db     storage.KeyValueStore
 	statDB statdb.DB
+	lru    *nodeLRU
 }
 
 // NewCache returns a new Cache
@@ -47,17 +49,69 @@ func NewCache(db storage.KeyValueStore, sdb statdb.DB) *Cache {
 	return &Cache{db: db, statDB: sdb}
 }
 
+// NewCacheWithLRU returns a new Cache that keeps up to size recently used
+// nodes in memory for ttl, in front of db. Nodes put into the cache, e.g. on
+// ConnSuccess, replace the ones in memory right away.
+func NewCacheWithLRU(db storage.KeyValueStore, sdb statdb.DB, size int, ttl time.Duration) *Cache {
+	cache := NewCache(db, sdb)
+	if size > 0 {
+		cache.lru = newNodeLRU(size, ttl)
+	}
+	return cache
+}
+
 // Inspect lists limited number of items in the cache
 func (cache *Cache) Inspect(ctx context.Context) (storage.Keys, error) {
 	return cache.db.List(nil, 0)
 }
 
+// List returns up to limit nodes from the cache with IDs after cursor, in ID order.
+// A zero cursor starts from the first node.
+func (cache *Cache) List(ctx context.Context, cursor storj.NodeID, limit int) ([]*pb.Node, error) {
+	var start storage.Key
+	if !cursor.IsZero() {
+		start = storage.NextKey(cursor.Bytes())
+	}
+
+	keys, err := cache.db.List(start, limit)
+	if err != nil {
+		return nil, OverlayError.Wrap(err)
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	values, err := cache.db.GetAll(keys)
+	if err != nil {
+		return nil, OverlayError.Wrap(err)
+	}
+
+	var nodes []*pb.Node
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		node := &pb.Node{}
+		if err := proto.Unmarshal(v, node); err != nil {
+			return nil, OverlayError.New("could not unmarshal non-nil node: %v", err)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
 // Get looks up the provided nodeID from the overlay cache
 func (cache *Cache) Get(ctx context.Context, nodeID storj.NodeID) (*pb.Node, error) {
 	if nodeID.IsZero() {
 		return nil, ErrEmptyNode
 	}
 
+	if cache.lru != nil {
+		if node := cache.lru.get(nodeID); node != nil {
+			return node, nil
+		}
+	}
+
 	b, err := cache.db.Get(nodeID.Bytes())
 	if err != nil {
 		if storage.ErrKeyNotFound.Has(err) {
@@ -73,6 +127,9 @@ func (cache *Cache) Get(ctx context.Context, nodeID storj.NodeID) (*pb.Node, err
 	if err := proto.Unmarshal(b, na); err != nil {
 		return nil, err
 	}
+	if cache.lru != nil {
+		cache.lru.put(nodeID, b)
+	}
 	return na, nil
 }
 
@@ -81,18 +138,31 @@ func (cache *Cache) GetAll(ctx context.Context, nodeIDs storj.NodeIDList) ([]*pb
 	if len(nodeIDs) == 0 {
 		return nil, OverlayError.New("no nodeIDs provided")
 	}
+	ns := make([]*pb.Node, len(nodeIDs))
+
+	// only the nodes missing from memory are read from the database
 	var ks storage.Keys
-	for _, v := range nodeIDs {
+	var missing []int
+	for i, v := range nodeIDs {
+		if cache.lru != nil {
+			if node := cache.lru.get(v); node != nil {
+				ns[i] = node
+				continue
+			}
+		}
 		ks = append(ks, v.Bytes())
+		missing = append(missing, i)
+	}
+	if len(ks) == 0 {
+		return ns, nil
 	}
+
 	vs, err := cache.db.GetAll(ks)
 	if err != nil {
 		return nil, err
 	}
-	var ns []*pb.Node
-	for _, v := range vs {
+	for k, v := range vs {
 		if v == nil {
-			ns = append(ns, nil)
 			continue
 		}
 		na := &pb.Node{}
@@ -100,7 +170,10 @@ func (cache *Cache) GetAll(ctx context.Context, nodeIDs storj.NodeIDList) ([]*pb
 		if err != nil {
 			return nil, OverlayError.New("could not unmarshal non-nil node: %v", err)
 		}
-		ns = append(ns, na)
+		ns[missing[k]] = na
+		if cache.lru != nil {
+			cache.lru.put(nodeIDs[missing[k]], v)
+		}
 	}
 	return ns, nil
 }
@@ -113,6 +186,20 @@ func (cache *Cache) Put(ctx context.Context, nodeID storj.NodeID, value pb.Node)
 		return nil
 	}
 
+	// updates without a version come from kademlia, they keep what the node reported
+	// when it last checked in, including a refused node's lack of capacity
+	if value.Version == "" {
+		existing, err := cache.Get(ctx, nodeID)
+		if err != nil && err != ErrNodeNotFound {
+			return err
+		}
+		if existing.GetVersion() != "" {
+			value.Version = existing.Version
+			value.UsedSpace = existing.UsedSpace
+			value.Restrictions = existing.Restrictions
+		}
+	}
+
 	// get existing node rep, or create a new statdb node with 0 rep
 	stats, err := cache.statDB.CreateEntryIfNotExists(ctx, nodeID)
 	if err != nil {
@@ -125,14 +212,90 @@ func (cache *Cache) Put(ctx context.Context, nodeID storj.NodeID, value pb.Node)
 		UptimeRatio:        stats.UptimeRatio,
 		UptimeSuccessCount: stats.UptimeSuccessCount,
 		UptimeCount:        stats.UptimeCount,
+		State:              pb.NodeState(stats.State),
+		Latency_90:         stats.Latency90,
+		SpeedKbps:          stats.SpeedKbps,
 	}
+	value.LatencyList = stats.LatencyList
 
 	data, err := proto.Marshal(&value)
 	if err != nil {
 		return err
 	}
 
-	return cache.db.Put(nodeID.Bytes(), data)
+	if err := cache.db.Put(nodeID.Bytes(), data); err != nil {
+		return err
+	}
+	if cache.lru != nil {
+		cache.lru.put(nodeID, data)
+	}
+	return nil
+}
+
+// UpdatePerformance records a latency and speed measurement of a node in statdb
+// and refreshes the cached node, so that node selection sees the new values.
+// Zero values are not measurements and are ignored.
+func (cache *Cache) UpdatePerformance(ctx context.Context, nodeID storj.NodeID, latency time.Duration, speedKbps int64) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = cache.statDB.UpdatePerformance(ctx, nodeID, latency, speedKbps)
+	if err != nil {
+		return err
+	}
+
+	node, err := cache.Get(ctx, nodeID)
+	if err != nil {
+		return err
+	}
+	return cache.Put(ctx, nodeID, *node)
+}
+
+// UpdateState moves a node to a new state in statdb and refreshes the cached node,
+// so that node selection sees the new state
+func (cache *Cache) UpdateState(ctx context.Context, nodeID storj.NodeID, state statdb.NodeState) (stats *statdb.NodeStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	stats, err = cache.statDB.UpdateState(ctx, nodeID, state)
+	if err != nil {
+		return nil, err
+	}
+	return stats, cache.refresh(ctx, nodeID)
+}
+
+// OverrideState moves a node to a state chosen by an operator in statdb, which is kept
+// until it is released, and refreshes the cached node
+func (cache *Cache) OverrideState(ctx context.Context, nodeID storj.NodeID, state statdb.NodeState) (stats *statdb.NodeStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	stats, err = cache.statDB.OverrideState(ctx, nodeID, state)
+	if err != nil {
+		return nil, err
+	}
+	return stats, cache.refresh(ctx, nodeID)
+}
+
+// ReleaseState lets a node's stats move it between states again
+func (cache *Cache) ReleaseState(ctx context.Context, nodeID storj.NodeID) (stats *statdb.NodeStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	stats, err = cache.statDB.ReleaseState(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	return stats, cache.refresh(ctx, nodeID)
+}
+
+// refresh puts a cached node again, which reloads its reputation from statdb.
+// Nodes that aren't cached are skipped, they pick up their reputation once they are.
+func (cache *Cache) refresh(ctx context.Context, nodeID storj.NodeID) error {
+	node, err := cache.Get(ctx, nodeID)
+	if err == ErrNodeNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return cache.Put(ctx, nodeID, *node)
 }
 
 // Delete will remove the node from the cache. Used when a node hard disconnects or fails
@@ -142,6 +305,10 @@ func (cache *Cache) Delete(ctx context.Context, id storj.NodeID) error {
 		return ErrEmptyNode
 	}
 
+	if cache.lru != nil {
+		cache.lru.remove(id)
+	}
+
 	err := cache.db.Delete(id.Bytes())
 	if err != nil {
 		return ErrDelete
@@ -150,11 +317,20 @@ func (cache *Cache) Delete(ctx context.Context, id storj.NodeID) error {
 	return nil
 }
 
+// contacted records that the satellite just talked to a cached node, if the cache
+// database keeps track of it for node selection
+func (cache *Cache) contacted(ctx context.Context, nodeID storj.NodeID) error {
+	db, ok := cache.db.(SelectionDB)
+	if !ok {
+		return nil
+	}
+	return db.UpdateLastContact(ctx, nodeID, time.Now())
+}
+
 // ConnFailure implements the Transport Observer `ConnFailure` function
 func (cache *Cache) ConnFailure(ctx context.Context, node *pb.Node, failureError error) {
-	// TODO: Kademlia paper specifies 5 unsuccessful PINGs before removing the node
-	// from our routing table, but this is the cache so maybe we want to treat
-	// it differently.
+	// the routing table evicts nodes after several consecutive failures, while the
+	// cache keeps them and lets statdb track how reliable they are
 	_, err := cache.statDB.UpdateUptime(ctx, node.Id, false)
 	if err != nil {
 		zap.L().Debug("error updating uptime for node in statDB", zap.Error(err))
@@ -167,6 +343,10 @@ func (cache *Cache) ConnSuccess(ctx context.Context, node *pb.Node) {
 	if err != nil {
 		zap.L().Debug("error updating uptime for node in statDB", zap.Error(err))
 	}
+	err = cache.contacted(ctx, node.Id)
+	if err != nil {
+		zap.L().Debug("error updating last contact of node", zap.Error(err))
+	}
 	_, err = cache.statDB.UpdateUptime(ctx, node.Id, true)
 	if err != nil {
 		zap.L().Debug("error updating statdDB with node connection info", zap.Error(err))
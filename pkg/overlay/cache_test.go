@@ -7,11 +7,15 @@ import (
 	"context"
 	"math/rand"
 	"testing"
+	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"storj.io/storj/internal/testcontext"
 	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/internal/teststorj"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/statdb"
@@ -99,6 +103,35 @@ func testCache(ctx context.Context, t *testing.T, store storage.KeyValueStore, s
 		}
 	}
 
+	{ // UpdateState
+		_, err := cache.OverrideState(ctx, valid1ID, statdb.StateSuspended)
+		assert.NoError(t, err)
+
+		// the cached node is refreshed, so node selection sees the new state
+		node, err := cache.Get(ctx, valid1ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, pb.NodeState(statdb.StateSuspended), node.GetReputation().GetState())
+		}
+
+		_, err = cache.ReleaseState(ctx, valid1ID)
+		assert.NoError(t, err)
+		_, err = cache.UpdateState(ctx, valid1ID, statdb.StateActive)
+		assert.NoError(t, err)
+
+		node, err = cache.Get(ctx, valid1ID)
+		if assert.NoError(t, err) {
+			assert.Equal(t, pb.NodeState(statdb.StateActive), node.GetReputation().GetState())
+		}
+
+		// uncached nodes are only updated in statdb
+		_, err = sdb.CreateEntryIfNotExists(ctx, missingID)
+		assert.NoError(t, err)
+		_, err = cache.UpdateState(ctx, missingID, statdb.StateActive)
+		assert.NoError(t, err)
+		_, err = cache.Get(ctx, missingID)
+		assert.True(t, err == overlay.ErrNodeNotFound)
+	}
+
 	{ // Delete
 		// Test standard delete
 		err := cache.Delete(ctx, valid1ID)
@@ -136,3 +169,64 @@ func TestCache_Masterdb(t *testing.T) {
 		testCache(ctx, t, db.OverlayCache(), db.StatDB())
 	})
 }
+
+func TestCacheLRU(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		store := db.OverlayCache()
+		cache := overlay.NewCacheWithLRU(store, db.StatDB(), 2, time.Hour)
+
+		node := func(name, address string) pb.Node {
+			return pb.Node{
+				Id:      teststorj.NodeIDFromString(name),
+				Type:    pb.NodeType_STORAGE,
+				Address: &pb.NodeAddress{Address: address},
+			}
+		}
+		// putStore changes a node behind the back of the cache
+		putStore := func(n pb.Node) {
+			data, err := proto.Marshal(&n)
+			require.NoError(t, err)
+			require.NoError(t, store.Put(n.Id.Bytes(), data))
+		}
+		address := func(name string) string {
+			n, err := cache.Get(ctx, teststorj.NodeIDFromString(name))
+			require.NoError(t, err)
+			return n.Address.Address
+		}
+
+		a := node("lru-a", "127.0.0.1:1")
+		require.NoError(t, cache.Put(ctx, a.Id, a))
+
+		// nodes are served from memory
+		putStore(node("lru-a", "127.0.0.1:2"))
+		assert.Equal(t, "127.0.0.1:1", address("lru-a"))
+
+		// address changes are pushed into memory
+		changed := node("lru-a", "127.0.0.1:3")
+		cache.ConnSuccess(ctx, &changed)
+		assert.Equal(t, "127.0.0.1:3", address("lru-a"))
+
+		missing := teststorj.NodeIDFromString("lru-missing")
+		nodes, err := cache.GetAll(ctx, storj.NodeIDList{a.Id, missing, a.Id})
+		require.NoError(t, err)
+		require.Len(t, nodes, 3)
+		assert.Equal(t, "127.0.0.1:3", nodes[0].Address.Address)
+		assert.Nil(t, nodes[1])
+		assert.Equal(t, "127.0.0.1:3", nodes[2].Address.Address)
+
+		// the least recently used node is evicted
+		for _, n := range []pb.Node{node("lru-b", "127.0.0.1:4"), node("lru-c", "127.0.0.1:5")} {
+			require.NoError(t, cache.Put(ctx, n.Id, n))
+		}
+		putStore(node("lru-a", "127.0.0.1:6"))
+		assert.Equal(t, "127.0.0.1:6", address("lru-a"))
+
+		// deleted nodes are gone from memory too
+		require.NoError(t, cache.Delete(ctx, teststorj.NodeIDFromString("lru-b")))
+		_, err = cache.Get(ctx, teststorj.NodeIDFromString("lru-b"))
+		assert.Equal(t, overlay.ErrNodeNotFound, err)
+	})
+}
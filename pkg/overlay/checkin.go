@@ -0,0 +1,139 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/gtank/cryptopasta"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/version"
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/identity"
+	"storj.io/storj/pkg/pb"
+)
+
+// maxCheckInSkew is how far a check-in timestamp may be from our clock
+const maxCheckInSkew = time.Hour
+
+// CheckIn updates the cache with the capacity, version and metadata a storage node reports.
+// Nodes running a version older than the configured minimum are kept in the cache
+// without any free capacity, so they aren't selected until they upgrade.
+func (server *Server) CheckIn(ctx context.Context, req *pb.CheckInRequest) (_ *pb.CheckInResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	data, err := verifyCheckIn(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	node := pb.Node{
+		Id:           data.NodeId,
+		Address:      data.Address,
+		Type:         pb.NodeType_STORAGE,
+		Restrictions: data.Capacity,
+		Metadata:     data.Metadata,
+		Version:      data.Version,
+		UsedSpace:    data.UsedSpace,
+	}
+
+	resp := &pb.CheckInResponse{MinimumVersion: server.selection.MinimumVersion}
+
+	var refused error
+	if server.selection.MinimumVersion != "" {
+		refused = checkVersion(data.Version, server.selection.MinimumVersion)
+		if refused != nil {
+			node.Restrictions = &pb.NodeRestrictions{}
+		}
+	}
+
+	if err := server.cache.Put(ctx, node.Id, node); err != nil {
+		server.log.Error("Error updating checked in node", zap.String("nodeID", node.Id.String()), zap.Error(err))
+		return nil, Error.Wrap(err)
+	}
+	if err := server.cache.contacted(ctx, node.Id); err != nil {
+		server.log.Error("Error updating last contact of checked in node", zap.String("nodeID", node.Id.String()), zap.Error(err))
+		return nil, Error.Wrap(err)
+	}
+
+	if refused != nil {
+		return nil, status.Error(codes.FailedPrecondition, refused.Error())
+	}
+	return resp, nil
+}
+
+// NewCheckInRequest signs data with the identity of the storage node checking in
+func NewCheckInRequest(id *identity.FullIdentity, data *pb.CheckInRequest_Data) (*pb.CheckInRequest, error) {
+	b, err := proto.Marshal(data)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	signature, err := auth.GenerateSignature(b, id)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return &pb.CheckInRequest{Data: b, Signature: signature}, nil
+}
+
+// verifyCheckIn checks that req was signed by the node sending it and is recent
+func verifyCheckIn(ctx context.Context, req *pb.CheckInRequest) (*pb.CheckInRequest_Data, error) {
+	peer, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	key, ok := peer.Leaf.PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, status.Errorf(codes.Unauthenticated, "unsupported public key type %T", peer.Leaf.PublicKey)
+	}
+	if !cryptopasta.Verify(req.GetData(), req.GetSignature(), key) {
+		return nil, status.Error(codes.PermissionDenied, "invalid check-in signature")
+	}
+
+	data := &pb.CheckInRequest_Data{}
+	if err := proto.Unmarshal(req.GetData(), data); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if data.NodeId != peer.ID {
+		return nil, status.Error(codes.PermissionDenied, "check-in is for a different node")
+	}
+
+	timestamp, err := ptypes.Timestamp(data.Timestamp)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if skew := time.Since(timestamp); skew > maxCheckInSkew || skew < -maxCheckInSkew {
+		return nil, status.Error(codes.InvalidArgument, "check-in timestamp is too far from the current time")
+	}
+
+	return data, nil
+}
+
+// acceptVersion returns whether node checked in with at least the minimum version, if set
+func acceptVersion(node *pb.Node, minimum string) bool {
+	return minimum == "" || checkVersion(node.GetVersion(), minimum) == nil
+}
+
+// checkVersion returns an error if nodeVersion is older than minimum
+func checkVersion(nodeVersion, minimum string) error {
+	min, err := version.Parse(minimum)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	v, err := version.Parse(nodeVersion)
+	if err != nil {
+		return Error.New("node version %q is below the minimum version %s", nodeVersion, min)
+	}
+	if v.Less(min) {
+		return Error.New("node version %s is below the minimum version %s", v, min)
+	}
+	return nil
+}
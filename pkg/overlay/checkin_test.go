@@ -0,0 +1,154 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestCheckIn(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		cache := overlay.NewCache(db.OverlayCache(), db.StatDB())
+		server := overlay.NewServer(zap.NewNop(), cache, overlay.NodeSelectionConfig{
+			MinimumVersion: "v0.2.0",
+		}, statdb.Config{})
+
+		node, err := testidentity.NewTestIdentity(ctx)
+		require.NoError(t, err)
+		other, err := testidentity.NewTestIdentity(ctx)
+		require.NoError(t, err)
+
+		nodeCtx := peerContext(ctx, node)
+		findOne := &pb.FindStorageNodesRequest{Opts: &pb.OverlayOptions{Amount: 1}}
+
+		data := func(version string) *pb.CheckInRequest_Data {
+			return &pb.CheckInRequest_Data{
+				NodeId:    node.ID,
+				Address:   &pb.NodeAddress{Address: "127.0.0.1:7777"},
+				Capacity:  &pb.NodeRestrictions{FreeBandwidth: 1000, FreeDisk: 2000},
+				UsedSpace: 500,
+				Version:   version,
+				Metadata:  &pb.NodeMetadata{Wallet: "0x1234"},
+				Timestamp: ptypes.TimestampNow(),
+			}
+		}
+
+		{ // a signed check-in updates the cache
+			req, err := overlay.NewCheckInRequest(node, data("v0.2.1"))
+			require.NoError(t, err)
+
+			resp, err := server.CheckIn(nodeCtx, req)
+			require.NoError(t, err)
+			assert.Equal(t, "v0.2.0", resp.MinimumVersion)
+
+			cached, err := cache.Get(ctx, node.ID)
+			require.NoError(t, err)
+			assert.Equal(t, pb.NodeType_STORAGE, cached.Type)
+			assert.Equal(t, "127.0.0.1:7777", cached.Address.Address)
+			assert.Equal(t, int64(2000), cached.Restrictions.FreeDisk)
+			assert.Equal(t, int64(500), cached.UsedSpace)
+			assert.Equal(t, "v0.2.1", cached.Version)
+			assert.Equal(t, "0x1234", cached.Metadata.Wallet)
+
+			result, err := server.FindStorageNodes(ctx, findOne)
+			require.NoError(t, err)
+			assert.Equal(t, node.ID, result.Nodes[0].Id)
+		}
+
+		{ // outdated nodes are refused and lose their capacity
+			req, err := overlay.NewCheckInRequest(node, data("v0.1.9"))
+			require.NoError(t, err)
+
+			_, err = server.CheckIn(nodeCtx, req)
+			assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+			cached, err := cache.Get(ctx, node.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "v0.1.9", cached.Version)
+			assert.Equal(t, int64(0), cached.Restrictions.GetFreeDisk())
+
+			// refreshing the node from kademlia doesn't restore its capacity
+			err = cache.Put(ctx, node.ID, pb.Node{
+				Id:           node.ID,
+				Type:         pb.NodeType_STORAGE,
+				Address:      &pb.NodeAddress{Address: "127.0.0.1:7777"},
+				Restrictions: &pb.NodeRestrictions{FreeBandwidth: 1000, FreeDisk: 2000},
+			})
+			require.NoError(t, err)
+
+			cached, err = cache.Get(ctx, node.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "v0.1.9", cached.Version)
+			assert.Equal(t, int64(0), cached.Restrictions.GetFreeDisk())
+			assert.Equal(t, int64(500), cached.UsedSpace)
+
+			// nor does it get selected when no capacity is asked for
+			_, err = server.FindStorageNodes(ctx, findOne)
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		}
+
+		{ // check-ins signed by another node are rejected
+			req, err := overlay.NewCheckInRequest(other, data("v0.2.1"))
+			require.NoError(t, err)
+
+			_, err = server.CheckIn(nodeCtx, req)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		}
+
+		{ // check-ins for another node are rejected
+			req, err := overlay.NewCheckInRequest(other, data("v0.2.1"))
+			require.NoError(t, err)
+
+			_, err = server.CheckIn(peerContext(ctx, other), req)
+			assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		}
+
+		{ // stale check-ins are rejected
+			stale := data("v0.2.1")
+			stale.Timestamp, err = ptypes.TimestampProto(time.Now().Add(-2 * time.Hour))
+			require.NoError(t, err)
+			req, err := overlay.NewCheckInRequest(node, stale)
+			require.NoError(t, err)
+
+			_, err = server.CheckIn(nodeCtx, req)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
+
+// peerContext returns a context as seen by a gRPC server called by id
+func peerContext(ctx context.Context, id *provider.FullIdentity) context.Context {
+	return peer.NewContext(ctx, &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{id.Leaf, id.CA},
+			},
+		},
+	})
+}
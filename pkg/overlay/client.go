@@ -5,9 +5,14 @@ package overlay
 
 import (
 	"context"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/zeebo/errs"
+	"google.golang.org/grpc"
 
+	"storj.io/storj/pkg/auth/grpcauth"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/storj"
@@ -30,6 +35,7 @@ type Client interface {
 	Choose(ctx context.Context, op Options) ([]*pb.Node, error)
 	Lookup(ctx context.Context, nodeID storj.NodeID) (*pb.Node, error)
 	BulkLookup(ctx context.Context, nodeIDs storj.NodeIDList) ([]*pb.Node, error)
+	ReportPerformance(ctx context.Context, measurements []Measurement) error
 }
 
 // client is the overlay concrete implementation of the client interface
@@ -47,12 +53,38 @@ type Options struct {
 	AuditSuccess float64
 	AuditCount   int64
 	Excluded     storj.NodeIDList
+	// RequiredRegions and ExcludedRegions are countries or regions, e.g. "US" or "US-CA"
+	RequiredRegions []string
+	ExcludedRegions []string
+	// MaxLatency and MinSpeedKbps exclude nodes measured to be slower
+	MaxLatency   time.Duration
+	MinSpeedKbps int64
+	// RankBySpeed prefers the fastest nodes
+	RankBySpeed bool
+}
+
+// Measurement is the latency and speed observed while talking to a node.
+// Zero values mean that they weren't measured.
+type Measurement struct {
+	NodeID    storj.NodeID
+	Latency   time.Duration
+	SpeedKbps int64
 }
 
 // NewClient returns a new intialized Overlay Client
 func NewClient(identity *provider.FullIdentity, address string) (Client, error) {
+	return newClient(identity, address)
+}
+
+// NewClientWithAPIKey is like NewClient, but sends APIKey with every request,
+// so that the client can report performance
+func NewClientWithAPIKey(identity *provider.FullIdentity, address string, APIKey string) (Client, error) {
+	return newClient(identity, address, grpc.WithUnaryInterceptor(grpcauth.NewAPIKeyInjector(APIKey)))
+}
+
+func newClient(identity *provider.FullIdentity, address string, opts ...grpc.DialOption) (Client, error) {
 	tc := transport.NewClient(identity, &Cache{}) // add overlay to transport client as observer
-	conn, err := tc.DialAddress(context.Background(), address)
+	conn, err := tc.DialAddress(context.Background(), address, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -72,12 +104,21 @@ var _ Client = (*client)(nil)
 func (client *client) Choose(ctx context.Context, op Options) ([]*pb.Node, error) {
 	var exIDs storj.NodeIDList
 	exIDs = append(exIDs, op.Excluded...)
+	var maxLatency *duration.Duration
+	if op.MaxLatency > 0 {
+		maxLatency = ptypes.DurationProto(op.MaxLatency)
+	}
 	// TODO(coyle): We will also need to communicate with the reputation service here
 	resp, err := client.conn.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
 		Opts: &pb.OverlayOptions{
-			Amount:        int64(op.Amount),
-			Restrictions:  &pb.NodeRestrictions{FreeDisk: op.Space, FreeBandwidth: op.Bandwidth},
-			ExcludedNodes: exIDs,
+			Amount:          int64(op.Amount),
+			Restrictions:    &pb.NodeRestrictions{FreeDisk: op.Space, FreeBandwidth: op.Bandwidth},
+			ExcludedNodes:   exIDs,
+			RequiredRegions: op.RequiredRegions,
+			ExcludedRegions: op.ExcludedRegions,
+			MaxLatency:      maxLatency,
+			MinSpeedKbps:    op.MinSpeedKbps,
+			RankBySpeed:     op.RankBySpeed,
 		},
 	})
 	if err != nil {
@@ -87,6 +128,56 @@ func (client *client) Choose(ctx context.Context, op Options) ([]*pb.Node, error
 	return resp.GetNodes(), nil
 }
 
+// placementClient is an overlay client that pins every upload to regions
+type placementClient struct {
+	Client
+	required []string
+	excluded []string
+}
+
+// NewPlacementClient returns a client that chooses nodes in the required
+// regions and outside of the excluded ones, unless Options says otherwise
+func NewPlacementClient(client Client, required, excluded []string) Client {
+	return &placementClient{Client: client, required: required, excluded: excluded}
+}
+
+// Choose returns nodes based on Options and the placement regions
+func (client *placementClient) Choose(ctx context.Context, op Options) ([]*pb.Node, error) {
+	if len(op.RequiredRegions) == 0 {
+		op.RequiredRegions = client.required
+	}
+	if len(op.ExcludedRegions) == 0 {
+		op.ExcludedRegions = client.excluded
+	}
+	return client.Client.Choose(ctx, op)
+}
+
+// performanceClient is an overlay client that prefers fast nodes for every upload
+type performanceClient struct {
+	Client
+	maxLatency   time.Duration
+	minSpeedKbps int64
+	rankBySpeed  bool
+}
+
+// NewPerformanceClient returns a client that chooses nodes measured to be
+// at least as fast as the limits, unless Options says otherwise
+func NewPerformanceClient(client Client, maxLatency time.Duration, minSpeedKbps int64, rankBySpeed bool) Client {
+	return &performanceClient{Client: client, maxLatency: maxLatency, minSpeedKbps: minSpeedKbps, rankBySpeed: rankBySpeed}
+}
+
+// Choose returns nodes based on Options and the performance limits
+func (client *performanceClient) Choose(ctx context.Context, op Options) ([]*pb.Node, error) {
+	if op.MaxLatency == 0 {
+		op.MaxLatency = client.maxLatency
+	}
+	if op.MinSpeedKbps == 0 {
+		op.MinSpeedKbps = client.minSpeedKbps
+	}
+	op.RankBySpeed = op.RankBySpeed || client.rankBySpeed
+	return client.Client.Choose(ctx, op)
+}
+
 // Lookup provides a Node with the given ID
 func (client *client) Lookup(ctx context.Context, nodeID storj.NodeID) (*pb.Node, error) {
 	resp, err := client.conn.Lookup(ctx, &pb.LookupRequest{NodeId: nodeID})
@@ -115,3 +206,17 @@ func (client *client) BulkLookup(ctx context.Context, nodeIDs storj.NodeIDList)
 	}
 	return nodes, nil
 }
+
+// ReportPerformance sends the latencies and speeds observed while talking to nodes
+func (client *client) ReportPerformance(ctx context.Context, measurements []Measurement) error {
+	req := &pb.PerformanceReport{}
+	for _, m := range measurements {
+		req.Measurements = append(req.Measurements, &pb.PerformanceReport_Measurement{
+			NodeId:    m.NodeID,
+			LatencyMs: int64(m.Latency / time.Millisecond),
+			SpeedKbps: m.SpeedKbps,
+		})
+	}
+	_, err := client.conn.ReportPerformance(ctx, req)
+	return ClientError.Wrap(err)
+}
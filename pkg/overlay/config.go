@@ -17,7 +17,6 @@ import (
 	"storj.io/storj/pkg/statdb"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/utils"
-	"storj.io/storj/storage"
 )
 
 var (
@@ -30,6 +29,8 @@ var (
 // Overlay cache responsibility.
 type Config struct {
 	RefreshInterval time.Duration `help:"the interval at which the cache refreshes itself in seconds" default:"1s"`
+	LRUSize         int           `help:"the number of recently used nodes kept in memory in front of the database, 0 to disable" default:"10000"`
+	LRUExpiration   time.Duration `help:"how long nodes are kept in memory before they are read from the database again" default:"5m"`
 	Node            NodeSelectionConfig
 }
 
@@ -46,6 +47,15 @@ type NodeSelectionConfig struct {
 	UptimeCount       int64   `help:"the number of times a node's uptime has been checked" default:"0"`
 	AuditSuccessRatio float64 `help:"a node's ratio of successful audits" default:"0"`
 	AuditCount        int64   `help:"the number of times a node has been audited" default:"0"`
+
+	NewNodePercentage float64       `help:"the fraction of each upload's nodes that are picked from unvetted nodes" default:"0.05"`
+	OnlineWindow      time.Duration `help:"nodes that haven't been contacted within this window aren't selected, 0 to disable (needs a database cache)" default:"0"`
+
+	MinimumVersion string `help:"storage nodes checking in with an older version are refused and not selected, empty to accept any version" default:""`
+
+	GeoIPDatabase string `help:"path to a GeoIP file tagging networks with regions, one per line (e.g. 203.0.113.0/24,US-CA), empty to disable region constraints" default:""`
+
+	LocalNetwork bool `help:"count nodes on loopback addresses with different ports as different subnets, only for local test networks" default:"false"`
 }
 
 // CtxKey used for assigning cache and server
@@ -64,22 +74,22 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (
 
 	sdb, ok := ctx.Value("masterdb").(interface {
 		StatDB() statdb.DB
-		OverlayCache() storage.KeyValueStore
+		OverlayCache() DB
 	})
 	if !ok {
 		return Error.Wrap(errs.New("unable to get master db instance"))
 	}
 
-	cache := NewCache(sdb.OverlayCache(), sdb.StatDB())
+	cache := NewCacheWithLRU(sdb.OverlayCache(), sdb.StatDB(), c.LRUSize, c.LRUExpiration)
 
-	ns := &pb.NodeStats{
-		UptimeCount:       c.Node.UptimeCount,
-		UptimeRatio:       c.Node.UptimeRatio,
-		AuditSuccessRatio: c.Node.AuditSuccessRatio,
-		AuditCount:        c.Node.AuditCount,
+	srv := NewServer(zap.L(), cache, c.Node, statdb.LoadConfigFromContext(ctx))
+	if c.Node.GeoIPDatabase != "" {
+		geoip, err := LoadGeoIP(c.Node.GeoIPDatabase)
+		if err != nil {
+			return err
+		}
+		srv.UseGeoIP(geoip)
 	}
-
-	srv := NewServer(zap.L(), cache, ns)
 	pb.RegisterOverlayServer(server.GRPC(), srv)
 
 	ctx2 := context.WithValue(ctx, ctxKeyOverlay, cache)
@@ -0,0 +1,99 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strings"
+)
+
+// GeoIP tags IP addresses with the country or region they are in
+type GeoIP struct {
+	// networks are sorted from the most to the least specific
+	networks []geoNetwork
+}
+
+type geoNetwork struct {
+	network *net.IPNet
+	region  string
+}
+
+// LoadGeoIP reads a GeoIP database from path. Each line of the file holds a
+// network in CIDR notation and its country or region, separated by a comma,
+// e.g. "203.0.113.0/24,US-CA". Empty lines and lines starting with # are
+// skipped.
+func LoadGeoIP(path string) (_ *GeoIP, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() { _ = f.Close() }()
+	return ParseGeoIP(f)
+}
+
+// ParseGeoIP reads a GeoIP database in the format described by LoadGeoIP
+func ParseGeoIP(r io.Reader) (*GeoIP, error) {
+	geoip := &GeoIP{}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, ",")
+		if len(fields) != 2 {
+			return nil, Error.New("geoip line %d: expected network and region", line)
+		}
+		_, network, err := net.ParseCIDR(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return nil, Error.New("geoip line %d: %v", line, err)
+		}
+		region := strings.ToUpper(strings.TrimSpace(fields[1]))
+		if region == "" {
+			return nil, Error.New("geoip line %d: missing region", line)
+		}
+		geoip.networks = append(geoip.networks, geoNetwork{network: network, region: region})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	sort.SliceStable(geoip.networks, func(i, k int) bool {
+		a, _ := geoip.networks[i].network.Mask.Size()
+		b, _ := geoip.networks[k].network.Mask.Size()
+		return a > b
+	})
+	return geoip, nil
+}
+
+// Region returns the country or region of the node at address, or an empty
+// string if it is unknown. Host names are not resolved.
+func (geoip *GeoIP) Region(address string) string {
+	if geoip == nil {
+		return ""
+	}
+	ip := net.ParseIP(hostOf(address))
+	if ip == nil {
+		return ""
+	}
+	for _, n := range geoip.networks {
+		if n.network.Contains(ip) {
+			return n.region
+		}
+	}
+	return ""
+}
+
+// inRegion checks whether region is the country or region want or lies
+// within it, so that "US-CA" is in "US"
+func inRegion(region, want string) bool {
+	want = strings.ToUpper(want)
+	return region == want || strings.HasPrefix(region, want+"-")
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// nodeLRU keeps the most recently used nodes in memory, so that looking them
+// up doesn't hit the database every time. Entries expire after ttl, so that
+// changes made to the database by others are eventually seen.
+type nodeLRU struct {
+	size int
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // of *lruEntry, most recently used first
+	entries map[storj.NodeID]*list.Element
+}
+
+type lruEntry struct {
+	id      storj.NodeID
+	data    []byte // the marshaled node, so that callers can't change it
+	expires time.Time
+}
+
+// newNodeLRU returns an LRU holding up to size nodes for ttl each. A
+// non-positive ttl never expires nodes.
+func newNodeLRU(size int, ttl time.Duration) *nodeLRU {
+	return &nodeLRU{
+		size:    size,
+		ttl:     ttl,
+		now:     time.Now,
+		order:   list.New(),
+		entries: make(map[storj.NodeID]*list.Element),
+	}
+}
+
+// get returns the node with id, or nil if it isn't cached
+func (lru *nodeLRU) get(id storj.NodeID) *pb.Node {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+
+	element, ok := lru.entries[id]
+	if !ok {
+		return nil
+	}
+	entry := element.Value.(*lruEntry)
+	if lru.ttl > 0 && lru.now().After(entry.expires) {
+		lru.removeElement(element)
+		return nil
+	}
+
+	lru.order.MoveToFront(element)
+	node := &pb.Node{}
+	if err := proto.Unmarshal(entry.data, node); err != nil {
+		lru.removeElement(element)
+		return nil
+	}
+	return node
+}
+
+// put caches the marshaled node data under id, evicting the least recently
+// used nodes beyond the size of the LRU
+func (lru *nodeLRU) put(id storj.NodeID, data []byte) {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+
+	entry := &lruEntry{
+		id:      id,
+		data:    data,
+		expires: lru.now().Add(lru.ttl),
+	}
+	if element, ok := lru.entries[id]; ok {
+		element.Value = entry
+		lru.order.MoveToFront(element)
+		return
+	}
+
+	lru.entries[id] = lru.order.PushFront(entry)
+	for lru.order.Len() > lru.size {
+		lru.removeElement(lru.order.Back())
+	}
+}
+
+// remove drops the node with id from the LRU
+func (lru *nodeLRU) remove(id storj.NodeID) {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+
+	if element, ok := lru.entries[id]; ok {
+		lru.removeElement(element)
+	}
+}
+
+func (lru *nodeLRU) removeElement(element *list.Element) {
+	lru.order.Remove(element)
+	delete(lru.entries, element.Value.(*lruEntry).id)
+}
@@ -0,0 +1,52 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestNodeLRU(t *testing.T) {
+	now := time.Now()
+	lru := newNodeLRU(2, time.Minute)
+	lru.now = func() time.Time { return now }
+
+	a, b, c := storj.NodeID{1}, storj.NodeID{2}, storj.NodeID{3}
+	put := func(id storj.NodeID) {
+		data, err := proto.Marshal(&pb.Node{Id: id})
+		require.NoError(t, err)
+		lru.put(id, data)
+	}
+	put(a)
+	put(b)
+
+	// every caller gets its own node
+	node := lru.get(a)
+	if assert.NotNil(t, node) {
+		node.Address = &pb.NodeAddress{Address: "changed"}
+		assert.Nil(t, lru.get(a).Address)
+	}
+
+	// a was used more recently than b
+	put(c)
+	assert.NotNil(t, lru.get(a))
+	assert.Nil(t, lru.get(b))
+	assert.NotNil(t, lru.get(c))
+
+	lru.remove(c)
+	assert.Nil(t, lru.get(c))
+
+	// nodes expire
+	now = now.Add(2 * time.Minute)
+	assert.Nil(t, lru.get(a))
+	assert.Empty(t, lru.entries)
+}
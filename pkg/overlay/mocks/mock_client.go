@@ -76,3 +76,15 @@ func (m *MockClient) BulkLookup(ctx context.Context, nodeIDs storj.NodeIDList) (
 func (mr *MockClientMockRecorder) BulkLookup(ctx, nodeIDs interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkLookup", reflect.TypeOf((*MockClient)(nil).BulkLookup), ctx, nodeIDs)
 }
+
+// ReportPerformance mocks base method
+func (m *MockClient) ReportPerformance(ctx context.Context, measurements []x.Measurement) error {
+	ret := m.ctrl.Call(m, "ReportPerformance", ctx, measurements)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReportPerformance indicates an expected call of ReportPerformance
+func (mr *MockClientMockRecorder) ReportPerformance(ctx, measurements interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportPerformance", reflect.TypeOf((*MockClient)(nil).ReportPerformance), ctx, measurements)
+}
@@ -69,6 +69,16 @@ func (mo *Overlay) BulkLookup(ctx context.Context, reqs *pb.LookupRequests) (
 	return &pb.LookupResponses{LookupResponse: responses}, nil
 }
 
+// CheckIn accepts every check-in without updating the static nodes
+func (mo *Overlay) CheckIn(ctx context.Context, req *pb.CheckInRequest) (*pb.CheckInResponse, error) {
+	return &pb.CheckInResponse{}, nil
+}
+
+// ReportPerformance ignores the measurements
+func (mo *Overlay) ReportPerformance(ctx context.Context, req *pb.PerformanceReport) (*pb.PerformanceReportResponse, error) {
+	return &pb.PerformanceReportResponse{}, nil
+}
+
 // Config specifies static nodes for mock overlay
 type Config struct {
 	Nodes string `help:"a comma-separated list of <node-id>:<ip>:<port>" default:""`
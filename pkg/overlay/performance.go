@@ -0,0 +1,128 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/duration"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// rankOversampling is how many more vetted nodes are sampled than needed when
+// ranking by speed, so that there are faster nodes to pick from
+const rankOversampling = 3
+
+// reportWindow is how long the measurements reported by every uplink are
+// counted before the counts are reset
+const reportWindow = time.Minute
+
+// performance filters and ranks nodes by the latency and speed measured for
+// them. Nodes without measurements are never filtered out, so that new nodes
+// get a chance to be measured.
+type performance struct {
+	maxLatency   int64 // milliseconds
+	minSpeedKbps int64
+	rankBySpeed  bool
+}
+
+func newPerformance(opts *pb.OverlayOptions) *performance {
+	return &performance{
+		maxLatency:   durationMillis(opts.GetMaxLatency()),
+		minSpeedKbps: opts.GetMinSpeedKbps(),
+		rankBySpeed:  opts.GetRankBySpeed(),
+	}
+}
+
+// accept checks whether the measured latency and speed of a node are good enough
+func (p *performance) accept(reputation *pb.NodeStats) bool {
+	latency := reputation.GetLatency_90()
+	if p.maxLatency > 0 && latency > 0 && latency > p.maxLatency {
+		return false
+	}
+	speed := reputation.GetSpeedKbps()
+	if p.minSpeedKbps > 0 && speed > 0 && speed < p.minSpeedKbps {
+		return false
+	}
+	return true
+}
+
+// sample returns how many candidates to sample for count nodes
+func (p *performance) sample(count int) int {
+	if p.rankBySpeed {
+		return count * rankOversampling
+	}
+	return count
+}
+
+// rank sorts nodes from the fastest to the slowest if ranking was asked for.
+// Faster transfers come first, then lower latencies; unmeasured nodes go last.
+func (p *performance) rank(nodes []*pb.Node) {
+	if !p.rankBySpeed {
+		return
+	}
+	sort.SliceStable(nodes, func(i, k int) bool {
+		a, b := nodes[i].GetReputation(), nodes[k].GetReputation()
+		if a.GetSpeedKbps() != b.GetSpeedKbps() {
+			return a.GetSpeedKbps() > b.GetSpeedKbps()
+		}
+		return lessLatency(a.GetLatency_90(), b.GetLatency_90())
+	})
+}
+
+// lessLatency orders latencies ascending with unmeasured latencies last
+func lessLatency(a, b int64) bool {
+	switch {
+	case a == b:
+		return false
+	case a == 0:
+		return false
+	case b == 0:
+		return true
+	}
+	return a < b
+}
+
+// durationMillis converts d to milliseconds, 0 if it is not set
+func durationMillis(d *duration.Duration) int64 {
+	if d == nil {
+		return 0
+	}
+	return int64(time.Duration(d.Seconds)*time.Second/time.Millisecond) + int64(d.Nanos)/int64(time.Millisecond)
+}
+
+// reportLimiter bounds the measurements every uplink may report per window
+// to maxMeasurements, and to one measurement of each node, so that a single
+// uplink can't outweigh the others measuring a node
+type reportLimiter struct {
+	mu       sync.Mutex
+	start    time.Time
+	reported map[storj.NodeID]map[storj.NodeID]bool
+}
+
+// allow returns whether reporter may report a measurement of nodeID at now
+func (limiter *reportLimiter) allow(reporter, nodeID storj.NodeID, now time.Time) bool {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	if limiter.reported == nil || now.Sub(limiter.start) >= reportWindow {
+		limiter.start = now
+		limiter.reported = make(map[storj.NodeID]map[storj.NodeID]bool)
+	}
+
+	nodes, ok := limiter.reported[reporter]
+	if !ok {
+		nodes = make(map[storj.NodeID]bool)
+		limiter.reported[reporter] = nodes
+	}
+	if nodes[nodeID] || len(nodes) >= maxMeasurements {
+		return false
+	}
+	nodes[nodeID] = true
+	return true
+}
@@ -0,0 +1,178 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/teststore"
+)
+
+func TestFindStorageNodesPerformance(t *testing.T) {
+	t.Run("scan", func(t *testing.T) {
+		testFindStorageNodesPerformance(t, teststore.New())
+	})
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		testFindStorageNodesPerformance(t, db.OverlayCache())
+	})
+}
+
+func testFindStorageNodesPerformance(t *testing.T, store storage.KeyValueStore) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	// the database may hold nodes of other tests
+	var others storj.NodeIDList
+	keys, err := store.List(nil, 0)
+	require.NoError(t, err)
+	for _, key := range keys {
+		id, err := storj.NodeIDFromBytes(key)
+		require.NoError(t, err)
+		others = append(others, id)
+	}
+
+	for _, n := range []struct {
+		name    string
+		address string
+		latency int64
+		speed   int64
+	}{
+		{"fast", "10.0.1.1:7777", 10, 5000},
+		{"medium", "10.0.2.1:7777", 50, 1000},
+		{"slow", "10.0.3.1:7777", 500, 100},
+		{"unmeasured", "10.0.4.1:7777", 0, 0},
+	} {
+		node := &pb.Node{
+			Id:         teststorj.NodeIDFromString(n.name),
+			Type:       pb.NodeType_STORAGE,
+			Address:    &pb.NodeAddress{Address: n.address},
+			Reputation: &pb.NodeStats{Latency_90: n.latency, SpeedKbps: n.speed},
+		}
+		data, err := proto.Marshal(node)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(node.Id.Bytes(), data))
+	}
+
+	server := overlay.NewServer(zap.NewNop(), overlay.NewCache(store, nil), overlay.NodeSelectionConfig{}, statdb.Config{})
+
+	find := func(opts *pb.OverlayOptions) ([]string, error) {
+		opts.ExcludedNodes = others
+		resp, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{Opts: opts})
+		var addresses []string
+		for _, node := range resp.GetNodes() {
+			addresses = append(addresses, node.Address.Address)
+		}
+		return addresses, err
+	}
+
+	{ // unmeasured nodes pass the latency limit
+		nodes, err := find(&pb.OverlayOptions{Amount: 3, MaxLatency: ptypes.DurationProto(100 * time.Millisecond)})
+		require.NoError(t, err)
+		assert.NotContains(t, nodes, "10.0.3.1:7777")
+
+		_, err = find(&pb.OverlayOptions{Amount: 4, MaxLatency: ptypes.DurationProto(100 * time.Millisecond)})
+		assert.Error(t, err)
+	}
+
+	{ // unmeasured nodes pass the speed limit
+		nodes, err := find(&pb.OverlayOptions{Amount: 2, MinSpeedKbps: 2000})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"10.0.1.1:7777", "10.0.4.1:7777"}, nodes)
+	}
+
+	{ // ranking picks the fastest nodes
+		nodes, err := find(&pb.OverlayOptions{Amount: 2, RankBySpeed: true})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.0.1.1:7777", "10.0.2.1:7777"}, nodes)
+	}
+}
+
+func TestReportPerformance(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		cache := overlay.NewCache(db.OverlayCache(), db.StatDB())
+		server := overlay.NewServer(zap.NewNop(), cache, overlay.NodeSelectionConfig{}, statdb.Config{})
+
+		known := teststorj.NodeIDFromString("measured")
+		unknown := teststorj.NodeIDFromString("unknown")
+		require.NoError(t, cache.Put(ctx, known, pb.Node{
+			Id:      known,
+			Type:    pb.NodeType_STORAGE,
+			Address: &pb.NodeAddress{Address: "127.0.0.1:7777"},
+		}))
+
+		uplink, err := testidentity.NewTestIdentity(ctx)
+		require.NoError(t, err)
+		other, err := testidentity.NewTestIdentity(ctx)
+		require.NoError(t, err)
+		// the API key of the tests is empty
+		uplinkCtx := auth.WithAPIKey(peerContext(ctx, uplink), nil)
+
+		report := &pb.PerformanceReport{
+			Measurements: []*pb.PerformanceReport_Measurement{
+				{NodeId: known, LatencyMs: 40, SpeedKbps: 800},
+				{NodeId: unknown, LatencyMs: 40},
+			},
+		}
+
+		{ // reports need the API key and an identity
+			_, err := server.ReportPerformance(peerContext(ctx, uplink), report)
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			_, err = server.ReportPerformance(auth.WithAPIKey(peerContext(ctx, uplink), []byte("wrong key")), report)
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			_, err = server.ReportPerformance(auth.WithAPIKey(ctx, nil), report)
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		}
+
+		_, err = server.ReportPerformance(uplinkCtx, report)
+		require.NoError(t, err)
+
+		node, err := cache.Get(ctx, known)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{40}, node.LatencyList)
+		assert.EqualValues(t, 40, node.Reputation.Latency_90)
+		assert.EqualValues(t, 800, node.Reputation.SpeedKbps)
+
+		// an uplink's further measurements of the node are ignored for a while,
+		// other uplinks are still heard
+		_, err = server.ReportPerformance(uplinkCtx, &pb.PerformanceReport{
+			Measurements: []*pb.PerformanceReport_Measurement{{NodeId: known, SpeedKbps: 100}},
+		})
+		require.NoError(t, err)
+		_, err = server.ReportPerformance(auth.WithAPIKey(peerContext(ctx, other), nil), &pb.PerformanceReport{
+			Measurements: []*pb.PerformanceReport_Measurement{{NodeId: known, SpeedKbps: 1600}},
+		})
+		require.NoError(t, err)
+
+		node, err = cache.Get(ctx, known)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1000, node.Reputation.SpeedKbps)
+
+		// reports don't add nodes
+		_, err = db.StatDB().Get(ctx, unknown)
+		assert.Error(t, err)
+	})
+}
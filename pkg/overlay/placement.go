@@ -0,0 +1,129 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"net"
+
+	"storj.io/storj/pkg/pb"
+)
+
+// placement decides which nodes may hold pieces of the same segment: at most
+// one node per subnet, in the requested regions only
+type placement struct {
+	geoip    *GeoIP
+	required []string
+	excluded []string
+
+	// localNetwork counts loopback addresses with different ports as different
+	// subnets, so that the nodes of a local test network can all be selected
+	localNetwork bool
+	lookupIP     func(ctx context.Context, host string) ([]net.IPAddr, error)
+
+	usedSubnets map[string]bool
+}
+
+func newPlacement(geoip *GeoIP, opts *pb.OverlayOptions, localNetwork bool) *placement {
+	return &placement{
+		geoip:        geoip,
+		required:     opts.GetRequiredRegions(),
+		excluded:     opts.GetExcludedRegions(),
+		localNetwork: localNetwork,
+		lookupIP:     net.DefaultResolver.LookupIPAddr,
+		usedSubnets:  make(map[string]bool),
+	}
+}
+
+// hasRegions is whether the placement is constrained to regions
+func (p *placement) hasRegions() bool {
+	return len(p.required) > 0 || len(p.excluded) > 0
+}
+
+// accept checks whether node may be added to the segment and claims its
+// subnet if so
+func (p *placement) accept(ctx context.Context, node *pb.Node) bool {
+	address := node.Address.GetAddress()
+	ip := p.resolve(ctx, address)
+
+	if p.hasRegions() {
+		region := ""
+		if ip != nil {
+			region = p.geoip.Region(ip.String())
+		}
+		for _, excluded := range p.excluded {
+			if inRegion(region, excluded) {
+				return false
+			}
+		}
+		if len(p.required) > 0 {
+			found := false
+			for _, required := range p.required {
+				if inRegion(region, required) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+
+	subnet := p.subnetOf(address, ip)
+	if p.usedSubnets[subnet] {
+		return false
+	}
+	p.usedSubnets[subnet] = true
+	return true
+}
+
+// claim marks the subnet of a node already holding a piece of the segment as used
+func (p *placement) claim(ctx context.Context, node *pb.Node) {
+	if node == nil {
+		return
+	}
+	address := node.Address.GetAddress()
+	p.usedSubnets[p.subnetOf(address, p.resolve(ctx, address))] = true
+}
+
+// resolve returns the IP of the host of address, looking up hostnames, so that
+// nodes advertising different hostnames for the same subnet count as one subnet.
+// It returns nil when the hostname doesn't resolve.
+func (p *placement) resolve(ctx context.Context, address string) net.IP {
+	host := hostOf(address)
+	if ip := net.ParseIP(host); ip != nil {
+		return ip
+	}
+	addrs, err := p.lookupIP(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return nil
+	}
+	return addrs[0].IP
+}
+
+// subnetOf returns the /24 of an IPv4 or the /64 of an IPv6 address, or the
+// host of an address without an IP. Loopback addresses keep their port on a
+// local network.
+func (p *placement) subnetOf(address string, ip net.IP) string {
+	switch {
+	case ip == nil:
+		return hostOf(address)
+	case p.localNetwork && ip.IsLoopback():
+		return address
+	case ip.To4() != nil:
+		return ip.Mask(net.CIDRMask(24, 32)).String()
+	default:
+		return ip.Mask(net.CIDRMask(64, 128)).String()
+	}
+}
+
+// hostOf strips the port from address, if it has one
+func hostOf(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return host
+}
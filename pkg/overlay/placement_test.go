@@ -0,0 +1,209 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+	"storj.io/storj/storage"
+	"storj.io/storj/storage/teststore"
+)
+
+const testGeoIP = `
+# network, region
+10.0.0.0/8,US
+10.0.1.0/24,US-CA
+10.0.2.0/24,us-ny
+192.0.2.0/24,DE
+2001:db8::/32,DE
+`
+
+func TestGeoIP(t *testing.T) {
+	geoip, err := overlay.ParseGeoIP(strings.NewReader(testGeoIP))
+	require.NoError(t, err)
+
+	for address, region := range map[string]string{
+		"10.0.1.7:7777":       "US-CA",
+		"10.0.2.7:7777":       "US-NY",
+		"10.0.3.7:7777":       "US",
+		"192.0.2.1:7777":      "DE",
+		"[2001:db8::1]:7777":  "DE",
+		"198.51.100.1:7777":   "",
+		"node.example.com:80": "",
+	} {
+		assert.Equal(t, region, geoip.Region(address), address)
+	}
+
+	_, err = overlay.ParseGeoIP(strings.NewReader("10.0.0.0/33,US"))
+	assert.Error(t, err)
+	_, err = overlay.ParseGeoIP(strings.NewReader("10.0.0.0/8"))
+	assert.Error(t, err)
+}
+
+func TestFindStorageNodesPlacement(t *testing.T) {
+	t.Run("scan", func(t *testing.T) {
+		testFindStorageNodesPlacement(t, teststore.New())
+	})
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		testFindStorageNodesPlacement(t, db.OverlayCache())
+	})
+}
+
+func testFindStorageNodesPlacement(t *testing.T, store storage.KeyValueStore) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	// the database may hold nodes of other tests, their subnets would count as used
+	keys, err := store.List(nil, 0)
+	require.NoError(t, err)
+	for _, key := range keys {
+		require.NoError(t, store.Delete(key))
+	}
+
+	for name, address := range map[string]string{
+		// four nodes in one subnet
+		"a1": "10.0.1.1:7777", "a2": "10.0.1.2:7777", "a3": "10.0.1.3:7777", "a4": "10.0.1.4:7777",
+		// one host behind two ports
+		"b1": "10.0.2.1:7777", "b2": "10.0.2.1:7778",
+		"c1": "192.0.2.1:7777",
+		// one IPv6 /64
+		"d1": "[2001:db8::1]:7777", "d2": "[2001:db8::2]:7777",
+		"e1": "198.51.100.1:7777",
+	} {
+		node := &pb.Node{
+			Id:         teststorj.NodeIDFromString(name),
+			Type:       pb.NodeType_STORAGE,
+			Address:    &pb.NodeAddress{Address: address},
+			Reputation: &pb.NodeStats{},
+		}
+		data, err := proto.Marshal(node)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(node.Id.Bytes(), data))
+	}
+
+	geoip, err := overlay.ParseGeoIP(strings.NewReader(testGeoIP))
+	require.NoError(t, err)
+
+	server := overlay.NewServer(zap.NewNop(), overlay.NewCache(store, nil), overlay.NodeSelectionConfig{}, statdb.Config{})
+	server.UseGeoIP(geoip)
+
+	find := func(amount int64, required, excluded []string) ([]*pb.Node, error) {
+		resp, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{Opts: &pb.OverlayOptions{
+			Amount:          amount,
+			RequiredRegions: required,
+			ExcludedRegions: excluded,
+		}})
+		return resp.GetNodes(), err
+	}
+
+	{ // one node per subnet
+		nodes, err := find(5, nil, nil)
+		require.NoError(t, err)
+		subnets := map[string]bool{}
+		for _, node := range nodes {
+			address := node.Address.Address
+			subnets[address[:strings.LastIndexAny(address, ".:")]] = true
+		}
+		assert.Len(t, subnets, 5)
+
+		_, err = find(6, nil, nil)
+		assert.Error(t, err)
+	}
+
+	{ // nodes in the subnets of excluded nodes aren't selected
+		resp, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{Opts: &pb.OverlayOptions{
+			Amount:        4,
+			ExcludedNodes: storj.NodeIDList{teststorj.NodeIDFromString("a1")},
+		}})
+		require.NoError(t, err)
+		for _, node := range resp.GetNodes() {
+			assert.False(t, strings.HasPrefix(node.Address.Address, "10.0.1."), node.Address.Address)
+		}
+
+		_, err = server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{Opts: &pb.OverlayOptions{
+			Amount:        5,
+			ExcludedNodes: storj.NodeIDList{teststorj.NodeIDFromString("a1")},
+		}})
+		assert.Error(t, err)
+	}
+
+	{ // required regions include their subregions
+		nodes, err := find(2, []string{"US"}, nil)
+		require.NoError(t, err)
+		for _, node := range nodes {
+			assert.True(t, strings.HasPrefix(node.Address.Address, "10.0."))
+		}
+		_, err = find(3, []string{"US"}, nil)
+		assert.Error(t, err)
+
+		nodes, err = find(1, []string{"us-ca"}, nil)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(nodes[0].Address.Address, "10.0.1."))
+	}
+
+	{ // nodes of unknown regions are only excluded by required regions
+		nodes, err := find(3, nil, []string{"US"})
+		require.NoError(t, err)
+		for _, node := range nodes {
+			assert.False(t, strings.HasPrefix(node.Address.Address, "10."))
+		}
+		_, err = find(4, nil, []string{"US"})
+		assert.Error(t, err)
+	}
+
+	{ // region constraints need a GeoIP database
+		server := overlay.NewServer(zap.NewNop(), overlay.NewCache(store, nil), overlay.NodeSelectionConfig{}, statdb.Config{})
+		_, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{Opts: &pb.OverlayOptions{
+			Amount:          1,
+			RequiredRegions: []string{"US"},
+		}})
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	}
+}
+
+type recordingClient struct {
+	overlay.Client
+	options overlay.Options
+}
+
+func (client *recordingClient) Choose(ctx context.Context, op overlay.Options) ([]*pb.Node, error) {
+	client.options = op
+	return nil, nil
+}
+
+func TestPlacementClient(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	recorder := &recordingClient{}
+	client := overlay.NewPlacementClient(recorder, []string{"US"}, []string{"US-CA"})
+
+	_, err := client.Choose(ctx, overlay.Options{Amount: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"US"}, recorder.options.RequiredRegions)
+	assert.Equal(t, []string{"US-CA"}, recorder.options.ExcludedRegions)
+	assert.Equal(t, 3, recorder.options.Amount)
+
+	// explicit options win
+	_, err = client.Choose(ctx, overlay.Options{RequiredRegions: []string{"DE"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"DE"}, recorder.options.RequiredRegions)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"time"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+// DB is the overlay cache database
+type DB interface {
+	storage.KeyValueStore
+	SelectionDB
+}
+
+// SelectionDB selects storage nodes using indexes instead of scanning the whole cache
+type SelectionDB interface {
+	// SelectStorageNodes returns up to count randomly sampled storage nodes matching criteria
+	SelectStorageNodes(ctx context.Context, count int, criteria *NodeCriteria) ([]*pb.Node, error)
+	// UpdateLastContact records that the satellite contacted the node at contacted
+	UpdateLastContact(ctx context.Context, nodeID storj.NodeID, contacted time.Time) error
+}
+
+// NodeCriteria are the requirements storage nodes need to meet to be selected
+type NodeCriteria struct {
+	FreeBandwidth int64
+	FreeDisk      int64
+
+	AuditCount        int64
+	AuditSuccessRatio float64
+	UptimeCount       int64
+	UptimeRatio       float64
+
+	// MaxLatency and MinSpeedKbps only exclude nodes that have been measured
+	MaxLatency   int64 // milliseconds
+	MinSpeedKbps int64
+
+	// States decides which nodes are vetted, suspended or disqualified
+	States statdb.Config
+	// NewNodes selects nodes that are still vetting instead of vetted nodes
+	NewNodes bool
+	// ContactedSince excludes nodes that haven't connected to the satellite or checked in since, if set
+	ContactedSince time.Time
+	// MinimumVersion excludes nodes that didn't check in with at least this version, if set.
+	// Versions can't be compared by the database, selectNodes filters them.
+	MinimumVersion string
+
+	Excluded storj.NodeIDList
+}
+
+// selectNodes picks count nodes from db that placement accepts. Every sampled node is
+// added to the excluded nodes of criteria.
+func selectNodes(ctx context.Context, db SelectionDB, count int, criteria *NodeCriteria, placement *placement) (nodes []*pb.Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for len(nodes) < count {
+		want := count - len(nodes)
+		selected, err := db.SelectStorageNodes(ctx, want, criteria)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range selected {
+			criteria.Excluded = append(criteria.Excluded, n.Id)
+
+			if len(nodes) >= count || !acceptVersion(n, criteria.MinimumVersion) || !placement.accept(ctx, n) {
+				continue
+			}
+			nodes = append(nodes, n)
+		}
+
+		// every matching node has been returned
+		if len(selected) < want {
+			break
+		}
+	}
+
+	return nodes, nil
+}
@@ -0,0 +1,176 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/satellite"
+	"storj.io/storj/satellite/satellitedb/satellitedbtest"
+)
+
+func TestSelectStorageNodes(t *testing.T) {
+	satellitedbtest.Run(t, func(t *testing.T, db satellite.DB) {
+		ctx := testcontext.New(t)
+		defer ctx.Cleanup()
+
+		testSelectStorageNodes(ctx, t, db.OverlayCache())
+	})
+}
+
+func testSelectStorageNodes(ctx context.Context, t *testing.T, store overlay.DB) {
+	put := func(name string, node *pb.Node) storj.NodeID {
+		node.Id = teststorj.NodeIDFromString(name)
+		if node.Type == pb.NodeType_INVALID {
+			node.Type = pb.NodeType_STORAGE
+		}
+		node.Address = &pb.NodeAddress{Address: name + ":7777"}
+		data, err := proto.Marshal(node)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(node.Id.Bytes(), data))
+		return node.Id
+	}
+
+	vetted := &pb.NodeStats{AuditCount: 10, AuditSuccessCount: 10, AuditSuccessRatio: 1, UptimeCount: 10, UptimeSuccessCount: 10, UptimeRatio: 1}
+	restrictions := &pb.NodeRestrictions{FreeBandwidth: 100, FreeDisk: 100}
+
+	var good storj.NodeIDList
+	for i := 0; i < 10; i++ {
+		good = append(good, put(fmt.Sprintf("good%d", i), &pb.Node{Restrictions: restrictions, Reputation: vetted}))
+	}
+	newNodes := storj.NodeIDList{put("unvetted", &pb.Node{Restrictions: restrictions, Reputation: &pb.NodeStats{}})}
+	put("small", &pb.Node{Restrictions: &pb.NodeRestrictions{FreeBandwidth: 100, FreeDisk: 1}, Reputation: vetted})
+	put("uplink", &pb.Node{Type: pb.NodeType_UPLINK, Restrictions: restrictions, Reputation: vetted})
+	put("suspended", &pb.Node{Restrictions: restrictions, Reputation: &pb.NodeStats{
+		AuditSuccessCount: 10, UptimeSuccessCount: 10, State: pb.NodeState_SUSPENDED,
+	}})
+	newNodes = append(newNodes, put("unreliable", &pb.Node{Restrictions: restrictions, Reputation: &pb.NodeStats{
+		AuditCount: 10, AuditSuccessCount: 10, AuditSuccessRatio: 1, UptimeCount: 10, UptimeSuccessCount: 2, UptimeRatio: 0.2,
+	}}))
+
+	criteria := func() *overlay.NodeCriteria {
+		return &overlay.NodeCriteria{
+			FreeBandwidth: 10,
+			FreeDisk:      10,
+			UptimeRatio:   0.5,
+			States:        statdb.Config{VettingAuditCount: 5, VettingUptimeCount: 5},
+		}
+	}
+
+	{ // only nodes matching the criteria are selected
+		nodes, err := store.SelectStorageNodes(ctx, 20, criteria())
+		require.NoError(t, err)
+		assert.Len(t, nodes, len(good))
+		for _, node := range nodes {
+			assert.Contains(t, good, node.Id)
+		}
+	}
+
+	{ // a sample of the requested size
+		nodes, err := store.SelectStorageNodes(ctx, 3, criteria())
+		require.NoError(t, err)
+		assert.Len(t, nodes, 3)
+	}
+
+	{ // every node is equally likely to be selected, regardless of the gaps between node IDs
+		const runs = 500
+		selected := map[storj.NodeID]int{}
+		for i := 0; i < runs; i++ {
+			nodes, err := store.SelectStorageNodes(ctx, 1, criteria())
+			require.NoError(t, err)
+			require.Len(t, nodes, 1)
+			selected[nodes[0].Id]++
+		}
+		for _, id := range good {
+			// expected runs/len(good) = 50 times each
+			assert.InDelta(t, runs/len(good), selected[id], 30, id.String())
+		}
+	}
+
+	{ // excluded nodes
+		c := criteria()
+		c.Excluded = good[:8]
+		nodes, err := store.SelectStorageNodes(ctx, 20, c)
+		require.NoError(t, err)
+		assert.Len(t, nodes, 2)
+
+		// excluded nodes are skipped without ending the sample early
+		c.Excluded = good[:9]
+		for i := 0; i < 10; i++ {
+			nodes, err := store.SelectStorageNodes(ctx, 1, c)
+			require.NoError(t, err)
+			if assert.Len(t, nodes, 1) {
+				assert.Equal(t, good[9], nodes[0].Id)
+			}
+		}
+	}
+
+	{ // nodes that weren't contacted recently
+		c := criteria()
+		c.ContactedSince = time.Now().Add(-time.Hour)
+		nodes, err := store.SelectStorageNodes(ctx, 20, c)
+		require.NoError(t, err)
+		assert.Empty(t, nodes)
+
+		require.NoError(t, store.UpdateLastContact(ctx, good[0], time.Now()))
+		// updating the node's values isn't a contact and keeps the last one
+		put("good0", &pb.Node{Restrictions: restrictions, Reputation: vetted})
+
+		nodes, err = store.SelectStorageNodes(ctx, 20, c)
+		require.NoError(t, err)
+		if assert.Len(t, nodes, 1) {
+			assert.Equal(t, good[0], nodes[0].Id)
+		}
+	}
+
+	{ // new nodes
+		c := criteria()
+		c.NewNodes = true
+		c.UptimeRatio = 0
+		nodes, err := store.SelectStorageNodes(ctx, 20, c)
+		require.NoError(t, err)
+		assert.Len(t, nodes, len(newNodes))
+		for _, node := range nodes {
+			assert.Contains(t, newNodes, node.Id)
+		}
+	}
+
+	{ // overlay server picks nodes from the database
+		cache := overlay.NewCache(store, nil)
+		server := overlay.NewServer(zap.NewNop(), cache, overlay.NodeSelectionConfig{
+			UptimeRatio:       0.5,
+			NewNodePercentage: 0.2,
+		}, statdb.Config{VettingAuditCount: 5, VettingUptimeCount: 5})
+
+		result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+			Opts: &pb.OverlayOptions{Amount: 5, Restrictions: &pb.NodeRestrictions{FreeBandwidth: 10, FreeDisk: 10}},
+		})
+		require.NoError(t, err)
+		if assert.Len(t, result.Nodes, 5) {
+			assert.Contains(t, newNodes, result.Nodes[0].Id)
+			for _, node := range result.Nodes[1:] {
+				assert.Contains(t, good, node.Id)
+			}
+		}
+
+		_, err = server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{
+			Opts: &pb.OverlayOptions{Amount: 13, Restrictions: &pb.NodeRestrictions{FreeBandwidth: 10, FreeDisk: 10}},
+		})
+		assert.Error(t, err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/zeebo/errs"
@@ -15,7 +16,11 @@ import (
 	"google.golang.org/grpc/status"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
+	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/identity"
 	"storj.io/storj/pkg/pb"
+	pointerdbAuth "storj.io/storj/pkg/pointerdb/auth"
+	"storj.io/storj/pkg/statdb"
 	"storj.io/storj/pkg/storj"
 	"storj.io/storj/storage"
 )
@@ -23,24 +28,44 @@ import (
 // ServerError creates class of errors for stack traces
 var ServerError = errs.Class("Server Error")
 
+// maxMeasurements is the most measurements accepted in one performance report
+const maxMeasurements = 1000
+
 // Server implements our overlay RPC service
 type Server struct {
 	log       *zap.Logger
 	cache     *Cache
 	metrics   *monkit.Registry
 	nodeStats *pb.NodeStats
+	selection NodeSelectionConfig
+	states    statdb.Config
+	geoip     *GeoIP
+	reports   reportLimiter
 }
 
 // NewServer creates a new Overlay Server
-func NewServer(log *zap.Logger, cache *Cache, nodeStats *pb.NodeStats) *Server {
+func NewServer(log *zap.Logger, cache *Cache, selection NodeSelectionConfig, states statdb.Config) *Server {
 	return &Server{
-		cache:     cache,
-		log:       log,
-		metrics:   monkit.Default,
-		nodeStats: nodeStats,
+		cache:   cache,
+		log:     log,
+		metrics: monkit.Default,
+		nodeStats: &pb.NodeStats{
+			UptimeCount:       selection.UptimeCount,
+			UptimeRatio:       selection.UptimeRatio,
+			AuditSuccessRatio: selection.AuditSuccessRatio,
+			AuditCount:        selection.AuditCount,
+		},
+		selection: selection,
+		states:    states,
 	}
 }
 
+// UseGeoIP tags nodes with regions from geoip, so that uploads can be
+// pinned to or kept out of regions
+func (server *Server) UseGeoIP(geoip *GeoIP) {
+	server.geoip = geoip
+}
+
 // Lookup finds the address of a node in our overlay network
 func (server *Server) Lookup(ctx context.Context, req *pb.LookupRequest) (*pb.LookupResponse, error) {
 	na, err := server.cache.Get(ctx, req.NodeId)
@@ -72,54 +97,193 @@ func (server *Server) FindStorageNodes(ctx context.Context, req *pb.FindStorageN
 		maxNodes = opts.GetAmount()
 	}
 
-	excluded := opts.ExcludedNodes
+	excluded := append(storj.NodeIDList(nil), opts.ExcludedNodes...)
 	restrictions := opts.GetRestrictions()
-	reputation := server.nodeStats
 
-	var startID storj.NodeID
-	result := []*pb.Node{}
-	for {
-		var nodes []*pb.Node
-		nodes, startID, err = server.populate(ctx, req.Start, maxNodes, restrictions, reputation, excluded)
+	placement := newPlacement(server.geoip, opts, server.selection.LocalNetwork)
+	if placement.hasRegions() && server.geoip == nil {
+		return nil, status.Error(codes.FailedPrecondition, "region constraints are not supported by this satellite")
+	}
+	// repairs exclude the nodes already holding pieces, new pieces don't go to their subnets
+	if len(excluded) > 0 {
+		holders, err := server.cache.GetAll(ctx, excluded)
 		if err != nil {
 			return nil, Error.Wrap(err)
 		}
-
-		resultNodes := []*pb.Node{}
-		usedAddrs := make(map[string]bool)
-		for _, n := range nodes {
-			addr := n.Address.GetAddress()
-			excluded = append(excluded, n.Id) // exclude all nodes on next iteration
-			if !usedAddrs[addr] {
-				resultNodes = append(resultNodes, n)
-				usedAddrs[addr] = true
-			}
-		}
-		if len(resultNodes) <= 0 {
-			break
+		for _, node := range holders {
+			placement.claim(ctx, node)
 		}
+	}
+	performance := newPerformance(opts)
 
-		result = append(result, resultNodes...)
+	// up to newNodeCount nodes are taken from the unvetted pool, the rest from vetted nodes
+	newNodeCount := int(float64(maxNodes) * server.selection.NewNodePercentage)
 
-		if len(result) >= int(maxNodes) || startID == (storj.NodeID{}) {
+	var vettedNodes, newNodes []*pb.Node
+	if db, ok := server.cache.db.(SelectionDB); ok {
+		vettedNodes, newNodes, err = server.selectByIndex(ctx, db, int(maxNodes), newNodeCount, restrictions, excluded, placement, performance)
+	} else {
+		vettedNodes, newNodes, err = server.selectByScan(ctx, req.Start, maxNodes, newNodeCount, restrictions, excluded, placement, performance)
+	}
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	performance.rank(vettedNodes)
+
+	// vetted nodes make up for any shortage of new nodes
+	result := newNodes
+	for _, n := range vettedNodes {
+		if len(result) >= int(maxNodes) {
 			break
 		}
-
+		result = append(result, n)
 	}
 
 	if len(result) < int(maxNodes) {
 		return nil, status.Errorf(codes.ResourceExhausted, fmt.Sprintf("requested %d nodes, only %d nodes matched the criteria requested", maxNodes, len(result)))
 	}
 
-	if len(result) > int(maxNodes) {
-		result = result[:maxNodes]
-	}
-
 	return &pb.FindStorageNodesResponse{
 		Nodes: result,
 	}, nil
 }
 
+// ReportPerformance records the latencies and speeds an uplink observed while talking to storage nodes.
+// Reports move nodes in and out of selection, so they need the API key and are limited per uplink.
+func (server *Server) ReportPerformance(ctx context.Context, req *pb.PerformanceReport) (_ *pb.PerformanceReportResponse, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	// restricted API keys are handed to others, they can't report
+	apiKey, ok := auth.GetAPIKey(ctx)
+	if !ok || !pointerdbAuth.ValidateAPIKey(string(apiKey)) {
+		return nil, status.Error(codes.Unauthenticated, "Invalid API credential")
+	}
+	reporter, err := identity.PeerIdentityFromContext(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	measurements := req.GetMeasurements()
+	if len(measurements) > maxMeasurements {
+		return nil, status.Errorf(codes.InvalidArgument, "too many measurements: %d > %d", len(measurements), maxMeasurements)
+	}
+
+	now := time.Now()
+	for _, m := range measurements {
+		latency := time.Duration(m.LatencyMs) * time.Millisecond
+		if latency < 0 || m.SpeedKbps < 0 {
+			continue
+		}
+		if !server.reports.allow(reporter.ID, m.NodeId, now) {
+			continue
+		}
+		// only nodes the satellite knows of are measured, so that reports can't fill statdb
+		if _, err := server.cache.Get(ctx, m.NodeId); err != nil {
+			continue
+		}
+		if err := server.cache.UpdatePerformance(ctx, m.NodeId, latency, m.SpeedKbps); err != nil {
+			server.log.Debug("error updating node performance", zap.String("nodeID", m.NodeId.String()), zap.Error(err))
+		}
+	}
+
+	return &pb.PerformanceReportResponse{}, nil
+}
+
+// selectByIndex randomly samples new and vetted nodes from a cache database that supports indexed selection
+func (server *Server) selectByIndex(ctx context.Context, db SelectionDB, maxNodes, newNodeCount int,
+	restrictions *pb.NodeRestrictions, excluded storj.NodeIDList, placement *placement, performance *performance) (vetted []*pb.Node, unvetted []*pb.Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	criteria := &NodeCriteria{
+		FreeBandwidth:  restrictions.GetFreeBandwidth(),
+		FreeDisk:       restrictions.GetFreeDisk(),
+		MaxLatency:     performance.maxLatency,
+		MinSpeedKbps:   performance.minSpeedKbps,
+		States:         server.states,
+		NewNodes:       true,
+		MinimumVersion: server.selection.MinimumVersion,
+		Excluded:       excluded,
+	}
+	if server.selection.OnlineWindow > 0 {
+		criteria.ContactedSince = time.Now().Add(-server.selection.OnlineWindow)
+	}
+
+	unvetted, err = selectNodes(ctx, db, newNodeCount, criteria, placement)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// reputation minimums only apply to vetted nodes
+	criteria.NewNodes = false
+	criteria.AuditCount = server.nodeStats.AuditCount
+	criteria.AuditSuccessRatio = server.nodeStats.AuditSuccessRatio
+	criteria.UptimeCount = server.nodeStats.UptimeCount
+	criteria.UptimeRatio = server.nodeStats.UptimeRatio
+
+	vetted, err = selectNodes(ctx, db, performance.sample(maxNodes-len(unvetted)), criteria, placement)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vetted, unvetted, nil
+}
+
+// selectByScan pages through the cache from startID until enough new and vetted nodes are found
+func (server *Server) selectByScan(ctx context.Context, startID storj.NodeID, maxNodes int64, newNodeCount int,
+	restrictions *pb.NodeRestrictions, excluded storj.NodeIDList, placement *placement, performance *performance) (vettedNodes []*pb.Node, newNodes []*pb.Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	vettedCount := performance.sample(int(maxNodes))
+
+	for {
+		var vetted, unvetted []*pb.Node
+		vetted, unvetted, startID, err = server.populate(ctx, startID, maxNodes, restrictions, server.nodeStats, performance, excluded)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, n := range unvetted {
+			if len(newNodes) < newNodeCount && placement.accept(ctx, n) {
+				newNodes = append(newNodes, n)
+				excluded = append(excluded, n.Id)
+			}
+		}
+		for _, n := range vetted {
+			if len(vettedNodes) < vettedCount && placement.accept(ctx, n) {
+				vettedNodes = append(vettedNodes, n)
+				excluded = append(excluded, n.Id)
+			}
+		}
+
+		if len(vettedNodes) >= vettedCount && len(newNodes) >= newNodeCount {
+			break
+		}
+		if startID == (storj.NodeID{}) {
+			break
+		}
+	}
+
+	return vettedNodes, newNodes, nil
+}
+
+// nodeState returns the state of a node based on the stats it was cached with.
+// Suspensions and disqualifications are only lifted when statdb moves the node out of them.
+func (server *Server) nodeState(nodeID storj.NodeID, reputation *pb.NodeStats) statdb.NodeState {
+	state := statdb.NodeState(reputation.GetState())
+	if state == statdb.StateSuspended || state == statdb.StateDisqualified {
+		return state
+	}
+	return server.states.Evaluate(&statdb.NodeStats{
+		NodeID:             nodeID,
+		AuditSuccessRatio:  reputation.GetAuditSuccessRatio(),
+		AuditSuccessCount:  reputation.GetAuditSuccessCount(),
+		AuditCount:         reputation.GetAuditCount(),
+		UptimeRatio:        reputation.GetUptimeRatio(),
+		UptimeSuccessCount: reputation.GetUptimeSuccessCount(),
+		UptimeCount:        reputation.GetUptimeCount(),
+		State:              state,
+	})
+}
+
 func (server *Server) getNodes(ctx context.Context, keys storage.Keys) ([]*pb.Node, error) {
 	values, err := server.cache.db.GetAll(keys)
 	if err != nil {
@@ -140,28 +304,29 @@ func (server *Server) getNodes(ctx context.Context, keys storage.Keys) ([]*pb.No
 
 }
 
+// populate lists a page of nodes from the cache starting at startID and splits the nodes that
+// meet the requirements into vetted and new nodes. Suspended and disqualified nodes are skipped
+// and reputation minimums only apply to vetted nodes.
 func (server *Server) populate(ctx context.Context, startID storj.NodeID, maxNodes int64,
-	minRestrictions *pb.NodeRestrictions, minReputation *pb.NodeStats,
-	excluded storj.NodeIDList) ([]*pb.Node, storj.NodeID, error) {
+	minRestrictions *pb.NodeRestrictions, minReputation *pb.NodeStats, performance *performance,
+	excluded storj.NodeIDList) (vetted []*pb.Node, unvetted []*pb.Node, nextStart storj.NodeID, err error) {
 
 	limit := int(maxNodes * 2)
 	keys, err := server.cache.db.List(startID.Bytes(), limit)
 	if err != nil {
 		server.log.Error("Error listing nodes", zap.Error(err))
-		return nil, storj.NodeID{}, Error.Wrap(err)
+		return nil, nil, storj.NodeID{}, Error.Wrap(err)
 	}
 
 	if len(keys) <= 0 {
 		server.log.Info("No Keys returned from List operation")
-		return []*pb.Node{}, startID, nil
+		return nil, nil, storj.NodeID{}, nil
 	}
 
-	// TODO: should this be `var result []*pb.Node` ?
-	result := []*pb.Node{}
 	nodes, err := server.getNodes(ctx, keys)
 	if err != nil {
 		server.log.Error("Error getting nodes", zap.Error(err))
-		return nil, storj.NodeID{}, Error.Wrap(err)
+		return nil, nil, storj.NodeID{}, Error.Wrap(err)
 	}
 
 	for _, v := range nodes {
@@ -174,27 +339,39 @@ func (server *Server) populate(ctx context.Context, startID storj.NodeID, maxNod
 
 		if restrictions.GetFreeBandwidth() < minRestrictions.GetFreeBandwidth() ||
 			restrictions.GetFreeDisk() < minRestrictions.GetFreeDisk() ||
-			reputation.GetUptimeRatio() < minReputation.GetUptimeRatio() ||
+			!performance.accept(reputation) ||
+			!acceptVersion(v, server.selection.MinimumVersion) ||
+			contains(excluded, v.Id) {
+			continue
+		}
+
+		switch server.nodeState(v.Id, reputation) {
+		case statdb.StateSuspended, statdb.StateDisqualified:
+			continue
+		case statdb.StateVetting:
+			unvetted = append(unvetted, v)
+			continue
+		}
+
+		if reputation.GetUptimeRatio() < minReputation.GetUptimeRatio() ||
 			reputation.GetUptimeCount() < minReputation.GetUptimeCount() ||
 			reputation.GetAuditSuccessRatio() < minReputation.GetAuditSuccessRatio() ||
-			reputation.GetAuditCount() < minReputation.GetAuditCount() ||
-			contains(excluded, v.Id) {
+			reputation.GetAuditCount() < minReputation.GetAuditCount() {
 			continue
 		}
-		result = append(result, v)
+		vetted = append(vetted, v)
 	}
 
-	var nextStart storj.NodeID
 	if len(keys) < limit {
-		nextStart = storj.NodeID{}
-	} else {
-		nextStart, err = storj.NodeIDFromBytes(keys[len(keys)-1])
+		return vetted, unvetted, storj.NodeID{}, nil
 	}
+
+	nextStart, err = storj.NodeIDFromBytes(keys[len(keys)-1])
 	if err != nil {
-		return nil, storj.NodeID{}, Error.Wrap(err)
+		return nil, nil, storj.NodeID{}, Error.Wrap(err)
 	}
 
-	return result, nextStart, nil
+	return vetted, unvetted, nextStart, nil
 }
 
 // contains checks if item exists in list
@@ -4,15 +4,22 @@
 package overlay_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"storj.io/storj/internal/testcontext"
 	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/internal/teststorj"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/storage/teststore"
 )
 
 func TestServer(t *testing.T) {
@@ -30,7 +37,7 @@ func TestServer(t *testing.T) {
 	time.Sleep(2 * time.Second)
 
 	satellite := planet.Satellites[0]
-	server := overlay.NewServer(satellite.Log.Named("overlay"), satellite.Overlay, &pb.NodeStats{})
+	server := overlay.NewServer(satellite.Log.Named("overlay"), satellite.Overlay, overlay.NodeSelectionConfig{LocalNetwork: true}, statdb.Config{})
 	// TODO: handle cleanup
 
 	{ // FindStorageNodes
@@ -65,3 +72,88 @@ func TestServer(t *testing.T) {
 		}
 	}
 }
+
+func TestFindStorageNodesVetting(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	store := teststore.New()
+	vetted := map[string]bool{}
+	for i := 0; i < 24; i++ {
+		node := &pb.Node{
+			Id:         teststorj.NodeIDFromString(fmt.Sprintf("node%02d", i)),
+			Type:       pb.NodeType_STORAGE,
+			Address:    &pb.NodeAddress{Address: fmt.Sprintf("127.0.0.1:%d", 10000+i)},
+			Reputation: &pb.NodeStats{},
+		}
+		// odd nodes have passed vetting
+		if i%2 == 1 {
+			node.Reputation = &pb.NodeStats{
+				AuditCount:         10,
+				AuditSuccessCount:  10,
+				UptimeCount:        10,
+				UptimeSuccessCount: 10,
+			}
+			vetted[node.Id.String()] = true
+		}
+		// the last nodes are suspended or disqualified and never picked
+		if i >= 20 {
+			node.Reputation.State = pb.NodeState_SUSPENDED
+			if i >= 22 {
+				node.Reputation.State = pb.NodeState_DISQUALIFIED
+			}
+		}
+		data, err := proto.Marshal(node)
+		require.NoError(t, err)
+		require.NoError(t, store.Put(node.Id.Bytes(), data))
+	}
+	cache := overlay.NewCache(store, nil)
+
+	states := statdb.Config{
+		VettingAuditCount:  10,
+		VettingUptimeCount: 10,
+	}
+
+	countNew := func(nodes []*pb.Node) (count int) {
+		for _, node := range nodes {
+			if !vetted[node.Id.String()] {
+				count++
+			}
+		}
+		return count
+	}
+
+	{ // a fraction of the nodes are picked from new nodes
+		server := overlay.NewServer(zap.NewNop(), cache, overlay.NodeSelectionConfig{
+			NewNodePercentage: 0.25,
+			LocalNetwork:      true,
+		}, states)
+		result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{Opts: &pb.OverlayOptions{Amount: 8}})
+		require.NoError(t, err)
+		assert.Len(t, result.Nodes, 8)
+		assert.Equal(t, 2, countNew(result.Nodes))
+	}
+
+	{ // no new nodes when the percentage is zero
+		server := overlay.NewServer(zap.NewNop(), cache, overlay.NodeSelectionConfig{LocalNetwork: true}, states)
+		result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{Opts: &pb.OverlayOptions{Amount: 10}})
+		require.NoError(t, err)
+		assert.Len(t, result.Nodes, 10)
+		assert.Equal(t, 0, countNew(result.Nodes))
+
+		_, err = server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{Opts: &pb.OverlayOptions{Amount: 11}})
+		assert.Error(t, err)
+	}
+
+	{ // reputation minimums don't apply to new nodes
+		server := overlay.NewServer(zap.NewNop(), cache, overlay.NodeSelectionConfig{
+			AuditCount:        1,
+			NewNodePercentage: 0.5,
+			LocalNetwork:      true,
+		}, states)
+		result, err := server.FindStorageNodes(ctx, &pb.FindStorageNodesRequest{Opts: &pb.OverlayOptions{Amount: 4}})
+		require.NoError(t, err)
+		assert.Len(t, result.Nodes, 4)
+		assert.Equal(t, 2, countNew(result.Nodes))
+	}
+}
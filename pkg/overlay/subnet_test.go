@@ -0,0 +1,62 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package overlay
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/pkg/pb"
+)
+
+func TestPlacementSubnets(t *testing.T) {
+	ctx := context.Background()
+
+	hosts := map[string]string{
+		"a.example.com": "10.0.1.1",
+		"b.example.com": "10.0.1.2",
+		"c.example.com": "10.0.2.1",
+	}
+	lookupIP := func(ctx context.Context, host string) ([]net.IPAddr, error) {
+		ip, ok := hosts[host]
+		if !ok {
+			return nil, &net.DNSError{Err: "no such host", Name: host}
+		}
+		return []net.IPAddr{{IP: net.ParseIP(ip)}}, nil
+	}
+	node := func(address string) *pb.Node {
+		return &pb.Node{Address: &pb.NodeAddress{Address: address}}
+	}
+
+	{ // hostnames count as the subnet they resolve to
+		p := newPlacement(nil, nil, false)
+		p.lookupIP = lookupIP
+		assert.True(t, p.accept(ctx, node("a.example.com:7777")))
+		assert.False(t, p.accept(ctx, node("b.example.com:7777")))
+		assert.False(t, p.accept(ctx, node("10.0.1.3:7777")))
+		assert.True(t, p.accept(ctx, node("c.example.com:7777")))
+
+		// unresolvable hostnames count as their own subnet
+		assert.True(t, p.accept(ctx, node("unknown.example.com:7777")))
+		assert.False(t, p.accept(ctx, node("unknown.example.com:7778")))
+	}
+
+	{ // loopback addresses are one subnet
+		p := newPlacement(nil, nil, false)
+		assert.True(t, p.accept(ctx, node("127.0.0.1:7777")))
+		assert.False(t, p.accept(ctx, node("127.0.0.1:7778")))
+	}
+
+	{ // unless the nodes are on a local test network
+		p := newPlacement(nil, nil, true)
+		assert.True(t, p.accept(ctx, node("127.0.0.1:7777")))
+		assert.True(t, p.accept(ctx, node("127.0.0.1:7778")))
+		assert.False(t, p.accept(ctx, node("127.0.0.1:7778")))
+		assert.True(t, p.accept(ctx, node("10.0.1.1:7777")))
+		assert.False(t, p.accept(ctx, node("10.0.1.2:7777")))
+	}
+}
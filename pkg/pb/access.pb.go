@@ -0,0 +1,252 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: access.proto
+
+package pb
+
+import proto "github.com/gogo/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
+
+// Caveat restricts what an API key gives access to
+type Caveat struct {
+	// only the pointers of this bucket and encrypted path prefix are accessible
+	PathPrefix string `protobuf:"bytes,1,opt,name=path_prefix,json=pathPrefix,proto3" json:"path_prefix,omitempty"`
+	ReadOnly   bool   `protobuf:"varint,2,opt,name=read_only,json=readOnly,proto3" json:"read_only,omitempty"`
+	// the API key doesn't give access anymore after this time, if set
+	NotAfter             *timestamp.Timestamp `protobuf:"bytes,3,opt,name=not_after,json=notAfter" json:"not_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Caveat) Reset()         { *m = Caveat{} }
+func (m *Caveat) String() string { return proto.CompactTextString(m) }
+func (*Caveat) ProtoMessage()    {}
+func (*Caveat) Descriptor() ([]byte, []int) {
+	return fileDescriptor_access_6a1d37183655af14, []int{0}
+}
+func (m *Caveat) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Caveat.Unmarshal(m, b)
+}
+func (m *Caveat) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Caveat.Marshal(b, m, deterministic)
+}
+func (dst *Caveat) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Caveat.Merge(dst, src)
+}
+func (m *Caveat) XXX_Size() int {
+	return xxx_messageInfo_Caveat.Size(m)
+}
+func (m *Caveat) XXX_DiscardUnknown() {
+	xxx_messageInfo_Caveat.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Caveat proto.InternalMessageInfo
+
+func (m *Caveat) GetPathPrefix() string {
+	if m != nil {
+		return m.PathPrefix
+	}
+	return ""
+}
+
+func (m *Caveat) GetReadOnly() bool {
+	if m != nil {
+		return m.ReadOnly
+	}
+	return false
+}
+
+func (m *Caveat) GetNotAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.NotAfter
+	}
+	return nil
+}
+
+// RestrictedAPIKey is an API key restricted by a caveat
+type RestrictedAPIKey struct {
+	// the marshaled caveat
+	Caveat []byte `protobuf:"bytes,1,opt,name=caveat,proto3" json:"caveat,omitempty"`
+	// the HMAC-SHA256 of the caveat keyed with the API key being restricted
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestrictedAPIKey) Reset()         { *m = RestrictedAPIKey{} }
+func (m *RestrictedAPIKey) String() string { return proto.CompactTextString(m) }
+func (*RestrictedAPIKey) ProtoMessage()    {}
+func (*RestrictedAPIKey) Descriptor() ([]byte, []int) {
+	return fileDescriptor_access_6a1d37183655af14, []int{1}
+}
+func (m *RestrictedAPIKey) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestrictedAPIKey.Unmarshal(m, b)
+}
+func (m *RestrictedAPIKey) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestrictedAPIKey.Marshal(b, m, deterministic)
+}
+func (dst *RestrictedAPIKey) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestrictedAPIKey.Merge(dst, src)
+}
+func (m *RestrictedAPIKey) XXX_Size() int {
+	return xxx_messageInfo_RestrictedAPIKey.Size(m)
+}
+func (m *RestrictedAPIKey) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestrictedAPIKey.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestrictedAPIKey proto.InternalMessageInfo
+
+func (m *RestrictedAPIKey) GetCaveat() []byte {
+	if m != nil {
+		return m.Caveat
+	}
+	return nil
+}
+
+func (m *RestrictedAPIKey) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+// Access is everything an uplink needs to access a path prefix
+type Access struct {
+	SatelliteAddress string `protobuf:"bytes,1,opt,name=satellite_address,json=satelliteAddress,proto3" json:"satellite_address,omitempty"`
+	ApiKey           string `protobuf:"bytes,2,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	// the unencrypted path prefix, starting with the bucket
+	PathPrefix string `protobuf:"bytes,3,opt,name=path_prefix,json=pathPrefix,proto3" json:"path_prefix,omitempty"`
+	// the path prefix, encrypted after the bucket
+	EncryptedPathPrefix string `protobuf:"bytes,4,opt,name=encrypted_path_prefix,json=encryptedPathPrefix,proto3" json:"encrypted_path_prefix,omitempty"`
+	// the key of the path prefix, to derive the keys of the paths under it
+	PathKey []byte `protobuf:"bytes,5,opt,name=path_key,json=pathKey,proto3" json:"path_key,omitempty"`
+	// the content key of the bucket, to read the bucket's metadata
+	BucketContentKey []byte `protobuf:"bytes,6,opt,name=bucket_content_key,json=bucketContentKey,proto3" json:"bucket_content_key,omitempty"`
+	// when the restricted API key expires, if set
+	NotAfter             *timestamp.Timestamp `protobuf:"bytes,7,opt,name=not_after,json=notAfter" json:"not_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Access) Reset()         { *m = Access{} }
+func (m *Access) String() string { return proto.CompactTextString(m) }
+func (*Access) ProtoMessage()    {}
+func (*Access) Descriptor() ([]byte, []int) {
+	return fileDescriptor_access_6a1d37183655af14, []int{2}
+}
+func (m *Access) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Access.Unmarshal(m, b)
+}
+func (m *Access) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Access.Marshal(b, m, deterministic)
+}
+func (dst *Access) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Access.Merge(dst, src)
+}
+func (m *Access) XXX_Size() int {
+	return xxx_messageInfo_Access.Size(m)
+}
+func (m *Access) XXX_DiscardUnknown() {
+	xxx_messageInfo_Access.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Access proto.InternalMessageInfo
+
+func (m *Access) GetSatelliteAddress() string {
+	if m != nil {
+		return m.SatelliteAddress
+	}
+	return ""
+}
+
+func (m *Access) GetApiKey() string {
+	if m != nil {
+		return m.ApiKey
+	}
+	return ""
+}
+
+func (m *Access) GetPathPrefix() string {
+	if m != nil {
+		return m.PathPrefix
+	}
+	return ""
+}
+
+func (m *Access) GetEncryptedPathPrefix() string {
+	if m != nil {
+		return m.EncryptedPathPrefix
+	}
+	return ""
+}
+
+func (m *Access) GetPathKey() []byte {
+	if m != nil {
+		return m.PathKey
+	}
+	return nil
+}
+
+func (m *Access) GetBucketContentKey() []byte {
+	if m != nil {
+		return m.BucketContentKey
+	}
+	return nil
+}
+
+func (m *Access) GetNotAfter() *timestamp.Timestamp {
+	if m != nil {
+		return m.NotAfter
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Caveat)(nil), "access.Caveat")
+	proto.RegisterType((*RestrictedAPIKey)(nil), "access.RestrictedAPIKey")
+	proto.RegisterType((*Access)(nil), "access.Access")
+}
+
+func init() { proto.RegisterFile("access.proto", fileDescriptor_access_6a1d37183655af14) }
+
+var fileDescriptor_access_6a1d37183655af14 = []byte{
+	// 346 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x90, 0x3d, 0x6f, 0xe2, 0x40,
+	0x10, 0x86, 0x65, 0xe0, 0x8c, 0x3d, 0x50, 0x70, 0x7b, 0xba, 0x3b, 0x8e, 0x8b, 0x04, 0xa2, 0x42,
+	0x4a, 0x64, 0x24, 0x52, 0xa4, 0x76, 0x68, 0x12, 0x51, 0x04, 0x59, 0xa9, 0xd2, 0x58, 0x6b, 0x7b,
+	0x20, 0x16, 0x66, 0x77, 0xb5, 0x3b, 0x44, 0x71, 0x95, 0x5f, 0x93, 0xff, 0x19, 0x79, 0xcd, 0x47,
+	0x44, 0x9a, 0x94, 0xfb, 0xce, 0xb3, 0x7a, 0x3f, 0xa0, 0xcb, 0xd3, 0x14, 0x8d, 0x09, 0x94, 0x96,
+	0x24, 0x99, 0x5b, 0xbf, 0x06, 0xc3, 0xb5, 0x94, 0xeb, 0x02, 0xa7, 0x56, 0x4d, 0x76, 0xab, 0x29,
+	0xe5, 0x5b, 0x34, 0xc4, 0xb7, 0xaa, 0x06, 0xc7, 0x6f, 0xe0, 0xce, 0xf9, 0x0b, 0x72, 0x62, 0x43,
+	0xe8, 0x28, 0x4e, 0xcf, 0xb1, 0xd2, 0xb8, 0xca, 0x5f, 0xfb, 0xce, 0xc8, 0x99, 0xf8, 0x11, 0x54,
+	0xd2, 0xd2, 0x2a, 0xec, 0x3f, 0xf8, 0x1a, 0x79, 0x16, 0x4b, 0x51, 0x94, 0xfd, 0xc6, 0xc8, 0x99,
+	0x78, 0x91, 0x57, 0x09, 0x0f, 0xa2, 0x28, 0xd9, 0x0d, 0xf8, 0x42, 0x52, 0xcc, 0x57, 0x84, 0xba,
+	0xdf, 0x1c, 0x39, 0x93, 0xce, 0x6c, 0x10, 0xd4, 0xe6, 0xc1, 0xc1, 0x3c, 0x78, 0x3c, 0x98, 0x47,
+	0x9e, 0x90, 0x14, 0x56, 0xec, 0xf8, 0x0e, 0x7a, 0x11, 0x1a, 0xd2, 0x79, 0x4a, 0x98, 0x85, 0xcb,
+	0xfb, 0x05, 0x96, 0xec, 0x0f, 0xb8, 0xa9, 0x0d, 0x65, 0x53, 0x74, 0xa3, 0xfd, 0x8b, 0x5d, 0x80,
+	0x6f, 0xf2, 0xb5, 0xe0, 0xb4, 0xd3, 0x68, 0x13, 0x74, 0xa3, 0x93, 0x30, 0x7e, 0x6f, 0x80, 0x1b,
+	0xda, 0xda, 0xec, 0x12, 0x7e, 0x1a, 0x4e, 0x58, 0x14, 0x39, 0x61, 0xcc, 0xb3, 0x4c, 0xa3, 0x31,
+	0xfb, 0x46, 0xbd, 0xe3, 0x21, 0xac, 0x75, 0xf6, 0x17, 0xda, 0x5c, 0xe5, 0xf1, 0x06, 0xeb, 0x56,
+	0x7e, 0xe4, 0x72, 0x95, 0x57, 0x31, 0xce, 0x16, 0x69, 0x7e, 0x59, 0x64, 0x06, 0xbf, 0x51, 0xa4,
+	0xba, 0x54, 0x84, 0x59, 0xfc, 0x19, 0x6d, 0x59, 0xf4, 0xd7, 0xf1, 0xb8, 0x3c, 0xfd, 0xf9, 0x07,
+	0x9e, 0x25, 0x2b, 0xbb, 0x1f, 0xb6, 0x42, 0xbb, 0x7a, 0x57, 0x7e, 0x57, 0xc0, 0x92, 0x5d, 0xba,
+	0x41, 0x8a, 0x53, 0x29, 0x08, 0x05, 0x59, 0xc8, 0xb5, 0x50, 0xaf, 0xbe, 0xcc, 0xeb, 0xc3, 0x02,
+	0xcf, 0x16, 0x6f, 0x7f, 0x7f, 0xf1, 0xdb, 0xd6, 0x53, 0x43, 0x25, 0x89, 0x6b, 0x99, 0xeb, 0x8f,
+	0x01, 0x00, 0x8b, 0xd8, 0x05, 0xe6, 0x38, 0x02, 0x00, 0x00,
+}
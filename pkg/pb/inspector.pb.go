@@ -7,6 +7,7 @@ import proto "github.com/gogo/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import _ "github.com/gogo/protobuf/gogoproto"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
 
 import (
 	context "golang.org/x/net/context"
@@ -24,6 +25,233 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+// PendingAudit
+type PendingAudit struct {
+	NodeId               NodeID               `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	PieceId              string               `protobuf:"bytes,2,opt,name=piece_id,json=pieceId,proto3" json:"piece_id,omitempty"`
+	PieceNum             int64                `protobuf:"varint,3,opt,name=piece_num,json=pieceNum,proto3" json:"piece_num,omitempty"`
+	StripeIndex          int64                `protobuf:"varint,4,opt,name=stripe_index,json=stripeIndex,proto3" json:"stripe_index,omitempty"`
+	ShareSize            int64                `protobuf:"varint,5,opt,name=share_size,json=shareSize,proto3" json:"share_size,omitempty"`
+	ReverifyCount        int64                `protobuf:"varint,6,opt,name=reverify_count,json=reverifyCount,proto3" json:"reverify_count,omitempty"`
+	Path                 string               `protobuf:"bytes,7,opt,name=path,proto3" json:"path,omitempty"`
+	CreatedAt            *timestamp.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *PendingAudit) Reset()         { *m = PendingAudit{} }
+func (m *PendingAudit) String() string { return proto.CompactTextString(m) }
+func (*PendingAudit) ProtoMessage()    {}
+func (*PendingAudit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_57217d68dc36899e, []int{0}
+}
+func (m *PendingAudit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PendingAudit.Unmarshal(m, b)
+}
+func (m *PendingAudit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PendingAudit.Marshal(b, m, deterministic)
+}
+func (dst *PendingAudit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PendingAudit.Merge(dst, src)
+}
+func (m *PendingAudit) XXX_Size() int {
+	return xxx_messageInfo_PendingAudit.Size(m)
+}
+func (m *PendingAudit) XXX_DiscardUnknown() {
+	xxx_messageInfo_PendingAudit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PendingAudit proto.InternalMessageInfo
+
+func (m *PendingAudit) GetPieceId() string {
+	if m != nil {
+		return m.PieceId
+	}
+	return ""
+}
+
+func (m *PendingAudit) GetPieceNum() int64 {
+	if m != nil {
+		return m.PieceNum
+	}
+	return 0
+}
+
+func (m *PendingAudit) GetStripeIndex() int64 {
+	if m != nil {
+		return m.StripeIndex
+	}
+	return 0
+}
+
+func (m *PendingAudit) GetShareSize() int64 {
+	if m != nil {
+		return m.ShareSize
+	}
+	return 0
+}
+
+func (m *PendingAudit) GetReverifyCount() int64 {
+	if m != nil {
+		return m.ReverifyCount
+	}
+	return 0
+}
+
+func (m *PendingAudit) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *PendingAudit) GetCreatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.CreatedAt
+	}
+	return nil
+}
+
+// ListPendingAudits
+type ListPendingAuditsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListPendingAuditsRequest) Reset()         { *m = ListPendingAuditsRequest{} }
+func (m *ListPendingAuditsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListPendingAuditsRequest) ProtoMessage()    {}
+func (*ListPendingAuditsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_57217d68dc36899e, []int{1}
+}
+func (m *ListPendingAuditsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPendingAuditsRequest.Unmarshal(m, b)
+}
+func (m *ListPendingAuditsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPendingAuditsRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListPendingAuditsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPendingAuditsRequest.Merge(dst, src)
+}
+func (m *ListPendingAuditsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListPendingAuditsRequest.Size(m)
+}
+func (m *ListPendingAuditsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPendingAuditsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPendingAuditsRequest proto.InternalMessageInfo
+
+type ListPendingAuditsResponse struct {
+	PendingAudits        []*PendingAudit `protobuf:"bytes,1,rep,name=pending_audits,json=pendingAudits" json:"pending_audits,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListPendingAuditsResponse) Reset()         { *m = ListPendingAuditsResponse{} }
+func (m *ListPendingAuditsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListPendingAuditsResponse) ProtoMessage()    {}
+func (*ListPendingAuditsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_57217d68dc36899e, []int{2}
+}
+func (m *ListPendingAuditsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListPendingAuditsResponse.Unmarshal(m, b)
+}
+func (m *ListPendingAuditsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListPendingAuditsResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListPendingAuditsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListPendingAuditsResponse.Merge(dst, src)
+}
+func (m *ListPendingAuditsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListPendingAuditsResponse.Size(m)
+}
+func (m *ListPendingAuditsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListPendingAuditsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListPendingAuditsResponse proto.InternalMessageInfo
+
+func (m *ListPendingAuditsResponse) GetPendingAudits() []*PendingAudit {
+	if m != nil {
+		return m.PendingAudits
+	}
+	return nil
+}
+
+// GetPendingAudit
+type GetPendingAuditRequest struct {
+	NodeId               NodeID   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPendingAuditRequest) Reset()         { *m = GetPendingAuditRequest{} }
+func (m *GetPendingAuditRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPendingAuditRequest) ProtoMessage()    {}
+func (*GetPendingAuditRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_57217d68dc36899e, []int{3}
+}
+func (m *GetPendingAuditRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPendingAuditRequest.Unmarshal(m, b)
+}
+func (m *GetPendingAuditRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPendingAuditRequest.Marshal(b, m, deterministic)
+}
+func (dst *GetPendingAuditRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPendingAuditRequest.Merge(dst, src)
+}
+func (m *GetPendingAuditRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPendingAuditRequest.Size(m)
+}
+func (m *GetPendingAuditRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPendingAuditRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPendingAuditRequest proto.InternalMessageInfo
+
+type GetPendingAuditResponse struct {
+	PendingAudit         *PendingAudit `protobuf:"bytes,1,opt,name=pending_audit,json=pendingAudit" json:"pending_audit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *GetPendingAuditResponse) Reset()         { *m = GetPendingAuditResponse{} }
+func (m *GetPendingAuditResponse) String() string { return proto.CompactTextString(m) }
+func (*GetPendingAuditResponse) ProtoMessage()    {}
+func (*GetPendingAuditResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_57217d68dc36899e, []int{4}
+}
+func (m *GetPendingAuditResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPendingAuditResponse.Unmarshal(m, b)
+}
+func (m *GetPendingAuditResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPendingAuditResponse.Marshal(b, m, deterministic)
+}
+func (dst *GetPendingAuditResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPendingAuditResponse.Merge(dst, src)
+}
+func (m *GetPendingAuditResponse) XXX_Size() int {
+	return xxx_messageInfo_GetPendingAuditResponse.Size(m)
+}
+func (m *GetPendingAuditResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPendingAuditResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPendingAuditResponse proto.InternalMessageInfo
+
+func (m *GetPendingAuditResponse) GetPendingAudit() *PendingAudit {
+	if m != nil {
+		return m.PendingAudit
+	}
+	return nil
+}
+
 // GetStats
 type GetStatsRequest struct {
 	NodeId               NodeID   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
@@ -36,7 +264,7 @@ func (m *GetStatsRequest) Reset()         { *m = GetStatsRequest{} }
 func (m *GetStatsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetStatsRequest) ProtoMessage()    {}
 func (*GetStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{0}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{5}
 }
 func (m *GetStatsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStatsRequest.Unmarshal(m, b)
@@ -57,10 +285,14 @@ func (m *GetStatsRequest) XXX_DiscardUnknown() {
 var xxx_messageInfo_GetStatsRequest proto.InternalMessageInfo
 
 type GetStatsResponse struct {
-	AuditCount           int64    `protobuf:"varint,1,opt,name=audit_count,json=auditCount,proto3" json:"audit_count,omitempty"`
-	AuditRatio           float64  `protobuf:"fixed64,2,opt,name=audit_ratio,json=auditRatio,proto3" json:"audit_ratio,omitempty"`
-	UptimeCount          int64    `protobuf:"varint,3,opt,name=uptime_count,json=uptimeCount,proto3" json:"uptime_count,omitempty"`
-	UptimeRatio          float64  `protobuf:"fixed64,4,opt,name=uptime_ratio,json=uptimeRatio,proto3" json:"uptime_ratio,omitempty"`
+	AuditCount     int64                `protobuf:"varint,1,opt,name=audit_count,json=auditCount,proto3" json:"audit_count,omitempty"`
+	AuditRatio     float64              `protobuf:"fixed64,2,opt,name=audit_ratio,json=auditRatio,proto3" json:"audit_ratio,omitempty"`
+	UptimeCount    int64                `protobuf:"varint,3,opt,name=uptime_count,json=uptimeCount,proto3" json:"uptime_count,omitempty"`
+	UptimeRatio    float64              `protobuf:"fixed64,4,opt,name=uptime_ratio,json=uptimeRatio,proto3" json:"uptime_ratio,omitempty"`
+	State          NodeState            `protobuf:"varint,5,opt,name=state,proto3,enum=node.NodeState" json:"state,omitempty"`
+	StateUpdatedAt *timestamp.Timestamp `protobuf:"bytes,6,opt,name=state_updated_at,json=stateUpdatedAt" json:"state_updated_at,omitempty"`
+	// whether the state was set by an operator, it's then kept until released
+	StateOverride        bool     `protobuf:"varint,7,opt,name=state_override,json=stateOverride,proto3" json:"state_override,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -70,7 +302,7 @@ func (m *GetStatsResponse) Reset()         { *m = GetStatsResponse{} }
 func (m *GetStatsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetStatsResponse) ProtoMessage()    {}
 func (*GetStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{1}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{6}
 }
 func (m *GetStatsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetStatsResponse.Unmarshal(m, b)
@@ -118,6 +350,27 @@ func (m *GetStatsResponse) GetUptimeRatio() float64 {
 	return 0
 }
 
+func (m *GetStatsResponse) GetState() NodeState {
+	if m != nil {
+		return m.State
+	}
+	return NodeState_VETTING
+}
+
+func (m *GetStatsResponse) GetStateUpdatedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.StateUpdatedAt
+	}
+	return nil
+}
+
+func (m *GetStatsResponse) GetStateOverride() bool {
+	if m != nil {
+		return m.StateOverride
+	}
+	return false
+}
+
 // CreateStats
 type CreateStatsRequest struct {
 	NodeId               NodeID   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
@@ -134,7 +387,7 @@ func (m *CreateStatsRequest) Reset()         { *m = CreateStatsRequest{} }
 func (m *CreateStatsRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateStatsRequest) ProtoMessage()    {}
 func (*CreateStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{2}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{7}
 }
 func (m *CreateStatsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateStatsRequest.Unmarshal(m, b)
@@ -192,7 +445,7 @@ func (m *CreateStatsResponse) Reset()         { *m = CreateStatsResponse{} }
 func (m *CreateStatsResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateStatsResponse) ProtoMessage()    {}
 func (*CreateStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{3}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{8}
 }
 func (m *CreateStatsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateStatsResponse.Unmarshal(m, b)
@@ -212,6 +465,85 @@ func (m *CreateStatsResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_CreateStatsResponse proto.InternalMessageInfo
 
+// UpdateNodeState
+type UpdateNodeStateRequest struct {
+	NodeId NodeID    `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	State  NodeState `protobuf:"varint,2,opt,name=state,proto3,enum=node.NodeState" json:"state,omitempty"`
+	// release lets the node's stats move it between states again instead of setting state
+	Release              bool     `protobuf:"varint,3,opt,name=release,proto3" json:"release,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateNodeStateRequest) Reset()         { *m = UpdateNodeStateRequest{} }
+func (m *UpdateNodeStateRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateNodeStateRequest) ProtoMessage()    {}
+func (*UpdateNodeStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_57217d68dc36899e, []int{9}
+}
+func (m *UpdateNodeStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateNodeStateRequest.Unmarshal(m, b)
+}
+func (m *UpdateNodeStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateNodeStateRequest.Marshal(b, m, deterministic)
+}
+func (dst *UpdateNodeStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateNodeStateRequest.Merge(dst, src)
+}
+func (m *UpdateNodeStateRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateNodeStateRequest.Size(m)
+}
+func (m *UpdateNodeStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateNodeStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateNodeStateRequest proto.InternalMessageInfo
+
+func (m *UpdateNodeStateRequest) GetState() NodeState {
+	if m != nil {
+		return m.State
+	}
+	return NodeState_VETTING
+}
+
+func (m *UpdateNodeStateRequest) GetRelease() bool {
+	if m != nil {
+		return m.Release
+	}
+	return false
+}
+
+type UpdateNodeStateResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateNodeStateResponse) Reset()         { *m = UpdateNodeStateResponse{} }
+func (m *UpdateNodeStateResponse) String() string { return proto.CompactTextString(m) }
+func (*UpdateNodeStateResponse) ProtoMessage()    {}
+func (*UpdateNodeStateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_57217d68dc36899e, []int{10}
+}
+func (m *UpdateNodeStateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateNodeStateResponse.Unmarshal(m, b)
+}
+func (m *UpdateNodeStateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateNodeStateResponse.Marshal(b, m, deterministic)
+}
+func (dst *UpdateNodeStateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateNodeStateResponse.Merge(dst, src)
+}
+func (m *UpdateNodeStateResponse) XXX_Size() int {
+	return xxx_messageInfo_UpdateNodeStateResponse.Size(m)
+}
+func (m *UpdateNodeStateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateNodeStateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateNodeStateResponse proto.InternalMessageInfo
+
 // CountNodes
 type CountNodesResponse struct {
 	Kademlia             int64    `protobuf:"varint,1,opt,name=kademlia,proto3" json:"kademlia,omitempty"`
@@ -225,7 +557,7 @@ func (m *CountNodesResponse) Reset()         { *m = CountNodesResponse{} }
 func (m *CountNodesResponse) String() string { return proto.CompactTextString(m) }
 func (*CountNodesResponse) ProtoMessage()    {}
 func (*CountNodesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{4}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{11}
 }
 func (m *CountNodesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CountNodesResponse.Unmarshal(m, b)
@@ -269,7 +601,7 @@ func (m *CountNodesRequest) Reset()         { *m = CountNodesRequest{} }
 func (m *CountNodesRequest) String() string { return proto.CompactTextString(m) }
 func (*CountNodesRequest) ProtoMessage()    {}
 func (*CountNodesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{5}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{12}
 }
 func (m *CountNodesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CountNodesRequest.Unmarshal(m, b)
@@ -300,7 +632,7 @@ func (m *GetBucketsRequest) Reset()         { *m = GetBucketsRequest{} }
 func (m *GetBucketsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBucketsRequest) ProtoMessage()    {}
 func (*GetBucketsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{6}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{13}
 }
 func (m *GetBucketsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBucketsRequest.Unmarshal(m, b)
@@ -332,7 +664,7 @@ func (m *GetBucketsResponse) Reset()         { *m = GetBucketsResponse{} }
 func (m *GetBucketsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBucketsResponse) ProtoMessage()    {}
 func (*GetBucketsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{7}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{14}
 }
 func (m *GetBucketsResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBucketsResponse.Unmarshal(m, b)
@@ -371,7 +703,7 @@ func (m *GetBucketRequest) Reset()         { *m = GetBucketRequest{} }
 func (m *GetBucketRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBucketRequest) ProtoMessage()    {}
 func (*GetBucketRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{8}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{15}
 }
 func (m *GetBucketRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBucketRequest.Unmarshal(m, b)
@@ -403,7 +735,7 @@ func (m *GetBucketResponse) Reset()         { *m = GetBucketResponse{} }
 func (m *GetBucketResponse) String() string { return proto.CompactTextString(m) }
 func (*GetBucketResponse) ProtoMessage()    {}
 func (*GetBucketResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{9}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{16}
 }
 func (m *GetBucketResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetBucketResponse.Unmarshal(m, b)
@@ -441,7 +773,7 @@ func (m *Bucket) Reset()         { *m = Bucket{} }
 func (m *Bucket) String() string { return proto.CompactTextString(m) }
 func (*Bucket) ProtoMessage()    {}
 func (*Bucket) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{10}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{17}
 }
 func (m *Bucket) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Bucket.Unmarshal(m, b)
@@ -479,7 +811,7 @@ func (m *BucketList) Reset()         { *m = BucketList{} }
 func (m *BucketList) String() string { return proto.CompactTextString(m) }
 func (*BucketList) ProtoMessage()    {}
 func (*BucketList) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{11}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{18}
 }
 func (m *BucketList) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_BucketList.Unmarshal(m, b)
@@ -519,7 +851,7 @@ func (m *PingNodeRequest) Reset()         { *m = PingNodeRequest{} }
 func (m *PingNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*PingNodeRequest) ProtoMessage()    {}
 func (*PingNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{12}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{19}
 }
 func (m *PingNodeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingNodeRequest.Unmarshal(m, b)
@@ -557,7 +889,7 @@ func (m *PingNodeResponse) Reset()         { *m = PingNodeResponse{} }
 func (m *PingNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*PingNodeResponse) ProtoMessage()    {}
 func (*PingNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{13}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{20}
 }
 func (m *PingNodeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingNodeResponse.Unmarshal(m, b)
@@ -596,7 +928,7 @@ func (m *LookupNodeRequest) Reset()         { *m = LookupNodeRequest{} }
 func (m *LookupNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*LookupNodeRequest) ProtoMessage()    {}
 func (*LookupNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{14}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{21}
 }
 func (m *LookupNodeRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupNodeRequest.Unmarshal(m, b)
@@ -642,7 +974,7 @@ func (m *LookupNodeResponse) Reset()         { *m = LookupNodeResponse{} }
 func (m *LookupNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*LookupNodeResponse) ProtoMessage()    {}
 func (*LookupNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_inspector_4d95b6ca0d033b47, []int{15}
+	return fileDescriptor_inspector_57217d68dc36899e, []int{22}
 }
 func (m *LookupNodeResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupNodeResponse.Unmarshal(m, b)
@@ -676,11 +1008,164 @@ func (m *LookupNodeResponse) GetMeta() *NodeMetadata {
 	return nil
 }
 
+// ExportRoutingTable
+type ExportRoutingTableRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportRoutingTableRequest) Reset()         { *m = ExportRoutingTableRequest{} }
+func (m *ExportRoutingTableRequest) String() string { return proto.CompactTextString(m) }
+func (*ExportRoutingTableRequest) ProtoMessage()    {}
+func (*ExportRoutingTableRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_57217d68dc36899e, []int{23}
+}
+func (m *ExportRoutingTableRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportRoutingTableRequest.Unmarshal(m, b)
+}
+func (m *ExportRoutingTableRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportRoutingTableRequest.Marshal(b, m, deterministic)
+}
+func (dst *ExportRoutingTableRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportRoutingTableRequest.Merge(dst, src)
+}
+func (m *ExportRoutingTableRequest) XXX_Size() int {
+	return xxx_messageInfo_ExportRoutingTableRequest.Size(m)
+}
+func (m *ExportRoutingTableRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportRoutingTableRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportRoutingTableRequest proto.InternalMessageInfo
+
+type ExportRoutingTableResponse struct {
+	Nodes                []*Node  `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExportRoutingTableResponse) Reset()         { *m = ExportRoutingTableResponse{} }
+func (m *ExportRoutingTableResponse) String() string { return proto.CompactTextString(m) }
+func (*ExportRoutingTableResponse) ProtoMessage()    {}
+func (*ExportRoutingTableResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_57217d68dc36899e, []int{24}
+}
+func (m *ExportRoutingTableResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExportRoutingTableResponse.Unmarshal(m, b)
+}
+func (m *ExportRoutingTableResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExportRoutingTableResponse.Marshal(b, m, deterministic)
+}
+func (dst *ExportRoutingTableResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExportRoutingTableResponse.Merge(dst, src)
+}
+func (m *ExportRoutingTableResponse) XXX_Size() int {
+	return xxx_messageInfo_ExportRoutingTableResponse.Size(m)
+}
+func (m *ExportRoutingTableResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExportRoutingTableResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExportRoutingTableResponse proto.InternalMessageInfo
+
+func (m *ExportRoutingTableResponse) GetNodes() []*Node {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+// ImportRoutingTable
+type ImportRoutingTableRequest struct {
+	Nodes                []*Node  `protobuf:"bytes,1,rep,name=nodes" json:"nodes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportRoutingTableRequest) Reset()         { *m = ImportRoutingTableRequest{} }
+func (m *ImportRoutingTableRequest) String() string { return proto.CompactTextString(m) }
+func (*ImportRoutingTableRequest) ProtoMessage()    {}
+func (*ImportRoutingTableRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_57217d68dc36899e, []int{25}
+}
+func (m *ImportRoutingTableRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportRoutingTableRequest.Unmarshal(m, b)
+}
+func (m *ImportRoutingTableRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportRoutingTableRequest.Marshal(b, m, deterministic)
+}
+func (dst *ImportRoutingTableRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportRoutingTableRequest.Merge(dst, src)
+}
+func (m *ImportRoutingTableRequest) XXX_Size() int {
+	return xxx_messageInfo_ImportRoutingTableRequest.Size(m)
+}
+func (m *ImportRoutingTableRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportRoutingTableRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportRoutingTableRequest proto.InternalMessageInfo
+
+func (m *ImportRoutingTableRequest) GetNodes() []*Node {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+type ImportRoutingTableResponse struct {
+	Responding           int64    `protobuf:"varint,1,opt,name=responding,proto3" json:"responding,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ImportRoutingTableResponse) Reset()         { *m = ImportRoutingTableResponse{} }
+func (m *ImportRoutingTableResponse) String() string { return proto.CompactTextString(m) }
+func (*ImportRoutingTableResponse) ProtoMessage()    {}
+func (*ImportRoutingTableResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_inspector_57217d68dc36899e, []int{26}
+}
+func (m *ImportRoutingTableResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ImportRoutingTableResponse.Unmarshal(m, b)
+}
+func (m *ImportRoutingTableResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ImportRoutingTableResponse.Marshal(b, m, deterministic)
+}
+func (dst *ImportRoutingTableResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportRoutingTableResponse.Merge(dst, src)
+}
+func (m *ImportRoutingTableResponse) XXX_Size() int {
+	return xxx_messageInfo_ImportRoutingTableResponse.Size(m)
+}
+func (m *ImportRoutingTableResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportRoutingTableResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportRoutingTableResponse proto.InternalMessageInfo
+
+func (m *ImportRoutingTableResponse) GetResponding() int64 {
+	if m != nil {
+		return m.Responding
+	}
+	return 0
+}
+
 func init() {
+	proto.RegisterType((*PendingAudit)(nil), "inspector.PendingAudit")
+	proto.RegisterType((*ListPendingAuditsRequest)(nil), "inspector.ListPendingAuditsRequest")
+	proto.RegisterType((*ListPendingAuditsResponse)(nil), "inspector.ListPendingAuditsResponse")
+	proto.RegisterType((*GetPendingAuditRequest)(nil), "inspector.GetPendingAuditRequest")
+	proto.RegisterType((*GetPendingAuditResponse)(nil), "inspector.GetPendingAuditResponse")
 	proto.RegisterType((*GetStatsRequest)(nil), "inspector.GetStatsRequest")
 	proto.RegisterType((*GetStatsResponse)(nil), "inspector.GetStatsResponse")
 	proto.RegisterType((*CreateStatsRequest)(nil), "inspector.CreateStatsRequest")
 	proto.RegisterType((*CreateStatsResponse)(nil), "inspector.CreateStatsResponse")
+	proto.RegisterType((*UpdateNodeStateRequest)(nil), "inspector.UpdateNodeStateRequest")
+	proto.RegisterType((*UpdateNodeStateResponse)(nil), "inspector.UpdateNodeStateResponse")
 	proto.RegisterType((*CountNodesResponse)(nil), "inspector.CountNodesResponse")
 	proto.RegisterType((*CountNodesRequest)(nil), "inspector.CountNodesRequest")
 	proto.RegisterType((*GetBucketsRequest)(nil), "inspector.GetBucketsRequest")
@@ -693,6 +1178,10 @@ func init() {
 	proto.RegisterType((*PingNodeResponse)(nil), "inspector.PingNodeResponse")
 	proto.RegisterType((*LookupNodeRequest)(nil), "inspector.LookupNodeRequest")
 	proto.RegisterType((*LookupNodeResponse)(nil), "inspector.LookupNodeResponse")
+	proto.RegisterType((*ExportRoutingTableRequest)(nil), "inspector.ExportRoutingTableRequest")
+	proto.RegisterType((*ExportRoutingTableResponse)(nil), "inspector.ExportRoutingTableResponse")
+	proto.RegisterType((*ImportRoutingTableRequest)(nil), "inspector.ImportRoutingTableRequest")
+	proto.RegisterType((*ImportRoutingTableResponse)(nil), "inspector.ImportRoutingTableResponse")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -718,11 +1207,22 @@ type InspectorClient interface {
 	PingNode(ctx context.Context, in *PingNodeRequest, opts ...grpc.CallOption) (*PingNodeResponse, error)
 	// LookupNode triggers a Kademlia FindNode and returns the response
 	LookupNode(ctx context.Context, in *LookupNodeRequest, opts ...grpc.CallOption) (*LookupNodeResponse, error)
+	// ExportRoutingTable returns every node in the routing table
+	ExportRoutingTable(ctx context.Context, in *ExportRoutingTableRequest, opts ...grpc.CallOption) (*ExportRoutingTableResponse, error)
+	// ImportRoutingTable pings the given nodes, adding those that respond to the routing table
+	ImportRoutingTable(ctx context.Context, in *ImportRoutingTableRequest, opts ...grpc.CallOption) (*ImportRoutingTableResponse, error)
 	// StatDB commands:
 	// GetStats returns the stats for a particular node ID
 	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
 	// CreateStats creates a node with specified stats
 	CreateStats(ctx context.Context, in *CreateStatsRequest, opts ...grpc.CallOption) (*CreateStatsResponse, error)
+	// UpdateNodeState overrides the state of a node
+	UpdateNodeState(ctx context.Context, in *UpdateNodeStateRequest, opts ...grpc.CallOption) (*UpdateNodeStateResponse, error)
+	// Audit commands:
+	// ListPendingAudits returns the pending audits of all contained nodes
+	ListPendingAudits(ctx context.Context, in *ListPendingAuditsRequest, opts ...grpc.CallOption) (*ListPendingAuditsResponse, error)
+	// GetPendingAudit returns the pending audit of a contained node
+	GetPendingAudit(ctx context.Context, in *GetPendingAuditRequest, opts ...grpc.CallOption) (*GetPendingAuditResponse, error)
 }
 
 type inspectorClient struct {
@@ -778,6 +1278,24 @@ func (c *inspectorClient) LookupNode(ctx context.Context, in *LookupNodeRequest,
 	return out, nil
 }
 
+func (c *inspectorClient) ExportRoutingTable(ctx context.Context, in *ExportRoutingTableRequest, opts ...grpc.CallOption) (*ExportRoutingTableResponse, error) {
+	out := new(ExportRoutingTableResponse)
+	err := c.cc.Invoke(ctx, "/inspector.Inspector/ExportRoutingTable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inspectorClient) ImportRoutingTable(ctx context.Context, in *ImportRoutingTableRequest, opts ...grpc.CallOption) (*ImportRoutingTableResponse, error) {
+	out := new(ImportRoutingTableResponse)
+	err := c.cc.Invoke(ctx, "/inspector.Inspector/ImportRoutingTable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *inspectorClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
 	out := new(GetStatsResponse)
 	err := c.cc.Invoke(ctx, "/inspector.Inspector/GetStats", in, out, opts...)
@@ -796,6 +1314,33 @@ func (c *inspectorClient) CreateStats(ctx context.Context, in *CreateStatsReques
 	return out, nil
 }
 
+func (c *inspectorClient) UpdateNodeState(ctx context.Context, in *UpdateNodeStateRequest, opts ...grpc.CallOption) (*UpdateNodeStateResponse, error) {
+	out := new(UpdateNodeStateResponse)
+	err := c.cc.Invoke(ctx, "/inspector.Inspector/UpdateNodeState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inspectorClient) ListPendingAudits(ctx context.Context, in *ListPendingAuditsRequest, opts ...grpc.CallOption) (*ListPendingAuditsResponse, error) {
+	out := new(ListPendingAuditsResponse)
+	err := c.cc.Invoke(ctx, "/inspector.Inspector/ListPendingAudits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inspectorClient) GetPendingAudit(ctx context.Context, in *GetPendingAuditRequest, opts ...grpc.CallOption) (*GetPendingAuditResponse, error) {
+	out := new(GetPendingAuditResponse)
+	err := c.cc.Invoke(ctx, "/inspector.Inspector/GetPendingAudit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InspectorServer is the server API for Inspector service.
 type InspectorServer interface {
 	// Kad/Overlay commands:
@@ -809,11 +1354,22 @@ type InspectorServer interface {
 	PingNode(context.Context, *PingNodeRequest) (*PingNodeResponse, error)
 	// LookupNode triggers a Kademlia FindNode and returns the response
 	LookupNode(context.Context, *LookupNodeRequest) (*LookupNodeResponse, error)
+	// ExportRoutingTable returns every node in the routing table
+	ExportRoutingTable(context.Context, *ExportRoutingTableRequest) (*ExportRoutingTableResponse, error)
+	// ImportRoutingTable pings the given nodes, adding those that respond to the routing table
+	ImportRoutingTable(context.Context, *ImportRoutingTableRequest) (*ImportRoutingTableResponse, error)
 	// StatDB commands:
 	// GetStats returns the stats for a particular node ID
 	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
 	// CreateStats creates a node with specified stats
 	CreateStats(context.Context, *CreateStatsRequest) (*CreateStatsResponse, error)
+	// UpdateNodeState overrides the state of a node
+	UpdateNodeState(context.Context, *UpdateNodeStateRequest) (*UpdateNodeStateResponse, error)
+	// Audit commands:
+	// ListPendingAudits returns the pending audits of all contained nodes
+	ListPendingAudits(context.Context, *ListPendingAuditsRequest) (*ListPendingAuditsResponse, error)
+	// GetPendingAudit returns the pending audit of a contained node
+	GetPendingAudit(context.Context, *GetPendingAuditRequest) (*GetPendingAuditResponse, error)
 }
 
 func RegisterInspectorServer(s *grpc.Server, srv InspectorServer) {
@@ -910,6 +1466,42 @@ func _Inspector_LookupNode_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Inspector_ExportRoutingTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRoutingTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InspectorServer).ExportRoutingTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.Inspector/ExportRoutingTable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InspectorServer).ExportRoutingTable(ctx, req.(*ExportRoutingTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inspector_ImportRoutingTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportRoutingTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InspectorServer).ImportRoutingTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.Inspector/ImportRoutingTable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InspectorServer).ImportRoutingTable(ctx, req.(*ImportRoutingTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Inspector_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetStatsRequest)
 	if err := dec(in); err != nil {
@@ -946,6 +1538,60 @@ func _Inspector_CreateStats_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Inspector_UpdateNodeState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateNodeStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InspectorServer).UpdateNodeState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.Inspector/UpdateNodeState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InspectorServer).UpdateNodeState(ctx, req.(*UpdateNodeStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inspector_ListPendingAudits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingAuditsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InspectorServer).ListPendingAudits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.Inspector/ListPendingAudits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InspectorServer).ListPendingAudits(ctx, req.(*ListPendingAuditsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Inspector_GetPendingAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPendingAuditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InspectorServer).GetPendingAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/inspector.Inspector/GetPendingAudit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InspectorServer).GetPendingAudit(ctx, req.(*GetPendingAuditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Inspector_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "inspector.Inspector",
 	HandlerType: (*InspectorServer)(nil),
@@ -970,6 +1616,14 @@ var _Inspector_serviceDesc = grpc.ServiceDesc{
 			MethodName: "LookupNode",
 			Handler:    _Inspector_LookupNode_Handler,
 		},
+		{
+			MethodName: "ExportRoutingTable",
+			Handler:    _Inspector_ExportRoutingTable_Handler,
+		},
+		{
+			MethodName: "ImportRoutingTable",
+			Handler:    _Inspector_ImportRoutingTable_Handler,
+		},
 		{
 			MethodName: "GetStats",
 			Handler:    _Inspector_GetStats_Handler,
@@ -978,53 +1632,95 @@ var _Inspector_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateStats",
 			Handler:    _Inspector_CreateStats_Handler,
 		},
+		{
+			MethodName: "UpdateNodeState",
+			Handler:    _Inspector_UpdateNodeState_Handler,
+		},
+		{
+			MethodName: "ListPendingAudits",
+			Handler:    _Inspector_ListPendingAudits_Handler,
+		},
+		{
+			MethodName: "GetPendingAudit",
+			Handler:    _Inspector_GetPendingAudit_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "inspector.proto",
 }
 
-func init() { proto.RegisterFile("inspector.proto", fileDescriptor_inspector_4d95b6ca0d033b47) }
-
-var fileDescriptor_inspector_4d95b6ca0d033b47 = []byte{
-	// 636 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xdd, 0x8e, 0xd2, 0x40,
-	0x18, 0xb5, 0xa5, 0xcb, 0xc2, 0x07, 0x81, 0x65, 0x58, 0x13, 0x52, 0x70, 0xc1, 0xb9, 0x50, 0xe2,
-	0x05, 0x31, 0x78, 0x67, 0xe2, 0x0d, 0x18, 0x57, 0x14, 0x8d, 0xe9, 0xc6, 0x1b, 0x63, 0xb2, 0x99,
-	0xa5, 0x13, 0xd2, 0xf0, 0x33, 0xb5, 0x33, 0x35, 0xf1, 0x1d, 0x7c, 0x02, 0x9f, 0xc8, 0x67, 0xf0,
-	0x62, 0x6f, 0x7c, 0x11, 0xd3, 0x99, 0xe9, 0x1f, 0x2d, 0xd9, 0x8d, 0x77, 0xcc, 0x77, 0xce, 0x9c,
-	0xf9, 0xce, 0x9c, 0x8f, 0x29, 0xb4, 0xbd, 0x3d, 0xf7, 0xe9, 0x4a, 0xb0, 0x60, 0xe2, 0x07, 0x4c,
-	0x30, 0x54, 0x4f, 0x0a, 0x36, 0xac, 0xd9, 0x9a, 0xa9, 0xb2, 0x0d, 0x7b, 0xe6, 0x52, 0xf5, 0x1b,
-	0xbf, 0x84, 0xf6, 0x25, 0x15, 0x57, 0x82, 0x08, 0xee, 0xd0, 0x6f, 0x21, 0xe5, 0x02, 0x3d, 0x85,
-	0xd3, 0x88, 0x70, 0xed, 0xb9, 0x3d, 0x63, 0x64, 0x8c, 0x9b, 0xb3, 0xd6, 0xef, 0xdb, 0xe1, 0x83,
-	0x3f, 0xb7, 0xc3, 0xea, 0x47, 0xe6, 0xd2, 0xc5, 0x6b, 0xa7, 0x1a, 0xc1, 0x0b, 0x17, 0xff, 0x32,
-	0xe0, 0x2c, 0xdd, 0xcc, 0x7d, 0xb6, 0xe7, 0x14, 0x0d, 0xa1, 0x41, 0x42, 0xd7, 0x13, 0xd7, 0x2b,
-	0x16, 0xee, 0x85, 0x54, 0xa8, 0x38, 0x20, 0x4b, 0xf3, 0xa8, 0x92, 0x12, 0x02, 0x22, 0x3c, 0xd6,
-	0x33, 0x47, 0xc6, 0xd8, 0xd0, 0x04, 0x27, 0xaa, 0xa0, 0xc7, 0xd0, 0x0c, 0x7d, 0xe1, 0xed, 0xa8,
-	0x96, 0xa8, 0x48, 0x89, 0x86, 0xaa, 0x29, 0x8d, 0x94, 0xa2, 0x44, 0x2c, 0x29, 0xa2, 0x29, 0x52,
-	0x05, 0xff, 0x35, 0x00, 0xcd, 0x03, 0x4a, 0x04, 0xfd, 0x2f, 0x73, 0x87, 0x3e, 0xcc, 0x82, 0x8f,
-	0x09, 0x74, 0x15, 0x81, 0x87, 0xab, 0x15, 0xe5, 0x3c, 0xd7, 0x6d, 0x47, 0x42, 0x57, 0x0a, 0x39,
-	0xec, 0x59, 0x11, 0xad, 0xa2, 0xad, 0xe7, 0x70, 0xae, 0x29, 0x79, 0xcd, 0x13, 0x49, 0x45, 0x0a,
-	0xcb, 0x8a, 0xe2, 0x87, 0xd0, 0xcd, 0x99, 0x54, 0x21, 0xe0, 0x77, 0x80, 0x24, 0x1e, 0x79, 0x4a,
-	0xa3, 0xb1, 0xa1, 0xb6, 0x21, 0x2e, 0xdd, 0x6d, 0x3d, 0xa2, 0x73, 0x49, 0xd6, 0xa8, 0x07, 0xa7,
-	0xec, 0x3b, 0x0d, 0xb6, 0xe4, 0x87, 0xb6, 0x1a, 0x2f, 0x71, 0x17, 0x3a, 0x59, 0x2d, 0x79, 0x8d,
-	0x51, 0xf1, 0x92, 0x8a, 0x59, 0xb8, 0xda, 0xd0, 0xe4, 0x6e, 0xf1, 0x5b, 0x40, 0xd9, 0xa2, 0x3e,
-	0xf5, 0x1c, 0x4e, 0x04, 0x13, 0x64, 0xab, 0x8f, 0x54, 0x0b, 0x34, 0x80, 0x8a, 0xe7, 0xf2, 0x9e,
-	0x39, 0xaa, 0x8c, 0x9b, 0x33, 0xc8, 0xdc, 0x7f, 0x54, 0xc6, 0x53, 0x39, 0x58, 0x4a, 0x29, 0x4e,
-	0xee, 0x02, 0xcc, 0xa3, 0xa1, 0x99, 0x9e, 0x8b, 0x3f, 0x67, 0x5a, 0x4a, 0x0e, 0xbf, 0x63, 0x13,
-	0x1a, 0xc1, 0x49, 0x94, 0xb7, 0x6a, 0xa4, 0x31, 0x85, 0x89, 0xfc, 0x6b, 0x44, 0x04, 0x47, 0x01,
-	0xf8, 0x19, 0x54, 0x95, 0xe6, 0x3d, 0xb8, 0x13, 0x00, 0xc5, 0x5d, 0x7a, 0x3c, 0xc3, 0x37, 0x8e,
-	0xf1, 0xdf, 0x43, 0xfb, 0x93, 0xb7, 0x5f, 0xcb, 0xd2, 0xfd, 0x5c, 0x46, 0x39, 0x11, 0xd7, 0x0d,
-	0x28, 0xe7, 0x32, 0xa7, 0xba, 0x13, 0x2f, 0x31, 0x86, 0xb3, 0x54, 0x4c, 0xdb, 0x6f, 0x81, 0xc9,
-	0x36, 0x52, 0xad, 0xe6, 0x98, 0x6c, 0x83, 0x5f, 0x41, 0x67, 0xc9, 0xd8, 0x26, 0xf4, 0xb3, 0x47,
-	0xb6, 0x92, 0x23, 0xeb, 0x77, 0x1c, 0xf1, 0x15, 0x50, 0x76, 0x7b, 0x72, 0xc7, 0x56, 0x64, 0x47,
-	0x2a, 0xe4, 0x6d, 0xca, 0x3a, 0x7a, 0x02, 0xd6, 0x8e, 0x0a, 0x22, 0xc5, 0x1a, 0x53, 0x94, 0xe2,
-	0x1f, 0xa8, 0x20, 0x2e, 0x11, 0xc4, 0x91, 0xf8, 0xf4, 0xa7, 0x05, 0xf5, 0x45, 0xfc, 0x60, 0xa1,
-	0x05, 0x40, 0x3a, 0x76, 0x68, 0x30, 0x49, 0xdf, 0xb6, 0xc2, 0x34, 0xda, 0x8f, 0x8e, 0xa0, 0xba,
-	0xc1, 0x05, 0x40, 0x3a, 0x97, 0x39, 0xa9, 0xc2, 0x0c, 0xe7, 0xa4, 0x4a, 0x86, 0xf9, 0x0d, 0xd4,
-	0x93, 0x2a, 0xea, 0x97, 0x71, 0x63, 0xa1, 0x41, 0x39, 0xa8, 0x75, 0xe6, 0x50, 0x8b, 0xc3, 0x42,
-	0x76, 0x86, 0x79, 0x30, 0x0e, 0x76, 0xbf, 0x14, 0x4b, 0x7d, 0xa5, 0x71, 0xe4, 0x7c, 0x15, 0x42,
-	0xce, 0xf9, 0x2a, 0xc9, 0x70, 0x0e, 0xb5, 0xf8, 0x25, 0xcf, 0xf5, 0x73, 0xf0, 0x6d, 0xb0, 0xfb,
-	0xa5, 0x98, 0x16, 0x59, 0x42, 0x23, 0xf3, 0x18, 0xa1, 0x5c, 0x2a, 0x85, 0x97, 0xd8, 0xbe, 0x38,
-	0x06, 0x2b, 0xb5, 0x99, 0xf5, 0xc5, 0xf4, 0x6f, 0x6e, 0xaa, 0xf2, 0x33, 0xf5, 0xe2, 0x5f, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0x1b, 0x61, 0x84, 0xdb, 0xdc, 0x06, 0x00, 0x00,
+func init() { proto.RegisterFile("inspector.proto", fileDescriptor_inspector_57217d68dc36899e) }
+
+var fileDescriptor_inspector_57217d68dc36899e = []byte{
+	// 1107 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x56, 0x5f, 0x73, 0xdb, 0x44,
+	0x10, 0x47, 0x8a, 0xe3, 0xd8, 0xeb, 0xc4, 0x49, 0x2e, 0xa5, 0x51, 0x94, 0x7f, 0xee, 0xd1, 0x80,
+	0x87, 0x07, 0x97, 0x31, 0x4f, 0x30, 0x6d, 0x67, 0x92, 0x14, 0x82, 0x20, 0x94, 0x8e, 0xd2, 0x0e,
+	0x0c, 0x30, 0x78, 0x2e, 0xd6, 0xd5, 0xd5, 0xc4, 0xd2, 0x09, 0xe9, 0xd4, 0x69, 0xfb, 0xc8, 0x97,
+	0xe1, 0x8d, 0xcf, 0xc1, 0x67, 0xe0, 0xa1, 0x2f, 0xcc, 0xf0, 0x39, 0x18, 0xdd, 0x9d, 0x2c, 0xc9,
+	0xb2, 0x6c, 0xb7, 0x6f, 0xba, 0xfd, 0xfd, 0xee, 0xb7, 0x7b, 0xbb, 0x7b, 0xda, 0x83, 0x4d, 0xd7,
+	0x8f, 0x02, 0x3a, 0xe4, 0x2c, 0xec, 0x05, 0x21, 0xe3, 0x0c, 0x35, 0x27, 0x06, 0x13, 0x46, 0x6c,
+	0xc4, 0xa4, 0xd9, 0x3c, 0x1e, 0x31, 0x36, 0x1a, 0xd3, 0x7b, 0x62, 0x75, 0x1d, 0x3f, 0xbf, 0xc7,
+	0x5d, 0x8f, 0x46, 0x9c, 0x78, 0x81, 0x22, 0x80, 0xcf, 0x1c, 0x2a, 0xbf, 0xf1, 0x9f, 0x3a, 0xac,
+	0x3f, 0xa1, 0xbe, 0xe3, 0xfa, 0xa3, 0xd3, 0xd8, 0x71, 0x39, 0xfa, 0x04, 0xd6, 0x12, 0x78, 0xe0,
+	0x3a, 0x86, 0xd6, 0xd1, 0xba, 0xeb, 0x67, 0xed, 0xbf, 0xdf, 0x1e, 0x7f, 0xf0, 0xcf, 0xdb, 0xe3,
+	0xfa, 0x63, 0xe6, 0x50, 0xeb, 0x91, 0x5d, 0x4f, 0x60, 0xcb, 0x41, 0x7b, 0xd0, 0x08, 0x5c, 0x3a,
+	0x14, 0x4c, 0xbd, 0xa3, 0x75, 0x9b, 0xf6, 0x9a, 0x58, 0x5b, 0x0e, 0xda, 0x87, 0xa6, 0x84, 0xfc,
+	0xd8, 0x33, 0x56, 0x3a, 0x5a, 0x77, 0xc5, 0x96, 0xdc, 0xc7, 0xb1, 0x87, 0xee, 0xc0, 0x7a, 0xc4,
+	0x43, 0x37, 0xa0, 0x03, 0xd7, 0x77, 0xe8, 0x2b, 0xa3, 0x26, 0xf0, 0x96, 0xb4, 0x59, 0x89, 0x09,
+	0x1d, 0x02, 0x44, 0x2f, 0x48, 0x48, 0x07, 0x91, 0xfb, 0x86, 0x1a, 0xab, 0x82, 0xd0, 0x14, 0x96,
+	0x2b, 0xf7, 0x0d, 0x45, 0x27, 0xd0, 0x0e, 0xe9, 0x4b, 0x1a, 0xba, 0xcf, 0x5f, 0x0f, 0x86, 0x2c,
+	0xf6, 0xb9, 0x51, 0x17, 0x94, 0x8d, 0xd4, 0x7a, 0x9e, 0x18, 0x11, 0x82, 0x5a, 0x40, 0xf8, 0x0b,
+	0x63, 0x4d, 0x04, 0x27, 0xbe, 0xd1, 0x17, 0x00, 0xc3, 0x90, 0x12, 0x4e, 0x9d, 0x01, 0xe1, 0x46,
+	0xa3, 0xa3, 0x75, 0x5b, 0x7d, 0xb3, 0x27, 0x13, 0xd6, 0x4b, 0x13, 0xd6, 0x7b, 0x9a, 0x26, 0xcc,
+	0x6e, 0x2a, 0xf6, 0x29, 0xc7, 0x26, 0x18, 0x97, 0x6e, 0xc4, 0xf3, 0xc9, 0x8a, 0x6c, 0xfa, 0x7b,
+	0x4c, 0x23, 0x8e, 0x7f, 0x81, 0xbd, 0x19, 0x58, 0x14, 0x30, 0x3f, 0xa2, 0xe8, 0x21, 0xb4, 0x03,
+	0x09, 0x0c, 0x88, 0x40, 0x0c, 0xad, 0xb3, 0xd2, 0x6d, 0xf5, 0x77, 0x7b, 0x59, 0x41, 0xf3, 0x3b,
+	0xed, 0x8d, 0x20, 0xaf, 0x83, 0x4f, 0xe1, 0xf6, 0x05, 0x2d, 0x68, 0x2b, 0xb7, 0x4b, 0xd7, 0x0a,
+	0xff, 0x08, 0xbb, 0x25, 0x09, 0x15, 0xdd, 0x7d, 0xd8, 0x28, 0x44, 0x27, 0x94, 0xe6, 0x04, 0xb7,
+	0x9e, 0x0f, 0x0e, 0x7f, 0x09, 0x9b, 0x17, 0x94, 0x5f, 0x71, 0xc2, 0xa3, 0x77, 0x0e, 0xea, 0x2f,
+	0x1d, 0xb6, 0xb2, 0xcd, 0x2a, 0x9c, 0x63, 0x68, 0x89, 0x30, 0x54, 0x61, 0x35, 0x51, 0x58, 0x10,
+	0x26, 0x59, 0xd5, 0x09, 0x21, 0x24, 0xdc, 0x65, 0xa2, 0xf3, 0x34, 0x45, 0xb0, 0x13, 0x4b, 0xd2,
+	0x5f, 0x71, 0x90, 0xb4, 0xbc, 0x92, 0x90, 0xfd, 0xd7, 0x92, 0x36, 0xa9, 0x91, 0x51, 0xa4, 0x48,
+	0x4d, 0x88, 0x28, 0x8a, 0x54, 0x39, 0x81, 0xd5, 0x88, 0x13, 0x2e, 0xbb, 0xaf, 0xdd, 0xdf, 0xec,
+	0x89, 0x3b, 0x93, 0x9c, 0x20, 0x89, 0x97, 0xda, 0x12, 0x45, 0x8f, 0x60, 0x4b, 0x7c, 0x0c, 0xe2,
+	0xc0, 0x49, 0xbb, 0xaa, 0xbe, 0xb0, 0xab, 0xda, 0x62, 0xcf, 0x33, 0xb9, 0xe5, 0x94, 0x27, 0x0d,
+	0x2d, 0x55, 0xd8, 0x4b, 0x1a, 0x86, 0xae, 0x43, 0x45, 0xcf, 0x36, 0xec, 0x0d, 0x61, 0xfd, 0x41,
+	0x19, 0xf1, 0xbf, 0x1a, 0xa0, 0x73, 0xd1, 0x8f, 0xef, 0x95, 0xf0, 0xe9, 0xdc, 0xea, 0xa5, 0xdc,
+	0xf6, 0x60, 0x47, 0x12, 0xa2, 0x78, 0x38, 0xa4, 0x51, 0x54, 0xc8, 0xe0, 0xb6, 0x80, 0xae, 0x24,
+	0x32, 0x9d, 0x47, 0x49, 0xac, 0x95, 0x53, 0xfd, 0x19, 0xdc, 0x52, 0x94, 0xa2, 0xa6, 0xbc, 0xd4,
+	0x48, 0x62, 0x79, 0x51, 0xfc, 0x21, 0xec, 0x14, 0x0e, 0x29, 0x1b, 0x03, 0xff, 0xa1, 0xc1, 0x6d,
+	0x99, 0xb1, 0xac, 0x08, 0xef, 0x9a, 0x80, 0x49, 0x51, 0xf5, 0xb9, 0x45, 0x35, 0x60, 0x2d, 0xa4,
+	0x63, 0x4a, 0x22, 0x2a, 0x8e, 0xde, 0xb0, 0xd3, 0x25, 0xde, 0x83, 0xdd, 0x52, 0x0c, 0x2a, 0xbe,
+	0x6f, 0x01, 0x89, 0xf8, 0x13, 0x24, 0x6b, 0x67, 0x13, 0x1a, 0x37, 0xc4, 0xa1, 0xde, 0xd8, 0x25,
+	0xaa, 0x97, 0x27, 0xeb, 0xc4, 0x4d, 0x52, 0xef, 0x31, 0x79, 0xad, 0x4a, 0x91, 0x2e, 0xf1, 0x0e,
+	0x6c, 0xe7, 0xb5, 0xe4, 0x3f, 0x66, 0x07, 0xb6, 0x2f, 0x28, 0x3f, 0x8b, 0x87, 0x37, 0x34, 0xfb,
+	0xf1, 0x7c, 0x03, 0x28, 0x6f, 0x54, 0x5e, 0x6f, 0xc1, 0x2a, 0x67, 0x9c, 0x8c, 0x95, 0x4b, 0xb9,
+	0x40, 0x07, 0xb0, 0xe2, 0x3a, 0x91, 0xa1, 0x77, 0x56, 0xba, 0xeb, 0x67, 0x90, 0x4b, 0x4f, 0x62,
+	0xc6, 0x7d, 0xd8, 0x9a, 0x28, 0xa5, 0x89, 0x3d, 0x02, 0xbd, 0x32, 0xa7, 0xba, 0xeb, 0xe0, 0x67,
+	0xb9, 0x90, 0x26, 0xce, 0x17, 0x6c, 0x42, 0x1d, 0x58, 0x4d, 0xd2, 0x2e, 0x03, 0x69, 0xf5, 0x21,
+	0x2b, 0x82, 0x2d, 0x01, 0xfc, 0x29, 0xd4, 0xa5, 0xe6, 0x12, 0xdc, 0x1e, 0x80, 0xe4, 0x26, 0xff,
+	0xdf, 0x8c, 0xaf, 0x55, 0xf1, 0xbf, 0x83, 0xcd, 0x27, 0xae, 0x3f, 0x12, 0xa6, 0xe5, 0x4e, 0x99,
+	0xd4, 0x89, 0x38, 0x4e, 0x48, 0xa3, 0x28, 0x9d, 0x73, 0x6a, 0x89, 0x31, 0x6c, 0x65, 0x62, 0xea,
+	0xf8, 0x6d, 0xd0, 0xd9, 0x8d, 0x50, 0x6b, 0xd8, 0x3a, 0xbb, 0xc1, 0x0f, 0x60, 0xfb, 0x92, 0xb1,
+	0x9b, 0x38, 0xc8, 0xbb, 0x6c, 0x4f, 0x5c, 0x36, 0x17, 0xb8, 0xf8, 0x15, 0x50, 0x7e, 0xfb, 0x24,
+	0xc7, 0xb5, 0xe4, 0x38, 0xea, 0x5f, 0x9d, 0x3f, 0xa6, 0xb0, 0xa3, 0x8f, 0xa1, 0xe6, 0x51, 0x4e,
+	0x84, 0x58, 0xab, 0x8f, 0x32, 0xfc, 0x7b, 0xca, 0x89, 0x43, 0x38, 0xb1, 0x05, 0x8e, 0xf7, 0x61,
+	0xef, 0xab, 0x57, 0x01, 0x0b, 0xb9, 0xcd, 0x62, 0xee, 0xfa, 0xa3, 0xa7, 0xe4, 0x7a, 0x9c, 0x06,
+	0x89, 0x1f, 0x82, 0x39, 0x0b, 0x54, 0x21, 0x2c, 0x4e, 0xf5, 0x03, 0xd8, 0xb3, 0xbc, 0x0a, 0xf1,
+	0x25, 0xb6, 0xdf, 0x07, 0xd3, 0xf2, 0x2a, 0xdd, 0x1f, 0x01, 0x84, 0xe2, 0x3b, 0x99, 0x45, 0xe9,
+	0x98, 0xc8, 0x2c, 0xfd, 0xff, 0xd6, 0xa0, 0x69, 0xa5, 0x13, 0x0c, 0x59, 0x00, 0xd9, 0x85, 0x42,
+	0x07, 0xb9, 0xd9, 0x56, 0xba, 0x67, 0xe6, 0x61, 0x05, 0xaa, 0x1c, 0x5b, 0x00, 0xd9, 0x8d, 0x2b,
+	0x48, 0x95, 0x6e, 0xa7, 0x79, 0x58, 0x81, 0x2a, 0xa9, 0xaf, 0xa1, 0x39, 0xb1, 0xa2, 0xfd, 0x59,
+	0xdc, 0x54, 0xe8, 0x60, 0x36, 0xa8, 0x74, 0xce, 0xa1, 0x91, 0xb6, 0x21, 0x32, 0xf3, 0x73, 0xbb,
+	0xd8, 0xe8, 0xe6, 0xfe, 0x4c, 0x2c, 0x3b, 0x57, 0xd6, 0x68, 0x85, 0x73, 0x95, 0xda, 0xd7, 0x3c,
+	0xac, 0x40, 0x95, 0x14, 0x01, 0x54, 0x6e, 0x1c, 0x74, 0x37, 0xb7, 0xa9, 0xb2, 0xe9, 0xcc, 0x93,
+	0x05, 0xac, 0xcc, 0x85, 0xe5, 0xcd, 0x75, 0x61, 0x79, 0xcb, 0xb8, 0x98, 0xd3, 0x61, 0xe7, 0xd0,
+	0x48, 0x5f, 0x27, 0x85, 0xac, 0x4e, 0xbd, 0x77, 0xcc, 0xfd, 0x99, 0x98, 0x12, 0xb9, 0x84, 0x56,
+	0x6e, 0x98, 0xa1, 0x42, 0x6f, 0x95, 0x26, 0xb9, 0x79, 0x54, 0x05, 0x2b, 0xb5, 0x9f, 0x60, 0x73,
+	0x6a, 0xfc, 0xa0, 0x3b, 0xb9, 0x2d, 0xb3, 0xc7, 0xa3, 0x89, 0xe7, 0x51, 0x94, 0xf2, 0x6f, 0xb0,
+	0x5d, 0x7a, 0xc0, 0xa2, 0x8f, 0xf2, 0x65, 0xae, 0x78, 0xfa, 0x9a, 0x77, 0xe7, 0x93, 0xb2, 0xc8,
+	0xa7, 0x1e, 0xa0, 0x85, 0xc8, 0x67, 0xbf, 0x6f, 0x4d, 0x3c, 0x8f, 0x22, 0x95, 0xcf, 0x6a, 0x3f,
+	0xeb, 0xc1, 0xf5, 0x75, 0x5d, 0xbc, 0xb2, 0x3e, 0xff, 0x7f, 0x00, 0x5b, 0x24, 0x99, 0x01, 0x24,
+	0x0d, 0x00, 0x00,
 }
@@ -20,18 +20,22 @@ const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 // SerializableMeta is the object metadata that will be stored serialized
 type SerializableMeta struct {
-	ContentType          string            `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
-	UserDefined          map[string]string `protobuf:"bytes,2,rep,name=user_defined,json=userDefined" json:"user_defined,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	ContentType string            `protobuf:"bytes,1,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	UserDefined map[string]string `protobuf:"bytes,2,rep,name=user_defined,json=userDefined" json:"user_defined,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// version is the number of the object version, 0 if the bucket isn't versioned
+	Version uint32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	// delete_marker is whether the version marks the deletion of the object
+	DeleteMarker         bool     `protobuf:"varint,4,opt,name=delete_marker,json=deleteMarker,proto3" json:"delete_marker,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *SerializableMeta) Reset()         { *m = SerializableMeta{} }
 func (m *SerializableMeta) String() string { return proto.CompactTextString(m) }
 func (*SerializableMeta) ProtoMessage()    {}
 func (*SerializableMeta) Descriptor() ([]byte, []int) {
-	return fileDescriptor_meta_12afffbb4ed1a0bb, []int{0}
+	return fileDescriptor_meta_7a80e738d4700562, []int{0}
 }
 func (m *SerializableMeta) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SerializableMeta.Unmarshal(m, b)
@@ -65,25 +69,42 @@ func (m *SerializableMeta) GetUserDefined() map[string]string {
 	return nil
 }
 
+func (m *SerializableMeta) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *SerializableMeta) GetDeleteMarker() bool {
+	if m != nil {
+		return m.DeleteMarker
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*SerializableMeta)(nil), "objects.SerializableMeta")
 	proto.RegisterMapType((map[string]string)(nil), "objects.SerializableMeta.UserDefinedEntry")
 }
 
-func init() { proto.RegisterFile("meta.proto", fileDescriptor_meta_12afffbb4ed1a0bb) }
+func init() { proto.RegisterFile("meta.proto", fileDescriptor_meta_7a80e738d4700562) }
 
-var fileDescriptor_meta_12afffbb4ed1a0bb = []byte{
-	// 191 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xca, 0x4d, 0x2d, 0x49,
-	0xd4, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0xcf, 0x4f, 0xca, 0x4a, 0x4d, 0x2e, 0x29, 0x56,
-	0x3a, 0xc2, 0xc8, 0x25, 0x10, 0x9c, 0x5a, 0x94, 0x99, 0x98, 0x93, 0x59, 0x95, 0x98, 0x94, 0x93,
-	0xea, 0x9b, 0x5a, 0x92, 0x28, 0xa4, 0xc8, 0xc5, 0x93, 0x9c, 0x9f, 0x57, 0x92, 0x9a, 0x57, 0x12,
-	0x5f, 0x52, 0x59, 0x90, 0x2a, 0xc1, 0xa8, 0xc0, 0xa8, 0xc1, 0x19, 0xc4, 0x0d, 0x15, 0x0b, 0xa9,
-	0x2c, 0x48, 0x15, 0xf2, 0xe5, 0xe2, 0x29, 0x2d, 0x4e, 0x2d, 0x8a, 0x4f, 0x49, 0x4d, 0xcb, 0xcc,
-	0x4b, 0x4d, 0x91, 0x60, 0x52, 0x60, 0xd6, 0xe0, 0x36, 0xd2, 0xd2, 0x83, 0x9a, 0xab, 0x87, 0x6e,
-	0xa6, 0x5e, 0x68, 0x71, 0x6a, 0x91, 0x0b, 0x44, 0xb1, 0x6b, 0x5e, 0x49, 0x51, 0x65, 0x10, 0x77,
-	0x29, 0x42, 0x44, 0xca, 0x8e, 0x4b, 0x00, 0x5d, 0x81, 0x90, 0x00, 0x17, 0x73, 0x76, 0x6a, 0x25,
-	0xd4, 0x72, 0x10, 0x53, 0x48, 0x84, 0x8b, 0xb5, 0x2c, 0x31, 0xa7, 0x34, 0x55, 0x82, 0x09, 0x2c,
-	0x06, 0xe1, 0x58, 0x31, 0x59, 0x30, 0x3a, 0xb1, 0x44, 0x31, 0x15, 0x24, 0x25, 0xb1, 0x81, 0x3d,
-	0x67, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff, 0x4f, 0x25, 0xc0, 0xd0, 0xea, 0x00, 0x00, 0x00,
+var fileDescriptor_meta_7a80e738d4700562 = []byte{
+	// 232 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0xd0, 0x31, 0x4b, 0xc4, 0x40,
+	0x10, 0x05, 0x60, 0x76, 0xef, 0xf4, 0x74, 0x92, 0x83, 0xb0, 0x58, 0x2c, 0x56, 0x51, 0x9b, 0x60,
+	0x91, 0x42, 0x1b, 0xb1, 0xb0, 0x10, 0x2d, 0xd3, 0x44, 0x6d, 0x6c, 0xc2, 0xe6, 0xf2, 0x84, 0x78,
+	0xb9, 0xdd, 0xb0, 0x99, 0x1c, 0xc4, 0xd6, 0x3f, 0x2e, 0x26, 0x11, 0xe1, 0xba, 0x79, 0x1f, 0xc3,
+	0x63, 0x18, 0xa2, 0x1d, 0xd8, 0xa4, 0xad, 0x77, 0xec, 0xd4, 0xca, 0x95, 0x9f, 0xd8, 0x70, 0x77,
+	0xf9, 0x2d, 0x29, 0x7a, 0x81, 0xaf, 0x4d, 0x53, 0x7f, 0x99, 0xb2, 0x41, 0x06, 0x36, 0xea, 0x82,
+	0xc2, 0x8d, 0xb3, 0x0c, 0xcb, 0x05, 0x0f, 0x2d, 0xb4, 0x88, 0x45, 0x72, 0x9a, 0x07, 0xb3, 0xbd,
+	0x0e, 0x2d, 0x54, 0x46, 0x61, 0xdf, 0xc1, 0x17, 0x15, 0x3e, 0x6a, 0x8b, 0x4a, 0xcb, 0x78, 0x91,
+	0x04, 0x37, 0xd7, 0xe9, 0xdc, 0x9b, 0x1e, 0x76, 0xa6, 0x6f, 0x1d, 0xfc, 0xd3, 0xb4, 0xfc, 0x6c,
+	0xd9, 0x0f, 0x79, 0xd0, 0xff, 0x8b, 0xd2, 0xb4, 0xda, 0xc3, 0x77, 0xb5, 0xb3, 0x7a, 0x11, 0x8b,
+	0x64, 0x9d, 0xff, 0x45, 0x75, 0x45, 0xeb, 0x0a, 0x0d, 0x18, 0xc5, 0xce, 0xf8, 0x2d, 0xbc, 0x5e,
+	0xc6, 0x22, 0x39, 0xc9, 0xc3, 0x09, 0xb3, 0xd1, 0xce, 0x1f, 0x28, 0x3a, 0xec, 0x57, 0x11, 0x2d,
+	0xb6, 0x18, 0xe6, 0xdb, 0x7f, 0x47, 0x75, 0x46, 0x47, 0x7b, 0xd3, 0xf4, 0xd0, 0x72, 0xb4, 0x29,
+	0xdc, 0xcb, 0x3b, 0xf1, 0xb8, 0x7c, 0x97, 0x6d, 0x59, 0x1e, 0x8f, 0xbf, 0xb9, 0xfd, 0x19, 0x00,
+	0x86, 0x67, 0x59, 0xba, 0x29, 0x01, 0x00, 0x00,
 }
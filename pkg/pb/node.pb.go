@@ -49,7 +49,7 @@ func (x NodeType) String() string {
 	return proto.EnumName(NodeType_name, int32(x))
 }
 func (NodeType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_node_67af40c3abfaa4ce, []int{0}
+	return fileDescriptor_node_25b830a8f2051dde, []int{0}
 }
 
 // NodeTransport is an enum of possible transports for the overlay network
@@ -70,10 +70,40 @@ func (x NodeTransport) String() string {
 	return proto.EnumName(NodeTransport_name, int32(x))
 }
 func (NodeTransport) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_node_67af40c3abfaa4ce, []int{1}
+	return fileDescriptor_node_25b830a8f2051dde, []int{1}
 }
 
-//  NodeRestrictions contains all relevant data about a nodes ability to store data
+// NodeState is the lifecycle state of a storage node on a satellite
+type NodeState int32
+
+const (
+	NodeState_VETTING      NodeState = 0
+	NodeState_ACTIVE       NodeState = 1
+	NodeState_SUSPENDED    NodeState = 2
+	NodeState_DISQUALIFIED NodeState = 3
+)
+
+var NodeState_name = map[int32]string{
+	0: "VETTING",
+	1: "ACTIVE",
+	2: "SUSPENDED",
+	3: "DISQUALIFIED",
+}
+var NodeState_value = map[string]int32{
+	"VETTING":      0,
+	"ACTIVE":       1,
+	"SUSPENDED":    2,
+	"DISQUALIFIED": 3,
+}
+
+func (x NodeState) String() string {
+	return proto.EnumName(NodeState_name, int32(x))
+}
+func (NodeState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_node_25b830a8f2051dde, []int{2}
+}
+
+// NodeRestrictions contains all relevant data about a nodes ability to store data
 type NodeRestrictions struct {
 	FreeBandwidth        int64    `protobuf:"varint,1,opt,name=free_bandwidth,json=freeBandwidth,proto3" json:"free_bandwidth,omitempty"`
 	FreeDisk             int64    `protobuf:"varint,2,opt,name=free_disk,json=freeDisk,proto3" json:"free_disk,omitempty"`
@@ -86,7 +116,7 @@ func (m *NodeRestrictions) Reset()         { *m = NodeRestrictions{} }
 func (m *NodeRestrictions) String() string { return proto.CompactTextString(m) }
 func (*NodeRestrictions) ProtoMessage()    {}
 func (*NodeRestrictions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_67af40c3abfaa4ce, []int{0}
+	return fileDescriptor_node_25b830a8f2051dde, []int{0}
 }
 func (m *NodeRestrictions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeRestrictions.Unmarshal(m, b)
@@ -136,6 +166,8 @@ type Node struct {
 	UpdateLatency        bool              `protobuf:"varint,10,opt,name=update_latency,json=updateLatency,proto3" json:"update_latency,omitempty"`
 	UpdateAuditSuccess   bool              `protobuf:"varint,11,opt,name=update_audit_success,json=updateAuditSuccess,proto3" json:"update_audit_success,omitempty"`
 	UpdateUptime         bool              `protobuf:"varint,12,opt,name=update_uptime,json=updateUptime,proto3" json:"update_uptime,omitempty"`
+	Version              string            `protobuf:"bytes,13,opt,name=version,proto3" json:"version,omitempty"`
+	UsedSpace            int64             `protobuf:"varint,14,opt,name=used_space,json=usedSpace,proto3" json:"used_space,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -145,7 +177,7 @@ func (m *Node) Reset()         { *m = Node{} }
 func (m *Node) String() string { return proto.CompactTextString(m) }
 func (*Node) ProtoMessage()    {}
 func (*Node) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_67af40c3abfaa4ce, []int{1}
+	return fileDescriptor_node_25b830a8f2051dde, []int{1}
 }
 func (m *Node) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Node.Unmarshal(m, b)
@@ -242,6 +274,20 @@ func (m *Node) GetUpdateUptime() bool {
 	return false
 }
 
+func (m *Node) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Node) GetUsedSpace() int64 {
+	if m != nil {
+		return m.UsedSpace
+	}
+	return 0
+}
+
 // NodeAddress contains the information needed to communicate with a node on the network
 type NodeAddress struct {
 	Transport            NodeTransport `protobuf:"varint,1,opt,name=transport,proto3,enum=node.NodeTransport" json:"transport,omitempty"`
@@ -255,7 +301,7 @@ func (m *NodeAddress) Reset()         { *m = NodeAddress{} }
 func (m *NodeAddress) String() string { return proto.CompactTextString(m) }
 func (*NodeAddress) ProtoMessage()    {}
 func (*NodeAddress) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_67af40c3abfaa4ce, []int{2}
+	return fileDescriptor_node_25b830a8f2051dde, []int{2}
 }
 func (m *NodeAddress) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeAddress.Unmarshal(m, b)
@@ -291,24 +337,26 @@ func (m *NodeAddress) GetAddress() string {
 
 // NodeStats is the reputation characteristics of a node
 type NodeStats struct {
-	NodeId               NodeID   `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
-	Latency_90           int64    `protobuf:"varint,2,opt,name=latency_90,json=latency90,proto3" json:"latency_90,omitempty"`
-	AuditSuccessRatio    float64  `protobuf:"fixed64,3,opt,name=audit_success_ratio,json=auditSuccessRatio,proto3" json:"audit_success_ratio,omitempty"`
-	UptimeRatio          float64  `protobuf:"fixed64,4,opt,name=uptime_ratio,json=uptimeRatio,proto3" json:"uptime_ratio,omitempty"`
-	AuditCount           int64    `protobuf:"varint,5,opt,name=audit_count,json=auditCount,proto3" json:"audit_count,omitempty"`
-	AuditSuccessCount    int64    `protobuf:"varint,6,opt,name=audit_success_count,json=auditSuccessCount,proto3" json:"audit_success_count,omitempty"`
-	UptimeCount          int64    `protobuf:"varint,7,opt,name=uptime_count,json=uptimeCount,proto3" json:"uptime_count,omitempty"`
-	UptimeSuccessCount   int64    `protobuf:"varint,8,opt,name=uptime_success_count,json=uptimeSuccessCount,proto3" json:"uptime_success_count,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	NodeId               NodeID    `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	Latency_90           int64     `protobuf:"varint,2,opt,name=latency_90,json=latency90,proto3" json:"latency_90,omitempty"`
+	AuditSuccessRatio    float64   `protobuf:"fixed64,3,opt,name=audit_success_ratio,json=auditSuccessRatio,proto3" json:"audit_success_ratio,omitempty"`
+	UptimeRatio          float64   `protobuf:"fixed64,4,opt,name=uptime_ratio,json=uptimeRatio,proto3" json:"uptime_ratio,omitempty"`
+	AuditCount           int64     `protobuf:"varint,5,opt,name=audit_count,json=auditCount,proto3" json:"audit_count,omitempty"`
+	AuditSuccessCount    int64     `protobuf:"varint,6,opt,name=audit_success_count,json=auditSuccessCount,proto3" json:"audit_success_count,omitempty"`
+	UptimeCount          int64     `protobuf:"varint,7,opt,name=uptime_count,json=uptimeCount,proto3" json:"uptime_count,omitempty"`
+	UptimeSuccessCount   int64     `protobuf:"varint,8,opt,name=uptime_success_count,json=uptimeSuccessCount,proto3" json:"uptime_success_count,omitempty"`
+	State                NodeState `protobuf:"varint,9,opt,name=state,proto3,enum=node.NodeState" json:"state,omitempty"`
+	SpeedKbps            int64     `protobuf:"varint,10,opt,name=speed_kbps,json=speedKbps,proto3" json:"speed_kbps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *NodeStats) Reset()         { *m = NodeStats{} }
 func (m *NodeStats) String() string { return proto.CompactTextString(m) }
 func (*NodeStats) ProtoMessage()    {}
 func (*NodeStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_67af40c3abfaa4ce, []int{3}
+	return fileDescriptor_node_25b830a8f2051dde, []int{3}
 }
 func (m *NodeStats) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeStats.Unmarshal(m, b)
@@ -377,6 +425,20 @@ func (m *NodeStats) GetUptimeSuccessCount() int64 {
 	return 0
 }
 
+func (m *NodeStats) GetState() NodeState {
+	if m != nil {
+		return m.State
+	}
+	return NodeState_VETTING
+}
+
+func (m *NodeStats) GetSpeedKbps() int64 {
+	if m != nil {
+		return m.SpeedKbps
+	}
+	return 0
+}
+
 type NodeMetadata struct {
 	Email                string   `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
 	Wallet               string   `protobuf:"bytes,2,opt,name=wallet,proto3" json:"wallet,omitempty"`
@@ -389,7 +451,7 @@ func (m *NodeMetadata) Reset()         { *m = NodeMetadata{} }
 func (m *NodeMetadata) String() string { return proto.CompactTextString(m) }
 func (*NodeMetadata) ProtoMessage()    {}
 func (*NodeMetadata) Descriptor() ([]byte, []int) {
-	return fileDescriptor_node_67af40c3abfaa4ce, []int{4}
+	return fileDescriptor_node_25b830a8f2051dde, []int{4}
 }
 func (m *NodeMetadata) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NodeMetadata.Unmarshal(m, b)
@@ -431,51 +493,59 @@ func init() {
 	proto.RegisterType((*NodeMetadata)(nil), "node.NodeMetadata")
 	proto.RegisterEnum("node.NodeType", NodeType_name, NodeType_value)
 	proto.RegisterEnum("node.NodeTransport", NodeTransport_name, NodeTransport_value)
-}
-
-func init() { proto.RegisterFile("node.proto", fileDescriptor_node_67af40c3abfaa4ce) }
-
-var fileDescriptor_node_67af40c3abfaa4ce = []byte{
-	// 652 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0xc1, 0x4e, 0xdb, 0x40,
-	0x10, 0x86, 0x49, 0x6c, 0x9c, 0x78, 0xec, 0xa4, 0x66, 0x40, 0xc8, 0x6a, 0xd5, 0x12, 0x82, 0xaa,
-	0x46, 0x54, 0x4a, 0x29, 0x3d, 0x51, 0xf5, 0x92, 0x00, 0x42, 0x51, 0xdd, 0x10, 0x6d, 0x0c, 0x07,
-	0x2e, 0x96, 0x89, 0xb7, 0x74, 0x45, 0x88, 0x2d, 0x7b, 0x2d, 0xc4, 0x1b, 0xf6, 0xd0, 0x27, 0xe8,
-	0x81, 0x57, 0xe8, 0x2b, 0x54, 0xbb, 0xeb, 0x24, 0xb6, 0xaa, 0xde, 0xb2, 0xff, 0xff, 0x79, 0xc6,
-	0x3b, 0xff, 0xc4, 0x00, 0x8b, 0x38, 0xa2, 0xfd, 0x24, 0x8d, 0x79, 0x8c, 0xba, 0xf8, 0xfd, 0x12,
-	0xee, 0xe2, 0xbb, 0x58, 0x29, 0xdd, 0x6b, 0x70, 0xc6, 0x71, 0x44, 0x09, 0xcd, 0x78, 0xca, 0x66,
-	0x9c, 0xc5, 0x8b, 0x0c, 0xdf, 0x42, 0xfb, 0x7b, 0x4a, 0x69, 0x70, 0x1b, 0x2e, 0xa2, 0x47, 0x16,
-	0xf1, 0x1f, 0x6e, 0xad, 0x53, 0xeb, 0x69, 0xa4, 0x25, 0xd4, 0xe1, 0x52, 0xc4, 0x57, 0x60, 0x4a,
-	0x2c, 0x62, 0xd9, 0xbd, 0x5b, 0x97, 0x44, 0x53, 0x08, 0x67, 0x2c, 0xbb, 0xef, 0xfe, 0xd1, 0x40,
-	0x17, 0x85, 0xf1, 0x0d, 0xd4, 0x59, 0x24, 0x0b, 0xd8, 0xc3, 0xf6, 0xcf, 0xe7, 0xbd, 0x8d, 0xdf,
-	0xcf, 0x7b, 0x86, 0x70, 0x46, 0x67, 0xa4, 0xce, 0x22, 0x7c, 0x0f, 0x8d, 0x30, 0x8a, 0x52, 0x9a,
-	0x65, 0xb2, 0x86, 0x75, 0xbc, 0xd5, 0x97, 0x2f, 0x2c, 0x90, 0x81, 0x32, 0xc8, 0x92, 0xc0, 0x2e,
-	0xe8, 0xfc, 0x29, 0xa1, 0xae, 0xd6, 0xa9, 0xf5, 0xda, 0xc7, 0xed, 0x35, 0xe9, 0x3f, 0x25, 0x94,
-	0x48, 0x0f, 0x3f, 0x83, 0x9d, 0x96, 0x6e, 0xe3, 0xea, 0xb2, 0xea, 0xee, 0x9a, 0x2d, 0xdf, 0x95,
-	0x54, 0x58, 0xfc, 0x00, 0x90, 0xd2, 0x24, 0xe7, 0xa1, 0x38, 0xba, 0x9b, 0xf2, 0xc9, 0x17, 0xeb,
-	0x27, 0xa7, 0x3c, 0xe4, 0x19, 0x29, 0x21, 0xd8, 0x87, 0xe6, 0x03, 0xe5, 0x61, 0x14, 0xf2, 0xd0,
-	0x35, 0x24, 0x8e, 0x6b, 0xfc, 0x5b, 0xe1, 0x90, 0x15, 0x83, 0xfb, 0x60, 0xcf, 0x43, 0x4e, 0x17,
-	0xb3, 0xa7, 0x60, 0xce, 0x32, 0xee, 0x36, 0x3a, 0x5a, 0x4f, 0x23, 0x56, 0xa1, 0x79, 0x2c, 0xe3,
-	0x78, 0x00, 0xad, 0x30, 0x8f, 0x18, 0x0f, 0xb2, 0x7c, 0x36, 0x13, 0x63, 0x69, 0x76, 0x6a, 0xbd,
-	0x26, 0xb1, 0xa5, 0x38, 0x55, 0x1a, 0x6e, 0xc3, 0x26, 0xcb, 0x82, 0x3c, 0x71, 0x4d, 0x69, 0xea,
-	0x2c, 0xbb, 0x4a, 0x44, 0x6e, 0x79, 0x12, 0x85, 0x9c, 0x06, 0x45, 0x3d, 0x17, 0xa4, 0xdb, 0x52,
-	0xaa, 0xa7, 0x44, 0x3c, 0x82, 0x9d, 0x02, 0xab, 0xf6, 0xb1, 0x24, 0x8c, 0xca, 0x1b, 0x94, 0xbb,
-	0x1d, 0x40, 0x51, 0x22, 0xc8, 0x13, 0xce, 0x1e, 0xa8, 0x6b, 0xab, 0x57, 0x52, 0xe2, 0x95, 0xd4,
-	0xba, 0x37, 0x60, 0x95, 0x32, 0xc3, 0x8f, 0x60, 0xf2, 0x34, 0x5c, 0x64, 0x49, 0x9c, 0x72, 0x19,
-	0x7f, 0xfb, 0x78, 0xbb, 0x94, 0xd7, 0xd2, 0x22, 0x6b, 0x0a, 0xdd, 0xea, 0x2a, 0x98, 0xab, 0xdc,
-	0xbb, 0xbf, 0xea, 0x60, 0xae, 0x02, 0xc0, 0x77, 0xd0, 0x10, 0x85, 0x82, 0xff, 0xee, 0x95, 0x21,
-	0xec, 0x51, 0x84, 0xaf, 0x01, 0x96, 0xd3, 0x3e, 0x39, 0x2a, 0x56, 0xd4, 0x2c, 0x94, 0x93, 0x23,
-	0xec, 0xc3, 0x76, 0x65, 0x02, 0x41, 0x2a, 0x42, 0x95, 0xcb, 0x55, 0x23, 0x5b, 0xe5, 0x79, 0x13,
-	0x61, 0x88, 0xf0, 0xd4, 0xfd, 0x0b, 0x50, 0x97, 0xa0, 0xa5, 0x34, 0x85, 0xec, 0x81, 0xa5, 0x4a,
-	0xce, 0xe2, 0x7c, 0xc1, 0xe5, 0x06, 0x69, 0x04, 0xa4, 0x74, 0x2a, 0x94, 0x7f, 0x7b, 0x2a, 0xd0,
-	0x90, 0x60, 0xa5, 0xa7, 0xe2, 0xd7, 0x3d, 0x15, 0xd8, 0x90, 0x60, 0xd1, 0x53, 0x21, 0x32, 0x4f,
-	0x89, 0x54, 0x6b, 0x36, 0x25, 0x8a, 0xca, 0x2b, 0x17, 0xed, 0x7e, 0x01, 0xbb, 0xbc, 0x9f, 0xb8,
-	0x03, 0x9b, 0xf4, 0x21, 0x64, 0x73, 0x39, 0x4e, 0x93, 0xa8, 0x03, 0xee, 0x82, 0xf1, 0x18, 0xce,
-	0xe7, 0x94, 0x17, 0x69, 0x14, 0xa7, 0xc3, 0x31, 0x34, 0x97, 0x7f, 0x39, 0xb4, 0xa0, 0x31, 0x1a,
-	0x5f, 0x0f, 0xbc, 0xd1, 0x99, 0xb3, 0x81, 0x2d, 0x30, 0xa7, 0x03, 0xff, 0xdc, 0xf3, 0x46, 0xfe,
-	0xb9, 0x53, 0x13, 0xde, 0xd4, 0xbf, 0x24, 0x83, 0x8b, 0x73, 0xa7, 0x8e, 0x00, 0xc6, 0xd5, 0xc4,
-	0x1b, 0x8d, 0xbf, 0x3a, 0x9a, 0xe0, 0x86, 0x97, 0x97, 0xfe, 0xd4, 0x27, 0x83, 0x89, 0xa3, 0x1f,
-	0xee, 0x43, 0xab, 0xb2, 0x12, 0xe8, 0x80, 0xed, 0x9f, 0x4e, 0x02, 0xdf, 0x9b, 0x06, 0x17, 0x64,
-	0x72, 0xea, 0x6c, 0x0c, 0xf5, 0x9b, 0x7a, 0x72, 0x7b, 0x6b, 0xc8, 0x4f, 0xd6, 0xa7, 0xbf, 0x01,
-	0x00, 0x00, 0xff, 0xff, 0xc0, 0x8a, 0x68, 0xa5, 0xd2, 0x04, 0x00, 0x00,
+	proto.RegisterEnum("node.NodeState", NodeState_name, NodeState_value)
+}
+
+func init() { proto.RegisterFile("node.proto", fileDescriptor_node_25b830a8f2051dde) }
+
+var fileDescriptor_node_25b830a8f2051dde = []byte{
+	// 763 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0xdf, 0x4e, 0xdb, 0x48,
+	0x18, 0xc5, 0x93, 0xd8, 0xf9, 0xe3, 0x2f, 0x7f, 0xd6, 0x0c, 0x08, 0x59, 0xbb, 0xda, 0x25, 0x04,
+	0xa1, 0x8d, 0x58, 0x29, 0x4b, 0xe9, 0x15, 0x55, 0x6f, 0x9c, 0xc4, 0x45, 0x16, 0x6e, 0x48, 0xc7,
+	0x4e, 0x2e, 0xb8, 0xb1, 0x9c, 0x78, 0x4a, 0x2d, 0x42, 0x6c, 0x79, 0xc6, 0x45, 0xbc, 0x46, 0x9f,
+	0xa2, 0x8f, 0xd2, 0x67, 0xe8, 0x05, 0xcf, 0x52, 0xcd, 0x8c, 0x43, 0x9c, 0x56, 0xbd, 0x63, 0xce,
+	0xf9, 0xe5, 0x7c, 0xf6, 0x7c, 0xc7, 0x00, 0xac, 0xe3, 0x90, 0x0c, 0x92, 0x34, 0x66, 0x31, 0x52,
+	0xf9, 0xdf, 0x7f, 0xc2, 0x5d, 0x7c, 0x17, 0x4b, 0xa5, 0x37, 0x07, 0x7d, 0x12, 0x87, 0x04, 0x13,
+	0xca, 0xd2, 0x68, 0xc9, 0xa2, 0x78, 0x4d, 0xd1, 0x29, 0x74, 0x3e, 0xa6, 0x84, 0xf8, 0x8b, 0x60,
+	0x1d, 0x3e, 0x46, 0x21, 0xfb, 0x64, 0x94, 0xbb, 0xe5, 0xbe, 0x82, 0xdb, 0x5c, 0x1d, 0x6e, 0x44,
+	0xf4, 0x17, 0x68, 0x02, 0x0b, 0x23, 0x7a, 0x6f, 0x54, 0x04, 0xd1, 0xe0, 0xc2, 0x38, 0xa2, 0xf7,
+	0xbd, 0xaf, 0x2a, 0xa8, 0x3c, 0x18, 0xfd, 0x03, 0x95, 0x28, 0x14, 0x01, 0xad, 0x61, 0xe7, 0xdb,
+	0xf3, 0x51, 0xe9, 0xfb, 0xf3, 0x51, 0x8d, 0x3b, 0xf6, 0x18, 0x57, 0xa2, 0x10, 0xfd, 0x07, 0xf5,
+	0x20, 0x0c, 0x53, 0x42, 0xa9, 0xc8, 0x68, 0x5e, 0xec, 0x0d, 0xc4, 0x03, 0x73, 0xc4, 0x94, 0x06,
+	0xde, 0x10, 0xa8, 0x07, 0x2a, 0x7b, 0x4a, 0x88, 0xa1, 0x74, 0xcb, 0xfd, 0xce, 0x45, 0x67, 0x4b,
+	0x7a, 0x4f, 0x09, 0xc1, 0xc2, 0x43, 0x6f, 0xa0, 0x95, 0x16, 0xde, 0xc6, 0x50, 0x45, 0xea, 0xe1,
+	0x96, 0x2d, 0xbe, 0x2b, 0xde, 0x61, 0xd1, 0xff, 0x00, 0x29, 0x49, 0x32, 0x16, 0xf0, 0xa3, 0x51,
+	0x15, 0xbf, 0xfc, 0x63, 0xfb, 0x4b, 0x97, 0x05, 0x8c, 0xe2, 0x02, 0x82, 0x06, 0xd0, 0x78, 0x20,
+	0x2c, 0x08, 0x03, 0x16, 0x18, 0x35, 0x81, 0xa3, 0x2d, 0xfe, 0x3e, 0x77, 0xf0, 0x0b, 0x83, 0x8e,
+	0xa1, 0xb5, 0x0a, 0x18, 0x59, 0x2f, 0x9f, 0xfc, 0x55, 0x44, 0x99, 0x51, 0xef, 0x2a, 0x7d, 0x05,
+	0x37, 0x73, 0xcd, 0x89, 0x28, 0x43, 0x27, 0xd0, 0x0e, 0xb2, 0x30, 0x62, 0x3e, 0xcd, 0x96, 0x4b,
+	0x7e, 0x2d, 0x8d, 0x6e, 0xb9, 0xdf, 0xc0, 0x2d, 0x21, 0xba, 0x52, 0x43, 0xfb, 0x50, 0x8d, 0xa8,
+	0x9f, 0x25, 0x86, 0x26, 0x4c, 0x35, 0xa2, 0xb3, 0x84, 0xef, 0x2d, 0x4b, 0xc2, 0x80, 0x11, 0x3f,
+	0xcf, 0x33, 0x40, 0xb8, 0x6d, 0xa9, 0x3a, 0x52, 0x44, 0xe7, 0x70, 0x90, 0x63, 0xbb, 0x73, 0x9a,
+	0x02, 0x46, 0xd2, 0x33, 0x8b, 0xd3, 0x4e, 0x20, 0x8f, 0xf0, 0xb3, 0x84, 0x45, 0x0f, 0xc4, 0x68,
+	0xc9, 0x47, 0x92, 0xe2, 0x4c, 0x68, 0xc8, 0x80, 0xfa, 0x67, 0x92, 0x52, 0x7e, 0x71, 0xed, 0x6e,
+	0xb9, 0xaf, 0xe1, 0xcd, 0x11, 0xfd, 0x0d, 0x90, 0x51, 0x12, 0xfa, 0x34, 0x09, 0x96, 0xc4, 0xe8,
+	0x88, 0xa6, 0x68, 0x5c, 0x71, 0xb9, 0xd0, 0xbb, 0x85, 0x66, 0x61, 0xd9, 0xe8, 0x15, 0x68, 0x2c,
+	0x0d, 0xd6, 0x34, 0x89, 0x53, 0x26, 0x7a, 0xd3, 0xb9, 0xd8, 0x2f, 0x2c, 0x7a, 0x63, 0xe1, 0x2d,
+	0xc5, 0x47, 0x17, 0x3b, 0xa4, 0xbd, 0x14, 0xa6, 0xf7, 0x45, 0x01, 0xed, 0x65, 0x73, 0xe8, 0x5f,
+	0xa8, 0xf3, 0x20, 0xff, 0xb7, 0x85, 0xac, 0x71, 0xdb, 0x0e, 0xf9, 0x13, 0x6f, 0xd6, 0x74, 0x79,
+	0x9e, 0x77, 0x5b, 0xcb, 0x95, 0xcb, 0x73, 0x34, 0x80, 0xfd, 0x9d, 0xab, 0xf3, 0x53, 0xde, 0x06,
+	0xd1, 0xca, 0x32, 0xde, 0x2b, 0x2e, 0x0a, 0x73, 0x83, 0x6f, 0x5d, 0x5e, 0x5c, 0x0e, 0xaa, 0x02,
+	0x6c, 0x4a, 0x4d, 0x22, 0x47, 0xd0, 0x94, 0x91, 0xcb, 0x38, 0x5b, 0x33, 0x51, 0x3d, 0x05, 0x83,
+	0x90, 0x46, 0x5c, 0xf9, 0x75, 0xa6, 0x04, 0x6b, 0x02, 0xdc, 0x99, 0x29, 0xf9, 0xed, 0x4c, 0x09,
+	0xd6, 0x05, 0x98, 0xcf, 0x94, 0x88, 0x28, 0x82, 0x40, 0x76, 0x33, 0x1b, 0x02, 0x45, 0xd2, 0xdb,
+	0x09, 0x3d, 0x85, 0x2a, 0x65, 0x01, 0x23, 0xa2, 0x76, 0x9d, 0x9f, 0x3f, 0x0d, 0x82, 0xa5, 0xcb,
+	0xaf, 0x8f, 0x26, 0x84, 0x84, 0xfe, 0xfd, 0x22, 0xa1, 0xa2, 0x84, 0x0a, 0xd6, 0x84, 0x72, 0xbd,
+	0x48, 0x68, 0xef, 0x2d, 0xb4, 0x8a, 0x9f, 0x07, 0x3a, 0x80, 0x2a, 0x79, 0x08, 0xa2, 0x95, 0x58,
+	0x8a, 0x86, 0xe5, 0x01, 0x1d, 0x42, 0xed, 0x31, 0x58, 0xad, 0x08, 0xcb, 0x77, 0x9a, 0x9f, 0xce,
+	0x26, 0xd0, 0xd8, 0x7c, 0xf1, 0xa8, 0x09, 0x75, 0x7b, 0x32, 0x37, 0x1d, 0x7b, 0xac, 0x97, 0x50,
+	0x1b, 0x34, 0xd7, 0xf4, 0x2c, 0xc7, 0xb1, 0x3d, 0x4b, 0x2f, 0x73, 0xcf, 0xf5, 0x6e, 0xb0, 0x79,
+	0x65, 0xe9, 0x15, 0x04, 0x50, 0x9b, 0x4d, 0x1d, 0x7b, 0x72, 0xad, 0x2b, 0x9c, 0x1b, 0xde, 0xdc,
+	0x78, 0xae, 0x87, 0xcd, 0xa9, 0xae, 0x9e, 0x1d, 0x43, 0x7b, 0xa7, 0x58, 0x48, 0x87, 0x96, 0x37,
+	0x9a, 0xfa, 0x9e, 0xe3, 0xfa, 0x57, 0x78, 0x3a, 0xd2, 0x4b, 0x67, 0xd6, 0xb6, 0x44, 0x62, 0xe6,
+	0xdc, 0xf2, 0x3c, 0x7b, 0x72, 0xa5, 0x97, 0x78, 0xae, 0x39, 0xf2, 0xec, 0x39, 0x1f, 0xc8, 0xe7,
+	0xcf, 0xdc, 0xa9, 0x35, 0x19, 0x5b, 0x63, 0xbd, 0xc2, 0x63, 0xc6, 0xb6, 0xfb, 0x61, 0x66, 0x3a,
+	0xf6, 0x3b, 0xdb, 0x1a, 0xeb, 0xca, 0x50, 0xbd, 0xad, 0x24, 0x8b, 0x45, 0x4d, 0xfc, 0xe3, 0x7d,
+	0xfd, 0x63, 0x00, 0xa2, 0xa2, 0x97, 0xa3, 0x98, 0x05, 0x00, 0x00,
 }
@@ -8,6 +8,7 @@ import fmt "fmt"
 import math "math"
 import _ "github.com/gogo/protobuf/gogoproto"
 import duration "github.com/golang/protobuf/ptypes/duration"
+import timestamp "github.com/golang/protobuf/ptypes/timestamp"
 
 import (
 	context "golang.org/x/net/context"
@@ -54,7 +55,7 @@ func (x Restriction_Operator) String() string {
 	return proto.EnumName(Restriction_Operator_name, int32(x))
 }
 func (Restriction_Operator) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{11, 0}
+	return fileDescriptor_overlay_09566a9ca006317a, []int{17, 0}
 }
 
 type Restriction_Operand int32
@@ -77,7 +78,7 @@ func (x Restriction_Operand) String() string {
 	return proto.EnumName(Restriction_Operand_name, int32(x))
 }
 func (Restriction_Operand) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{11, 1}
+	return fileDescriptor_overlay_09566a9ca006317a, []int{17, 1}
 }
 
 // LookupRequest is is request message for the lookup rpc call
@@ -92,7 +93,7 @@ func (m *LookupRequest) Reset()         { *m = LookupRequest{} }
 func (m *LookupRequest) String() string { return proto.CompactTextString(m) }
 func (*LookupRequest) ProtoMessage()    {}
 func (*LookupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{0}
+	return fileDescriptor_overlay_09566a9ca006317a, []int{0}
 }
 func (m *LookupRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupRequest.Unmarshal(m, b)
@@ -124,7 +125,7 @@ func (m *LookupResponse) Reset()         { *m = LookupResponse{} }
 func (m *LookupResponse) String() string { return proto.CompactTextString(m) }
 func (*LookupResponse) ProtoMessage()    {}
 func (*LookupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{1}
+	return fileDescriptor_overlay_09566a9ca006317a, []int{1}
 }
 func (m *LookupResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupResponse.Unmarshal(m, b)
@@ -163,7 +164,7 @@ func (m *LookupRequests) Reset()         { *m = LookupRequests{} }
 func (m *LookupRequests) String() string { return proto.CompactTextString(m) }
 func (*LookupRequests) ProtoMessage()    {}
 func (*LookupRequests) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{2}
+	return fileDescriptor_overlay_09566a9ca006317a, []int{2}
 }
 func (m *LookupRequests) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupRequests.Unmarshal(m, b)
@@ -202,7 +203,7 @@ func (m *LookupResponses) Reset()         { *m = LookupResponses{} }
 func (m *LookupResponses) String() string { return proto.CompactTextString(m) }
 func (*LookupResponses) ProtoMessage()    {}
 func (*LookupResponses) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{3}
+	return fileDescriptor_overlay_09566a9ca006317a, []int{3}
 }
 func (m *LookupResponses) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_LookupResponses.Unmarshal(m, b)
@@ -241,7 +242,7 @@ func (m *FindStorageNodesResponse) Reset()         { *m = FindStorageNodesRespon
 func (m *FindStorageNodesResponse) String() string { return proto.CompactTextString(m) }
 func (*FindStorageNodesResponse) ProtoMessage()    {}
 func (*FindStorageNodesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{4}
+	return fileDescriptor_overlay_09566a9ca006317a, []int{4}
 }
 func (m *FindStorageNodesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindStorageNodesResponse.Unmarshal(m, b)
@@ -284,7 +285,7 @@ func (m *FindStorageNodesRequest) Reset()         { *m = FindStorageNodesRequest
 func (m *FindStorageNodesRequest) String() string { return proto.CompactTextString(m) }
 func (*FindStorageNodesRequest) ProtoMessage()    {}
 func (*FindStorageNodesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{5}
+	return fileDescriptor_overlay_09566a9ca006317a, []int{5}
 }
 func (m *FindStorageNodesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_FindStorageNodesRequest.Unmarshal(m, b)
@@ -334,22 +335,28 @@ func (m *FindStorageNodesRequest) GetMaxNodes() int64 {
 
 // OverlayOptions is a set of criteria that a node must meet to be considered for a storage opportunity
 type OverlayOptions struct {
-	MaxLatency           *duration.Duration `protobuf:"bytes,1,opt,name=max_latency,json=maxLatency" json:"max_latency,omitempty"`
-	MinStats             *NodeStats         `protobuf:"bytes,2,opt,name=min_stats,json=minStats" json:"min_stats,omitempty"`
-	MinSpeedKbps         int64              `protobuf:"varint,3,opt,name=min_speed_kbps,json=minSpeedKbps,proto3" json:"min_speed_kbps,omitempty"`
-	Amount               int64              `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	Restrictions         *NodeRestrictions  `protobuf:"bytes,5,opt,name=restrictions" json:"restrictions,omitempty"`
-	ExcludedNodes        []NodeID           `protobuf:"bytes,6,rep,name=excluded_nodes,json=excludedNodes,customtype=NodeID" json:"excluded_nodes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	MaxLatency    *duration.Duration `protobuf:"bytes,1,opt,name=max_latency,json=maxLatency" json:"max_latency,omitempty"`
+	MinStats      *NodeStats         `protobuf:"bytes,2,opt,name=min_stats,json=minStats" json:"min_stats,omitempty"`
+	MinSpeedKbps  int64              `protobuf:"varint,3,opt,name=min_speed_kbps,json=minSpeedKbps,proto3" json:"min_speed_kbps,omitempty"`
+	Amount        int64              `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Restrictions  *NodeRestrictions  `protobuf:"bytes,5,opt,name=restrictions" json:"restrictions,omitempty"`
+	ExcludedNodes []NodeID           `protobuf:"bytes,6,rep,name=excluded_nodes,json=excludedNodes,customtype=NodeID" json:"excluded_nodes,omitempty"`
+	// only nodes in one of these countries or regions (e.g. "US" or "US-CA") are selected
+	RequiredRegions []string `protobuf:"bytes,7,rep,name=required_regions,json=requiredRegions" json:"required_regions,omitempty"`
+	// nodes in these countries or regions are never selected
+	ExcludedRegions []string `protobuf:"bytes,8,rep,name=excluded_regions,json=excludedRegions" json:"excluded_regions,omitempty"`
+	// prefer the fastest of the nodes meeting the criteria
+	RankBySpeed          bool     `protobuf:"varint,9,opt,name=rank_by_speed,json=rankBySpeed,proto3" json:"rank_by_speed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *OverlayOptions) Reset()         { *m = OverlayOptions{} }
 func (m *OverlayOptions) String() string { return proto.CompactTextString(m) }
 func (*OverlayOptions) ProtoMessage()    {}
 func (*OverlayOptions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{6}
+	return fileDescriptor_overlay_09566a9ca006317a, []int{6}
 }
 func (m *OverlayOptions) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_OverlayOptions.Unmarshal(m, b)
@@ -404,6 +411,310 @@ func (m *OverlayOptions) GetRestrictions() *NodeRestrictions {
 	return nil
 }
 
+func (m *OverlayOptions) GetRequiredRegions() []string {
+	if m != nil {
+		return m.RequiredRegions
+	}
+	return nil
+}
+
+func (m *OverlayOptions) GetExcludedRegions() []string {
+	if m != nil {
+		return m.ExcludedRegions
+	}
+	return nil
+}
+
+func (m *OverlayOptions) GetRankBySpeed() bool {
+	if m != nil {
+		return m.RankBySpeed
+	}
+	return false
+}
+
+// PerformanceReport holds what a client observed while talking to storage nodes
+type PerformanceReport struct {
+	Measurements         []*PerformanceReport_Measurement `protobuf:"bytes,1,rep,name=measurements" json:"measurements,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *PerformanceReport) Reset()         { *m = PerformanceReport{} }
+func (m *PerformanceReport) String() string { return proto.CompactTextString(m) }
+func (*PerformanceReport) ProtoMessage()    {}
+func (*PerformanceReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_09566a9ca006317a, []int{7}
+}
+func (m *PerformanceReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PerformanceReport.Unmarshal(m, b)
+}
+func (m *PerformanceReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PerformanceReport.Marshal(b, m, deterministic)
+}
+func (dst *PerformanceReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PerformanceReport.Merge(dst, src)
+}
+func (m *PerformanceReport) XXX_Size() int {
+	return xxx_messageInfo_PerformanceReport.Size(m)
+}
+func (m *PerformanceReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_PerformanceReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PerformanceReport proto.InternalMessageInfo
+
+func (m *PerformanceReport) GetMeasurements() []*PerformanceReport_Measurement {
+	if m != nil {
+		return m.Measurements
+	}
+	return nil
+}
+
+type PerformanceReport_Measurement struct {
+	NodeId NodeID `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	// round trip latency in milliseconds, 0 if not measured
+	LatencyMs int64 `protobuf:"varint,2,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	// transfer speed, 0 if not measured
+	SpeedKbps            int64    `protobuf:"varint,3,opt,name=speed_kbps,json=speedKbps,proto3" json:"speed_kbps,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PerformanceReport_Measurement) Reset()         { *m = PerformanceReport_Measurement{} }
+func (m *PerformanceReport_Measurement) String() string { return proto.CompactTextString(m) }
+func (*PerformanceReport_Measurement) ProtoMessage()    {}
+func (*PerformanceReport_Measurement) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_09566a9ca006317a, []int{7, 0}
+}
+func (m *PerformanceReport_Measurement) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PerformanceReport_Measurement.Unmarshal(m, b)
+}
+func (m *PerformanceReport_Measurement) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PerformanceReport_Measurement.Marshal(b, m, deterministic)
+}
+func (dst *PerformanceReport_Measurement) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PerformanceReport_Measurement.Merge(dst, src)
+}
+func (m *PerformanceReport_Measurement) XXX_Size() int {
+	return xxx_messageInfo_PerformanceReport_Measurement.Size(m)
+}
+func (m *PerformanceReport_Measurement) XXX_DiscardUnknown() {
+	xxx_messageInfo_PerformanceReport_Measurement.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PerformanceReport_Measurement proto.InternalMessageInfo
+
+func (m *PerformanceReport_Measurement) GetLatencyMs() int64 {
+	if m != nil {
+		return m.LatencyMs
+	}
+	return 0
+}
+
+func (m *PerformanceReport_Measurement) GetSpeedKbps() int64 {
+	if m != nil {
+		return m.SpeedKbps
+	}
+	return 0
+}
+
+type PerformanceReportResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PerformanceReportResponse) Reset()         { *m = PerformanceReportResponse{} }
+func (m *PerformanceReportResponse) String() string { return proto.CompactTextString(m) }
+func (*PerformanceReportResponse) ProtoMessage()    {}
+func (*PerformanceReportResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_09566a9ca006317a, []int{8}
+}
+func (m *PerformanceReportResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PerformanceReportResponse.Unmarshal(m, b)
+}
+func (m *PerformanceReportResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PerformanceReportResponse.Marshal(b, m, deterministic)
+}
+func (dst *PerformanceReportResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PerformanceReportResponse.Merge(dst, src)
+}
+func (m *PerformanceReportResponse) XXX_Size() int {
+	return xxx_messageInfo_PerformanceReportResponse.Size(m)
+}
+func (m *PerformanceReportResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PerformanceReportResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PerformanceReportResponse proto.InternalMessageInfo
+
+// CheckInRequest is the request message for the CheckIn rpc call, signed by the storage node
+type CheckInRequest struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Signature            []byte   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckInRequest) Reset()         { *m = CheckInRequest{} }
+func (m *CheckInRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckInRequest) ProtoMessage()    {}
+func (*CheckInRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_09566a9ca006317a, []int{9}
+}
+func (m *CheckInRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckInRequest.Unmarshal(m, b)
+}
+func (m *CheckInRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckInRequest.Marshal(b, m, deterministic)
+}
+func (dst *CheckInRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckInRequest.Merge(dst, src)
+}
+func (m *CheckInRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckInRequest.Size(m)
+}
+func (m *CheckInRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckInRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckInRequest proto.InternalMessageInfo
+
+func (m *CheckInRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *CheckInRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+type CheckInRequest_Data struct {
+	NodeId               NodeID               `protobuf:"bytes,1,opt,name=node_id,json=nodeId,proto3,customtype=NodeID" json:"node_id"`
+	Address              *NodeAddress         `protobuf:"bytes,2,opt,name=address" json:"address,omitempty"`
+	Capacity             *NodeRestrictions    `protobuf:"bytes,3,opt,name=capacity" json:"capacity,omitempty"`
+	UsedSpace            int64                `protobuf:"varint,4,opt,name=used_space,json=usedSpace,proto3" json:"used_space,omitempty"`
+	Version              string               `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	Metadata             *NodeMetadata        `protobuf:"bytes,6,opt,name=metadata" json:"metadata,omitempty"`
+	Timestamp            *timestamp.Timestamp `protobuf:"bytes,7,opt,name=timestamp" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CheckInRequest_Data) Reset()         { *m = CheckInRequest_Data{} }
+func (m *CheckInRequest_Data) String() string { return proto.CompactTextString(m) }
+func (*CheckInRequest_Data) ProtoMessage()    {}
+func (*CheckInRequest_Data) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_09566a9ca006317a, []int{9, 0}
+}
+func (m *CheckInRequest_Data) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckInRequest_Data.Unmarshal(m, b)
+}
+func (m *CheckInRequest_Data) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckInRequest_Data.Marshal(b, m, deterministic)
+}
+func (dst *CheckInRequest_Data) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckInRequest_Data.Merge(dst, src)
+}
+func (m *CheckInRequest_Data) XXX_Size() int {
+	return xxx_messageInfo_CheckInRequest_Data.Size(m)
+}
+func (m *CheckInRequest_Data) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckInRequest_Data.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckInRequest_Data proto.InternalMessageInfo
+
+func (m *CheckInRequest_Data) GetAddress() *NodeAddress {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (m *CheckInRequest_Data) GetCapacity() *NodeRestrictions {
+	if m != nil {
+		return m.Capacity
+	}
+	return nil
+}
+
+func (m *CheckInRequest_Data) GetUsedSpace() int64 {
+	if m != nil {
+		return m.UsedSpace
+	}
+	return 0
+}
+
+func (m *CheckInRequest_Data) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *CheckInRequest_Data) GetMetadata() *NodeMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *CheckInRequest_Data) GetTimestamp() *timestamp.Timestamp {
+	if m != nil {
+		return m.Timestamp
+	}
+	return nil
+}
+
+// CheckInResponse is the response message for the CheckIn rpc call
+type CheckInResponse struct {
+	MinimumVersion       string   `protobuf:"bytes,1,opt,name=minimum_version,json=minimumVersion,proto3" json:"minimum_version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckInResponse) Reset()         { *m = CheckInResponse{} }
+func (m *CheckInResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckInResponse) ProtoMessage()    {}
+func (*CheckInResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_09566a9ca006317a, []int{10}
+}
+func (m *CheckInResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckInResponse.Unmarshal(m, b)
+}
+func (m *CheckInResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckInResponse.Marshal(b, m, deterministic)
+}
+func (dst *CheckInResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckInResponse.Merge(dst, src)
+}
+func (m *CheckInResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckInResponse.Size(m)
+}
+func (m *CheckInResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckInResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckInResponse proto.InternalMessageInfo
+
+func (m *CheckInResponse) GetMinimumVersion() string {
+	if m != nil {
+		return m.MinimumVersion
+	}
+	return ""
+}
+
 type QueryRequest struct {
 	Sender               *Node    `protobuf:"bytes,1,opt,name=sender" json:"sender,omitempty"`
 	Target               *Node    `protobuf:"bytes,2,opt,name=target" json:"target,omitempty"`
@@ -418,7 +729,7 @@ func (m *QueryRequest) Reset()         { *m = QueryRequest{} }
 func (m *QueryRequest) String() string { return proto.CompactTextString(m) }
 func (*QueryRequest) ProtoMessage()    {}
 func (*QueryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{7}
+	return fileDescriptor_overlay_09566a9ca006317a, []int{11}
 }
 func (m *QueryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryRequest.Unmarshal(m, b)
@@ -478,7 +789,7 @@ func (m *QueryResponse) Reset()         { *m = QueryResponse{} }
 func (m *QueryResponse) String() string { return proto.CompactTextString(m) }
 func (*QueryResponse) ProtoMessage()    {}
 func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{8}
+	return fileDescriptor_overlay_09566a9ca006317a, []int{12}
 }
 func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_QueryResponse.Unmarshal(m, b)
@@ -522,7 +833,7 @@ func (m *PingRequest) Reset()         { *m = PingRequest{} }
 func (m *PingRequest) String() string { return proto.CompactTextString(m) }
 func (*PingRequest) ProtoMessage()    {}
 func (*PingRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{9}
+	return fileDescriptor_overlay_09566a9ca006317a, []int{13}
 }
 func (m *PingRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingRequest.Unmarshal(m, b)
@@ -552,7 +863,7 @@ func (m *PingResponse) Reset()         { *m = PingResponse{} }
 func (m *PingResponse) String() string { return proto.CompactTextString(m) }
 func (*PingResponse) ProtoMessage()    {}
 func (*PingResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{10}
+	return fileDescriptor_overlay_09566a9ca006317a, []int{14}
 }
 func (m *PingResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_PingResponse.Unmarshal(m, b)
@@ -572,6 +883,109 @@ func (m *PingResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PingResponse proto.InternalMessageInfo
 
+// DialBackRequest asks to be dialed back on port at the address the request came from
+type DialBackRequest struct {
+	Port                 int32    `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DialBackRequest) Reset()         { *m = DialBackRequest{} }
+func (m *DialBackRequest) String() string { return proto.CompactTextString(m) }
+func (*DialBackRequest) ProtoMessage()    {}
+func (*DialBackRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_09566a9ca006317a, []int{15}
+}
+func (m *DialBackRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DialBackRequest.Unmarshal(m, b)
+}
+func (m *DialBackRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DialBackRequest.Marshal(b, m, deterministic)
+}
+func (dst *DialBackRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DialBackRequest.Merge(dst, src)
+}
+func (m *DialBackRequest) XXX_Size() int {
+	return xxx_messageInfo_DialBackRequest.Size(m)
+}
+func (m *DialBackRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DialBackRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DialBackRequest proto.InternalMessageInfo
+
+func (m *DialBackRequest) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+type DialBackResponse struct {
+	// address the request was observed coming from
+	ObservedAddress string `protobuf:"bytes,1,opt,name=observed_address,json=observedAddress,proto3" json:"observed_address,omitempty"`
+	// address that was dialed back
+	DialedAddress        string   `protobuf:"bytes,2,opt,name=dialed_address,json=dialedAddress,proto3" json:"dialed_address,omitempty"`
+	Reachable            bool     `protobuf:"varint,3,opt,name=reachable,proto3" json:"reachable,omitempty"`
+	Error                string   `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DialBackResponse) Reset()         { *m = DialBackResponse{} }
+func (m *DialBackResponse) String() string { return proto.CompactTextString(m) }
+func (*DialBackResponse) ProtoMessage()    {}
+func (*DialBackResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_overlay_09566a9ca006317a, []int{16}
+}
+func (m *DialBackResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DialBackResponse.Unmarshal(m, b)
+}
+func (m *DialBackResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DialBackResponse.Marshal(b, m, deterministic)
+}
+func (dst *DialBackResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DialBackResponse.Merge(dst, src)
+}
+func (m *DialBackResponse) XXX_Size() int {
+	return xxx_messageInfo_DialBackResponse.Size(m)
+}
+func (m *DialBackResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DialBackResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DialBackResponse proto.InternalMessageInfo
+
+func (m *DialBackResponse) GetObservedAddress() string {
+	if m != nil {
+		return m.ObservedAddress
+	}
+	return ""
+}
+
+func (m *DialBackResponse) GetDialedAddress() string {
+	if m != nil {
+		return m.DialedAddress
+	}
+	return ""
+}
+
+func (m *DialBackResponse) GetReachable() bool {
+	if m != nil {
+		return m.Reachable
+	}
+	return false
+}
+
+func (m *DialBackResponse) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
 type Restriction struct {
 	Operator             Restriction_Operator `protobuf:"varint,1,opt,name=operator,proto3,enum=overlay.Restriction_Operator" json:"operator,omitempty"`
 	Operand              Restriction_Operand  `protobuf:"varint,2,opt,name=operand,proto3,enum=overlay.Restriction_Operand" json:"operand,omitempty"`
@@ -585,7 +999,7 @@ func (m *Restriction) Reset()         { *m = Restriction{} }
 func (m *Restriction) String() string { return proto.CompactTextString(m) }
 func (*Restriction) ProtoMessage()    {}
 func (*Restriction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_overlay_99a51b72c26b0776, []int{11}
+	return fileDescriptor_overlay_09566a9ca006317a, []int{17}
 }
 func (m *Restriction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Restriction.Unmarshal(m, b)
@@ -634,10 +1048,18 @@ func init() {
 	proto.RegisterType((*FindStorageNodesResponse)(nil), "overlay.FindStorageNodesResponse")
 	proto.RegisterType((*FindStorageNodesRequest)(nil), "overlay.FindStorageNodesRequest")
 	proto.RegisterType((*OverlayOptions)(nil), "overlay.OverlayOptions")
+	proto.RegisterType((*PerformanceReport)(nil), "overlay.PerformanceReport")
+	proto.RegisterType((*PerformanceReport_Measurement)(nil), "overlay.PerformanceReport.Measurement")
+	proto.RegisterType((*PerformanceReportResponse)(nil), "overlay.PerformanceReportResponse")
+	proto.RegisterType((*CheckInRequest)(nil), "overlay.CheckInRequest")
+	proto.RegisterType((*CheckInRequest_Data)(nil), "overlay.CheckInRequest.Data")
+	proto.RegisterType((*CheckInResponse)(nil), "overlay.CheckInResponse")
 	proto.RegisterType((*QueryRequest)(nil), "overlay.QueryRequest")
 	proto.RegisterType((*QueryResponse)(nil), "overlay.QueryResponse")
 	proto.RegisterType((*PingRequest)(nil), "overlay.PingRequest")
 	proto.RegisterType((*PingResponse)(nil), "overlay.PingResponse")
+	proto.RegisterType((*DialBackRequest)(nil), "overlay.DialBackRequest")
+	proto.RegisterType((*DialBackResponse)(nil), "overlay.DialBackResponse")
 	proto.RegisterType((*Restriction)(nil), "overlay.Restriction")
 	proto.RegisterEnum("overlay.Restriction_Operator", Restriction_Operator_name, Restriction_Operator_value)
 	proto.RegisterEnum("overlay.Restriction_Operand", Restriction_Operand_name, Restriction_Operand_value)
@@ -661,6 +1083,10 @@ type OverlayClient interface {
 	BulkLookup(ctx context.Context, in *LookupRequests, opts ...grpc.CallOption) (*LookupResponses, error)
 	// FindStorageNodes finds a list of nodes in the network that meet the specified request parameters
 	FindStorageNodes(ctx context.Context, in *FindStorageNodesRequest, opts ...grpc.CallOption) (*FindStorageNodesResponse, error)
+	// CheckIn lets a storage node report its capacity, version and operator metadata
+	CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*CheckInResponse, error)
+	// ReportPerformance records the latencies and speeds observed while talking to storage nodes
+	ReportPerformance(ctx context.Context, in *PerformanceReport, opts ...grpc.CallOption) (*PerformanceReportResponse, error)
 }
 
 type overlayClient struct {
@@ -698,6 +1124,24 @@ func (c *overlayClient) FindStorageNodes(ctx context.Context, in *FindStorageNod
 	return out, nil
 }
 
+func (c *overlayClient) CheckIn(ctx context.Context, in *CheckInRequest, opts ...grpc.CallOption) (*CheckInResponse, error) {
+	out := new(CheckInResponse)
+	err := c.cc.Invoke(ctx, "/overlay.Overlay/CheckIn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *overlayClient) ReportPerformance(ctx context.Context, in *PerformanceReport, opts ...grpc.CallOption) (*PerformanceReportResponse, error) {
+	out := new(PerformanceReportResponse)
+	err := c.cc.Invoke(ctx, "/overlay.Overlay/ReportPerformance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OverlayServer is the server API for Overlay service.
 type OverlayServer interface {
 	// Lookup finds a nodes address from the network
@@ -706,6 +1150,10 @@ type OverlayServer interface {
 	BulkLookup(context.Context, *LookupRequests) (*LookupResponses, error)
 	// FindStorageNodes finds a list of nodes in the network that meet the specified request parameters
 	FindStorageNodes(context.Context, *FindStorageNodesRequest) (*FindStorageNodesResponse, error)
+	// CheckIn lets a storage node report its capacity, version and operator metadata
+	CheckIn(context.Context, *CheckInRequest) (*CheckInResponse, error)
+	// ReportPerformance records the latencies and speeds observed while talking to storage nodes
+	ReportPerformance(context.Context, *PerformanceReport) (*PerformanceReportResponse, error)
 }
 
 func RegisterOverlayServer(s *grpc.Server, srv OverlayServer) {
@@ -766,6 +1214,42 @@ func _Overlay_FindStorageNodes_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Overlay_CheckIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OverlayServer).CheckIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/overlay.Overlay/CheckIn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OverlayServer).CheckIn(ctx, req.(*CheckInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Overlay_ReportPerformance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PerformanceReport)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OverlayServer).ReportPerformance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/overlay.Overlay/ReportPerformance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OverlayServer).ReportPerformance(ctx, req.(*PerformanceReport))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Overlay_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "overlay.Overlay",
 	HandlerType: (*OverlayServer)(nil),
@@ -782,6 +1266,14 @@ var _Overlay_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FindStorageNodes",
 			Handler:    _Overlay_FindStorageNodes_Handler,
 		},
+		{
+			MethodName: "CheckIn",
+			Handler:    _Overlay_CheckIn_Handler,
+		},
+		{
+			MethodName: "ReportPerformance",
+			Handler:    _Overlay_ReportPerformance_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "overlay.proto",
@@ -793,6 +1285,8 @@ var _Overlay_serviceDesc = grpc.ServiceDesc{
 type NodesClient interface {
 	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	// DialBack asks the receiver to dial the sender back to check it is reachable
+	DialBack(ctx context.Context, in *DialBackRequest, opts ...grpc.CallOption) (*DialBackResponse, error)
 }
 
 type nodesClient struct {
@@ -821,10 +1315,21 @@ func (c *nodesClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.Ca
 	return out, nil
 }
 
+func (c *nodesClient) DialBack(ctx context.Context, in *DialBackRequest, opts ...grpc.CallOption) (*DialBackResponse, error) {
+	out := new(DialBackResponse)
+	err := c.cc.Invoke(ctx, "/overlay.Nodes/DialBack", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NodesServer is the server API for Nodes service.
 type NodesServer interface {
 	Query(context.Context, *QueryRequest) (*QueryResponse, error)
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	// DialBack asks the receiver to dial the sender back to check it is reachable
+	DialBack(context.Context, *DialBackRequest) (*DialBackResponse, error)
 }
 
 func RegisterNodesServer(s *grpc.Server, srv NodesServer) {
@@ -867,6 +1372,24 @@ func _Nodes_Ping_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Nodes_DialBack_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DialBackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodesServer).DialBack(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/overlay.Nodes/DialBack",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodesServer).DialBack(ctx, req.(*DialBackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Nodes_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "overlay.Nodes",
 	HandlerType: (*NodesServer)(nil),
@@ -879,66 +1402,99 @@ var _Nodes_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _Nodes_Ping_Handler,
 		},
+		{
+			MethodName: "DialBack",
+			Handler:    _Nodes_DialBack_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "overlay.proto",
 }
 
-func init() { proto.RegisterFile("overlay.proto", fileDescriptor_overlay_99a51b72c26b0776) }
-
-var fileDescriptor_overlay_99a51b72c26b0776 = []byte{
-	// 845 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xdd, 0x8e, 0xdb, 0x44,
-	0x14, 0x5e, 0xe7, 0xc7, 0xc9, 0x9e, 0x24, 0x5e, 0x6b, 0xd4, 0xee, 0x06, 0x03, 0xdd, 0x60, 0x55,
-	0xb0, 0x12, 0x55, 0x0a, 0x29, 0xaa, 0x68, 0x05, 0x02, 0xa2, 0xa4, 0x65, 0xd5, 0xa8, 0x4b, 0x27,
-	0x91, 0x2a, 0xc1, 0x85, 0xe5, 0xc4, 0x83, 0x31, 0xeb, 0x78, 0x8c, 0x67, 0x5c, 0xed, 0xf6, 0x09,
-	0x78, 0x13, 0x5e, 0x85, 0x67, 0xe0, 0x62, 0x1f, 0x81, 0x07, 0xe0, 0x0a, 0xcd, 0x8f, 0x1d, 0x67,
-	0x77, 0x53, 0xf5, 0x6a, 0xe6, 0x9c, 0xf3, 0x7d, 0x67, 0xe6, 0x3b, 0x73, 0xe6, 0x40, 0x8f, 0xbe,
-	0x21, 0x59, 0xec, 0x5f, 0x0e, 0xd3, 0x8c, 0x72, 0x8a, 0x5a, 0xda, 0x74, 0xee, 0x85, 0x94, 0x86,
-	0x31, 0x79, 0x28, 0xdd, 0xcb, 0xfc, 0xd7, 0x87, 0x41, 0x9e, 0xf9, 0x3c, 0xa2, 0x89, 0x02, 0x3a,
-	0x10, 0xd2, 0x90, 0x16, 0xfb, 0x84, 0x06, 0x44, 0xed, 0xdd, 0xaf, 0xa1, 0x37, 0xa3, 0xf4, 0x3c,
-	0x4f, 0x31, 0xf9, 0x23, 0x27, 0x8c, 0xa3, 0xcf, 0xa0, 0x25, 0xc2, 0x5e, 0x14, 0xf4, 0x8d, 0x81,
-	0x71, 0xd2, 0x1d, 0x5b, 0x7f, 0x5f, 0x1d, 0xef, 0xfd, 0x73, 0x75, 0x6c, 0xbe, 0xa4, 0x01, 0x39,
-	0x9d, 0x60, 0x53, 0x84, 0x4f, 0x03, 0xf7, 0x0b, 0xb0, 0x0a, 0x26, 0x4b, 0x69, 0xc2, 0x08, 0xba,
-	0x07, 0x0d, 0x11, 0x93, 0xbc, 0xce, 0x08, 0x86, 0xf2, 0x18, 0xc1, 0xc2, 0xd2, 0xef, 0x9e, 0x6d,
-	0x18, 0xf2, 0x2c, 0x86, 0xbe, 0x05, 0x2b, 0x96, 0x1e, 0x2f, 0x53, 0xae, 0xbe, 0x31, 0xa8, 0x9f,
-	0x74, 0x46, 0x87, 0xc3, 0x42, 0xe6, 0x16, 0x01, 0xf7, 0xe2, 0xaa, 0xe9, 0xce, 0xe1, 0x60, 0xfb,
-	0x0a, 0x0c, 0x7d, 0x0f, 0x07, 0x65, 0x46, 0xe5, 0xd3, 0x29, 0x8f, 0x6e, 0xa4, 0x54, 0x61, 0x6c,
-	0xc5, 0x5b, 0xb6, 0xfb, 0x0d, 0xf4, 0x9f, 0x45, 0x49, 0x30, 0xe7, 0x34, 0xf3, 0x43, 0x22, 0xae,
-	0xcf, 0x4a, 0x85, 0x03, 0x68, 0x0a, 0x25, 0x4c, 0xe7, 0xac, 0x4a, 0x54, 0x01, 0xf7, 0x5f, 0x03,
-	0x8e, 0x6e, 0xd2, 0x55, 0x69, 0x8f, 0xa1, 0x43, 0x97, 0xbf, 0x93, 0x15, 0xf7, 0x58, 0xf4, 0x56,
-	0x95, 0xa9, 0x8e, 0x41, 0xb9, 0xe6, 0xd1, 0x5b, 0x82, 0xc6, 0x70, 0xb0, 0xa2, 0x09, 0xcf, 0xfc,
-	0x15, 0xf7, 0x62, 0x92, 0x84, 0xfc, 0xb7, 0x7e, 0x4d, 0xd6, 0xf2, 0x83, 0xa1, 0x7a, 0xde, 0x61,
-	0xf1, 0xbc, 0xc3, 0x89, 0x7e, 0x5e, 0x6c, 0x15, 0x8c, 0x99, 0x24, 0xa0, 0xcf, 0xa1, 0x41, 0x53,
-	0xce, 0xfa, 0x75, 0x49, 0xdc, 0xa8, 0x3e, 0x53, 0xeb, 0x59, 0x2a, 0x58, 0x0c, 0x4b, 0x10, 0xba,
-	0x0f, 0x4d, 0xc6, 0xfd, 0x8c, 0xf7, 0x1b, 0xb7, 0x3e, 0xb5, 0x0a, 0xa2, 0x0f, 0x61, 0x7f, 0xed,
-	0x5f, 0x78, 0x4a, 0x79, 0x53, 0xde, 0xba, 0xbd, 0xf6, 0x2f, 0xa4, 0x36, 0xf7, 0xaf, 0x1a, 0x58,
-	0xdb, 0xb9, 0xd1, 0x53, 0xe8, 0x08, 0x7c, 0xec, 0x73, 0x92, 0xac, 0x2e, 0x75, 0x3b, 0xbc, 0x43,
-	0x02, 0xac, 0xfd, 0x8b, 0x99, 0x02, 0xa3, 0x07, 0xb0, 0xbf, 0x8e, 0x12, 0x8f, 0x71, 0x9f, 0x33,
-	0x2d, 0xfe, 0x60, 0x53, 0xe5, 0xb9, 0x70, 0xe3, 0xf6, 0x3a, 0x4a, 0xe4, 0x0e, 0xdd, 0x07, 0x4b,
-	0xa2, 0x53, 0x42, 0x02, 0xef, 0x7c, 0x99, 0x2a, 0xd9, 0x75, 0xdc, 0x15, 0x08, 0xe1, 0x7c, 0xb1,
-	0x4c, 0x19, 0x3a, 0x04, 0xd3, 0x5f, 0xd3, 0x3c, 0x51, 0x32, 0xeb, 0x58, 0x5b, 0xe8, 0x29, 0x74,
-	0x33, 0xc2, 0x78, 0x16, 0xad, 0xe4, 0xbd, 0xa5, 0x34, 0xd1, 0x7b, 0x9b, 0x47, 0xad, 0x44, 0xf1,
-	0x16, 0x16, 0x7d, 0x09, 0x16, 0xb9, 0x58, 0xc5, 0x79, 0x40, 0x02, 0x5d, 0x18, 0x73, 0x50, 0x3f,
-	0xe9, 0x8e, 0xa1, 0x52, 0xbe, 0x5e, 0x81, 0x50, 0x95, 0xfa, 0xd3, 0x80, 0xee, 0xab, 0x9c, 0x64,
-	0x97, 0x45, 0x3f, 0xb8, 0x60, 0x32, 0x92, 0x04, 0x24, 0xbb, 0xe5, 0xc7, 0xe8, 0x88, 0xc0, 0x70,
-	0x3f, 0x0b, 0x09, 0xd7, 0xc5, 0xd8, 0xc2, 0xa8, 0x08, 0xba, 0x03, 0xcd, 0x38, 0x5a, 0x47, 0x5c,
-	0x8b, 0x57, 0x06, 0x72, 0xa0, 0x9d, 0x46, 0x49, 0xb8, 0xf4, 0x57, 0xe7, 0x52, 0x77, 0x1b, 0x97,
-	0xb6, 0xfb, 0x0b, 0xf4, 0xf4, 0x4d, 0x74, 0x63, 0xbf, 0xcf, 0x55, 0x3e, 0x85, 0x76, 0xf9, 0xa7,
-	0x6a, 0x37, 0xfa, 0xbf, 0x8c, 0xb9, 0x3d, 0xe8, 0xfc, 0x14, 0x25, 0x61, 0xf1, 0x49, 0x2d, 0xe8,
-	0x2a, 0x53, 0x87, 0xff, 0x33, 0xa0, 0x53, 0x29, 0x2c, 0x7a, 0x02, 0x6d, 0x9a, 0x92, 0xcc, 0xe7,
-	0x54, 0x1d, 0x6e, 0x8d, 0x3e, 0x2e, 0x9b, 0xb6, 0x82, 0x1b, 0x9e, 0x69, 0x10, 0x2e, 0xe1, 0xe8,
-	0x31, 0xb4, 0xe4, 0x3e, 0x09, 0x64, 0x75, 0xac, 0xd1, 0x47, 0xbb, 0x99, 0x49, 0x80, 0x0b, 0xb0,
-	0x28, 0xd8, 0x1b, 0x3f, 0xce, 0x49, 0x51, 0x30, 0x69, 0xb8, 0x5f, 0x41, 0xbb, 0x38, 0x03, 0x99,
-	0x50, 0x9b, 0x2d, 0xec, 0x3d, 0xb1, 0x4e, 0x5f, 0xd9, 0x86, 0x58, 0x9f, 0x2f, 0xec, 0x1a, 0x6a,
-	0x41, 0x7d, 0xb6, 0x98, 0xda, 0x75, 0xb1, 0x79, 0xbe, 0x98, 0xda, 0x0d, 0xf7, 0x01, 0xb4, 0x74,
-	0x7e, 0x84, 0xc0, 0x7a, 0x86, 0xa7, 0x53, 0x6f, 0xfc, 0xc3, 0xcb, 0xc9, 0xeb, 0xd3, 0xc9, 0xe2,
-	0x47, 0x7b, 0x0f, 0xf5, 0x60, 0x5f, 0xfa, 0x26, 0xa7, 0xf3, 0x17, 0xb6, 0x31, 0xba, 0x32, 0xa0,
-	0xa5, 0x7f, 0x0b, 0x7a, 0x02, 0xa6, 0x1a, 0x45, 0x68, 0xc7, 0xb8, 0x73, 0x76, 0xcd, 0x2c, 0xf4,
-	0x1d, 0xc0, 0x38, 0x8f, 0xcf, 0x35, 0xfd, 0xe8, 0x76, 0x3a, 0x73, 0xfa, 0x3b, 0xf8, 0x0c, 0xbd,
-	0x06, 0xfb, 0xfa, 0x94, 0x42, 0x83, 0x12, 0xbd, 0x63, 0x80, 0x39, 0x9f, 0xbc, 0x03, 0xa1, 0x32,
-	0x8f, 0x38, 0x34, 0x55, 0xb6, 0xc7, 0xd0, 0x94, 0x2d, 0x86, 0xee, 0x96, 0xa4, 0x6a, 0xf3, 0x3b,
-	0x87, 0xd7, 0xdd, 0x5a, 0xda, 0x23, 0x68, 0x88, 0x76, 0x41, 0x77, 0xca, 0x78, 0xa5, 0x99, 0x9c,
-	0xbb, 0xd7, 0xbc, 0x8a, 0x34, 0x6e, 0xfc, 0x5c, 0x4b, 0x97, 0x4b, 0x53, 0x8e, 0x96, 0x47, 0xff,
-	0x07, 0x00, 0x00, 0xff, 0xff, 0xae, 0x57, 0x27, 0x55, 0x24, 0x07, 0x00, 0x00,
+func init() { proto.RegisterFile("overlay.proto", fileDescriptor_overlay_09566a9ca006317a) }
+
+var fileDescriptor_overlay_09566a9ca006317a = []byte{
+	// 1301 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xdb, 0x6e, 0x13, 0xc7,
+	0x1b, 0x67, 0x6d, 0xc7, 0x87, 0xcf, 0xf6, 0xda, 0x8c, 0x38, 0x98, 0xe5, 0x10, 0xff, 0x57, 0x1c,
+	0x82, 0x40, 0xe6, 0xdf, 0x50, 0x21, 0x40, 0x54, 0x2d, 0x6e, 0x02, 0x4d, 0x09, 0x04, 0x26, 0x51,
+	0x91, 0xda, 0x0b, 0x6b, 0xec, 0x1d, 0xcc, 0x36, 0x7b, 0xea, 0xcc, 0x38, 0x4a, 0x78, 0x82, 0x3e,
+	0x42, 0x6f, 0xfb, 0x0c, 0x55, 0xdf, 0xa1, 0x2f, 0xd0, 0x9b, 0x5e, 0xf0, 0x08, 0x95, 0x7a, 0x57,
+	0xf5, 0xaa, 0x9a, 0xd3, 0xda, 0x8e, 0x63, 0xca, 0xd5, 0xce, 0xf7, 0xfb, 0x7e, 0xdf, 0xcc, 0x77,
+	0x9c, 0x59, 0x68, 0xa6, 0x07, 0x94, 0x45, 0xe4, 0xa8, 0x97, 0xb1, 0x54, 0xa4, 0xa8, 0x62, 0x44,
+	0xef, 0xca, 0x38, 0x4d, 0xc7, 0x11, 0xbd, 0xa3, 0xe0, 0xe1, 0xe4, 0xcd, 0x9d, 0x60, 0xc2, 0x88,
+	0x08, 0xd3, 0x44, 0x13, 0xbd, 0xd5, 0xe3, 0x7a, 0x11, 0xc6, 0x94, 0x0b, 0x12, 0x67, 0x86, 0x00,
+	0xe3, 0x74, 0x9c, 0xda, 0x75, 0x92, 0x06, 0x54, 0xaf, 0xfd, 0xfb, 0xd0, 0xdc, 0x4e, 0xd3, 0xfd,
+	0x49, 0x86, 0xe9, 0x0f, 0x13, 0xca, 0x05, 0xba, 0x01, 0x15, 0xa9, 0x1e, 0x84, 0x41, 0xc7, 0xe9,
+	0x3a, 0x6b, 0x8d, 0xbe, 0xfb, 0xdb, 0xfb, 0xd5, 0x53, 0x7f, 0xbc, 0x5f, 0x2d, 0xbf, 0x48, 0x03,
+	0xba, 0xb5, 0x81, 0xcb, 0x52, 0xbd, 0x15, 0xf8, 0xff, 0x07, 0xd7, 0x5a, 0xf2, 0x2c, 0x4d, 0x38,
+	0x45, 0x57, 0xa0, 0x24, 0x75, 0xca, 0xae, 0xbe, 0x0e, 0x3d, 0x75, 0x8c, 0xb4, 0xc2, 0x0a, 0xf7,
+	0x77, 0xc0, 0x9d, 0x3b, 0x8b, 0xa3, 0xcf, 0xc0, 0x8d, 0x14, 0x32, 0x60, 0x1a, 0xea, 0x38, 0xdd,
+	0xe2, 0x5a, 0x7d, 0xfd, 0x5c, 0xcf, 0xe6, 0x61, 0xce, 0x00, 0x37, 0xa3, 0x59, 0xd1, 0xdf, 0x85,
+	0xd6, 0xbc, 0x0b, 0x1c, 0x7d, 0x01, 0xad, 0x7c, 0x47, 0x8d, 0x99, 0x2d, 0xcf, 0x2f, 0x6c, 0xa9,
+	0xd5, 0xd8, 0x8d, 0xe6, 0x64, 0xff, 0x11, 0x74, 0x9e, 0x84, 0x49, 0xb0, 0x2b, 0x52, 0x46, 0xc6,
+	0x54, 0xba, 0xcf, 0xf3, 0x08, 0xbb, 0xb0, 0x22, 0x23, 0xe1, 0x66, 0xcf, 0xd9, 0x10, 0xb5, 0xc2,
+	0xff, 0xd3, 0x81, 0xf3, 0x8b, 0xe6, 0x3a, 0xb5, 0xab, 0x50, 0x4f, 0x87, 0xdf, 0xd3, 0x91, 0x18,
+	0xf0, 0xf0, 0x9d, 0x4e, 0x53, 0x11, 0x83, 0x86, 0x76, 0xc3, 0x77, 0x14, 0xf5, 0xa1, 0x35, 0x4a,
+	0x13, 0xc1, 0xc8, 0x48, 0x0c, 0x22, 0x9a, 0x8c, 0xc5, 0xdb, 0x4e, 0x41, 0xe5, 0xf2, 0x42, 0x4f,
+	0xd7, 0xb7, 0x67, 0xeb, 0xdb, 0xdb, 0x30, 0xf5, 0xc7, 0xae, 0xb5, 0xd8, 0x56, 0x06, 0xe8, 0x16,
+	0x94, 0xd2, 0x4c, 0xf0, 0x4e, 0xb1, 0xeb, 0xcc, 0x45, 0xbd, 0xa3, 0xbf, 0x3b, 0x99, 0xb4, 0xe2,
+	0x58, 0x91, 0xd0, 0x55, 0x58, 0xe1, 0x82, 0x30, 0xd1, 0x29, 0x9d, 0x58, 0x6a, 0xad, 0x44, 0x17,
+	0xa1, 0x16, 0x93, 0xc3, 0x81, 0x8e, 0x7c, 0x45, 0x79, 0x5d, 0x8d, 0xc9, 0xa1, 0x8a, 0xcd, 0xff,
+	0xb9, 0x08, 0xee, 0xfc, 0xde, 0xe8, 0x21, 0xd4, 0x25, 0x3f, 0x22, 0x82, 0x26, 0xa3, 0xa3, 0x8e,
+	0xf3, 0x5f, 0x21, 0x40, 0x4c, 0x0e, 0xb7, 0x35, 0x19, 0xdd, 0x86, 0x5a, 0x1c, 0x26, 0x03, 0x2e,
+	0x88, 0xe0, 0x26, 0xf8, 0xd6, 0x34, 0xcb, 0xbb, 0x12, 0xc6, 0xd5, 0x38, 0x4c, 0xd4, 0x0a, 0x5d,
+	0x05, 0x57, 0xb1, 0x33, 0x4a, 0x83, 0xc1, 0xfe, 0x30, 0xd3, 0x61, 0x17, 0x71, 0x43, 0x32, 0x24,
+	0xf8, 0x6c, 0x98, 0x71, 0x74, 0x0e, 0xca, 0x24, 0x4e, 0x27, 0x89, 0x0e, 0xb3, 0x88, 0x8d, 0x84,
+	0x1e, 0x42, 0x83, 0x51, 0x2e, 0x58, 0x38, 0x52, 0x7e, 0xab, 0xd0, 0x64, 0xef, 0x4d, 0x8b, 0x3a,
+	0xa3, 0xc5, 0x73, 0x5c, 0xf4, 0x09, 0xb8, 0xf4, 0x70, 0x14, 0x4d, 0x02, 0x1a, 0x98, 0xc4, 0x94,
+	0xbb, 0xc5, 0xb5, 0x46, 0x1f, 0x66, 0xd2, 0xd7, 0xb4, 0x0c, 0x29, 0x73, 0x74, 0x13, 0xda, 0xb2,
+	0xcb, 0x43, 0x46, 0x83, 0x01, 0xa3, 0x63, 0x75, 0x64, 0xa5, 0x5b, 0x5c, 0xab, 0xe1, 0x96, 0xc5,
+	0xb1, 0x86, 0x25, 0x35, 0xdf, 0xdd, 0x52, 0xab, 0x9a, 0x6a, 0x71, 0x4b, 0xf5, 0xa1, 0xc9, 0x48,
+	0xb2, 0x3f, 0x18, 0x1e, 0xe9, 0x34, 0x74, 0x6a, 0x5d, 0x67, 0xad, 0x8a, 0xeb, 0x12, 0xec, 0x1f,
+	0xa9, 0x24, 0xf8, 0xbf, 0x3b, 0x70, 0xfa, 0x25, 0x65, 0x6f, 0x52, 0x16, 0x93, 0x64, 0x44, 0x31,
+	0xcd, 0x52, 0x26, 0xd0, 0xd7, 0xd0, 0x88, 0x29, 0xe1, 0x13, 0x46, 0x63, 0x9a, 0x08, 0xdb, 0xd3,
+	0xd7, 0xf3, 0x8e, 0x59, 0xb0, 0xe8, 0x3d, 0x9f, 0xd2, 0xf1, 0x9c, 0xad, 0x27, 0xa0, 0x3e, 0xa3,
+	0xfc, 0xe8, 0x4b, 0x04, 0x5d, 0x06, 0x30, 0x6d, 0x32, 0x88, 0x75, 0xbd, 0x8b, 0xb8, 0x66, 0x90,
+	0xe7, 0x5c, 0xaa, 0x17, 0x6a, 0x5b, 0xe3, 0xb6, 0xb0, 0xfe, 0x45, 0xb8, 0xb0, 0xe0, 0x64, 0x3e,
+	0xc7, 0x7f, 0x17, 0xc0, 0xfd, 0xf2, 0x2d, 0x1d, 0xed, 0x6f, 0x25, 0x76, 0x00, 0x11, 0x94, 0x02,
+	0x22, 0x88, 0xf6, 0x09, 0xab, 0x35, 0xba, 0x04, 0x35, 0x1e, 0x8e, 0x13, 0x22, 0x26, 0x8c, 0x2a,
+	0x07, 0x1a, 0x78, 0x0a, 0x78, 0xbf, 0x14, 0xa0, 0xb4, 0x21, 0x69, 0x1f, 0x1d, 0xd1, 0x2d, 0xa8,
+	0x90, 0x20, 0x60, 0x94, 0xdb, 0xf6, 0x3d, 0x3d, 0xed, 0xa7, 0xc7, 0x5a, 0x81, 0x2d, 0x03, 0xad,
+	0x43, 0x75, 0x44, 0x32, 0x32, 0x0a, 0xc5, 0x51, 0xa7, 0xf8, 0xc1, 0xee, 0xcb, 0x79, 0x32, 0x27,
+	0x13, 0x4e, 0x83, 0x01, 0xcf, 0xc8, 0x88, 0x9a, 0x8e, 0xae, 0x49, 0x64, 0x57, 0x02, 0xa8, 0x03,
+	0x95, 0x03, 0xca, 0x78, 0x98, 0x26, 0xaa, 0x9f, 0x6b, 0xd8, 0x8a, 0xa8, 0x07, 0xd5, 0x98, 0x0a,
+	0xa2, 0x32, 0x50, 0x56, 0x87, 0xa1, 0xe9, 0x61, 0xcf, 0x8d, 0x06, 0xe7, 0x1c, 0x74, 0x1f, 0x6a,
+	0xf9, 0x2b, 0xd2, 0xa9, 0x28, 0x03, 0x6f, 0x61, 0x88, 0xf7, 0x2c, 0x03, 0x4f, 0xc9, 0xfe, 0x43,
+	0x68, 0xe5, 0x99, 0x37, 0x37, 0xe7, 0x0d, 0x68, 0xc5, 0x61, 0x12, 0xc6, 0x93, 0x78, 0x60, 0xdd,
+	0x73, 0x94, 0x7b, 0xae, 0x81, 0xbf, 0xd1, 0xa8, 0xff, 0xa3, 0x03, 0x8d, 0x57, 0x13, 0xca, 0x8e,
+	0x6c, 0xd1, 0x7c, 0x28, 0x73, 0x9a, 0x04, 0x94, 0x9d, 0xf0, 0xae, 0x18, 0x8d, 0xe4, 0x08, 0xc2,
+	0xc6, 0x54, 0x74, 0x0a, 0x8b, 0x1c, 0xad, 0x41, 0x67, 0x60, 0x25, 0x0a, 0xe3, 0x50, 0x98, 0x36,
+	0xd2, 0x02, 0xf2, 0xa0, 0x9a, 0x85, 0xc9, 0x78, 0x48, 0x46, 0xfb, 0x2a, 0x97, 0x55, 0x9c, 0xcb,
+	0xfe, 0x77, 0xd0, 0x34, 0x9e, 0x98, 0x20, 0x3e, 0xc6, 0x95, 0xeb, 0x50, 0xcd, 0x5f, 0x9e, 0xc2,
+	0xc2, 0x2b, 0x91, 0xeb, 0xfc, 0x26, 0xd4, 0x5f, 0x86, 0xc9, 0xd8, 0x3e, 0x65, 0x2e, 0x34, 0xb4,
+	0x68, 0xd4, 0xd7, 0xa0, 0xb5, 0x11, 0x92, 0xa8, 0x4f, 0x46, 0xfb, 0x33, 0xdd, 0x2b, 0x1b, 0x5c,
+	0x9d, 0xbd, 0x82, 0xd5, 0xda, 0xff, 0xc9, 0x81, 0xf6, 0x94, 0x67, 0xdc, 0xbc, 0x09, 0xed, 0x74,
+	0xc8, 0x29, 0x3b, 0xa0, 0xc1, 0xc0, 0xf6, 0xa2, 0x4e, 0x76, 0xcb, 0xe2, 0xa6, 0x13, 0xd1, 0x35,
+	0x70, 0x83, 0x90, 0x44, 0x33, 0xc4, 0x82, 0x22, 0x36, 0x35, 0x6a, 0x69, 0x97, 0xa0, 0xc6, 0x28,
+	0x19, 0xbd, 0x25, 0xc3, 0x88, 0xaa, 0xfc, 0x55, 0xf1, 0x14, 0x90, 0x99, 0xa5, 0x8c, 0xa5, 0x4c,
+	0x25, 0xb0, 0x86, 0xb5, 0xe0, 0xff, 0xe3, 0x40, 0x7d, 0xa6, 0x85, 0xd1, 0x03, 0xa8, 0xa6, 0x19,
+	0x65, 0x44, 0xa4, 0x3a, 0x7d, 0xee, 0xfa, 0xe5, 0xfc, 0xaa, 0x99, 0xe1, 0xf5, 0x76, 0x0c, 0x09,
+	0xe7, 0x74, 0x74, 0x0f, 0x2a, 0x6a, 0x9d, 0x04, 0xca, 0x3d, 0x77, 0xfd, 0xd2, 0x72, 0xcb, 0x24,
+	0xc0, 0x96, 0x2c, 0x1d, 0x3b, 0x20, 0xd1, 0x84, 0xda, 0x92, 0x2b, 0xc1, 0xff, 0x14, 0xaa, 0xf6,
+	0x0c, 0x54, 0x86, 0xc2, 0xf6, 0x5e, 0xfb, 0x94, 0xfc, 0x6e, 0xbe, 0x6a, 0x3b, 0xf2, 0xfb, 0x74,
+	0xaf, 0x5d, 0x40, 0x15, 0x28, 0x6e, 0xef, 0x6d, 0xb6, 0x8b, 0x72, 0xf1, 0x74, 0x6f, 0xb3, 0x5d,
+	0xf2, 0x6f, 0x43, 0xc5, 0xec, 0x8f, 0x10, 0xb8, 0x4f, 0xf0, 0xe6, 0xe6, 0xa0, 0xff, 0xf8, 0xc5,
+	0xc6, 0xeb, 0xad, 0x8d, 0xbd, 0xaf, 0xda, 0xa7, 0x50, 0x13, 0x6a, 0x0a, 0xdb, 0xd8, 0xda, 0x7d,
+	0xd6, 0x76, 0xd6, 0xff, 0x2a, 0x40, 0xc5, 0xbc, 0x8a, 0xe8, 0x01, 0x94, 0xf5, 0x2f, 0x07, 0x5a,
+	0xf2, 0x5b, 0xe3, 0x2d, 0xfb, 0x37, 0x41, 0x9f, 0x03, 0xf4, 0x27, 0xd1, 0xbe, 0x31, 0x3f, 0x7f,
+	0xb2, 0x39, 0xf7, 0x3a, 0x4b, 0xec, 0x39, 0x7a, 0x0d, 0xed, 0xe3, 0x7f, 0x23, 0xa8, 0x9b, 0xb3,
+	0x97, 0xfc, 0xa8, 0x78, 0xff, 0xfb, 0x00, 0xc3, 0x78, 0xf6, 0x08, 0x2a, 0x66, 0xc4, 0x67, 0xdc,
+	0x9a, 0xbf, 0x6e, 0xbd, 0xce, 0xa2, 0xc2, 0x58, 0xef, 0xc2, 0x69, 0x7d, 0x5b, 0xcf, 0x5c, 0xdf,
+	0xc8, 0x5b, 0xfe, 0xf2, 0x78, 0xfe, 0x72, 0x9d, 0xdd, 0x74, 0xfd, 0x57, 0x07, 0x56, 0x74, 0x84,
+	0xf7, 0x60, 0x45, 0x0d, 0x2e, 0x3a, 0x9b, 0x9b, 0xcd, 0x5e, 0x29, 0xde, 0xb9, 0xe3, 0xb0, 0x71,
+	0xeb, 0x2e, 0x94, 0xe4, 0x10, 0xa2, 0x33, 0xd3, 0xd3, 0xa6, 0x23, 0xea, 0x9d, 0x3d, 0x86, 0xe6,
+	0x35, 0xaa, 0xda, 0x09, 0x44, 0xd3, 0x88, 0x8f, 0x0d, 0xaf, 0x77, 0xe1, 0x04, 0x8d, 0xde, 0xa0,
+	0x5f, 0xfa, 0xb6, 0x90, 0x0d, 0x87, 0x65, 0x75, 0xa5, 0xde, 0xfd, 0x77, 0x00, 0xbe, 0xbf, 0xf1,
+	0xe0, 0x02, 0x0c, 0x00, 0x00,
 }
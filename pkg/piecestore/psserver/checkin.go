@@ -0,0 +1,162 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package psserver
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"storj.io/storj/internal/version"
+	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/utils"
+)
+
+// checkInService contains the information needed to periodically report
+// the capacity, version and metadata of the storage node to satellites
+type checkInService struct {
+	log        *zap.Logger
+	ticker     *time.Ticker
+	kad        *kademlia.Kademlia
+	rt         *kademlia.RoutingTable
+	server     *Server
+	identity   *provider.FullIdentity
+	transport  transport.Client
+	satellites storj.NodeIDList
+}
+
+func newCheckInService(log *zap.Logger, interval time.Duration, kad *kademlia.Kademlia, rt *kademlia.RoutingTable, server *Server, identity *provider.FullIdentity, satellites storj.NodeIDList) *checkInService {
+	return &checkInService{
+		log:        log,
+		ticker:     time.NewTicker(interval),
+		kad:        kad,
+		rt:         rt,
+		server:     server,
+		identity:   identity,
+		transport:  transport.NewClient(identity),
+		satellites: satellites,
+	}
+}
+
+// Run runs the check-in service
+func (service *checkInService) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	for {
+		err := service.process(ctx)
+		if err != nil {
+			service.log.Error("check-in", zap.Error(err))
+		}
+
+		select {
+		case <-service.ticker.C: // wait for the next interval to happen
+		case <-ctx.Done(): // or the check-in service is canceled via context
+			return ctx.Err()
+		}
+	}
+}
+
+// process checks in with every known satellite
+func (service *checkInService) process(ctx context.Context) error {
+	stats, err := service.server.Stats(ctx, nil)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	self := service.rt.Local()
+	req, err := overlay.NewCheckInRequest(service.identity, &pb.CheckInRequest_Data{
+		NodeId:  self.Id,
+		Address: self.Address,
+		Capacity: &pb.NodeRestrictions{
+			FreeBandwidth: stats.AvailableBandwidth,
+			FreeDisk:      stats.AvailableSpace,
+		},
+		UsedSpace: stats.UsedSpace,
+		Version:   version.Build,
+		Metadata:  self.Metadata,
+		Timestamp: ptypes.TimestampNow(),
+	})
+	if err != nil {
+		return Error.Wrap(err)
+	}
+
+	var errs []error
+	for _, satellite := range service.findSatellites(ctx) {
+		if err := service.checkIn(ctx, satellite, req); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utils.CombineErrors(errs...)
+}
+
+// findSatellites returns the configured satellites and the satellites in the routing table
+func (service *checkInService) findSatellites(ctx context.Context) []*pb.Node {
+	var satellites []*pb.Node
+	found := make(map[storj.NodeID]bool)
+
+	for _, id := range service.satellites {
+		satellite, err := service.kad.FindNode(ctx, id)
+		if err != nil {
+			service.log.Error("could not find satellite", zap.String("satelliteID", id.String()), zap.Error(err))
+			continue
+		}
+		found[id] = true
+		satellites = append(satellites, &satellite)
+	}
+
+	nodes, err := service.kad.GetNodes(ctx, storj.NodeID{}, 0)
+	if err != nil {
+		service.log.Error("could not list routing table", zap.Error(err))
+	}
+	for _, node := range nodes {
+		if node.Type == pb.NodeType_SATELLITE && !found[node.Id] {
+			found[node.Id] = true
+			satellites = append(satellites, node)
+		}
+	}
+
+	return satellites
+}
+
+// checkIn sends req to a single satellite
+func (service *checkInService) checkIn(ctx context.Context, satellite *pb.Node, req *pb.CheckInRequest) (err error) {
+	conn, err := service.transport.DialNode(ctx, satellite)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { err = utils.CombineErrors(err, conn.Close()) }()
+
+	_, err = pb.NewOverlayClient(conn).CheckIn(ctx, req)
+	if status.Code(err) == codes.FailedPrecondition {
+		service.log.Warn("satellite refused check-in, this version needs to be upgraded",
+			zap.String("satelliteID", satellite.Id.String()), zap.String("version", version.Build), zap.Error(err))
+	}
+	return Error.Wrap(err)
+}
+
+// parseNodeIDs parses a comma-separated list of node IDs
+func parseNodeIDs(s string) (ids storj.NodeIDList, err error) {
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := storj.NodeIDFromString(part)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -30,6 +30,8 @@ type Config struct {
 	AllocatedDiskSpace     int64         `help:"total allocated disk space, default(1GB)" default:"1073741824"`
 	AllocatedBandwidth     int64         `help:"total allocated bandwidth, default(100GB)" default:"107374182400"`
 	KBucketRefreshInterval time.Duration `help:"how frequently checker should audit segments" default:"3600s"`
+	CheckInInterval        time.Duration `help:"how frequently the node reports its capacity and version to satellites" default:"1h"`
+	CheckInSatellites      string        `help:"comma-separated IDs of satellites to check in with, besides the satellites in the routing table" default:""`
 }
 
 // Run implements provider.Responsibility
@@ -68,6 +70,18 @@ func (c Config) Run(ctx context.Context, server *provider.Provider) (err error)
 		}
 	}()
 
+	//checkin
+	satellites, err := parseNodeIDs(c.CheckInSatellites)
+	if err != nil {
+		return ServerError.Wrap(err)
+	}
+	checkIn := newCheckInService(zap.L(), c.CheckInInterval, k, krt, s, server.Identity(), satellites)
+	go func() {
+		if err := checkIn.Run(ctx); err != nil {
+			s.log.Debug("check-in stopped", zap.Error(err))
+		}
+	}()
+
 	//agreementsender
 	agreementsender := agreementsender.New(zap.L(), s.DB, server.Identity(), k)
 	go agreementsender.Run(ctx)
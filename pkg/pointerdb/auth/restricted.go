@@ -0,0 +1,132 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/zeebo/errs"
+
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/storj"
+)
+
+// Error is the default auth error class
+var Error = errs.Class("auth error")
+
+// restrictedPrefix starts the API keys restricted by a caveat
+const restrictedPrefix = "restricted:"
+
+// Operation is what a request does with the pointers at a path
+type Operation int
+
+const (
+	// Read gets a pointer
+	Read Operation = iota
+	// List lists the pointers under a prefix
+	List
+	// Write puts or deletes a pointer
+	Write
+)
+
+// Restrict returns an API key giving access only to what caveat allows.
+// Restricted API keys can't be restricted further.
+func Restrict(key string, caveat *pb.Caveat) (string, error) {
+	if strings.HasPrefix(key, restrictedPrefix) {
+		return "", Error.New("API key is already restricted")
+	}
+	if strings.Trim(caveat.GetPathPrefix(), "/") == "" {
+		return "", Error.New("caveat must restrict a path prefix")
+	}
+
+	data, err := proto.Marshal(caveat)
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	restricted, err := proto.Marshal(&pb.RestrictedAPIKey{
+		Caveat:    data,
+		Signature: sign(key, data),
+	})
+	if err != nil {
+		return "", Error.Wrap(err)
+	}
+	return restrictedPrefix + base58.Encode(restricted), nil
+}
+
+// ValidateAccess validates the X-API-Key header like ValidateAPIKey. If the
+// header is a restricted API key, it also checks that its caveat allows op on
+// the pointers at path.
+func ValidateAccess(header string, path storj.Path, op Operation) bool {
+	if !strings.HasPrefix(header, restrictedPrefix) {
+		return ValidateAPIKey(header)
+	}
+
+	caveat, err := parseRestricted(*apiKey, strings.TrimPrefix(header, restrictedPrefix))
+	if err != nil {
+		return false
+	}
+	if caveat.NotAfter != nil {
+		notAfter, err := ptypes.Timestamp(caveat.NotAfter)
+		if err != nil || time.Now().After(notAfter) {
+			return false
+		}
+	}
+	return allows(caveat, path, op)
+}
+
+// parseRestricted returns the caveat of a restricted API key if it was signed
+// with key
+func parseRestricted(key, encoded string) (*pb.Caveat, error) {
+	data := base58.Decode(encoded)
+	if len(data) == 0 {
+		return nil, Error.New("invalid restricted API key")
+	}
+	restricted := &pb.RestrictedAPIKey{}
+	if err := proto.Unmarshal(data, restricted); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if !hmac.Equal(sign(key, restricted.Caveat), restricted.Signature) {
+		return nil, Error.New("invalid restricted API key signature")
+	}
+	caveat := &pb.Caveat{}
+	if err := proto.Unmarshal(restricted.Caveat, caveat); err != nil {
+		return nil, Error.Wrap(err)
+	}
+	return caveat, nil
+}
+
+// allows checks whether caveat allows op on the pointers at path. Pointer paths
+// start with the segment, e.g. "l" or "s0", followed by the bucket and the
+// encrypted path. The bucket itself may be read, so that its metadata is
+// accessible, but not listed or written.
+func allows(caveat *pb.Caveat, path storj.Path, op Operation) bool {
+	if op == Write && caveat.ReadOnly {
+		return false
+	}
+
+	prefix := strings.Trim(caveat.PathPrefix, "/")
+	comps := storj.SplitPath(strings.Trim(path, "/"))
+	if prefix == "" || len(comps) < 2 {
+		return false
+	}
+
+	rest := storj.JoinPaths(comps[1:]...)
+	if rest == prefix || strings.HasPrefix(rest, prefix+"/") {
+		return true
+	}
+	return op == Read && rest == storj.SplitPath(prefix)[0]
+}
+
+// sign returns the HMAC-SHA256 of data keyed with key
+func sign(key string, data []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(key))
+	_, _ = mac.Write(data)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package auth
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/pb"
+)
+
+func TestRestrictedAPIKey(t *testing.T) {
+	defer func(key string) { *apiKey = key }(*apiKey)
+	*apiKey = "satellite key"
+
+	restricted, err := Restrict("satellite key", &pb.Caveat{PathPrefix: "bucket/enc1", ReadOnly: true})
+	require.NoError(t, err)
+
+	for i, tt := range []struct {
+		path    string
+		op      Operation
+		allowed bool
+	}{
+		{"l/bucket/enc1", Read, true},
+		{"l/bucket/enc1/enc2", Read, true},
+		{"s0/bucket/enc1/enc2", Read, true},
+		{"l/bucket/enc1/", List, true},
+		{"l/bucket", Read, true},
+		{"l/bucket", List, false},
+		{"l/bucket/enc1/enc2", Write, false},
+		{"l/bucket/enc10", Read, false},
+		{"l/bucket/other", Read, false},
+		{"l/other/enc1", Read, false},
+		{"l", List, false},
+		{"", List, false},
+	} {
+		errTag := fmt.Sprintf("%d. %+v", i, tt)
+		assert.Equal(t, tt.allowed, ValidateAccess(restricted, tt.path, tt.op), errTag)
+	}
+
+	// restricted keys aren't valid for satellites with another key
+	*apiKey = "other key"
+	assert.False(t, ValidateAccess(restricted, "l/bucket/enc1", Read))
+	*apiKey = "satellite key"
+
+	// tampered caveats aren't valid
+	assert.False(t, ValidateAccess(restricted+"1", "l/bucket/enc1", Read))
+
+	// restricted keys can't be restricted further
+	_, err = Restrict(restricted, &pb.Caveat{PathPrefix: "bucket/enc1/enc2"})
+	assert.Error(t, err)
+
+	// writable keys allow writes
+	writable, err := Restrict("satellite key", &pb.Caveat{PathPrefix: "bucket/enc1"})
+	require.NoError(t, err)
+	assert.True(t, ValidateAccess(writable, "l/bucket/enc1/enc2", Write))
+
+	// expired keys aren't valid
+	notAfter, err := ptypes.TimestampProto(time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	expired, err := Restrict("satellite key", &pb.Caveat{PathPrefix: "bucket/enc1", NotAfter: notAfter})
+	require.NoError(t, err)
+	assert.False(t, ValidateAccess(expired, "l/bucket/enc1/enc2", Read))
+
+	// unrestricted keys are validated as before
+	assert.True(t, ValidateAccess("satellite key", "l", List))
+	assert.False(t, ValidateAccess("other key", "l/bucket/enc1", Read))
+}
@@ -8,6 +8,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"storj.io/storj/pkg/durability"
 	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/provider"
@@ -34,6 +35,8 @@ type Config struct {
 	MinRemoteSegmentSize int    `default:"1240" help:"minimum remote segment size"`
 	MaxInlineSegmentSize int    `default:"8000" help:"maximum inline segment size"`
 	Overlay              bool   `default:"true" help:"toggle flag if overlay is enabled"`
+
+	durability.Config
 }
 
 func newKeyValueStore(dbURLString string) (db storage.KeyValueStore, err error) {
@@ -4,6 +4,7 @@
 package pointerdb
 
 import (
+	"bytes"
 	"context"
 	"crypto/ecdsa"
 	"crypto/x509"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/skyrings/skyring-common/tools/uuid"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
@@ -25,6 +27,7 @@ import (
 	pointerdbAuth "storj.io/storj/pkg/pointerdb/auth"
 	"storj.io/storj/pkg/provider"
 	"storj.io/storj/pkg/storage/meta"
+	"storj.io/storj/pkg/storj"
 	"storj.io/storj/storage"
 )
 
@@ -53,9 +56,9 @@ func NewServer(db storage.KeyValueStore, cache *overlay.Cache, logger *zap.Logge
 	}
 }
 
-func (s *Server) validateAuth(ctx context.Context) error {
+func (s *Server) validateAuth(ctx context.Context, path storj.Path, op pointerdbAuth.Operation) error {
 	APIKey, ok := auth.GetAPIKey(ctx)
-	if !ok || !pointerdbAuth.ValidateAPIKey(string(APIKey)) {
+	if !ok || !pointerdbAuth.ValidateAccess(string(APIKey), path, op) {
 		s.logger.Error("unauthorized request: ", zap.Error(status.Errorf(codes.Unauthenticated, "Invalid API credential")))
 		return status.Errorf(codes.Unauthenticated, "Invalid API credential")
 	}
@@ -78,6 +81,34 @@ func (s *Server) validateSegment(req *pb.PutRequest) error {
 		return segmentError.New("inline segment size %d greater than maximum allowed %d", inlineSize, max)
 	}
 
+	if remote != nil && s.config.MinDurability > 0 {
+		return s.validateDurability(remote.GetRedundancy())
+	}
+
+	return nil
+}
+
+// validateDurability rejects redundancy schemes less durable than the
+// configured minimum
+func (s *Server) validateDurability(redundancy *pb.RedundancyScheme) error {
+	scheme := storj.RedundancyScheme{
+		Algorithm:      storj.ReedSolomon,
+		ShareSize:      redundancy.GetErasureShareSize(),
+		RequiredShares: int16(redundancy.GetMinReq()),
+		RepairShares:   int16(redundancy.GetRepairThreshold()),
+		OptimalShares:  int16(redundancy.GetSuccessThreshold()),
+		TotalShares:    int16(redundancy.GetTotal()),
+	}
+
+	estimate, err := s.config.Durability.Estimate(scheme)
+	if err != nil {
+		return segmentError.Wrap(err)
+	}
+
+	if estimate.Nines() < s.config.MinDurability {
+		return segmentError.New("redundancy scheme durability of %.2f nines less than minimum allowed %.2f", estimate.Nines(), s.config.MinDurability)
+	}
+
 	return nil
 }
 
@@ -90,12 +121,16 @@ func (s *Server) Put(ctx context.Context, req *pb.PutRequest) (resp *pb.PutRespo
 		return nil, status.Errorf(codes.InvalidArgument, err.Error())
 	}
 
-	if err = s.validateAuth(ctx); err != nil {
+	if err = s.validateAuth(ctx, req.GetPath(), pointerdbAuth.Write); err != nil {
 		return nil, err
 	}
 
 	// Update the pointer with the creation date
-	req.GetPointer().CreationDate = ptypes.TimestampNow()
+	req.GetPointer().CreationDate, err = s.creationDate(req.GetPath(), req.GetPointer())
+	if err != nil {
+		s.logger.Error("err getting creation date", zap.Error(err))
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
 
 	pointerBytes, err := proto.Marshal(req.GetPointer())
 	if err != nil {
@@ -114,11 +149,46 @@ func (s *Server) Put(ctx context.Context, req *pb.PutRequest) (resp *pb.PutRespo
 	return &pb.PutResponse{}, nil
 }
 
+// creationDate returns the creation date of the pointer put at path. A pointer
+// that updates the segment stored at path, like a changed expiration, keeps
+// the creation date of the stored one. Otherwise the segment is new.
+func (s *Server) creationDate(path string, pointer *pb.Pointer) (*timestamp.Timestamp, error) {
+	storedBytes, err := s.DB.Get([]byte(path))
+	if err != nil {
+		if storage.ErrKeyNotFound.Has(err) {
+			return ptypes.TimestampNow(), nil
+		}
+		return nil, err
+	}
+
+	stored := &pb.Pointer{}
+	err = proto.Unmarshal(storedBytes, stored)
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.GetCreationDate() == nil || !sameSegment(stored, pointer) {
+		return ptypes.TimestampNow(), nil
+	}
+	return stored.GetCreationDate(), nil
+}
+
+// sameSegment is whether the pointers a and b point to the same segment
+func sameSegment(a, b *pb.Pointer) bool {
+	if a.GetType() != b.GetType() {
+		return false
+	}
+	if a.GetType() == pb.Pointer_INLINE {
+		return bytes.Equal(a.GetInlineSegment(), b.GetInlineSegment())
+	}
+	return a.GetRemote().GetPieceId() == b.GetRemote().GetPieceId()
+}
+
 // Get formats and hands off a file path to get from boltdb
 func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = s.validateAuth(ctx); err != nil {
+	if err = s.validateAuth(ctx, req.GetPath(), pointerdbAuth.Read); err != nil {
 		return nil, err
 	}
 
@@ -150,8 +220,6 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetRespo
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
 
-	nodes := []*pb.Node{}
-
 	var r = &pb.GetResponse{
 		Pointer:       pointer,
 		Nodes:         nil,
@@ -159,19 +227,28 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetRespo
 		Authorization: authorization,
 	}
 
-	if !s.config.Overlay || pointer.Remote == nil {
+	if !s.config.Overlay || pointer.Remote == nil || len(pointer.Remote.RemotePieces) == 0 {
 		return r, nil
 	}
 
+	// the nodes are sent along with the pointer, so that the uplink doesn't
+	// have to look them up. Nodes missing from the cache are sent as empty
+	// nodes, to keep them aligned with the pieces.
+	var nodeIDs storj.NodeIDList
 	for _, piece := range pointer.Remote.RemotePieces {
-		node, err := s.cache.Get(ctx, piece.NodeId)
-		if err != nil {
-			s.logger.Error("Error getting node from cache")
-		}
-		nodes = append(nodes, node)
+		nodeIDs = append(nodeIDs, piece.NodeId)
+	}
+	nodes, err := s.cache.GetAll(ctx, nodeIDs)
+	if err != nil {
+		s.logger.Error("Error getting nodes from cache", zap.Error(err))
+		return r, nil
 	}
 
-	for _, v := range nodes {
+	for i, v := range nodes {
+		if v == nil {
+			nodes[i] = &pb.Node{Id: nodeIDs[i], Type: pb.NodeType_STORAGE}
+			continue
+		}
 		v.Type.DPanicOnInvalid("pdb server Get")
 	}
 	r = &pb.GetResponse{
@@ -188,7 +265,7 @@ func (s *Server) Get(ctx context.Context, req *pb.GetRequest) (resp *pb.GetRespo
 func (s *Server) List(ctx context.Context, req *pb.ListRequest) (resp *pb.ListResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = s.validateAuth(ctx); err != nil {
+	if err = s.validateAuth(ctx, req.GetPrefix(), pointerdbAuth.List); err != nil {
 		return nil, err
 	}
 
@@ -274,7 +351,7 @@ func (s *Server) setMetadata(item *pb.ListResponse_Item, data []byte, metaFlags
 func (s *Server) Delete(ctx context.Context, req *pb.DeleteRequest) (resp *pb.DeleteResponse, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	if err = s.validateAuth(ctx); err != nil {
+	if err = s.validateAuth(ctx, req.GetPath(), pointerdbAuth.Write); err != nil {
 		return nil, err
 	}
 
@@ -10,11 +10,13 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
@@ -22,7 +24,10 @@ import (
 	"google.golang.org/grpc/status"
 
 	"storj.io/storj/internal/testidentity"
+	"storj.io/storj/internal/teststorj"
 	"storj.io/storj/pkg/auth"
+	"storj.io/storj/pkg/durability"
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/storage/meta"
 	"storj.io/storj/storage"
@@ -65,6 +70,84 @@ func TestServicePut(t *testing.T) {
 	}
 }
 
+func TestServicePutDurability(t *testing.T) {
+	config := Config{
+		MaxInlineSegmentSize: 8000,
+		Config: durability.Config{
+			MinDurability: 8,
+			Durability:    durability.Model{ChurnRate: 0.01, RepairInterval: 6 * time.Hour},
+		},
+	}
+
+	for i, tt := range []struct {
+		redundancy *pb.RedundancyScheme
+		valid      bool
+	}{
+		{&pb.RedundancyScheme{MinReq: 29, RepairThreshold: 35, SuccessThreshold: 80, Total: 95, ErasureShareSize: 1024}, true},
+		{&pb.RedundancyScheme{MinReq: 29, RepairThreshold: 31, SuccessThreshold: 80, Total: 95, ErasureShareSize: 1024}, false},
+		{&pb.RedundancyScheme{MinReq: 29, RepairThreshold: 35, SuccessThreshold: 30, Total: 95, ErasureShareSize: 1024}, false},
+	} {
+		ctx := auth.WithAPIKey(context.Background(), nil)
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		s := Server{DB: teststore.New(), logger: zap.NewNop(), config: config}
+
+		pr := pb.Pointer{
+			Type:        pb.Pointer_REMOTE,
+			Remote:      &pb.RemoteSegment{Redundancy: tt.redundancy},
+			SegmentSize: 10000,
+		}
+
+		_, err := s.Put(ctx, &pb.PutRequest{Path: "a/b/c", Pointer: &pr})
+		if tt.valid {
+			assert.NoError(t, err, errTag)
+		} else {
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), errTag)
+		}
+	}
+}
+
+func TestServicePutCreationDate(t *testing.T) {
+	ctx := auth.WithAPIKey(context.Background(), nil)
+	s := Server{DB: teststore.New(), logger: zap.NewNop(), config: Config{MaxInlineSegmentSize: 8000}}
+
+	get := func(path string) *pb.Pointer {
+		pointerBytes, err := s.DB.Get([]byte(path))
+		require.NoError(t, err)
+		pointer := &pb.Pointer{}
+		require.NoError(t, proto.Unmarshal(pointerBytes, pointer))
+		return pointer
+	}
+
+	// the creation date sent by the client is ignored
+	forged, err := ptypes.TimestampProto(time.Now().Add(-24 * time.Hour))
+	require.NoError(t, err)
+	pointer := &pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: []byte("one"), CreationDate: forged}
+	_, err = s.Put(ctx, &pb.PutRequest{Path: "a/b/c", Pointer: pointer})
+	require.NoError(t, err)
+	created := get("a/b/c").GetCreationDate()
+	assert.NotEqual(t, forged, created)
+
+	// updating the stored segment keeps its creation date
+	time.Sleep(time.Millisecond)
+	pointer = &pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: []byte("one"), ExpirationDate: ptypes.TimestampNow()}
+	_, err = s.Put(ctx, &pb.PutRequest{Path: "a/b/c", Pointer: pointer})
+	require.NoError(t, err)
+	assert.Equal(t, created, get("a/b/c").GetCreationDate())
+
+	// a new segment at the same path, or the same one at another path, is
+	// created again
+	pointer = &pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: []byte("two")}
+	_, err = s.Put(ctx, &pb.PutRequest{Path: "a/b/c", Pointer: pointer})
+	require.NoError(t, err)
+	assert.NotEqual(t, created, get("a/b/c").GetCreationDate())
+
+	pointer = &pb.Pointer{Type: pb.Pointer_INLINE, InlineSegment: []byte("one"), CreationDate: created}
+	_, err = s.Put(ctx, &pb.PutRequest{Path: "a/b/d", Pointer: pointer})
+	require.NoError(t, err)
+	assert.NotEqual(t, created, get("a/b/d").GetCreationDate())
+}
+
 func TestServiceGet(t *testing.T) {
 	ctx := context.Background()
 	ca, err := testidentity.NewTestCA(ctx)
@@ -123,6 +206,50 @@ func TestServiceGet(t *testing.T) {
 	}
 }
 
+func TestServiceGetNodes(t *testing.T) {
+	ctx := context.Background()
+	ca, err := testidentity.NewTestCA(ctx)
+	assert.NoError(t, err)
+	identity, err := ca.NewIdentity()
+	assert.NoError(t, err)
+
+	info := credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{identity.Leaf, identity.CA}}}
+	ctx = auth.WithAPIKey(ctx, nil)
+	ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: info})
+
+	known, unknown := teststorj.NodeIDFromString("known"), teststorj.NodeIDFromString("unknown")
+
+	nodes := teststore.New()
+	node := &pb.Node{Id: known, Type: pb.NodeType_STORAGE, Address: &pb.NodeAddress{Address: "127.0.0.1:7777"}}
+	nodeBytes, err := proto.Marshal(node)
+	assert.NoError(t, err)
+	assert.NoError(t, nodes.Put(known.Bytes(), nodeBytes))
+
+	db := teststore.New()
+	s := Server{DB: db, cache: overlay.NewCache(nodes, nil), config: Config{Overlay: true}, logger: zap.NewNop(), identity: identity}
+
+	pr := &pb.Pointer{
+		Type: pb.Pointer_REMOTE,
+		Remote: &pb.RemoteSegment{
+			RemotePieces: []*pb.RemotePiece{
+				{PieceNum: 0, NodeId: known},
+				{PieceNum: 1, NodeId: unknown},
+			},
+		},
+	}
+	prBytes, err := proto.Marshal(pr)
+	assert.NoError(t, err)
+	assert.NoError(t, db.Put(storage.Key("a/b/c"), prBytes))
+
+	resp, err := s.Get(ctx, &pb.GetRequest{Path: "a/b/c"})
+	if assert.NoError(t, err) && assert.Len(t, resp.GetNodes(), 2) {
+		assert.Equal(t, node.Address.Address, resp.Nodes[0].Address.GetAddress())
+		// unknown nodes keep their place, but have no address
+		assert.Equal(t, unknown, resp.Nodes[1].Id)
+		assert.Nil(t, resp.Nodes[1].Address)
+	}
+}
+
 func TestServiceDelete(t *testing.T) {
 	for i, tt := range []struct {
 		apiKey    []byte
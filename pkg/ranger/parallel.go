@@ -0,0 +1,157 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ranger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+
+	"github.com/zeebo/errs"
+)
+
+// ConcatParallel concatenates Rangers like Concat, but the readers of its
+// ranges read up to parallelism of the concatenated Rangers ahead at the same
+// time. Every Ranger read ahead is buffered in memory.
+func ConcatParallel(parallelism int, r ...Ranger) Ranger {
+	if parallelism <= 1 || len(r) <= 1 {
+		return Concat(r...)
+	}
+	return &parallelConcat{parallelism: parallelism, rangers: r}
+}
+
+type parallelConcat struct {
+	parallelism int
+	rangers     []Ranger
+}
+
+// part is the range of a single Ranger read by a parallelReader
+type part struct {
+	ranger         Ranger
+	offset, length int64
+}
+
+// fetched is the data of a part read ahead
+type fetched struct {
+	data []byte
+	err  error
+}
+
+func (c *parallelConcat) Size() int64 {
+	var size int64
+	for _, rr := range c.rangers {
+		size += rr.Size()
+	}
+	return size
+}
+
+func (c *parallelConcat) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	if offset < 0 {
+		return nil, Error.New("negative offset")
+	}
+	if length < 0 {
+		return nil, Error.New("negative length")
+	}
+	if offset+length > c.Size() {
+		return nil, Error.New("range beyond end")
+	}
+
+	var parts []part
+	for _, rr := range c.rangers {
+		size := rr.Size()
+		if offset >= size {
+			offset -= size
+			continue
+		}
+		if length <= 0 {
+			break
+		}
+		n := size - offset
+		if n > length {
+			n = length
+		}
+		parts = append(parts, part{ranger: rr, offset: offset, length: n})
+		offset, length = 0, length-n
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	reader := &parallelReader{
+		ctx:         ctx,
+		cancel:      cancel,
+		parallelism: c.parallelism,
+		parts:       parts,
+		results:     make([]chan fetched, len(parts)),
+	}
+	for i := range reader.results {
+		reader.results[i] = make(chan fetched, 1)
+	}
+	for i := 0; i < c.parallelism && i < len(parts); i++ {
+		reader.fetch(i)
+	}
+	return reader, nil
+}
+
+// parallelReader reads parts in order while the next ones are read ahead
+type parallelReader struct {
+	ctx         context.Context
+	cancel      func()
+	parallelism int
+	parts       []part
+	results     []chan fetched
+	wg          sync.WaitGroup
+
+	next    int
+	current *bytes.Reader
+	err     error
+}
+
+// fetch reads the part at index ahead
+func (r *parallelReader) fetch(index int) {
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		data, err := readPart(r.ctx, r.parts[index])
+		r.results[index] <- fetched{data: data, err: err}
+	}()
+}
+
+func readPart(ctx context.Context, part part) (_ []byte, err error) {
+	rc, err := part.ranger.Range(ctx, part.offset, part.length)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = errs.Combine(err, rc.Close()) }()
+	return ioutil.ReadAll(rc)
+}
+
+func (r *parallelReader) Read(p []byte) (n int, err error) {
+	for r.err == nil {
+		if r.current != nil && r.current.Len() > 0 {
+			return r.current.Read(p)
+		}
+		if r.next >= len(r.parts) {
+			return 0, io.EOF
+		}
+
+		result := <-r.results[r.next]
+		if result.err != nil {
+			r.err = result.err
+			break
+		}
+		if ahead := r.next + r.parallelism; ahead < len(r.parts) {
+			r.fetch(ahead)
+		}
+		r.next++
+		r.current = bytes.NewReader(result.data)
+	}
+	return 0, r.err
+}
+
+func (r *parallelReader) Close() error {
+	r.cancel()
+	r.wg.Wait()
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package ranger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+func TestConcatParallel(t *testing.T) {
+	for _, parallelism := range []int{1, 2, 3, 8} {
+		for _, example := range []struct {
+			data                 []string
+			size, offset, length int64
+			substr               string
+		}{
+			{[]string{}, 0, 0, 0, ""},
+			{[]string{"abcdef", "ghijkl"}, 12, 0, 0, ""},
+			{[]string{"abcdef", "ghijkl"}, 12, 1, 4, "bcde"},
+			{[]string{"abcdef", "ghijkl"}, 12, 1, 6, "bcdefg"},
+			{[]string{"abcdef", "ghijkl"}, 12, 6, 4, "ghij"},
+			{[]string{"abcdef", "ghijkl"}, 12, 0, 12, "abcdefghijkl"},
+			{[]string{"abcdef", "ghijkl", "mnopqr"}, 18, 7, 7, "hijklmn"},
+			{[]string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l"}, 12, 2, 9, "cdefghijk"},
+		} {
+			var readers []Ranger
+			for _, data := range example.data {
+				readers = append(readers, ByteRanger([]byte(data)))
+			}
+			rr := ConcatParallel(parallelism, readers...)
+			if rr.Size() != example.size {
+				t.Fatalf("invalid size: %v != %v", rr.Size(), example.size)
+			}
+			r, err := rr.Range(context.Background(), example.offset, example.length)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			data, err := ioutil.ReadAll(r)
+			if err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if err := r.Close(); err != nil {
+				t.Fatalf("unexpected err: %v", err)
+			}
+			if !bytes.Equal(data, []byte(example.substr)) {
+				t.Fatalf("invalid subrange with parallelism %d: %#v != %#v", parallelism, string(data), example.substr)
+			}
+		}
+	}
+}
+
+type failingRanger struct{ size int64 }
+
+func (f failingRanger) Size() int64 { return f.size }
+
+func (f failingRanger) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	return nil, errors.New("failing ranger")
+}
+
+func TestConcatParallelError(t *testing.T) {
+	rr := ConcatParallel(2, ByteRanger("abc"), failingRanger{3}, ByteRanger("ghi"))
+
+	if _, err := rr.Range(context.Background(), 0, 10); err == nil {
+		t.Fatal("expected error for a range beyond the end")
+	}
+
+	r, err := rr.Range(context.Background(), 0, 9)
+	if err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+	data, err := ioutil.ReadAll(r)
+	if err == nil {
+		t.Fatal("expected error of the failing ranger")
+	}
+	if string(data) != "abc" {
+		t.Fatalf("invalid data before the error: %#v", string(data))
+	}
+	if err := r.Close(); err != nil {
+		t.Fatalf("unexpected err: %v", err)
+	}
+}
@@ -9,3 +9,6 @@ import (
 
 // Error is the default boltdb errs class
 var Error = errs.Class("statdb error")
+
+// ErrNodeNotFound is returned when a node has no stats entry
+var ErrNodeNotFound = errs.Class("node not found")
@@ -0,0 +1,48 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package statdb
+
+import (
+	"sort"
+	"time"
+)
+
+// LatencySamples is the number of recent latencies kept for each node
+const LatencySamples = 20
+
+// AddLatency appends latency in milliseconds to the recent latencies,
+// dropping the oldest ones beyond LatencySamples
+func AddLatency(latencies []int64, latency time.Duration) []int64 {
+	ms := int64(latency / time.Millisecond)
+	if ms < 1 {
+		// anything faster than a millisecond still counts as measured
+		ms = 1
+	}
+	latencies = append(latencies, ms)
+	if len(latencies) > LatencySamples {
+		latencies = latencies[len(latencies)-LatencySamples:]
+	}
+	return latencies
+}
+
+// Latency90 returns the 90th percentile of latencies, 0 if there are none
+func Latency90(latencies []int64) int64 {
+	if len(latencies) == 0 {
+		return 0
+	}
+	sorted := append([]int64(nil), latencies...)
+	sort.Slice(sorted, func(i, k int) bool { return sorted[i] < sorted[k] })
+	// nearest rank
+	rank := (len(sorted)*9 + 9) / 10
+	return sorted[rank-1]
+}
+
+// AverageSpeed folds a new speed measurement into the average speed,
+// weighting recent measurements more
+func AverageSpeed(average, speedKbps int64) int64 {
+	if average <= 0 {
+		return speedKbps
+	}
+	return (3*average + speedKbps) / 4
+}
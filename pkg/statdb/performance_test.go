@@ -0,0 +1,40 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package statdb_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/pkg/statdb"
+)
+
+func TestLatency90(t *testing.T) {
+	assert.Equal(t, int64(0), statdb.Latency90(nil))
+	assert.Equal(t, int64(7), statdb.Latency90([]int64{7}))
+
+	var latencies []int64
+	for i := 1; i <= 10; i++ {
+		latencies = statdb.AddLatency(latencies, time.Duration(11-i)*10*time.Millisecond)
+	}
+	assert.Equal(t, int64(90), statdb.Latency90(latencies))
+
+	// a single slow outlier doesn't move the 90th percentile of 20 samples
+	for i := 0; i < statdb.LatencySamples; i++ {
+		latencies = statdb.AddLatency(latencies, 5*time.Millisecond)
+	}
+	latencies = statdb.AddLatency(latencies, time.Second)
+	assert.Len(t, latencies, statdb.LatencySamples)
+	assert.Equal(t, int64(5), statdb.Latency90(latencies))
+
+	// sub-millisecond latencies are still measured
+	assert.Equal(t, []int64{1}, statdb.AddLatency(nil, time.Microsecond))
+}
+
+func TestAverageSpeed(t *testing.T) {
+	assert.Equal(t, int64(1000), statdb.AverageSpeed(0, 1000))
+	assert.Equal(t, int64(1250), statdb.AverageSpeed(1000, 2000))
+}
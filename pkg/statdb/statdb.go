@@ -5,6 +5,7 @@ package statdb
 
 import (
 	"context"
+	"time"
 
 	"storj.io/storj/pkg/storj"
 )
@@ -15,7 +16,7 @@ type DB interface {
 	Create(ctx context.Context, nodeID storj.NodeID, initial *NodeStats) (stats *NodeStats, err error)
 	// Get returns node stats.
 	Get(ctx context.Context, nodeID storj.NodeID) (stats *NodeStats, err error)
-	// FindInvalidNodes finds a subset of storagenodes that have stats below provided reputation requirements.
+	// FindInvalidNodes finds a subset of storagenodes that are disqualified or have stats below provided reputation requirements.
 	FindInvalidNodes(ctx context.Context, nodeIDs storj.NodeIDList, maxStats *NodeStats) (invalid storj.NodeIDList, err error)
 	// Update all parts of single storagenode's stats.
 	Update(ctx context.Context, request *UpdateRequest) (stats *NodeStats, err error)
@@ -27,6 +28,15 @@ type DB interface {
 	UpdateBatch(ctx context.Context, requests []*UpdateRequest) (statslist []*NodeStats, failed []*UpdateRequest, err error)
 	// CreateEntryIfNotExists creates a node stats entry if it didn't already exist.
 	CreateEntryIfNotExists(ctx context.Context, nodeID storj.NodeID) (stats *NodeStats, err error)
+	// UpdateState moves a single storagenode to a new state.
+	UpdateState(ctx context.Context, nodeID storj.NodeID, state NodeState) (stats *NodeStats, err error)
+	// OverrideState moves a single storagenode to a state chosen by an operator, which is kept until released.
+	OverrideState(ctx context.Context, nodeID storj.NodeID, state NodeState) (stats *NodeStats, err error)
+	// ReleaseState lets a single storagenode's stats move it between states again.
+	ReleaseState(ctx context.Context, nodeID storj.NodeID) (stats *NodeStats, err error)
+	// UpdatePerformance records a latency and transfer speed observed for a single storagenode.
+	// Zero values weren't measured and are ignored.
+	UpdatePerformance(ctx context.Context, nodeID storj.NodeID, latency time.Duration, speedKbps int64) (stats *NodeStats, err error)
 }
 
 // UpdateRequest is used to update a node status.
@@ -45,4 +55,12 @@ type NodeStats struct {
 	UptimeRatio        float64
 	UptimeSuccessCount int64
 	UptimeCount        int64
+	State              NodeState
+	StateUpdatedAt     time.Time
+	// StateOverride is set when an operator chose State, it isn't re-evaluated from the stats then
+	StateOverride bool
+	// LatencyList holds the most recent latencies in milliseconds
+	LatencyList []int64
+	Latency90   int64
+	SpeedKbps   int64
 }
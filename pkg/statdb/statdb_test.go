@@ -6,6 +6,7 @@ package statdb_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -107,6 +108,7 @@ func testDatabase(ctx context.Context, t *testing.T, sdb statdb.DB) {
 			{storj.NodeID{5}, 20, 20, 1, 0, 0, 0.25},  // "bad" uptime success, no checks
 			{storj.NodeID{6}, 0, 1, 0, 5, 5, 1},       // bad audit success exactly one audit
 			{storj.NodeID{7}, 0, 20, 0, 20, 20, 1},    // bad ratios, excluded from query
+			{storj.NodeID{8}, 20, 20, 1, 20, 20, 1},   // good stats, disqualified below
 		} {
 			nodeStats := &statdb.NodeStats{
 				AuditSuccessRatio:  tt.auditSuccessRatio,
@@ -121,10 +123,14 @@ func testDatabase(ctx context.Context, t *testing.T, sdb statdb.DB) {
 			assert.NoError(t, err)
 		}
 
+		_, err := sdb.UpdateState(ctx, storj.NodeID{8}, statdb.StateDisqualified)
+		assert.NoError(t, err)
+
 		nodeIds := storj.NodeIDList{
 			storj.NodeID{1}, storj.NodeID{2},
 			storj.NodeID{3}, storj.NodeID{4},
 			storj.NodeID{5}, storj.NodeID{6},
+			storj.NodeID{8},
 		}
 		maxStats := &statdb.NodeStats{
 			AuditSuccessRatio: 0.5,
@@ -137,7 +143,55 @@ func testDatabase(ctx context.Context, t *testing.T, sdb statdb.DB) {
 		assert.Contains(t, invalid, storj.NodeID{2})
 		assert.Contains(t, invalid, storj.NodeID{3})
 		assert.Contains(t, invalid, storj.NodeID{6})
-		assert.Len(t, invalid, 3)
+		assert.Contains(t, invalid, storj.NodeID{8})
+		assert.Len(t, invalid, 4)
+	}
+
+	{ // TestUpdateState
+		stats, err := sdb.Get(ctx, nodeID)
+		assert.NoError(t, err)
+		assert.Equal(t, statdb.StateVetting, stats.State)
+		assert.False(t, stats.StateUpdatedAt.IsZero())
+
+		stats, err = sdb.UpdateState(ctx, nodeID, statdb.StateActive)
+		assert.NoError(t, err)
+		assert.Equal(t, statdb.StateActive, stats.State)
+
+		stats, err = sdb.Get(ctx, nodeID)
+		assert.NoError(t, err)
+		assert.Equal(t, statdb.StateActive, stats.State)
+
+		_, err = sdb.UpdateState(ctx, storj.NodeID{255, 255, 255, 255}, statdb.StateActive)
+		assert.Error(t, err)
+	}
+
+	{ // TestOverrideState
+		stats, err := sdb.OverrideState(ctx, nodeID, statdb.StateSuspended)
+		assert.NoError(t, err)
+		assert.Equal(t, statdb.StateSuspended, stats.State)
+		assert.True(t, stats.StateOverride)
+
+		stats, err = sdb.Get(ctx, nodeID)
+		assert.NoError(t, err)
+		assert.Equal(t, statdb.StateSuspended, stats.State)
+		assert.True(t, stats.StateOverride)
+
+		// the stats don't move an overridden node until it's released
+		stats, err = statdb.Config{}.Apply(ctx, sdb, stats)
+		assert.NoError(t, err)
+		assert.Equal(t, statdb.StateSuspended, stats.State)
+
+		stats, err = sdb.ReleaseState(ctx, nodeID)
+		assert.NoError(t, err)
+		assert.Equal(t, statdb.StateSuspended, stats.State)
+		assert.False(t, stats.StateOverride)
+
+		stats, err = statdb.Config{}.Apply(ctx, sdb, stats)
+		assert.NoError(t, err)
+		assert.Equal(t, statdb.StateActive, stats.State)
+
+		_, err = sdb.OverrideState(ctx, storj.NodeID{255, 255, 255, 255}, statdb.StateActive)
+		assert.Error(t, err)
 	}
 
 	{ // TestUpdateExists
@@ -223,6 +277,41 @@ func testDatabase(ctx context.Context, t *testing.T, sdb statdb.DB) {
 		assert.EqualValues(t, uptimeRatio, stats.UptimeRatio)
 	}
 
+	{ // TestUpdatePerformanceExists
+		stats, err := sdb.Get(ctx, nodeID)
+		assert.NoError(t, err)
+		assert.Empty(t, stats.LatencyList)
+		assert.EqualValues(t, 0, stats.Latency90)
+		assert.EqualValues(t, 0, stats.SpeedKbps)
+
+		stats, err = sdb.UpdatePerformance(ctx, nodeID, 30*time.Millisecond, 1000)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{30}, stats.LatencyList)
+		assert.EqualValues(t, 30, stats.Latency90)
+		assert.EqualValues(t, 1000, stats.SpeedKbps)
+
+		// zero values don't change the measurements
+		stats, err = sdb.UpdatePerformance(ctx, nodeID, 10*time.Millisecond, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{30, 10}, stats.LatencyList)
+		assert.EqualValues(t, 1000, stats.SpeedKbps)
+
+		stats, err = sdb.UpdatePerformance(ctx, nodeID, 0, 2000)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{30, 10}, stats.LatencyList)
+		assert.EqualValues(t, 30, stats.Latency90)
+		assert.EqualValues(t, 1250, stats.SpeedKbps)
+
+		stats, err = sdb.Get(ctx, nodeID)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{30, 10}, stats.LatencyList)
+		assert.EqualValues(t, 1250, stats.SpeedKbps)
+
+		// the other stats are untouched
+		assert.EqualValues(t, currUptimeCount, stats.UptimeCount)
+		assert.EqualValues(t, currAuditCount, stats.AuditCount)
+	}
+
 	{ // TestUpdateBatchExists
 		nodeID1 := storj.NodeID{255, 1}
 		nodeID2 := storj.NodeID{255, 2}
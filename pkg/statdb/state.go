@@ -0,0 +1,127 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package statdb
+
+import (
+	"context"
+	"strings"
+
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/storj"
+)
+
+// NodeState is the lifecycle state of a storage node
+type NodeState int
+
+const (
+	// StateVetting nodes are new and only receive a limited share of uploads
+	StateVetting NodeState = iota
+	// StateActive nodes have been vetted and are eligible for all uploads
+	StateActive
+	// StateSuspended nodes have poor uptime and don't receive new data until they recover
+	StateSuspended
+	// StateDisqualified nodes failed too many audits, their pieces are considered lost
+	StateDisqualified
+)
+
+var nodeStateNames = map[NodeState]string{
+	StateVetting:      "vetting",
+	StateActive:       "active",
+	StateSuspended:    "suspended",
+	StateDisqualified: "disqualified",
+}
+
+// String returns the name of the state
+func (state NodeState) String() string {
+	if name, ok := nodeStateNames[state]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseNodeState returns the state with the given name
+func ParseNodeState(name string) (NodeState, error) {
+	for state, stateName := range nodeStateNames {
+		if strings.EqualFold(name, stateName) {
+			return state, nil
+		}
+	}
+	return 0, Error.New("unknown node state %q", name)
+}
+
+// CtxKey used for assigning the statdb config
+type CtxKey int
+
+const (
+	ctxKeyConfig CtxKey = iota
+)
+
+// Config contains the thresholds that move nodes between states
+type Config struct {
+	VettingAuditCount  int64 `help:"the number of successful audits a node needs to finish vetting" default:"0"`
+	VettingUptimeCount int64 `help:"the number of successful uptime checks a node needs to finish vetting" default:"0"`
+
+	SuspensionUptimeRatio    float64 `help:"nodes whose uptime ratio drops below this are suspended" default:"0"`
+	SuspensionMinUptimeCount int64   `help:"the number of uptime checks before a node can be suspended" default:"100"`
+
+	DisqualificationAuditRatio    float64 `help:"nodes whose audit success ratio drops below this are disqualified" default:"0"`
+	DisqualificationMinAuditCount int64   `help:"the number of audits before a node can be disqualified" default:"100"`
+}
+
+// Run makes the node state thresholds available to the services started after it
+func (c Config) Run(ctx context.Context, server *provider.Provider) (err error) {
+	return server.Run(context.WithValue(ctx, ctxKeyConfig, c))
+}
+
+// LoadConfigFromContext gives access to the node state thresholds from the context,
+// or returns thresholds which never suspend or disqualify nodes
+func LoadConfigFromContext(ctx context.Context) Config {
+	if v, ok := ctx.Value(ctxKeyConfig).(Config); ok {
+		return v
+	}
+	return Config{}
+}
+
+// Vetted returns whether stats are good enough to finish vetting
+func (c Config) Vetted(stats *NodeStats) bool {
+	return stats.AuditSuccessCount >= c.VettingAuditCount &&
+		stats.UptimeSuccessCount >= c.VettingUptimeCount
+}
+
+// Evaluate returns the state a node should be in based on its stats.
+// Disqualification is permanent unless an operator overrides it, and
+// states chosen by an operator are kept until they are released.
+func (c Config) Evaluate(stats *NodeStats) NodeState {
+	switch {
+	case stats.StateOverride:
+		return stats.State
+	case stats.State == StateDisqualified:
+		return StateDisqualified
+	case stats.AuditCount >= c.DisqualificationMinAuditCount &&
+		stats.AuditSuccessRatio < c.DisqualificationAuditRatio:
+		return StateDisqualified
+	case stats.UptimeCount >= c.SuspensionMinUptimeCount &&
+		stats.UptimeRatio < c.SuspensionUptimeRatio:
+		return StateSuspended
+	case !c.Vetted(stats):
+		return StateVetting
+	default:
+		return StateActive
+	}
+}
+
+// StateUpdater moves nodes between states, e.g. DB or the overlay cache which
+// also refreshes the state node selection sees
+type StateUpdater interface {
+	UpdateState(ctx context.Context, nodeID storj.NodeID, state NodeState) (*NodeStats, error)
+}
+
+// Apply moves a node to the state its stats call for, if it isn't already in it
+func (c Config) Apply(ctx context.Context, db StateUpdater, stats *NodeStats) (*NodeStats, error) {
+	state := c.Evaluate(stats)
+	if state == stats.State {
+		return stats, nil
+	}
+	return db.UpdateState(ctx, stats.NodeID, state)
+}
@@ -0,0 +1,77 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package statdb_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/pkg/statdb"
+)
+
+func TestEvaluate(t *testing.T) {
+	config := statdb.Config{
+		VettingAuditCount:             10,
+		VettingUptimeCount:            10,
+		SuspensionUptimeRatio:         0.6,
+		SuspensionMinUptimeCount:      20,
+		DisqualificationAuditRatio:    0.6,
+		DisqualificationMinAuditCount: 20,
+	}
+
+	for _, tt := range []struct {
+		name     string
+		stats    statdb.NodeStats
+		expected statdb.NodeState
+	}{
+		{"new node", statdb.NodeStats{}, statdb.StateVetting},
+		{"not enough audits",
+			statdb.NodeStats{AuditSuccessCount: 9, UptimeSuccessCount: 10},
+			statdb.StateVetting},
+		{"vetted",
+			statdb.NodeStats{AuditSuccessCount: 10, UptimeSuccessCount: 10},
+			statdb.StateActive},
+		{"bad uptime with few checks",
+			statdb.NodeStats{AuditSuccessCount: 10, UptimeSuccessCount: 10, UptimeCount: 19, UptimeRatio: 0.5},
+			statdb.StateActive},
+		{"bad uptime",
+			statdb.NodeStats{AuditSuccessCount: 10, UptimeSuccessCount: 10, UptimeCount: 20, UptimeRatio: 0.5},
+			statdb.StateSuspended},
+		{"recovered uptime",
+			statdb.NodeStats{AuditSuccessCount: 10, UptimeSuccessCount: 20, UptimeCount: 30, UptimeRatio: 0.7, State: statdb.StateSuspended},
+			statdb.StateActive},
+		{"bad audits",
+			statdb.NodeStats{AuditSuccessCount: 10, AuditCount: 20, AuditSuccessRatio: 0.5, UptimeSuccessCount: 10},
+			statdb.StateDisqualified},
+		{"disqualification is permanent",
+			statdb.NodeStats{AuditSuccessCount: 10, UptimeSuccessCount: 10, State: statdb.StateDisqualified},
+			statdb.StateDisqualified},
+		{"overridden state is kept",
+			statdb.NodeStats{AuditSuccessCount: 10, UptimeSuccessCount: 10, UptimeCount: 20, UptimeRatio: 0.5, State: statdb.StateActive, StateOverride: true},
+			statdb.StateActive},
+		{"overridden disqualification is kept",
+			statdb.NodeStats{AuditSuccessCount: 10, UptimeSuccessCount: 10, State: statdb.StateDisqualified, StateOverride: true},
+			statdb.StateDisqualified},
+	} {
+		stats := tt.stats
+		assert.Equal(t, tt.expected, config.Evaluate(&stats), tt.name)
+	}
+
+	// the zero config vets every node immediately and never suspends or disqualifies
+	assert.Equal(t, statdb.StateActive, statdb.Config{}.Evaluate(&statdb.NodeStats{}))
+}
+
+func TestParseNodeState(t *testing.T) {
+	for _, state := range []statdb.NodeState{
+		statdb.StateVetting, statdb.StateActive, statdb.StateSuspended, statdb.StateDisqualified,
+	} {
+		parsed, err := statdb.ParseNodeState(state.String())
+		assert.NoError(t, err)
+		assert.Equal(t, state, parsed)
+	}
+
+	_, err := statdb.ParseNodeState("unknown")
+	assert.Error(t, err)
+}
@@ -6,6 +6,7 @@ package buckets
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"strconv"
 	"time"
 
@@ -25,7 +26,7 @@ var mon = monkit.Package()
 // Store creates an interface for interacting with buckets
 type Store interface {
 	Get(ctx context.Context, bucket string) (meta Meta, err error)
-	Put(ctx context.Context, bucket string, pathCipher storj.Cipher) (meta Meta, err error)
+	Put(ctx context.Context, bucket string, info Meta) (meta Meta, err error)
 	Delete(ctx context.Context, bucket string) (err error)
 	List(ctx context.Context, startAfter, endBefore string, limit int) (items []ListItem, more bool, err error)
 	GetObjectStore(ctx context.Context, bucketName string) (store objects.Store, err error)
@@ -47,6 +48,11 @@ type BucketStore struct {
 type Meta struct {
 	Created            time.Time
 	PathEncryptionType storj.Cipher
+	Versioning         bool
+	Lifecycle          []storj.LifecycleRule
+	SegmentsSize       int64
+	RedundancyScheme   storj.RedundancyScheme
+	EncryptionScheme   storj.EncryptionScheme
 }
 
 // NewStore instantiates BucketStore
@@ -95,14 +101,16 @@ func (b *BucketStore) Get(ctx context.Context, bucket string) (meta Meta, err er
 	return convertMeta(objMeta)
 }
 
-// Put calls objects store Put
-func (b *BucketStore) Put(ctx context.Context, bucket string, pathCipher storj.Cipher) (meta Meta, err error) {
+// Put calls objects store Put, storing the settings of info. The creation
+// date of info is ignored.
+func (b *BucketStore) Put(ctx context.Context, bucket string, info Meta) (meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	if bucket == "" {
 		return Meta{}, storj.ErrNoBucket.New("")
 	}
 
+	pathCipher := info.PathEncryptionType
 	if pathCipher < storj.Unencrypted || pathCipher > storj.SecretBox {
 		return Meta{}, encryption.ErrInvalidConfig.New("encryption type %d is not supported", pathCipher)
 	}
@@ -110,6 +118,31 @@ func (b *BucketStore) Put(ctx context.Context, bucket string, pathCipher storj.C
 	r := bytes.NewReader(nil)
 	userMeta := map[string]string{
 		"path-enc-type": strconv.Itoa(int(pathCipher)),
+		"versioning":    strconv.FormatBool(info.Versioning),
+	}
+	if len(info.Lifecycle) > 0 {
+		lifecycle, err := json.Marshal(info.Lifecycle)
+		if err != nil {
+			return Meta{}, err
+		}
+		userMeta["lifecycle"] = string(lifecycle)
+	}
+	if info.SegmentsSize > 0 {
+		userMeta["segment-size"] = strconv.FormatInt(info.SegmentsSize, 10)
+	}
+	if !info.RedundancyScheme.IsZero() {
+		redundancy, err := json.Marshal(info.RedundancyScheme)
+		if err != nil {
+			return Meta{}, err
+		}
+		userMeta["redundancy"] = string(redundancy)
+	}
+	if !info.EncryptionScheme.IsZero() {
+		encryptionScheme, err := json.Marshal(info.EncryptionScheme)
+		if err != nil {
+			return Meta{}, err
+		}
+		userMeta["encryption"] = string(encryptionScheme)
 	}
 	var exp time.Time
 	m, err := b.store.Put(ctx, bucket, r, pb.SerializableMeta{UserDefined: userMeta}, exp)
@@ -179,8 +212,47 @@ func convertMeta(m objects.Meta) (Meta, error) {
 		cipher = storj.Cipher(pet)
 	}
 
+	// buckets created before versioning don't have the setting
+	versioning := m.UserDefined["versioning"] == "true"
+
+	var lifecycle []storj.LifecycleRule
+	if rules := m.UserDefined["lifecycle"]; rules != "" {
+		if err := json.Unmarshal([]byte(rules), &lifecycle); err != nil {
+			return Meta{}, err
+		}
+	}
+
+	// the default settings for objects are unset for most buckets
+	var segmentsSize int64
+	if size := m.UserDefined["segment-size"]; size != "" {
+		var err error
+		segmentsSize, err = strconv.ParseInt(size, 10, 64)
+		if err != nil {
+			return Meta{}, err
+		}
+	}
+
+	var redundancy storj.RedundancyScheme
+	if scheme := m.UserDefined["redundancy"]; scheme != "" {
+		if err := json.Unmarshal([]byte(scheme), &redundancy); err != nil {
+			return Meta{}, err
+		}
+	}
+
+	var encryptionScheme storj.EncryptionScheme
+	if scheme := m.UserDefined["encryption"]; scheme != "" {
+		if err := json.Unmarshal([]byte(scheme), &encryptionScheme); err != nil {
+			return Meta{}, err
+		}
+	}
+
 	return Meta{
 		Created:            m.Modified,
 		PathEncryptionType: cipher,
+		Versioning:         versioning,
+		Lifecycle:          lifecycle,
+		SegmentsSize:       segmentsSize,
+		RedundancyScheme:   redundancy,
+		EncryptionScheme:   encryptionScheme,
 	}, nil
 }
@@ -14,6 +14,7 @@ import (
 	"gopkg.in/spacemonkeygo/monkit.v2"
 
 	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/piecestore/psclient"
 	"storj.io/storj/pkg/provider"
@@ -37,19 +38,35 @@ type Client interface {
 type psClientFunc func(context.Context, transport.Client, *pb.Node, int) (psclient.Client, error)
 type psClientHelper func(context.Context, *pb.Node) (psclient.Client, error)
 
+// Reporter receives the speeds and latencies observed while transferring pieces
+type Reporter interface {
+	ReportPerformance(ctx context.Context, measurements []overlay.Measurement) error
+}
+
+// reportTimeout bounds how long reporting the measurements of one upload may take
+const reportTimeout = 30 * time.Second
+
 type ecClient struct {
 	transport       transport.Client
 	memoryLimit     int
 	newPSClientFunc psClientFunc
+	reporter        Reporter
 }
 
 // NewClient from the given identity and max buffer memory
 func NewClient(identity *provider.FullIdentity, memoryLimit int) Client {
+	return NewClientWithReporter(identity, memoryLimit, nil)
+}
+
+// NewClientWithReporter is like NewClient, but also reports the upload speed
+// and download latency of every node to reporter, if it's not nil
+func NewClientWithReporter(identity *provider.FullIdentity, memoryLimit int, reporter Reporter) Client {
 	tc := transport.NewClient(identity)
 	return &ecClient{
 		transport:       tc,
 		memoryLimit:     memoryLimit,
 		newPSClientFunc: psclient.NewPSClient,
+		reporter:        reporter,
 	}
 }
 
@@ -80,8 +97,9 @@ func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.Redun
 	}
 
 	type info struct {
-		i   int
-		err error
+		i     int
+		err   error
+		speed int64
 	}
 	infos := make(chan info, len(nodes))
 
@@ -111,7 +129,11 @@ func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.Redun
 				infos <- info{i: i, err: err}
 				return
 			}
-			err = ps.Put(ctx, derivedPieceID, readers[i], expiration, pba, authorization)
+			piece := &timingReader{r: readers[i]}
+			start := time.Now()
+			err = ps.Put(ctx, derivedPieceID, piece, expiration, pba, authorization)
+			// the time spent waiting for the encoder isn't spent on the node
+			sending := time.Since(start) - piece.waiting
 			// normally the bellow call should be deferred, but doing so fails
 			// randomly the unit tests
 			utils.LogClose(ps)
@@ -125,19 +147,24 @@ func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.Redun
 				zap.S().Errorf("Failed putting piece %s -> %s to node %s (%+v): %v",
 					pieceID, derivedPieceID, n.Id, nodeAddress, err)
 			}
-			infos <- info{i: i, err: err}
+			infos <- info{i: i, err: err, speed: speedKbps(piece.n, sending)}
 		}(i, n)
 	}
 
 	successfulNodes = make([]*pb.Node, len(nodes))
 	var successfulCount int
+	var measurements []overlay.Measurement
 	for range nodes {
 		info := <-infos
 		if info.err == nil {
 			successfulNodes[info.i] = nodes[info.i]
 			successfulCount++
+			if info.speed > 0 {
+				measurements = append(measurements, overlay.Measurement{NodeID: nodes[info.i].Id, SpeedKbps: info.speed})
+			}
 		}
 	}
+	ec.report(measurements)
 
 	/* clean up the partially uploaded segment's pieces */
 	defer func() {
@@ -158,6 +185,60 @@ func (ec *ecClient) Put(ctx context.Context, nodes []*pb.Node, rs eestream.Redun
 	return successfulNodes, nil
 }
 
+// report sends measurements to the reporter in the background, so that
+// transfers don't wait for it
+func (ec *ecClient) report(measurements []overlay.Measurement) {
+	if ec.reporter == nil || len(measurements) == 0 {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), reportTimeout)
+		defer cancel()
+		if err := ec.reporter.ReportPerformance(ctx, measurements); err != nil {
+			zap.S().Debugf("Failed reporting the performance of %d nodes: %v", len(measurements), err)
+		}
+	}()
+}
+
+// timingReader counts the bytes read through it and the time spent waiting for them
+type timingReader struct {
+	r       io.Reader
+	n       int64
+	waiting time.Duration
+}
+
+func (tr *timingReader) Read(p []byte) (n int, err error) {
+	start := time.Now()
+	n, err = tr.r.Read(p)
+	tr.waiting += time.Since(start)
+	tr.n += int64(n)
+	return n, err
+}
+
+// firstByteReader calls measured with the time from start until the first bytes are read
+type firstByteReader struct {
+	io.ReadCloser
+	start    time.Time
+	measured func(time.Duration)
+}
+
+func (fr *firstByteReader) Read(p []byte) (n int, err error) {
+	n, err = fr.ReadCloser.Read(p)
+	if n > 0 && fr.measured != nil {
+		fr.measured(time.Since(fr.start))
+		fr.measured = nil
+	}
+	return n, err
+}
+
+// speedKbps returns the speed of transferring size bytes in elapsed time in kilobits per second
+func speedKbps(size int64, elapsed time.Duration) int64 {
+	if size <= 0 || elapsed <= 0 {
+		return 0
+	}
+	return size * 8 * int64(time.Second) / int64(elapsed) / 1000
+}
+
 func (ec *ecClient) Get(ctx context.Context, nodes []*pb.Node, es eestream.ErasureScheme,
 	pieceID psclient.PieceID, size int64, pba *pb.PayerBandwidthAllocation, authorization *pb.SignedMessage) (rr ranger.Ranger, err error) {
 	defer mon.Task()(&ctx)(&err)
@@ -202,6 +283,7 @@ func (ec *ecClient) Get(ctx context.Context, nodes []*pb.Node, es eestream.Erasu
 
 			rr := &lazyPieceRanger{
 				newPSClientHelper: ec.newPSClient,
+				report:            ec.report,
 				node:              n,
 				id:                derivedPieceID,
 				size:              pieceSize,
@@ -329,6 +411,7 @@ func calcPadded(size int64, blockSize int) int64 {
 type lazyPieceRanger struct {
 	ranger            ranger.Ranger
 	newPSClientHelper psClientHelper
+	report            func([]overlay.Measurement)
 	node              *pb.Node
 	id                psclient.PieceID
 	size              int64
@@ -345,6 +428,7 @@ func (lr *lazyPieceRanger) Size() int64 {
 func (lr *lazyPieceRanger) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
 	lr.node.Type.DPanicOnInvalid("Range")
 	if lr.ranger == nil {
+		start := time.Now()
 		ps, err := lr.newPSClientHelper(ctx, lr.node)
 		if err != nil {
 			return nil, err
@@ -354,6 +438,15 @@ func (lr *lazyPieceRanger) Range(ctx context.Context, offset, length int64) (io.
 			return nil, err
 		}
 		lr.ranger = ranger
+
+		reader, err := lr.ranger.Range(ctx, offset, length)
+		if err != nil || lr.report == nil {
+			return reader, err
+		}
+		// the latency of a download is how long the node takes to send the first bytes
+		return &firstByteReader{ReadCloser: reader, start: start, measured: func(latency time.Duration) {
+			lr.report([]overlay.Measurement{{NodeID: lr.node.Id, Latency: latency}})
+		}}, nil
 	}
 	return lr.ranger.Range(ctx, offset, length)
 }
@@ -337,3 +337,48 @@ func TestUnique(t *testing.T) {
 		assert.Equal(t, tt.unique, unique(tt.nodes), errTag)
 	}
 }
+
+type slowReader struct {
+	delay time.Duration
+	data  []byte
+}
+
+func (r *slowReader) Read(p []byte) (n int, err error) {
+	time.Sleep(r.delay)
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	n = copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestTransferMeasurements(t *testing.T) {
+	// waiting for slow data isn't counted as sending it
+	tr := &timingReader{r: &slowReader{delay: 20 * time.Millisecond, data: make([]byte, 1000)}}
+	start := time.Now()
+	_, err := io.Copy(ioutil.Discard, tr)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), tr.n)
+	assert.True(t, tr.waiting >= 40*time.Millisecond)
+	assert.True(t, time.Since(start)-tr.waiting < 20*time.Millisecond)
+
+	assert.Equal(t, int64(8), speedKbps(1000, time.Second))
+	assert.Equal(t, int64(0), speedKbps(1000, 0))
+
+	// the latency of a download is measured once, at the first bytes
+	var latencies []time.Duration
+	fr := &firstByteReader{
+		ReadCloser: ioutil.NopCloser(&slowReader{delay: 20 * time.Millisecond, data: make([]byte, 1000)}),
+		start:      time.Now(),
+		measured:   func(latency time.Duration) { latencies = append(latencies, latency) },
+	}
+	buf := make([]byte, 100)
+	for i := 0; i < 3; i++ {
+		_, err := fr.Read(buf)
+		assert.NoError(t, err)
+	}
+	if assert.Len(t, latencies, 1) {
+		assert.True(t, latencies[0] >= 20*time.Millisecond && latencies[0] < 40*time.Millisecond)
+	}
+}
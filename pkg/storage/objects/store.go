@@ -28,6 +28,8 @@ type Meta struct {
 	Expiration time.Time
 	Size       int64
 	Checksum   string
+
+	ChecksumAlgorithm storj.ChecksumAlgorithm
 }
 
 // ListItem is a single item in a listing
@@ -103,7 +105,7 @@ func (o *objStore) Put(ctx context.Context, path storj.Path, data io.Reader, met
 	if err != nil {
 		return Meta{}, err
 	}
-	m, err := o.store.Put(ctx, path, o.pathCipher, data, b, expiration)
+	m, err := o.store.Put(ctx, path, o.pathCipher, data, b, expiration, streams.Settings{})
 	return convertMeta(m), err
 }
 
@@ -155,6 +157,9 @@ func convertMeta(m streams.Meta) Meta {
 		Modified:         m.Modified,
 		Expiration:       m.Expiration,
 		Size:             m.Size,
+		Checksum:         string(m.Checksum),
 		SerializableMeta: ser,
+
+		ChecksumAlgorithm: m.ChecksumAlgorithm,
 	}
 }
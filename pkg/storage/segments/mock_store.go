@@ -12,6 +12,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 
+	eestream "storj.io/storj/pkg/eestream"
 	ranger "storj.io/storj/pkg/ranger"
 	storj "storj.io/storj/pkg/storj"
 )
@@ -79,16 +80,16 @@ func (mr *MockStoreMockRecorder) Repair(ctx, path, lostPieces interface{}) *gomo
 }
 
 // Put mocks base method
-func (m *MockStore) Put(ctx context.Context, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (Meta, error) {
-	ret := m.ctrl.Call(m, "Put", ctx, data, expiration, segmentInfo)
+func (m *MockStore) Put(ctx context.Context, data io.Reader, expiration, pending time.Time, rs eestream.RedundancyStrategy, segmentInfo func() (storj.Path, []byte, error)) (Meta, error) {
+	ret := m.ctrl.Call(m, "Put", ctx, data, expiration, pending, rs, segmentInfo)
 	ret0, _ := ret[0].(Meta)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Put indicates an expected call of Put
-func (mr *MockStoreMockRecorder) Put(ctx, data, expiration, segmentInfo interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStore)(nil).Put), ctx, data, expiration, segmentInfo)
+func (mr *MockStoreMockRecorder) Put(ctx, data, expiration, pending, rs, segmentInfo interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockStore)(nil).Put), ctx, data, expiration, pending, rs, segmentInfo)
 }
 
 // Delete mocks base method
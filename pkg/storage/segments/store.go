@@ -48,7 +48,7 @@ type ListItem struct {
 type Store interface {
 	Meta(ctx context.Context, path storj.Path) (meta Meta, err error)
 	Get(ctx context.Context, path storj.Path) (rr ranger.Ranger, meta Meta, err error)
-	Put(ctx context.Context, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error)
+	Put(ctx context.Context, data io.Reader, expiration, pending time.Time, rs eestream.RedundancyStrategy, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error)
 	Delete(ctx context.Context, path storj.Path) (err error)
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
 }
@@ -78,11 +78,23 @@ func (s *segmentStore) Meta(ctx context.Context, path storj.Path) (meta Meta, er
 	return convertMeta(pr), nil
 }
 
-// Put uploads a segment to an erasure code client
-func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.Time, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error) {
+// Put uploads a segment to an erasure code client. Remote segments are
+// encoded with rs, or with the redundancy strategy of the store when rs is
+// the zero value. The pieces expire at expiration, and so does the pointer
+// unless pending isn't zero: the pointer of a segment of a pending upload
+// expires at pending instead, until the upload is committed.
+func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration, pending time.Time, rs eestream.RedundancyStrategy, segmentInfo func() (storj.Path, []byte, error)) (meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	exp, err := ptypes.TimestampProto(expiration)
+	if rs.ErasureScheme == nil {
+		rs = s.rs
+	}
+
+	pointerExpiration := expiration
+	if !pending.IsZero() {
+		pointerExpiration = pending
+	}
+	exp, err := ptypes.TimestampProto(pointerExpiration)
 	if err != nil {
 		return Meta{}, Error.Wrap(err)
 	}
@@ -115,9 +127,9 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 		// uses overlay client to request a list of nodes according to configured standards
 		nodes, err := s.oc.Choose(ctx,
 			overlay.Options{
-				Amount:    s.rs.TotalCount(),
-				Bandwidth: sizedReader.Size() / int64(s.rs.TotalCount()),
-				Space:     sizedReader.Size() / int64(s.rs.TotalCount()),
+				Amount:    rs.TotalCount(),
+				Bandwidth: sizedReader.Size() / int64(rs.TotalCount()),
+				Space:     sizedReader.Size() / int64(rs.TotalCount()),
 				Excluded:  nil,
 			})
 		if err != nil {
@@ -135,7 +147,7 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 			return Meta{}, Error.Wrap(err)
 		}
 
-		successfulNodes, err := s.ec.Put(ctx, nodes, s.rs, pieceID, sizedReader, expiration, pba, authorization)
+		successfulNodes, err := s.ec.Put(ctx, nodes, rs, pieceID, sizedReader, expiration, pba, authorization)
 		if err != nil {
 			return Meta{}, Error.Wrap(err)
 		}
@@ -146,7 +158,7 @@ func (s *segmentStore) Put(ctx context.Context, data io.Reader, expiration time.
 		}
 		path = p
 
-		pointer, err = makeRemotePointer(successfulNodes, s.rs, pieceID, sizedReader.Size(), exp, metadata)
+		pointer, err = makeRemotePointer(successfulNodes, rs, pieceID, sizedReader.Size(), exp, metadata)
 		if err != nil {
 			return Meta{}, err
 		}
@@ -350,6 +362,11 @@ func calcNeededNodes(rs *pb.RedundancyScheme) int32 {
 func lookupAndAlignNodes(ctx context.Context, oc overlay.Client, nodes []*pb.Node, seg *pb.RemoteSegment) (result []*pb.Node, err error) {
 	defer mon.Task()(&ctx)(&err)
 
+	// the nodes sent along with the pointer are only usable if there is one for every piece
+	if len(nodes) != len(seg.GetRemotePieces()) {
+		nodes = nil
+	}
+
 	if nodes == nil {
 		// Get list of all nodes IDs storing a piece from the segment
 		var nodeIds storj.NodeIDList
@@ -362,13 +379,13 @@ func lookupAndAlignNodes(ctx context.Context, oc overlay.Client, nodes []*pb.Nod
 			return nil, Error.Wrap(err)
 		}
 	}
-	for _, v := range nodes {
-		v.Type.DPanicOnInvalid("lookup and align nodes")
-	}
-
-	// Realign the nodes
+	// Realign the nodes. Nodes the overlay doesn't know the address of are missing.
 	result = make([]*pb.Node, seg.GetRedundancy().GetTotal())
 	for i, p := range seg.GetRemotePieces() {
+		if i >= len(nodes) || nodes[i] == nil || nodes[i].GetAddress().GetAddress() == "" {
+			continue
+		}
+		nodes[i].Type.DPanicOnInvalid("lookup and align nodes")
 		result[p.PieceNum] = nodes[i]
 	}
 
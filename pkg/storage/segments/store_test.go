@@ -135,7 +135,7 @@ func TestSegmentStorePutRemote(t *testing.T) {
 		}
 		gomock.InOrder(calls...)
 
-		_, err := ss.Put(ctx, strings.NewReader(tt.readerContent), tt.expiration, func() (storj.Path, []byte, error) {
+		_, err := ss.Put(ctx, strings.NewReader(tt.readerContent), tt.expiration, time.Time{}, eestream.RedundancyStrategy{}, func() (storj.Path, []byte, error) {
 			return tt.pathInput, tt.mdInput, nil
 		})
 		assert.NoError(t, err, tt.name)
@@ -177,7 +177,7 @@ func TestSegmentStorePutInline(t *testing.T) {
 		}
 		gomock.InOrder(calls...)
 
-		_, err := ss.Put(ctx, strings.NewReader(tt.readerContent), tt.expiration, func() (storj.Path, []byte, error) {
+		_, err := ss.Put(ctx, strings.NewReader(tt.readerContent), tt.expiration, time.Time{}, eestream.RedundancyStrategy{}, func() (storj.Path, []byte, error) {
 			return tt.pathInput, tt.mdInput, nil
 		})
 		assert.NoError(t, err, tt.name)
@@ -490,3 +490,37 @@ func TestCalcNeededNodes(t *testing.T) {
 		assert.Equal(t, tt.needed, calcNeededNodes(&rs), tag)
 	}
 }
+
+func TestLookupAndAlignNodes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	a, b := teststorj.NodeIDFromString("a"), teststorj.NodeIDFromString("b")
+	seg := &pb.RemoteSegment{
+		Redundancy: &pb.RedundancyScheme{Total: 3},
+		RemotePieces: []*pb.RemotePiece{
+			{PieceNum: 2, NodeId: a},
+			{PieceNum: 0, NodeId: b},
+		},
+	}
+	nodeA := &pb.Node{Id: a, Type: pb.NodeType_STORAGE, Address: &pb.NodeAddress{Address: "127.0.0.1:1"}}
+	nodeB := &pb.Node{Id: b, Type: pb.NodeType_STORAGE, Address: &pb.NodeAddress{Address: "127.0.0.1:2"}}
+
+	{ // the nodes sent by pointerdb are used without looking them up
+		mockOC := mock_overlay.NewMockClient(ctrl)
+		unknownB := &pb.Node{Id: b, Type: pb.NodeType_STORAGE}
+
+		result, err := lookupAndAlignNodes(ctx, mockOC, []*pb.Node{nodeA, unknownB}, seg)
+		assert.NoError(t, err)
+		assert.Equal(t, []*pb.Node{nil, nil, nodeA}, result)
+	}
+
+	{ // nodes are looked up when pointerdb didn't send them all
+		mockOC := mock_overlay.NewMockClient(ctrl)
+		mockOC.EXPECT().BulkLookup(gomock.Any(), storj.NodeIDList{a, b}).Return([]*pb.Node{nodeA, nodeB}, nil)
+
+		result, err := lookupAndAlignNodes(ctx, mockOC, []*pb.Node{nodeA}, seg)
+		assert.NoError(t, err)
+		assert.Equal(t, []*pb.Node{nodeB, nil, nodeA}, result)
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"bytes"
+	"context"
+	"hash"
+	"io"
+
+	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/storj"
+)
+
+// checksumOf returns the checksum computed by contentHash, or nil if there is
+// none
+func checksumOf(contentHash hash.Hash) []byte {
+	if contentHash == nil {
+		return nil
+	}
+	return contentHash.Sum(nil)
+}
+
+// checksumRanger verifies the checksum of the content when it's read as a
+// whole. Partial ranges aren't verified.
+type checksumRanger struct {
+	ranger.Ranger
+	algorithm storj.ChecksumAlgorithm
+	checksum  []byte
+}
+
+// Range implements ranger.Ranger
+func (rr *checksumRanger) Range(ctx context.Context, offset, length int64) (io.ReadCloser, error) {
+	reader, err := rr.Ranger.Range(ctx, offset, length)
+	if err != nil {
+		return nil, err
+	}
+	if offset != 0 || length != rr.Size() {
+		return reader, nil
+	}
+
+	contentHash, err := rr.algorithm.New()
+	if err != nil || contentHash == nil {
+		// the stream was uploaded by a newer client, the content can't
+		// be verified
+		return reader, nil
+	}
+
+	return &checksumReader{
+		ReadCloser:  reader,
+		contentHash: contentHash,
+		checksum:    rr.checksum,
+	}, nil
+}
+
+// checksumReader fails the read at the end of the content if the content
+// doesn't match the checksum
+type checksumReader struct {
+	io.ReadCloser
+	contentHash hash.Hash
+	checksum    []byte
+}
+
+// Read implements io.Reader
+func (reader *checksumReader) Read(p []byte) (n int, err error) {
+	n, err = reader.ReadCloser.Read(p)
+	_, _ = reader.contentHash.Write(p[:n])
+	if err == io.EOF && !bytes.Equal(reader.contentHash.Sum(nil), reader.checksum) {
+		return n, storj.ErrChecksum.New("content doesn't match the checksum")
+	}
+	return n, err
+}
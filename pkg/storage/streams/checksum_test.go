@@ -0,0 +1,53 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/storj"
+)
+
+func TestChecksumRanger(t *testing.T) {
+	content := []byte("the content of the stream")
+	checksum := sha256.Sum256(content)
+
+	for i, tt := range []struct {
+		checksum []byte
+		offset   int64
+		length   int64
+		valid    bool
+	}{
+		{checksum[:], 0, int64(len(content)), true},
+		{checksum[:4], 0, int64(len(content)), false},
+		// partial ranges aren't verified
+		{checksum[:4], 4, 7, true},
+		{checksum[:4], 0, 7, true},
+	} {
+		rr := &checksumRanger{
+			Ranger:    ranger.ByteRanger(content),
+			algorithm: storj.SHA256,
+			checksum:  tt.checksum,
+		}
+
+		reader, err := rr.Range(ctx, tt.offset, tt.length)
+		if !assert.NoError(t, err, i) {
+			continue
+		}
+		data, err := ioutil.ReadAll(reader)
+		assert.NoError(t, reader.Close(), i)
+
+		if tt.valid {
+			assert.NoError(t, err, i)
+			assert.Equal(t, content[tt.offset:tt.offset+tt.length], data, i)
+		} else {
+			assert.True(t, storj.ErrChecksum.Has(err), i)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"bytes"
+	"context"
+	"hash"
+	"io"
+	"sync"
+	"time"
+
+	"storj.io/storj/pkg/storage/segments"
+	"storj.io/storj/pkg/storj"
+)
+
+// uploadParallel uploads data like upload, but up to s.parallelism segments
+// at the same time. Every segment is read into memory before it is uploaded,
+// one more being read while the others upload. The last segment is committed
+// only after all the others were, so that the stream is never listed before
+// it is complete.
+func (s *streamStore) uploadParallel(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, contentHash hash.Hash, metadata []byte, expiration time.Time) (m Meta, lastSegment int64, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	var started int64
+
+	defer func() {
+		select {
+		case <-ctx.Done():
+			s.cancelHandler(context.Background(), started, path, pathCipher)
+		default:
+		}
+	}()
+
+	derivedKey, err := s.keys.DeriveContentKey(path)
+	if err != nil {
+		return Meta{}, started, err
+	}
+	encPath, err := s.keys.EncryptAfterBucket(path, pathCipher)
+	if err != nil {
+		return Meta{}, started, err
+	}
+
+	uploadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		uploadErr error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if uploadErr == nil {
+			uploadErr = err
+			cancel()
+		}
+	}
+
+	// a slot is held by every segment in memory, except the one being read
+	slots := make(chan struct{}, s.parallelism)
+
+	var streamSize int64
+	current, err := s.readSegment(data)
+	for err == nil {
+		select {
+		case slots <- struct{}{}:
+		case <-uploadCtx.Done():
+			err = uploadCtx.Err()
+			continue
+		}
+
+		var next []byte
+		if int64(len(current)) == s.segmentSize {
+			next, err = s.readSegment(data)
+			if err != nil {
+				<-slots
+				continue
+			}
+		}
+		if len(next) == 0 {
+			// current is the last segment
+			<-slots
+			break
+		}
+
+		wg.Add(1)
+		go func(index int64, segment []byte) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			_, err := s.putSegment(uploadCtx, encPath, derivedKey, index, segment, false, nil, nil, expiration)
+			if err != nil {
+				fail(err)
+			}
+		}(started, current)
+
+		started++
+		streamSize += int64(len(current))
+		current = next
+	}
+
+	// every segment must be done before cleaning up or committing the last one
+	wg.Wait()
+	if err != nil {
+		fail(err)
+	}
+	mu.Lock()
+	err = uploadErr
+	mu.Unlock()
+	if err != nil {
+		return Meta{}, started, err
+	}
+
+	// all of data was read, so the checksum is complete
+	checksum := checksumOf(contentHash)
+	putMeta, err := s.putSegment(ctx, encPath, derivedKey, started, current, true, metadata, checksum, expiration)
+	if err != nil {
+		return Meta{}, started, err
+	}
+	streamSize += int64(len(current))
+
+	resultMeta := Meta{
+		Modified:   putMeta.Modified,
+		Expiration: expiration,
+		Size:       streamSize,
+		Data:       metadata,
+
+		Checksum:          checksum,
+		ChecksumAlgorithm: s.checksum,
+	}
+
+	return resultMeta, started + 1, nil
+}
+
+// readSegment reads the next segment of data into memory. The segment is
+// shorter than s.segmentSize only at the end of data.
+func (s *streamStore) readSegment(data io.Reader) ([]byte, error) {
+	segment := make([]byte, s.segmentSize)
+	n, err := io.ReadFull(data, segment)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = nil
+	}
+	return segment[:n], err
+}
+
+// putSegment encrypts and uploads the segment at index
+func (s *streamStore) putSegment(ctx context.Context, encPath storj.Path, derivedKey *storj.Key, index int64, segment []byte, last bool, metadata []byte, checksum []byte, expiration time.Time) (_ segments.Meta, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	enc, err := s.newSegmentEncryption(derivedKey, index)
+	if err != nil {
+		return segments.Meta{}, err
+	}
+
+	transformedReader, err := s.encryptSegment(enc, bytes.NewReader(segment))
+	if err != nil {
+		return segments.Meta{}, err
+	}
+
+	return s.segments.Put(ctx, transformedReader, expiration, s.pending, s.redundancy, func() (storj.Path, []byte, error) {
+		if last {
+			return s.lastSegmentInfo(encPath, index, enc, int64(len(segment)), metadata, checksum)
+		}
+		return s.segmentInfo(encPath, index, enc)
+	})
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package streams
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/pkg/eestream"
+	"storj.io/storj/pkg/encryption"
+	"storj.io/storj/pkg/ranger"
+	"storj.io/storj/pkg/storage/segments"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/storage"
+)
+
+// memSegments is a segments.Store keeping the segments in memory
+type memSegments struct {
+	mu       sync.Mutex
+	segments map[storj.Path]memSegment
+	puts     int
+	failPut  int // fails the put with this number, if positive
+}
+
+type memSegment struct {
+	data []byte
+	meta []byte
+}
+
+func newMemSegments() *memSegments {
+	return &memSegments{segments: make(map[storj.Path]memSegment)}
+}
+
+func (m *memSegments) Meta(ctx context.Context, path storj.Path) (segments.Meta, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	segment, ok := m.segments[path]
+	if !ok {
+		return segments.Meta{}, storage.ErrKeyNotFound.New("%q", path)
+	}
+	return segments.Meta{Size: int64(len(segment.data)), Data: segment.meta}, nil
+}
+
+func (m *memSegments) Get(ctx context.Context, path storj.Path) (ranger.Ranger, segments.Meta, error) {
+	meta, err := m.Meta(ctx, path)
+	if err != nil {
+		return nil, segments.Meta{}, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return ranger.ByteRanger(m.segments[path].data), meta, nil
+}
+
+func (m *memSegments) Put(ctx context.Context, data io.Reader, expiration, pending time.Time, rs eestream.RedundancyStrategy, segmentInfo func() (storj.Path, []byte, error)) (segments.Meta, error) {
+	m.mu.Lock()
+	m.puts++
+	fail := m.puts == m.failPut
+	m.mu.Unlock()
+	if fail {
+		return segments.Meta{}, errors.New("put failed")
+	}
+
+	content, err := ioutil.ReadAll(data)
+	if err != nil {
+		return segments.Meta{}, err
+	}
+	path, meta, err := segmentInfo()
+	if err != nil {
+		return segments.Meta{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.segments[path] = memSegment{data: content, meta: meta}
+	return segments.Meta{Size: int64(len(content)), Data: meta}, nil
+}
+
+func (m *memSegments) Delete(ctx context.Context, path storj.Path) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.segments[path]; !ok {
+		return storage.ErrKeyNotFound.New("%q", path)
+	}
+	delete(m.segments, path)
+	return nil
+}
+
+func (m *memSegments) List(ctx context.Context, prefix, startAfter, endBefore storj.Path, recursive bool, limit int, metaFlags uint32) ([]segments.ListItem, bool, error) {
+	return nil, false, errors.New("not implemented")
+}
+
+func (m *memSegments) paths() []storj.Path {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var paths []storj.Path
+	for path := range m.segments {
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func TestParallelSegments(t *testing.T) {
+	const segmentSize = 4096
+
+	for _, parallelism := range []int{1, 2, 4} {
+		for _, size := range []int{0, 100, segmentSize, 3 * segmentSize, 3*segmentSize + 17} {
+			errTag := fmt.Sprintf("parallelism %d, size %d", parallelism, size)
+
+			segmentStore := newMemSegments()
+			store, err := NewStreamStoreWithKeys(segmentStore, segmentSize, encryption.NewRootKeys(new(storj.Key)), 1024, storj.AESGCM, storj.SHA256, parallelism)
+			require.NoError(t, err, errTag)
+
+			data := make([]byte, size)
+			_, err = rand.Read(data)
+			require.NoError(t, err, errTag)
+
+			meta, err := store.Put(ctx, "bucket/file", storj.AESGCM, bytes.NewReader(data), []byte("metadata"), time.Time{}, Settings{})
+			require.NoError(t, err, errTag)
+			assert.EqualValues(t, size, meta.Size, errTag)
+
+			rr, meta, err := store.Get(ctx, "bucket/file", storj.AESGCM)
+			require.NoError(t, err, errTag)
+			assert.EqualValues(t, size, meta.Size, errTag)
+			assert.Equal(t, []byte("metadata"), meta.Data, errTag)
+
+			reader, err := rr.Range(ctx, 0, rr.Size())
+			require.NoError(t, err, errTag)
+			downloaded, err := ioutil.ReadAll(reader)
+			require.NoError(t, err, errTag)
+			require.NoError(t, reader.Close(), errTag)
+			assert.Equal(t, data, downloaded, errTag)
+
+			if size > 10 {
+				reader, err = rr.Range(ctx, 5, rr.Size()-10)
+				require.NoError(t, err, errTag)
+				downloaded, err = ioutil.ReadAll(reader)
+				require.NoError(t, err, errTag)
+				require.NoError(t, reader.Close(), errTag)
+				assert.Equal(t, data[5:size-5], downloaded, errTag)
+			}
+		}
+	}
+}
+
+func TestParallelUploadFailure(t *testing.T) {
+	const segmentSize = 4096
+
+	segmentStore := newMemSegments()
+	segmentStore.failPut = 3
+	store, err := NewStreamStoreWithKeys(segmentStore, segmentSize, encryption.NewRootKeys(new(storj.Key)), 1024, storj.AESGCM, storj.SHA256, 2)
+	require.NoError(t, err)
+
+	data := make([]byte, 5*segmentSize+1)
+	_, err = store.Put(ctx, "bucket/file", storj.AESGCM, bytes.NewReader(data), nil, time.Time{}, Settings{})
+	assert.Error(t, err)
+
+	// every uploaded segment is cleaned up and the stream isn't committed
+	assert.Empty(t, segmentStore.paths())
+}
@@ -8,16 +8,19 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
 	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/vivint/infectious"
 	"github.com/zeebo/errs"
 	"go.uber.org/zap"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
 
+	"storj.io/storj/pkg/durability"
 	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/encryption"
 	"storj.io/storj/pkg/pb"
@@ -36,6 +39,9 @@ type Meta struct {
 	Expiration time.Time
 	Size       int64
 	Data       []byte
+
+	Checksum          []byte
+	ChecksumAlgorithm storj.ChecksumAlgorithm
 }
 
 // convertMeta converts segment metadata to stream metadata
@@ -51,6 +57,9 @@ func convertMeta(lastSegmentMeta segments.Meta) (Meta, error) {
 		Expiration: lastSegmentMeta.Expiration,
 		Size:       ((stream.NumberOfSegments - 1) * stream.SegmentsSize) + stream.LastSegmentSize,
 		Data:       stream.Metadata,
+
+		Checksum:          stream.Checksum,
+		ChecksumAlgorithm: storj.ChecksumAlgorithm(stream.ChecksumAlgorithm),
 	}, nil
 }
 
@@ -58,47 +67,92 @@ func convertMeta(lastSegmentMeta segments.Meta) (Meta, error) {
 type Store interface {
 	Meta(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (Meta, error)
 	Get(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (ranger.Ranger, Meta, error)
-	Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (Meta, error)
+	Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time, settings Settings) (Meta, error)
 	Delete(ctx context.Context, path storj.Path, pathCipher storj.Cipher) error
 	List(ctx context.Context, prefix, startAfter, endBefore storj.Path, pathCipher storj.Cipher, recursive bool, limit int, metaFlags uint32) (items []ListItem, more bool, err error)
 }
 
+// Settings are the settings a stream is uploaded with. Unset values select
+// the settings of the store.
+type Settings struct {
+	SegmentSize       int64
+	RedundancyScheme  storj.RedundancyScheme
+	EncryptionScheme  storj.EncryptionScheme
+	ChecksumAlgorithm storj.ChecksumAlgorithm
+	// PendingExpiration is when the segments of the stream are removed by
+	// the satellite unless the upload is committed, zero for the expiration
+	// of the stream
+	PendingExpiration time.Time
+}
+
 // streamStore is a store for streams
 type streamStore struct {
 	segments     segments.Store
 	segmentSize  int64
-	rootKey      *storj.Key
+	keys         *encryption.PathKeys
 	encBlockSize int
 	cipher       storj.Cipher
+	checksum     storj.ChecksumAlgorithm
+	parallelism  int
+	pending      time.Time
+	// redundancy is the zero value for the redundancy of the segment store
+	redundancy eestream.RedundancyStrategy
 }
 
 // NewStreamStore stuff
 func NewStreamStore(segments segments.Store, segmentSize int64, rootKey *storj.Key, encBlockSize int, cipher storj.Cipher) (Store, error) {
+	if rootKey == nil {
+		return nil, errs.New("encryption key must not be empty")
+	}
+	return NewStreamStoreWithKeys(segments, segmentSize, encryption.NewRootKeys(rootKey), encBlockSize, cipher, storj.SHA256, 1)
+}
+
+// NewStreamStoreWithKeys creates a stream store deriving the path keys from
+// keys, which may be restricted to a path prefix. The content of uploaded
+// streams is hashed with checksum. Up to parallelism segments of a stream are
+// uploaded or downloaded at the same time, each buffered in memory when
+// parallelism is more than 1.
+func NewStreamStoreWithKeys(segments segments.Store, segmentSize int64, keys *encryption.PathKeys, encBlockSize int, cipher storj.Cipher, checksum storj.ChecksumAlgorithm, parallelism int) (Store, error) {
 	if segmentSize <= 0 {
 		return nil, errs.New("segment size must be larger than 0")
 	}
-	if rootKey == nil {
+	if keys == nil {
 		return nil, errs.New("encryption key must not be empty")
 	}
 	if encBlockSize <= 0 {
 		return nil, errs.New("encryption block size must be larger than 0")
 	}
+	if _, err := checksum.New(); err != nil {
+		return nil, err
+	}
+	if parallelism <= 0 {
+		return nil, errs.New("segment parallelism must be larger than 0")
+	}
 
 	return &streamStore{
 		segments:     segments,
 		segmentSize:  segmentSize,
-		rootKey:      rootKey,
+		keys:         keys,
 		encBlockSize: encBlockSize,
 		cipher:       cipher,
+		checksum:     checksum,
+		parallelism:  parallelism,
 	}, nil
 }
 
 // Put breaks up data as it comes in into s.segmentSize length pieces, then
 // store the first piece at s0/<path>, second piece at s1/<path>, and the
 // *last* piece at l/<path>. Store the given metadata, along with the number
-// of segments, in a new protobuf, in the metadata of l/<path>.
-func (s *streamStore) Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (m Meta, err error) {
+// of segments and the checksum of data, in a new protobuf, in the metadata of
+// l/<path>.
+func (s *streamStore) Put(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time, settings Settings) (m Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
+
+	store, err := s.withSettings(settings)
+	if err != nil {
+		return Meta{}, err
+	}
+
 	// previously file uploaded?
 	err = s.Delete(ctx, path, pathCipher)
 	if err != nil && !storage.ErrKeyNotFound.Has(err) {
@@ -107,7 +161,20 @@ func (s *streamStore) Put(ctx context.Context, path storj.Path, pathCipher storj
 		return Meta{}, err
 	}
 
-	m, lastSegment, err := s.upload(ctx, path, pathCipher, data, metadata, expiration)
+	contentHash, err := store.checksum.New()
+	if err != nil {
+		return Meta{}, err
+	}
+	if contentHash != nil {
+		data = io.TeeReader(data, contentHash)
+	}
+
+	upload := store.upload
+	if s.parallelism > 1 {
+		upload = store.uploadParallel
+	}
+
+	m, lastSegment, err := upload(ctx, path, pathCipher, data, contentHash, metadata, expiration)
 	if err != nil {
 		s.cancelHandler(context.Background(), lastSegment, path, pathCipher)
 	}
@@ -115,7 +182,53 @@ func (s *streamStore) Put(ctx context.Context, path storj.Path, pathCipher storj
 	return m, err
 }
 
-func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, metadata []byte, expiration time.Time) (m Meta, lastSegment int64, err error) {
+// withSettings returns a copy of the store uploading with settings
+func (s *streamStore) withSettings(settings Settings) (*streamStore, error) {
+	store := *s
+
+	if settings.SegmentSize > 0 {
+		store.segmentSize = settings.SegmentSize
+	}
+
+	if settings.ChecksumAlgorithm != storj.NoChecksum {
+		store.checksum = settings.ChecksumAlgorithm
+	}
+
+	store.pending = settings.PendingExpiration
+
+	if !settings.EncryptionScheme.IsZero() {
+		if settings.EncryptionScheme.BlockSize <= 0 {
+			return nil, errs.New("encryption block size must be larger than 0")
+		}
+		store.cipher = settings.EncryptionScheme.Cipher
+		store.encBlockSize = int(settings.EncryptionScheme.BlockSize)
+	}
+
+	if !settings.RedundancyScheme.IsZero() {
+		rs, err := newRedundancyStrategy(settings.RedundancyScheme)
+		if err != nil {
+			return nil, err
+		}
+		store.redundancy = rs
+	}
+
+	return &store, nil
+}
+
+// newRedundancyStrategy creates the redundancy strategy of scheme
+func newRedundancyStrategy(scheme storj.RedundancyScheme) (eestream.RedundancyStrategy, error) {
+	if err := durability.Validate(scheme); err != nil {
+		return eestream.RedundancyStrategy{}, err
+	}
+	fc, err := infectious.NewFEC(int(scheme.RequiredShares), int(scheme.TotalShares))
+	if err != nil {
+		return eestream.RedundancyStrategy{}, err
+	}
+	es := eestream.NewRSScheme(fc, int(scheme.ShareSize))
+	return eestream.NewRedundancyStrategy(es, int(scheme.RepairShares), int(scheme.OptimalShares))
+}
+
+func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher storj.Cipher, data io.Reader, contentHash hash.Hash, metadata []byte, expiration time.Time) (m Meta, lastSegment int64, err error) {
 	defer mon.Task()(&ctx)(&err)
 
 	var currentSegment int64
@@ -130,7 +243,7 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 		}
 	}()
 
-	derivedKey, err := encryption.DeriveContentKey(path, s.rootKey)
+	derivedKey, err := s.keys.DeriveContentKey(path)
 	if err != nil {
 		return Meta{}, currentSegment, err
 	}
@@ -138,123 +251,27 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 	eofReader := NewEOFReader(data)
 
 	for !eofReader.isEOF() && !eofReader.hasError() {
-		// generate random key for encrypting the segment's content
-		var contentKey storj.Key
-		_, err = rand.Read(contentKey[:])
-		if err != nil {
-			return Meta{}, currentSegment, err
-		}
-
-		// Initialize the content nonce with the segment's index incremented by 1.
-		// The increment by 1 is to avoid nonce reuse with the metadata encryption,
-		// which is encrypted with the zero nonce.
-		var contentNonce storj.Nonce
-		_, err := encryption.Increment(&contentNonce, currentSegment+1)
-		if err != nil {
-			return Meta{}, currentSegment, err
-		}
-
-		encrypter, err := encryption.NewEncrypter(s.cipher, &contentKey, &contentNonce, s.encBlockSize)
-		if err != nil {
-			return Meta{}, currentSegment, err
-		}
-
-		// generate random nonce for encrypting the content key
-		var keyNonce storj.Nonce
-		_, err = rand.Read(keyNonce[:])
-		if err != nil {
-			return Meta{}, currentSegment, err
-		}
-
-		encryptedKey, err := encryption.EncryptKey(&contentKey, s.cipher, derivedKey, &keyNonce)
+		enc, err := s.newSegmentEncryption(derivedKey, currentSegment)
 		if err != nil {
 			return Meta{}, currentSegment, err
 		}
 
 		sizeReader := NewSizeReader(eofReader)
-		segmentReader := io.LimitReader(sizeReader, s.segmentSize)
-		peekReader := segments.NewPeekThresholdReader(segmentReader)
-		largeData, err := peekReader.IsLargerThan(encrypter.InBlockSize())
+		transformedReader, err := s.encryptSegment(enc, io.LimitReader(sizeReader, s.segmentSize))
 		if err != nil {
 			return Meta{}, currentSegment, err
 		}
-		var transformedReader io.Reader
-		if largeData {
-			paddedReader := eestream.PadReader(ioutil.NopCloser(peekReader), encrypter.InBlockSize())
-			transformedReader = encryption.TransformReader(paddedReader, encrypter, 0)
-		} else {
-			data, err := ioutil.ReadAll(peekReader)
-			if err != nil {
-				return Meta{}, currentSegment, err
-			}
-			cipherData, err := encryption.Encrypt(data, s.cipher, &contentKey, &contentNonce)
-			if err != nil {
-				return Meta{}, currentSegment, err
-			}
-			transformedReader = bytes.NewReader(cipherData)
-		}
 
-		putMeta, err = s.segments.Put(ctx, transformedReader, expiration, func() (storj.Path, []byte, error) {
-			encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+		putMeta, err = s.segments.Put(ctx, transformedReader, expiration, s.pending, s.redundancy, func() (storj.Path, []byte, error) {
+			encPath, err := s.keys.EncryptAfterBucket(path, pathCipher)
 			if err != nil {
 				return "", nil, err
 			}
 
 			if !eofReader.isEOF() {
-				segmentPath := getSegmentPath(encPath, currentSegment)
-
-				if s.cipher == storj.Unencrypted {
-					return segmentPath, nil, nil
-				}
-
-				segmentMeta, err := proto.Marshal(&pb.SegmentMeta{
-					EncryptedKey: encryptedKey,
-					KeyNonce:     keyNonce[:],
-				})
-				if err != nil {
-					return "", nil, err
-				}
-
-				return segmentPath, segmentMeta, nil
-			}
-
-			lastSegmentPath := storj.JoinPaths("l", encPath)
-
-			streamInfo, err := proto.Marshal(&pb.StreamInfo{
-				NumberOfSegments: currentSegment + 1,
-				SegmentsSize:     s.segmentSize,
-				LastSegmentSize:  sizeReader.Size(),
-				Metadata:         metadata,
-			})
-			if err != nil {
-				return "", nil, err
-			}
-
-			// encrypt metadata with the content encryption key and zero nonce
-			encryptedStreamInfo, err := encryption.Encrypt(streamInfo, s.cipher, &contentKey, &storj.Nonce{})
-			if err != nil {
-				return "", nil, err
-			}
-
-			streamMeta := pb.StreamMeta{
-				EncryptedStreamInfo: encryptedStreamInfo,
-				EncryptionType:      int32(s.cipher),
-				EncryptionBlockSize: int32(s.encBlockSize),
-			}
-
-			if s.cipher != storj.Unencrypted {
-				streamMeta.LastSegmentMeta = &pb.SegmentMeta{
-					EncryptedKey: encryptedKey,
-					KeyNonce:     keyNonce[:],
-				}
-			}
-
-			lastSegmentMeta, err := proto.Marshal(&streamMeta)
-			if err != nil {
-				return "", nil, err
+				return s.segmentInfo(encPath, currentSegment, enc)
 			}
-
-			return lastSegmentPath, lastSegmentMeta, nil
+			return s.lastSegmentInfo(encPath, currentSegment, enc, sizeReader.Size(), metadata, checksumOf(contentHash))
 		})
 		if err != nil {
 			return Meta{}, currentSegment, err
@@ -273,11 +290,146 @@ func (s *streamStore) upload(ctx context.Context, path storj.Path, pathCipher st
 		Expiration: expiration,
 		Size:       streamSize,
 		Data:       metadata,
+
+		Checksum:          checksumOf(contentHash),
+		ChecksumAlgorithm: s.checksum,
 	}
 
 	return resultMeta, currentSegment, nil
 }
 
+// segmentEncryption holds the keys the content of a segment is encrypted with
+type segmentEncryption struct {
+	contentKey   storj.Key
+	contentNonce storj.Nonce
+	encryptedKey storj.EncryptedPrivateKey
+	keyNonce     storj.Nonce
+}
+
+// newSegmentEncryption generates the keys for encrypting the segment at index
+func (s *streamStore) newSegmentEncryption(derivedKey *storj.Key, index int64) (*segmentEncryption, error) {
+	enc := &segmentEncryption{}
+
+	// generate random key for encrypting the segment's content
+	_, err := rand.Read(enc.contentKey[:])
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize the content nonce with the segment's index incremented by 1.
+	// The increment by 1 is to avoid nonce reuse with the metadata encryption,
+	// which is encrypted with the zero nonce.
+	_, err = encryption.Increment(&enc.contentNonce, index+1)
+	if err != nil {
+		return nil, err
+	}
+
+	// generate random nonce for encrypting the content key
+	_, err = rand.Read(enc.keyNonce[:])
+	if err != nil {
+		return nil, err
+	}
+
+	enc.encryptedKey, err = encryption.EncryptKey(&enc.contentKey, s.cipher, derivedKey, &enc.keyNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	return enc, nil
+}
+
+// encryptSegment returns a reader of the encrypted content of a segment
+func (s *streamStore) encryptSegment(enc *segmentEncryption, segmentReader io.Reader) (io.Reader, error) {
+	encrypter, err := encryption.NewEncrypter(s.cipher, &enc.contentKey, &enc.contentNonce, s.encBlockSize)
+	if err != nil {
+		return nil, err
+	}
+
+	peekReader := segments.NewPeekThresholdReader(segmentReader)
+	largeData, err := peekReader.IsLargerThan(encrypter.InBlockSize())
+	if err != nil {
+		return nil, err
+	}
+	if largeData {
+		paddedReader := eestream.PadReader(ioutil.NopCloser(peekReader), encrypter.InBlockSize())
+		return encryption.TransformReader(paddedReader, encrypter, 0), nil
+	}
+
+	data, err := ioutil.ReadAll(peekReader)
+	if err != nil {
+		return nil, err
+	}
+	cipherData, err := encryption.Encrypt(data, s.cipher, &enc.contentKey, &enc.contentNonce)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(cipherData), nil
+}
+
+// segmentInfo returns the path and the metadata of the segment at index,
+// which isn't the last one
+func (s *streamStore) segmentInfo(encPath storj.Path, index int64, enc *segmentEncryption) (storj.Path, []byte, error) {
+	segmentPath := getSegmentPath(encPath, index)
+
+	if s.cipher == storj.Unencrypted {
+		return segmentPath, nil, nil
+	}
+
+	segmentMeta, err := proto.Marshal(&pb.SegmentMeta{
+		EncryptedKey: enc.encryptedKey,
+		KeyNonce:     enc.keyNonce[:],
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	return segmentPath, segmentMeta, nil
+}
+
+// lastSegmentInfo returns the path and the metadata of the last segment at
+// index, holding the stream info
+func (s *streamStore) lastSegmentInfo(encPath storj.Path, index int64, enc *segmentEncryption, lastSegmentSize int64, metadata []byte, checksum []byte) (storj.Path, []byte, error) {
+	lastSegmentPath := storj.JoinPaths("l", encPath)
+
+	streamInfo, err := proto.Marshal(&pb.StreamInfo{
+		NumberOfSegments:  index + 1,
+		SegmentsSize:      s.segmentSize,
+		LastSegmentSize:   lastSegmentSize,
+		Metadata:          metadata,
+		Checksum:          checksum,
+		ChecksumAlgorithm: int32(s.checksum),
+	})
+	if err != nil {
+		return "", nil, err
+	}
+
+	// encrypt metadata with the content encryption key and zero nonce
+	encryptedStreamInfo, err := encryption.Encrypt(streamInfo, s.cipher, &enc.contentKey, &storj.Nonce{})
+	if err != nil {
+		return "", nil, err
+	}
+
+	streamMeta := pb.StreamMeta{
+		EncryptedStreamInfo: encryptedStreamInfo,
+		EncryptionType:      int32(s.cipher),
+		EncryptionBlockSize: int32(s.encBlockSize),
+	}
+
+	if s.cipher != storj.Unencrypted {
+		streamMeta.LastSegmentMeta = &pb.SegmentMeta{
+			EncryptedKey: enc.encryptedKey,
+			KeyNonce:     enc.keyNonce[:],
+		}
+	}
+
+	lastSegmentMeta, err := proto.Marshal(&streamMeta)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return lastSegmentPath, lastSegmentMeta, nil
+}
+
 // getSegmentPath returns the unique path for a particular segment
 func getSegmentPath(path storj.Path, segNum int64) storj.Path {
 	return storj.JoinPaths(fmt.Sprintf("s%d", segNum), path)
@@ -289,7 +441,7 @@ func getSegmentPath(path storj.Path, segNum int64) storj.Path {
 func (s *streamStore) Get(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (rr ranger.Ranger, meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	encPath, err := s.keys.EncryptAfterBucket(path, pathCipher)
 	if err != nil {
 		return nil, Meta{}, err
 	}
@@ -299,7 +451,7 @@ func (s *streamStore) Get(ctx context.Context, path storj.Path, pathCipher storj
 		return nil, Meta{}, err
 	}
 
-	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.rootKey)
+	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.keys)
 	if err != nil {
 		return nil, Meta{}, err
 	}
@@ -316,7 +468,7 @@ func (s *streamStore) Get(ctx context.Context, path storj.Path, pathCipher storj
 		return nil, Meta{}, err
 	}
 
-	derivedKey, err := encryption.DeriveContentKey(path, s.rootKey)
+	derivedKey, err := s.keys.DeriveContentKey(path)
 	if err != nil {
 		return nil, Meta{}, err
 	}
@@ -364,7 +516,14 @@ func (s *streamStore) Get(ctx context.Context, path storj.Path, pathCipher storj
 	}
 	rangers = append(rangers, decryptedLastSegmentRanger)
 
-	catRangers := ranger.Concat(rangers...)
+	catRangers := ranger.ConcatParallel(s.parallelism, rangers...)
+	if len(stream.Checksum) > 0 {
+		catRangers = &checksumRanger{
+			Ranger:    catRangers,
+			algorithm: storj.ChecksumAlgorithm(stream.ChecksumAlgorithm),
+			checksum:  stream.Checksum,
+		}
+	}
 
 	lastSegmentMeta.Data = streamInfo
 	meta, err = convertMeta(lastSegmentMeta)
@@ -379,7 +538,7 @@ func (s *streamStore) Get(ctx context.Context, path storj.Path, pathCipher storj
 func (s *streamStore) Meta(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (meta Meta, err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	encPath, err := s.keys.EncryptAfterBucket(path, pathCipher)
 	if err != nil {
 		return Meta{}, err
 	}
@@ -389,7 +548,7 @@ func (s *streamStore) Meta(ctx context.Context, path storj.Path, pathCipher stor
 		return Meta{}, err
 	}
 
-	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.rootKey)
+	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.keys)
 	if err != nil {
 		return Meta{}, err
 	}
@@ -407,7 +566,7 @@ func (s *streamStore) Meta(ctx context.Context, path storj.Path, pathCipher stor
 func (s *streamStore) Delete(ctx context.Context, path storj.Path, pathCipher storj.Cipher) (err error) {
 	defer mon.Task()(&ctx)(&err)
 
-	encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+	encPath, err := s.keys.EncryptAfterBucket(path, pathCipher)
 	if err != nil {
 		return err
 	}
@@ -416,7 +575,7 @@ func (s *streamStore) Delete(ctx context.Context, path storj.Path, pathCipher st
 		return err
 	}
 
-	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.rootKey)
+	streamInfo, err := DecryptStreamInfo(ctx, lastSegmentMeta, path, s.keys)
 	if err != nil {
 		return err
 	}
@@ -428,7 +587,7 @@ func (s *streamStore) Delete(ctx context.Context, path storj.Path, pathCipher st
 	}
 
 	for i := 0; i < int(stream.NumberOfSegments-1); i++ {
-		encPath, err = EncryptAfterBucket(path, pathCipher, s.rootKey)
+		encPath, err = s.keys.EncryptAfterBucket(path, pathCipher)
 		if err != nil {
 			return err
 		}
@@ -461,22 +620,22 @@ func (s *streamStore) List(ctx context.Context, prefix, startAfter, endBefore st
 
 	prefix = strings.TrimSuffix(prefix, "/")
 
-	encPrefix, err := EncryptAfterBucket(prefix, pathCipher, s.rootKey)
+	encPrefix, err := s.keys.EncryptAfterBucket(prefix, pathCipher)
 	if err != nil {
 		return nil, false, err
 	}
 
-	prefixKey, err := encryption.DerivePathKey(prefix, s.rootKey, len(storj.SplitPath(prefix)))
+	prefixKey, err := s.keys.DerivePathKey(prefix, len(storj.SplitPath(prefix)))
 	if err != nil {
 		return nil, false, err
 	}
 
-	encStartAfter, err := s.encryptMarker(startAfter, pathCipher, prefixKey)
+	encStartAfter, err := s.encryptMarker(startAfter, pathCipher, prefix, prefixKey, recursive)
 	if err != nil {
 		return nil, false, err
 	}
 
-	encEndBefore, err := s.encryptMarker(endBefore, pathCipher, prefixKey)
+	encEndBefore, err := s.encryptMarker(endBefore, pathCipher, prefix, prefixKey, recursive)
 	if err != nil {
 		return nil, false, err
 	}
@@ -488,12 +647,12 @@ func (s *streamStore) List(ctx context.Context, prefix, startAfter, endBefore st
 
 	items = make([]ListItem, len(segments))
 	for i, item := range segments {
-		path, err := s.decryptMarker(item.Path, pathCipher, prefixKey)
+		path, err := s.decryptMarker(item.Path, pathCipher, prefix, prefixKey)
 		if err != nil {
 			return nil, false, err
 		}
 
-		streamInfo, err := DecryptStreamInfo(ctx, item.Meta, storj.JoinPaths(prefix, path), s.rootKey)
+		streamInfo, err := DecryptStreamInfo(ctx, item.Meta, storj.JoinPaths(prefix, path), s.keys)
 		if err != nil {
 			return nil, false, err
 		}
@@ -510,18 +669,27 @@ func (s *streamStore) List(ctx context.Context, prefix, startAfter, endBefore st
 	return items, more, nil
 }
 
-// encryptMarker is a helper method for encrypting startAfter and endBefore markers
-func (s *streamStore) encryptMarker(marker storj.Path, pathCipher storj.Cipher, prefixKey *storj.Key) (storj.Path, error) {
-	if bytes.Equal(s.rootKey[:], prefixKey[:]) { // empty prefix
-		return EncryptAfterBucket(marker, pathCipher, s.rootKey)
+// encryptMarker is a helper method for encrypting startAfter and endBefore markers.
+// In non-recursive listings, markers ending with a slash are prefixes, which are
+// listed as their encrypted path followed by a slash.
+func (s *streamStore) encryptMarker(marker storj.Path, pathCipher storj.Cipher, prefix storj.Path, prefixKey *storj.Key, recursive bool) (storj.Path, error) {
+	if !recursive && len(marker) > 1 && strings.HasSuffix(marker, "/") {
+		encrypted, err := s.encryptMarker(strings.TrimSuffix(marker, "/"), pathCipher, prefix, prefixKey, recursive)
+		if err != nil {
+			return "", err
+		}
+		return encrypted + "/", nil
+	}
+	if prefix == "" {
+		return s.keys.EncryptAfterBucket(marker, pathCipher)
 	}
 	return encryption.EncryptPath(marker, pathCipher, prefixKey)
 }
 
 // decryptMarker is a helper method for decrypting listed path markers
-func (s *streamStore) decryptMarker(marker storj.Path, pathCipher storj.Cipher, prefixKey *storj.Key) (storj.Path, error) {
-	if bytes.Equal(s.rootKey[:], prefixKey[:]) { // empty prefix
-		return DecryptAfterBucket(marker, pathCipher, s.rootKey)
+func (s *streamStore) decryptMarker(marker storj.Path, pathCipher storj.Cipher, prefix storj.Path, prefixKey *storj.Key) (storj.Path, error) {
+	if prefix == "" {
+		return s.keys.DecryptAfterBucket(marker, pathCipher)
 	}
 	return encryption.DecryptPath(marker, pathCipher, prefixKey)
 }
@@ -601,47 +769,18 @@ func decryptRanger(ctx context.Context, rr ranger.Ranger, decryptedSize int64, c
 
 // EncryptAfterBucket encrypts a path without encrypting its first element
 func EncryptAfterBucket(path storj.Path, cipher storj.Cipher, key *storj.Key) (encrypted storj.Path, err error) {
-	comps := storj.SplitPath(path)
-	if len(comps) <= 1 {
-		return path, nil
-	}
-
-	encrypted, err = encryption.EncryptPath(path, cipher, key)
-	if err != nil {
-		return "", err
-	}
-
-	// replace the first path component with the unencrypted bucket name
-	return storj.JoinPaths(comps[0], storj.JoinPaths(storj.SplitPath(encrypted)[1:]...)), nil
+	return encryption.NewRootKeys(key).EncryptAfterBucket(path, cipher)
 }
 
 // DecryptAfterBucket decrypts a path without modifying its first element
 func DecryptAfterBucket(path storj.Path, cipher storj.Cipher, key *storj.Key) (decrypted storj.Path, err error) {
-	comps := storj.SplitPath(path)
-	if len(comps) <= 1 {
-		return path, nil
-	}
-
-	bucket := comps[0]
-	toDecrypt := storj.JoinPaths(comps[1:]...)
-
-	bucketKey, err := encryption.DerivePathKey(path, key, 1)
-	if err != nil {
-		return "", err
-	}
-
-	decPath, err := encryption.DecryptPath(toDecrypt, cipher, bucketKey)
-	if err != nil {
-		return "", err
-	}
-
-	return storj.JoinPaths(bucket, decPath), nil
+	return encryption.NewRootKeys(key).DecryptAfterBucket(path, cipher)
 }
 
 // CancelHandler handles clean up of segments on receiving CTRL+C
 func (s *streamStore) cancelHandler(ctx context.Context, totalSegments int64, path storj.Path, pathCipher storj.Cipher) {
 	for i := int64(0); i < totalSegments; i++ {
-		encPath, err := EncryptAfterBucket(path, pathCipher, s.rootKey)
+		encPath, err := s.keys.EncryptAfterBucket(path, pathCipher)
 		if err != nil {
 			zap.S().Warnf("Failed deleting a segment due to encryption path %v %v", i, err)
 		}
@@ -666,14 +805,14 @@ func getEncryptedKeyAndNonce(m *pb.SegmentMeta) (storj.EncryptedPrivateKey, *sto
 }
 
 // DecryptStreamInfo decrypts stream info
-func DecryptStreamInfo(ctx context.Context, item segments.Meta, path storj.Path, rootKey *storj.Key) (streamInfo []byte, err error) {
+func DecryptStreamInfo(ctx context.Context, item segments.Meta, path storj.Path, keys *encryption.PathKeys) (streamInfo []byte, err error) {
 	streamMeta := pb.StreamMeta{}
 	err = proto.Unmarshal(item.Data, &streamMeta)
 	if err != nil {
 		return nil, err
 	}
 
-	derivedKey, err := encryption.DeriveContentKey(path, rootKey)
+	derivedKey, err := keys.DeriveContentKey(path)
 	if err != nil {
 		return nil, err
 	}
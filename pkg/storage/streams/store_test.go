@@ -5,6 +5,7 @@ package streams
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"strings"
@@ -15,6 +16,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"storj.io/storj/pkg/eestream"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/ranger"
 	"storj.io/storj/pkg/storage/segments"
@@ -119,11 +121,15 @@ func TestStreamStorePut(t *testing.T) {
 		Data:       []byte{},
 	}
 
+	checksum := sha256.Sum256([]byte("data"))
 	streamMeta := Meta{
 		Modified:   segmentMeta.Modified,
 		Expiration: segmentMeta.Expiration,
 		Size:       4,
 		Data:       []byte("metadata"),
+
+		Checksum:          checksum[:],
+		ChecksumAlgorithm: storj.SHA256,
 	}
 
 	for i, test := range []struct {
@@ -144,9 +150,9 @@ func TestStreamStorePut(t *testing.T) {
 		errTag := fmt.Sprintf("Test case #%d", i)
 
 		mockSegmentStore.EXPECT().
-			Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Put(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(test.segmentMeta, test.segmentError).
-			Do(func(ctx context.Context, data io.Reader, expiration time.Time, info func() (storj.Path, []byte, error)) {
+			Do(func(ctx context.Context, data io.Reader, expiration, pending time.Time, rs eestream.RedundancyStrategy, info func() (storj.Path, []byte, error)) {
 				for {
 					buf := make([]byte, 4)
 					_, err := data.Read(buf)
@@ -168,7 +174,7 @@ func TestStreamStorePut(t *testing.T) {
 			t.Fatal(err)
 		}
 
-		meta, err := streamStore.Put(ctx, test.path, storj.AESGCM, test.data, test.metadata, test.expiration)
+		meta, err := streamStore.Put(ctx, test.path, storj.AESGCM, test.data, test.metadata, test.expiration, Settings{})
 		if err != nil {
 			t.Fatal(err)
 		}
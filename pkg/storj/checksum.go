@@ -0,0 +1,41 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storj
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"hash"
+
+	"github.com/zeebo/errs"
+)
+
+// ErrChecksum is the error class for unsupported or mismatching checksums
+var ErrChecksum = errs.Class("checksum error")
+
+// ChecksumAlgorithm specifies the hash function of content checksums
+type ChecksumAlgorithm byte
+
+// List of supported checksum algorithms
+const (
+	NoChecksum = ChecksumAlgorithm(iota)
+	SHA256
+	// MD5 checksums match the ETags S3 clients expect
+	MD5
+)
+
+// New returns a hash computing checksums with the algorithm, or nil for
+// NoChecksum
+func (algorithm ChecksumAlgorithm) New() (hash.Hash, error) {
+	switch algorithm {
+	case NoChecksum:
+		return nil, nil
+	case SHA256:
+		return sha256.New(), nil
+	case MD5:
+		return md5.New(), nil
+	default:
+		return nil, ErrChecksum.New("unsupported checksum algorithm %d", algorithm)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storj
+
+import (
+	"strings"
+	"time"
+)
+
+// LifecycleRule is a bucket rule for the objects under a prefix
+type LifecycleRule struct {
+	// Prefix selects the objects of the rule, empty for all objects
+	Prefix Path `json:"prefix,omitempty"`
+	// ExpireDays is the number of days after which new objects expire,
+	// zero for never. Existing objects keep their expiration until the rules
+	// are applied to them.
+	ExpireDays int `json:"expire_days,omitempty"`
+	// AbortUploadDays is the number of days after which pending multipart
+	// uploads are aborted, zero for never
+	AbortUploadDays int `json:"abort_upload_days,omitempty"`
+}
+
+// Matches checks whether the rule applies to path
+func (rule LifecycleRule) Matches(path Path) bool {
+	return strings.HasPrefix(path, rule.Prefix)
+}
+
+// Expiration returns when an object created at created under path expires
+// by the lifecycle rules of the bucket. The earliest expiration of the
+// matching rules applies, the zero time means never.
+func (bucket Bucket) Expiration(path Path, created time.Time) time.Time {
+	days := earliest(bucket.Lifecycle, path, func(rule LifecycleRule) int { return rule.ExpireDays })
+	if days == 0 {
+		return time.Time{}
+	}
+	return created.AddDate(0, 0, days)
+}
+
+// AbortUploadAfter returns after how long a pending multipart upload to path
+// is aborted by the lifecycle rules of the bucket, zero for never
+func (bucket Bucket) AbortUploadAfter(path Path) time.Duration {
+	days := earliest(bucket.Lifecycle, path, func(rule LifecycleRule) int { return rule.AbortUploadDays })
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// earliest returns the lowest positive number of days of the rules matching
+// path, zero if there are none
+func earliest(rules []LifecycleRule, path Path, days func(LifecycleRule) int) (min int) {
+	for _, rule := range rules {
+		if d := days(rule); rule.Matches(path) && d > 0 && (min == 0 || d < min) {
+			min = d
+		}
+	}
+	return min
+}
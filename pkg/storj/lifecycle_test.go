@@ -0,0 +1,37 @@
+// Copyright (C) 2019 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package storj
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLifecycle(t *testing.T) {
+	created := time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)
+	bucket := Bucket{Lifecycle: []LifecycleRule{
+		{Prefix: "logs/", ExpireDays: 30},
+		{Prefix: "logs/debug/", ExpireDays: 7, AbortUploadDays: 2},
+		{Prefix: "tmp/", AbortUploadDays: 1},
+	}}
+
+	for i, tt := range []struct {
+		path       Path
+		expiration time.Time
+		abort      time.Duration
+	}{
+		{"a", time.Time{}, 0},
+		{"logs", time.Time{}, 0},
+		{"logs/a", created.AddDate(0, 0, 30), 0},
+		{"logs/debug/a", created.AddDate(0, 0, 7), 48 * time.Hour},
+		{"tmp/a", time.Time{}, 24 * time.Hour},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+		assert.Equal(t, tt.expiration, bucket.Expiration(tt.path, created), errTag)
+		assert.Equal(t, tt.abort, bucket.AbortUploadAfter(tt.path), errTag)
+	}
+}
@@ -22,6 +22,11 @@ type Metainfo interface {
 	GetBucket(ctx context.Context, bucket string) (Bucket, error)
 	// ListBuckets lists buckets starting from first
 	ListBuckets(ctx context.Context, options BucketListOptions) (BucketList, error)
+	// SetBucketLifecycle replaces the lifecycle rules of the bucket
+	SetBucketLifecycle(ctx context.Context, bucket string, rules []LifecycleRule) (Bucket, error)
+	// ApplyBucketLifecycle sets the expiration of the existing objects under
+	// prefix to the one given by the lifecycle rules of the bucket
+	ApplyBucketLifecycle(ctx context.Context, bucket string, prefix Path) (int, error)
 
 	// GetObject returns information about an object
 	GetObject(ctx context.Context, bucket string, path Path) (Object, error)
@@ -37,10 +42,22 @@ type Metainfo interface {
 	// ListObjects lists objects in bucket based on the ListOptions
 	ListObjects(ctx context.Context, bucket string, options ListOptions) (ObjectList, error)
 
+	// GetObjectVersion returns information about a version of an object
+	GetObjectVersion(ctx context.Context, bucket string, path Path, version uint32) (Object, error)
+	// GetObjectVersionStream returns interface for reading the stream of a version of an object
+	GetObjectVersionStream(ctx context.Context, bucket string, path Path, version uint32) (ReadOnlyStream, error)
+	// DeleteObjectVersion deletes a version of an object, making the previous
+	// version current when deleting the current one
+	DeleteObjectVersion(ctx context.Context, bucket string, path Path, version uint32) error
+
 	// ModifyPendingObject creates a mutable object for updating a partially uploaded object
 	ModifyPendingObject(ctx context.Context, bucket string, path Path) (MutableObject, error)
 	// ListPendingObjects lists pending objects in bucket based on the ListOptions
 	ListPendingObjects(ctx context.Context, bucket string, options ListOptions) (ObjectList, error)
+
+	// RotateKey encrypts all buckets and objects with keys derived from
+	// newKey instead of the root key, resuming an interrupted rotation
+	RotateKey(ctx context.Context, newKey *Key) error
 }
 
 // CreateObject has optional parameters that can be set
@@ -48,9 +65,13 @@ type CreateObject struct {
 	Metadata    map[string]string
 	ContentType string
 	Expires     time.Time
+	// PendingExpires is when the upload is removed unless committed, zero
+	// for never
+	PendingExpires time.Time
 
 	RedundancyScheme
 	EncryptionScheme
+	ChecksumAlgorithm
 }
 
 // Object converts the CreateObject to an object with unitialized values
@@ -61,14 +82,18 @@ func (create CreateObject) Object(bucket Bucket, path Path) Object {
 		Metadata:    create.Metadata,
 		ContentType: create.ContentType,
 		Expires:     create.Expires,
+
+		PendingExpires: create.PendingExpires,
+
 		Stream: Stream{
 			Size:             -1,  // unknown
 			Checksum:         nil, // unknown
 			SegmentCount:     -1,  // unknown
 			FixedSegmentSize: -1,  // unknown
 
-			RedundancyScheme: create.RedundancyScheme,
-			EncryptionScheme: create.EncryptionScheme,
+			RedundancyScheme:  create.RedundancyScheme,
+			EncryptionScheme:  create.EncryptionScheme,
+			ChecksumAlgorithm: create.ChecksumAlgorithm,
 		},
 	}
 }
@@ -95,6 +120,10 @@ type ListOptions struct {
 	Recursive bool
 	Direction ListDirection
 	Limit     int
+	// Versions lists every version of the objects, from the latest, with the
+	// delete markers. The cursor is the path of the last object listed, and
+	// all the versions of an object are listed in the same page.
+	Versions bool
 }
 
 // ObjectList is a list of objects
@@ -121,6 +150,7 @@ func (opts ListOptions) NextPage(list ObjectList) ListOptions {
 			Cursor:    list.Items[0].Path,
 			Direction: Before,
 			Limit:     opts.Limit,
+			Versions:  opts.Versions,
 		}
 	case After, Forward:
 		return ListOptions{
@@ -128,6 +158,7 @@ func (opts ListOptions) NextPage(list ObjectList) ListOptions {
 			Cursor:    list.Items[len(list.Items)-1].Path,
 			Direction: After,
 			Limit:     opts.Limit,
+			Versions:  opts.Versions,
 		}
 	}
 
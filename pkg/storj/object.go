@@ -28,6 +28,19 @@ type Bucket struct {
 	Name       string
 	Created    time.Time
 	PathCipher Cipher
+	// Versioning is whether every commit of an object keeps the previous
+	// versions of the object
+	Versioning bool
+	// Lifecycle are the rules for expiring the objects and aborting the
+	// pending uploads of the bucket
+	Lifecycle []LifecycleRule
+
+	// SegmentsSize, RedundancyScheme and EncryptionScheme are the defaults
+	// for the objects created in the bucket. Unset ones leave the choice to
+	// the uplink.
+	SegmentsSize     int64
+	RedundancyScheme RedundancyScheme
+	EncryptionScheme EncryptionScheme
 }
 
 // Object contains information about a specific object
@@ -37,6 +50,15 @@ type Object struct {
 	Path     Path
 	IsPrefix bool
 
+	// IsNoncurrent is whether the version isn't the current one of the
+	// object, because it was replaced or isn't committed yet
+	IsNoncurrent bool
+	// IsDeleteMarker is whether the version marks the deletion of the object
+	IsDeleteMarker bool
+	// UploadID identifies the pending upload of a new version, stored apart
+	// from the other uploads of the object until committed
+	UploadID string
+
 	Metadata map[string]string
 
 	ContentType string
@@ -44,15 +66,33 @@ type Object struct {
 	Modified    time.Time
 	Expires     time.Time
 
+	// PendingExpires is when the pending upload of the object is removed
+	// unless committed, zero for never
+	PendingExpires time.Time
+
 	Stream
 }
 
+// StreamPath returns the path of the stream holding the data of the object
+// version, relative to the bucket
+func (object Object) StreamPath() Path {
+	if object.UploadID != "" {
+		return UploadPath(object.Path, object.UploadID)
+	}
+	if object.IsNoncurrent {
+		return VersionPath(object.Path, object.Version)
+	}
+	return object.Path
+}
+
 // Stream is information about an object stream
 type Stream struct {
 	// Size is the total size of the stream in bytes
 	Size int64
-	// Checksum is the checksum of the segment checksums
+	// Checksum is the checksum of the content, nil if unknown
 	Checksum []byte
+	// ChecksumAlgorithm is the hash function of the checksum
+	ChecksumAlgorithm ChecksumAlgorithm
 
 	// SegmentCount is the number of segments
 	SegmentCount int64
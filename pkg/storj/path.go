@@ -4,9 +4,23 @@
 package storj
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 )
 
+const (
+	// versionSeparator follows the path of an object in the prefix holding
+	// its noncurrent versions by number
+	versionSeparator = "\x7fv"
+	// uploadSeparator separates the path of an object from the ID of a
+	// pending upload of a new version
+	uploadSeparator = "\x7fu"
+	// commitSuffix follows the path of an object in the path of the version
+	// being made current
+	commitSuffix = "\x7fc"
+)
+
 // Path represents a object path
 type Path = string
 
@@ -19,3 +33,53 @@ func SplitPath(path Path) []string {
 func JoinPaths(paths ...Path) Path {
 	return strings.Join(paths, "/")
 }
+
+// VersionsPrefix returns the prefix under which the noncurrent versions of
+// the object at path are stored, so that they can be listed together
+func VersionsPrefix(path Path) Path {
+	return path + versionSeparator
+}
+
+// VersionPath returns the path where the noncurrent version of the object at
+// path is stored
+func VersionPath(path Path, version uint32) Path {
+	return fmt.Sprintf("%s/%010d", VersionsPrefix(path), version)
+}
+
+// ParseVersionPath returns the object path and the version stored at path,
+// if path is the one of a noncurrent version
+func ParseVersionPath(path Path) (object Path, version uint32, ok bool) {
+	i := strings.LastIndex(path, versionSeparator+"/")
+	if i < 0 {
+		return "", 0, false
+	}
+	number := path[i+len(versionSeparator)+1:]
+	if strings.Contains(number, "/") {
+		return "", 0, false
+	}
+	parsed, err := strconv.ParseUint(number, 10, 32)
+	if err != nil {
+		return "", 0, false
+	}
+	return path[:i], uint32(parsed), true
+}
+
+// UploadPath returns the path where the pending upload of a new version of
+// the object at path is stored until committed
+func UploadPath(path Path, uploadID string) Path {
+	return path + uploadSeparator + uploadID
+}
+
+// CommitPath returns the path where the version replacing the current one of
+// the object at path is stored while it is made current
+func CommitPath(path Path) Path {
+	return path + commitSuffix
+}
+
+// IsReservedPath checks whether path can't be used for objects, as it would
+// be mistaken for a noncurrent, pending or committing version
+func IsReservedPath(path Path) bool {
+	return strings.Contains(path, versionSeparator) ||
+		strings.Contains(path, uploadSeparator) ||
+		strings.HasSuffix(path, commitSuffix)
+}
@@ -5,6 +5,7 @@ package storj
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -52,3 +53,51 @@ func TestJoinPaths(t *testing.T) {
 		assert.Equal(t, tt.path, JoinPaths(tt.comps...), errTag)
 	}
 }
+
+func TestVersionPath(t *testing.T) {
+	for i, tt := range []struct {
+		path    string
+		version uint32
+	}{
+		{"a", 0},
+		{"a", 1},
+		{"a/b/c", 4294967295},
+		{"a/b/", 7},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+
+		versionPath := VersionPath(tt.path, tt.version)
+		assert.True(t, IsReservedPath(versionPath), errTag)
+		assert.True(t, strings.HasPrefix(versionPath, VersionsPrefix(tt.path)+"/"), errTag)
+
+		path, version, ok := ParseVersionPath(versionPath)
+		assert.True(t, ok, errTag)
+		assert.Equal(t, tt.path, path, errTag)
+		assert.Equal(t, tt.version, version, errTag)
+	}
+
+	for i, path := range []string{"", "a", "a/b", "a\x7fv", "a\x7fv1", "a\x7fv/b", "a\x7fv/1/b"} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+		_, _, ok := ParseVersionPath(path)
+		assert.False(t, ok, errTag)
+	}
+}
+
+func TestReservedPath(t *testing.T) {
+	for i, tt := range []struct {
+		path     string
+		reserved bool
+	}{
+		{"a/b", false},
+		{"a\x7f/b", false},
+		{UploadPath("a/b", "0123"), true},
+		{CommitPath("a/b"), true},
+		{VersionPath(CommitPath("a"), 1), true},
+	} {
+		errTag := fmt.Sprintf("Test case #%d", i)
+		assert.Equal(t, tt.reserved, IsReservedPath(tt.path), errTag)
+	}
+
+	_, _, ok := ParseVersionPath(UploadPath("a", "1"))
+	assert.False(t, ok)
+}
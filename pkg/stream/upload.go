@@ -43,13 +43,14 @@ func NewUpload(ctx context.Context, stream storj.MutableStream, streams streams.
 		serMetaInfo := pb.SerializableMeta{
 			ContentType: obj.ContentType,
 			UserDefined: obj.Metadata,
+			Version:     obj.Version,
 		}
 		metadata, err := proto.Marshal(&serMetaInfo)
 		if err != nil {
 			return utils.CombineErrors(err, reader.CloseWithError(err))
 		}
 
-		_, err = streams.Put(ctx, storj.JoinPaths(obj.Bucket.Name, obj.Path), obj.Bucket.PathCipher, reader, metadata, obj.Expires)
+		_, err = streams.Put(ctx, storj.JoinPaths(obj.Bucket.Name, obj.StreamPath()), obj.Bucket.PathCipher, reader, metadata, obj.Expires, uploadSettings(obj))
 		if err != nil {
 			return utils.CombineErrors(err, reader.CloseWithError(err))
 		}
@@ -60,6 +61,19 @@ func NewUpload(ctx context.Context, stream storj.MutableStream, streams streams.
 	return &upload
 }
 
+// uploadSettings returns the settings for uploading the stream of obj. Unset
+// schemes, segment size and checksum algorithm select the settings of the
+// stream store.
+func uploadSettings(obj storj.Object) streams.Settings {
+	return streams.Settings{
+		SegmentSize:       obj.FixedSegmentSize,
+		RedundancyScheme:  obj.RedundancyScheme,
+		EncryptionScheme:  obj.EncryptionScheme,
+		ChecksumAlgorithm: obj.ChecksumAlgorithm,
+		PendingExpiration: obj.PendingExpires,
+	}
+}
+
 // Write writes len(data) bytes from data to the underlying data stream.
 //
 // See io.Writer for more details.
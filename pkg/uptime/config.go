@@ -0,0 +1,71 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uptime
+
+import (
+	"context"
+	"time"
+
+	"github.com/zeebo/errs"
+	"go.uber.org/zap"
+	monkit "gopkg.in/spacemonkeygo/monkit.v2"
+
+	"storj.io/storj/pkg/kademlia"
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/provider"
+	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/pkg/transport"
+)
+
+var (
+	mon = monkit.Package()
+	// Error is the errs class for uptime check errors
+	Error = errs.Class("uptime error")
+)
+
+// Config contains configurable values for uptime checks
+type Config struct {
+	Interval     time.Duration `help:"how often each storage node is checked" default:"1h"`
+	Jitter       time.Duration `help:"the maximum random delay added to each scheduled check" default:"10m"`
+	MaxBackoff   time.Duration `help:"the maximum time between checks of a node that keeps failing them" default:"24h"`
+	PollInterval time.Duration `help:"how often the schedule is scanned for checks that are due" default:"1m"`
+	Timeout      time.Duration `help:"how long to wait for a node to answer a ping" default:"10s"`
+	Concurrency  int           `help:"the number of nodes checked in parallel" default:"5"`
+}
+
+// Run runs the uptime checks with configured values
+func (c Config) Run(ctx context.Context, server *provider.Provider) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	cache := overlay.LoadFromContext(ctx)
+	if cache == nil {
+		return Error.New("failed to load overlay cache from context")
+	}
+
+	db, ok := ctx.Value("masterdb").(interface {
+		StatDB() statdb.DB
+	})
+	if !ok {
+		return Error.New("unable to get master db instance")
+	}
+
+	service := NewService(zap.L().Named("uptime"), cache, db.StatDB(),
+		transport.NewClient(server.Identity()), statdb.LoadConfigFromContext(ctx), c)
+
+	// nodes that reach us again are checked right away instead of waiting out their backoff
+	if kad := kademlia.LoadFromContext(ctx); kad != nil {
+		kad.AddObserver(service)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		if err := service.Run(ctx); err != nil {
+			defer cancel()
+			zap.L().Error("Error running uptime checks", zap.Error(err))
+		}
+	}()
+
+	return server.Run(ctx)
+}
@@ -0,0 +1,268 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uptime
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/storage"
+)
+
+// Service pings every storage node in the overlay cache on a jittered schedule
+// and records the results in statdb
+type Service struct {
+	log       *zap.Logger
+	cache     *overlay.Cache
+	statdb    statdb.DB
+	transport transport.Client
+	states    statdb.Config
+	config    Config
+
+	mu       sync.Mutex
+	schedule map[storj.NodeID]*check
+	wake     chan struct{}
+}
+
+// check is the schedule of a single node
+type check struct {
+	next     time.Time
+	failures int
+}
+
+// NewService creates a new uptime check service
+func NewService(log *zap.Logger, cache *overlay.Cache, sdb statdb.DB, tc transport.Client, states statdb.Config, config Config) *Service {
+	return &Service{
+		log:       log,
+		cache:     cache,
+		statdb:    sdb,
+		transport: tc,
+		states:    states,
+		config:    config,
+		schedule:  make(map[storj.NodeID]*check),
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Run checks nodes as they become due until ctx is canceled
+func (service *Service) Run(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	ticker := time.NewTicker(service.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := service.Tick(ctx); err != nil {
+			service.log.Error("uptime check failed", zap.Error(err))
+		}
+
+		select {
+		case <-ticker.C:
+		case <-service.wake:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Tick checks every storage node in the cache whose check is due. Nodes that are no
+// longer in the cache are removed from the schedule once all nodes were listed.
+func (service *Service) Tick(ctx context.Context) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	concurrency := service.config.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	listed := make(map[storj.NodeID]bool)
+
+	var cursor storj.NodeID
+	for {
+		nodes, err := service.cache.List(ctx, cursor, storage.LookupLimit)
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		if len(nodes) == 0 {
+			service.prune(listed)
+			return nil
+		}
+		cursor = nodes[len(nodes)-1].Id
+
+		for _, node := range nodes {
+			if node.Type != pb.NodeType_STORAGE {
+				continue
+			}
+			listed[node.Id] = true
+			if !service.due(node.Id) {
+				continue
+			}
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			wg.Add(1)
+			go func(node *pb.Node) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				service.check(ctx, node)
+			}(node)
+		}
+	}
+}
+
+// Recheck makes a node that failed its last check due right away
+func (service *Service) Recheck(nodeID storj.NodeID) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	c, ok := service.schedule[nodeID]
+	if !ok || c.failures == 0 {
+		return
+	}
+	c.next = time.Time{}
+
+	select {
+	case service.wake <- struct{}{}:
+	default:
+	}
+}
+
+// ConnSuccess implements the Transport Observer `ConnSuccess` function
+func (service *Service) ConnSuccess(ctx context.Context, node *pb.Node) {
+	service.Recheck(node.Id)
+}
+
+// ConnFailure implements the Transport Observer `ConnFailure` function
+func (service *Service) ConnFailure(ctx context.Context, node *pb.Node, err error) {}
+
+// due returns whether nodeID should be checked now. Nodes seen for the first time
+// are scheduled within the jitter window, so checks don't all happen at once.
+func (service *Service) due(nodeID storj.NodeID) bool {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	now := time.Now()
+	c, ok := service.schedule[nodeID]
+	if !ok {
+		c = &check{next: now.Add(service.jitter())}
+		service.schedule[nodeID] = c
+	}
+	return !c.next.After(now)
+}
+
+// prune removes the schedule of nodes that weren't listed
+func (service *Service) prune(listed map[storj.NodeID]bool) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	for nodeID := range service.schedule {
+		if !listed[nodeID] {
+			delete(service.schedule, nodeID)
+		}
+	}
+}
+
+// check pings node and records the result
+func (service *Service) check(ctx context.Context, node *pb.Node) {
+	latency, err := service.ping(ctx, node)
+	isUp := err == nil
+	if !isUp {
+		service.log.Debug("node failed uptime check", zap.String("nodeID", node.Id.String()), zap.Error(err))
+	}
+
+	service.reschedule(node.Id, isUp)
+
+	stats, err := service.statdb.UpdateUptime(ctx, node.Id, isUp)
+	if err != nil {
+		service.log.Error("error updating uptime in statdb", zap.String("nodeID", node.Id.String()), zap.Error(err))
+		return
+	}
+	if _, err := service.states.Apply(ctx, service.cache, stats); err != nil {
+		service.log.Error("error updating node state", zap.String("nodeID", node.Id.String()), zap.Error(err))
+	}
+
+	if isUp {
+		if err := service.cache.UpdatePerformance(ctx, node.Id, latency, 0); err != nil {
+			service.log.Debug("error updating node latency", zap.String("nodeID", node.Id.String()), zap.Error(err))
+		}
+	}
+}
+
+// ping calls the Nodes.Ping RPC on node over a fresh connection and returns
+// how long connecting and pinging took
+func (service *Service) ping(ctx context.Context, node *pb.Node) (latency time.Duration, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if service.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, service.config.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	conn, err := service.transport.DialNode(ctx, node)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if cerr := conn.Close(); cerr != nil {
+			service.log.Debug("error closing connection", zap.Error(cerr))
+		}
+	}()
+
+	_, err = pb.NewNodesClient(conn).Ping(ctx, &pb.PingRequest{})
+	return time.Since(start), err
+}
+
+// reschedule sets the next check of nodeID. Every consecutive failure doubles the
+// time until the next check, up to the maximum backoff.
+func (service *Service) reschedule(nodeID storj.NodeID, isUp bool) {
+	service.mu.Lock()
+	defer service.mu.Unlock()
+
+	c, ok := service.schedule[nodeID]
+	if !ok {
+		c = &check{}
+		service.schedule[nodeID] = c
+	}
+	if isUp {
+		c.failures = 0
+	} else {
+		c.failures++
+	}
+
+	delay := service.config.Interval
+	for i := 0; i < c.failures && delay < service.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if service.config.MaxBackoff > 0 && delay > service.config.MaxBackoff {
+		delay = service.config.MaxBackoff
+	}
+	c.next = time.Now().Add(delay + service.jitter())
+}
+
+// jitter returns a random delay up to the configured jitter
+func (service *Service) jitter() time.Duration {
+	if service.config.Jitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(service.config.Jitter)))
+}
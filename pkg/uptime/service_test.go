@@ -0,0 +1,110 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package uptime_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"storj.io/storj/internal/testcontext"
+	"storj.io/storj/internal/testplanet"
+	"storj.io/storj/internal/teststorj"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/transport"
+	"storj.io/storj/pkg/uptime"
+)
+
+func TestUptimeChecks(t *testing.T) {
+	ctx := testcontext.New(t)
+	defer ctx.Cleanup()
+
+	planet, err := testplanet.New(t, 1, 4, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ctx.Check(planet.Shutdown)
+
+	planet.Start(ctx)
+
+	satellite := planet.Satellites[0]
+	offline := &pb.Node{
+		Id:      teststorj.NodeIDFromString("offline"),
+		Type:    pb.NodeType_STORAGE,
+		Address: &pb.NodeAddress{Address: "127.0.0.1:1"},
+	}
+	require.NoError(t, satellite.Overlay.Put(ctx, offline.Id, *offline))
+
+	online := storj.NodeIDList{}
+	for _, storageNode := range planet.StorageNodes {
+		require.NoError(t, satellite.Overlay.Put(ctx, storageNode.ID(), storageNode.Info))
+		online = append(online, storageNode.ID())
+	}
+
+	service := uptime.NewService(satellite.Log.Named("uptime"), satellite.Overlay, satellite.StatDB,
+		transport.NewClient(satellite.Identity), statdb.Config{}, uptime.Config{
+			Interval:    time.Hour,
+			MaxBackoff:  24 * time.Hour,
+			Timeout:     5 * time.Second,
+			Concurrency: 2,
+		})
+
+	uptimeCounts := func(ids storj.NodeIDList) (counts, successes []int64) {
+		for _, id := range ids {
+			stats, err := satellite.StatDB.Get(ctx, id)
+			require.NoError(t, err)
+			counts = append(counts, stats.UptimeCount)
+			successes = append(successes, stats.UptimeSuccessCount)
+		}
+		return counts, successes
+	}
+	all := append(storj.NodeIDList{offline.Id}, online...)
+
+	counts, successes := uptimeCounts(all)
+	require.NoError(t, service.Tick(ctx))
+
+	{ // every node is checked once
+		newCounts, newSuccesses := uptimeCounts(all)
+		for i := range all {
+			assert.Equal(t, counts[i]+1, newCounts[i])
+		}
+		assert.Equal(t, successes[0], newSuccesses[0])
+		for i := 1; i < len(all); i++ {
+			assert.Equal(t, successes[i]+1, newSuccesses[i])
+		}
+		counts, successes = newCounts, newSuccesses
+	}
+
+	{ // nothing is due right after
+		require.NoError(t, service.Tick(ctx))
+		newCounts, _ := uptimeCounts(all)
+		assert.Equal(t, counts, newCounts)
+	}
+
+	{ // a failed node that contacts us is checked again right away, a healthy one is not
+		service.ConnSuccess(ctx, offline)
+		service.ConnSuccess(ctx, &planet.StorageNodes[0].Info)
+		require.NoError(t, service.Tick(ctx))
+
+		newCounts, newSuccesses := uptimeCounts(all)
+		assert.Equal(t, counts[0]+1, newCounts[0])
+		assert.Equal(t, successes[0], newSuccesses[0])
+		assert.Equal(t, counts[1:], newCounts[1:])
+		counts = newCounts
+	}
+
+	{ // nodes removed from the cache are forgotten, so they are new when they come back
+		require.NoError(t, satellite.Overlay.Delete(ctx, offline.Id))
+		require.NoError(t, service.Tick(ctx))
+		require.NoError(t, satellite.Overlay.Put(ctx, offline.Id, *offline))
+		require.NoError(t, service.Tick(ctx))
+
+		newCounts, _ := uptimeCounts(all)
+		assert.Equal(t, counts[0]+1, newCounts[0])
+	}
+}
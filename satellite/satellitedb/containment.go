@@ -0,0 +1,126 @@
+// Copyright (C) 2018 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package satellitedb
+
+import (
+	"context"
+	"database/sql"
+
+	"storj.io/storj/pkg/audit"
+	"storj.io/storj/pkg/storj"
+	"storj.io/storj/pkg/utils"
+	dbx "storj.io/storj/satellite/satellitedb/dbx"
+)
+
+type containment struct {
+	db *dbx.DB
+}
+
+// Get gets the pending audit by node id
+func (containment *containment) Get(ctx context.Context, nodeID storj.NodeID) (_ *audit.PendingAudit, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	pending, err := containment.db.Get_PendingAudits_By_NodeId(ctx, dbx.PendingAudits_NodeId(nodeID.Bytes()))
+	if err == sql.ErrNoRows {
+		return nil, audit.ErrContainedNotFound.New("%v", nodeID)
+	}
+	if err != nil {
+		return nil, audit.ContainError.Wrap(err)
+	}
+
+	return convertDBPending(pending)
+}
+
+// IncrementPending creates a new pending audit entry, or increases its reverify count if it already exists
+func (containment *containment) IncrementPending(ctx context.Context, pendingAudit *audit.PendingAudit) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	tx, err := containment.db.Open(ctx)
+	if err != nil {
+		return audit.ContainError.Wrap(err)
+	}
+
+	existing, err := tx.Get_PendingAudits_By_NodeId(ctx, dbx.PendingAudits_NodeId(pendingAudit.NodeID.Bytes()))
+	switch {
+	case err == sql.ErrNoRows:
+		_, err = tx.Create_PendingAudits(
+			ctx,
+			dbx.PendingAudits_NodeId(pendingAudit.NodeID.Bytes()),
+			dbx.PendingAudits_PieceId(pendingAudit.PieceID),
+			dbx.PendingAudits_PieceNum(int64(pendingAudit.PieceNum)),
+			dbx.PendingAudits_PieceSize(pendingAudit.PieceSize),
+			dbx.PendingAudits_StripeIndex(int64(pendingAudit.StripeIndex)),
+			dbx.PendingAudits_ShareSize(int64(pendingAudit.ShareSize)),
+			dbx.PendingAudits_ExpectedShareHash(pendingAudit.ExpectedShareHash),
+			dbx.PendingAudits_ReverifyCount(int64(pendingAudit.ReverifyCount)),
+			dbx.PendingAudits_Path(pendingAudit.Path),
+		)
+		if err != nil {
+			return audit.ContainError.Wrap(utils.CombineErrors(err, tx.Rollback()))
+		}
+	case err != nil:
+		return audit.ContainError.Wrap(utils.CombineErrors(err, tx.Rollback()))
+	default:
+		updateFields := dbx.PendingAudits_Update_Fields{}
+		updateFields.ReverifyCount = dbx.PendingAudits_ReverifyCount(existing.ReverifyCount + 1)
+		_, err = tx.Update_PendingAudits_By_NodeId(ctx, dbx.PendingAudits_NodeId(existing.NodeId), updateFields)
+		if err != nil {
+			return audit.ContainError.Wrap(utils.CombineErrors(err, tx.Rollback()))
+		}
+	}
+
+	return audit.ContainError.Wrap(tx.Commit())
+}
+
+// Delete removes a pending audit entry, releasing the node from containment
+func (containment *containment) Delete(ctx context.Context, nodeID storj.NodeID) (_ bool, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	deleted, err := containment.db.Delete_PendingAudits_By_NodeId(ctx, dbx.PendingAudits_NodeId(nodeID.Bytes()))
+	return deleted, audit.ContainError.Wrap(err)
+}
+
+// List returns all pending audits
+func (containment *containment) List(ctx context.Context) (_ []*audit.PendingAudit, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	rows, err := containment.db.All_PendingAudits(ctx)
+	if err != nil {
+		return nil, audit.ContainError.Wrap(err)
+	}
+
+	pendingAudits := make([]*audit.PendingAudit, 0, len(rows))
+	for _, row := range rows {
+		pending, err := convertDBPending(row)
+		if err != nil {
+			return nil, err
+		}
+		pendingAudits = append(pendingAudits, pending)
+	}
+	return pendingAudits, nil
+}
+
+func convertDBPending(info *dbx.PendingAudits) (*audit.PendingAudit, error) {
+	if info == nil {
+		return nil, audit.ContainError.New("missing info")
+	}
+
+	nodeID, err := storj.NodeIDFromBytes(info.NodeId)
+	if err != nil {
+		return nil, audit.ContainError.Wrap(err)
+	}
+
+	return &audit.PendingAudit{
+		NodeID:            nodeID,
+		PieceID:           info.PieceId,
+		PieceNum:          int(info.PieceNum),
+		PieceSize:         info.PieceSize,
+		StripeIndex:       int(info.StripeIndex),
+		ShareSize:         int(info.ShareSize),
+		ExpectedShareHash: info.ExpectedShareHash,
+		ReverifyCount:     int(info.ReverifyCount),
+		Path:              info.Path,
+		CreatedAt:         info.CreatedAt,
+	}, nil
+}
@@ -8,14 +8,15 @@ import (
 
 	"storj.io/storj/internal/migrate"
 	"storj.io/storj/pkg/accounting"
+	"storj.io/storj/pkg/audit"
 	"storj.io/storj/pkg/bwagreement"
 	"storj.io/storj/pkg/datarepair/irreparable"
 	"storj.io/storj/pkg/datarepair/queue"
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/statdb"
 	"storj.io/storj/pkg/utils"
 	"storj.io/storj/satellite"
 	dbx "storj.io/storj/satellite/satellitedb/dbx"
-	"storj.io/storj/storage"
 )
 
 var (
@@ -71,7 +72,7 @@ func (db *DB) StatDB() statdb.DB {
 }
 
 // OverlayCache is a getter for overlay cache repository
-func (db *DB) OverlayCache() storage.KeyValueStore {
+func (db *DB) OverlayCache() overlay.DB {
 	return &overlaycache{db: db.db}
 }
 
@@ -90,6 +91,11 @@ func (db *DB) Irreparable() irreparable.DB {
 	return &irreparableDB{db: db.db}
 }
 
+// Containment returns database for storing pending audits of contained nodes
+func (db *DB) Containment() audit.Containment {
+	return &containment{db: db.db}
+}
+
 // CreateTables is a method for creating all tables for database
 func (db *DB) CreateTables() error {
 	return migrate.Create("database", db.db)
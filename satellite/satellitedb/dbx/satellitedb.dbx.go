@@ -327,6 +327,12 @@ CREATE TABLE nodes (
 	uptime_success_count bigint NOT NULL,
 	total_uptime_count bigint NOT NULL,
 	uptime_ratio double precision NOT NULL,
+	state integer NOT NULL,
+	state_updated_at timestamp with time zone NOT NULL,
+	state_override boolean NOT NULL,
+	latency_list bytea NOT NULL,
+	latency_90 bigint NOT NULL,
+	speed_kbps bigint NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	updated_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
@@ -334,9 +340,42 @@ CREATE TABLE nodes (
 CREATE TABLE overlay_cache_nodes (
 	key bytea NOT NULL,
 	value bytea NOT NULL,
+	node_type integer NOT NULL,
+	address text NOT NULL,
+	free_bandwidth bigint NOT NULL,
+	free_disk bigint NOT NULL,
+	audit_success_ratio double precision NOT NULL,
+	audit_success_count bigint NOT NULL,
+	audit_count bigint NOT NULL,
+	uptime_ratio double precision NOT NULL,
+	uptime_success_count bigint NOT NULL,
+	uptime_count bigint NOT NULL,
+	state integer NOT NULL,
+	latency_90 bigint NOT NULL,
+	speed_kbps bigint NOT NULL,
+	selection_key bigint NOT NULL,
+	last_contact timestamp with time zone NOT NULL,
 	PRIMARY KEY ( key ),
 	UNIQUE ( key )
-);`
+);
+CREATE TABLE pending_audits (
+	node_id bytea NOT NULL,
+	piece_id text NOT NULL,
+	piece_num bigint NOT NULL,
+	piece_size bigint NOT NULL,
+	stripe_index bigint NOT NULL,
+	share_size bigint NOT NULL,
+	expected_share_hash bytea NOT NULL,
+	reverify_count bigint NOT NULL,
+	path text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id )
+);
+CREATE INDEX overlay_cache_nodes_free_disk_index ON overlay_cache_nodes ( free_disk );
+CREATE INDEX overlay_cache_nodes_free_bandwidth_index ON overlay_cache_nodes ( free_bandwidth );
+CREATE INDEX overlay_cache_nodes_reputation_index ON overlay_cache_nodes ( audit_success_ratio, uptime_ratio );
+CREATE INDEX overlay_cache_nodes_last_contact_index ON overlay_cache_nodes ( last_contact );
+CREATE INDEX overlay_cache_nodes_selection_key_index ON overlay_cache_nodes ( selection_key );`
 }
 
 func (obj *postgresDB) wrapTx(tx *sql.Tx) txMethods {
@@ -454,6 +493,12 @@ CREATE TABLE nodes (
 	uptime_success_count INTEGER NOT NULL,
 	total_uptime_count INTEGER NOT NULL,
 	uptime_ratio REAL NOT NULL,
+	state INTEGER NOT NULL,
+	state_updated_at TIMESTAMP NOT NULL,
+	state_override INTEGER NOT NULL,
+	latency_list BLOB NOT NULL,
+	latency_90 INTEGER NOT NULL,
+	speed_kbps INTEGER NOT NULL,
 	created_at TIMESTAMP NOT NULL,
 	updated_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( id )
@@ -461,9 +506,42 @@ CREATE TABLE nodes (
 CREATE TABLE overlay_cache_nodes (
 	key BLOB NOT NULL,
 	value BLOB NOT NULL,
+	node_type INTEGER NOT NULL,
+	address TEXT NOT NULL,
+	free_bandwidth INTEGER NOT NULL,
+	free_disk INTEGER NOT NULL,
+	audit_success_ratio REAL NOT NULL,
+	audit_success_count INTEGER NOT NULL,
+	audit_count INTEGER NOT NULL,
+	uptime_ratio REAL NOT NULL,
+	uptime_success_count INTEGER NOT NULL,
+	uptime_count INTEGER NOT NULL,
+	state INTEGER NOT NULL,
+	latency_90 INTEGER NOT NULL,
+	speed_kbps INTEGER NOT NULL,
+	selection_key INTEGER NOT NULL,
+	last_contact TIMESTAMP NOT NULL,
 	PRIMARY KEY ( key ),
 	UNIQUE ( key )
-);`
+);
+CREATE TABLE pending_audits (
+	node_id BLOB NOT NULL,
+	piece_id TEXT NOT NULL,
+	piece_num INTEGER NOT NULL,
+	piece_size INTEGER NOT NULL,
+	stripe_index INTEGER NOT NULL,
+	share_size INTEGER NOT NULL,
+	expected_share_hash BLOB NOT NULL,
+	reverify_count INTEGER NOT NULL,
+	path TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( node_id )
+);
+CREATE INDEX overlay_cache_nodes_free_disk_index ON overlay_cache_nodes ( free_disk );
+CREATE INDEX overlay_cache_nodes_free_bandwidth_index ON overlay_cache_nodes ( free_bandwidth );
+CREATE INDEX overlay_cache_nodes_reputation_index ON overlay_cache_nodes ( audit_success_ratio, uptime_ratio );
+CREATE INDEX overlay_cache_nodes_last_contact_index ON overlay_cache_nodes ( last_contact );
+CREATE INDEX overlay_cache_nodes_selection_key_index ON overlay_cache_nodes ( selection_key );`
 }
 
 func (obj *sqlite3DB) wrapTx(tx *sql.Tx) txMethods {
@@ -1127,6 +1205,12 @@ type Node struct {
 	UptimeSuccessCount int64
 	TotalUptimeCount   int64
 	UptimeRatio        float64
+	State              int
+	StateUpdatedAt     time.Time
+	StateOverride      bool
+	LatencyList        []byte
+	Latency90          int64
+	SpeedKbps          int64
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 }
@@ -1140,227 +1224,871 @@ type Node_Update_Fields struct {
 	UptimeSuccessCount Node_UptimeSuccessCount_Field
 	TotalUptimeCount   Node_TotalUptimeCount_Field
 	UptimeRatio        Node_UptimeRatio_Field
+	State              Node_State_Field
+	StateUpdatedAt     Node_StateUpdatedAt_Field
+	StateOverride      Node_StateOverride_Field
+	LatencyList        Node_LatencyList_Field
+	Latency90          Node_Latency90_Field
+	SpeedKbps          Node_SpeedKbps_Field
+}
+
+type Node_Id_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Node_Id(v []byte) Node_Id_Field {
+	return Node_Id_Field{_set: true, _value: v}
+}
+
+func (f Node_Id_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_Id_Field) _Column() string { return "id" }
+
+type Node_AuditSuccessCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func Node_AuditSuccessCount(v int64) Node_AuditSuccessCount_Field {
+	return Node_AuditSuccessCount_Field{_set: true, _value: v}
+}
+
+func (f Node_AuditSuccessCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_AuditSuccessCount_Field) _Column() string { return "audit_success_count" }
+
+type Node_TotalAuditCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func Node_TotalAuditCount(v int64) Node_TotalAuditCount_Field {
+	return Node_TotalAuditCount_Field{_set: true, _value: v}
+}
+
+func (f Node_TotalAuditCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_TotalAuditCount_Field) _Column() string { return "total_audit_count" }
+
+type Node_AuditSuccessRatio_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func Node_AuditSuccessRatio(v float64) Node_AuditSuccessRatio_Field {
+	return Node_AuditSuccessRatio_Field{_set: true, _value: v}
+}
+
+func (f Node_AuditSuccessRatio_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_AuditSuccessRatio_Field) _Column() string { return "audit_success_ratio" }
+
+type Node_UptimeSuccessCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func Node_UptimeSuccessCount(v int64) Node_UptimeSuccessCount_Field {
+	return Node_UptimeSuccessCount_Field{_set: true, _value: v}
+}
+
+func (f Node_UptimeSuccessCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_UptimeSuccessCount_Field) _Column() string { return "uptime_success_count" }
+
+type Node_TotalUptimeCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func Node_TotalUptimeCount(v int64) Node_TotalUptimeCount_Field {
+	return Node_TotalUptimeCount_Field{_set: true, _value: v}
+}
+
+func (f Node_TotalUptimeCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_TotalUptimeCount_Field) _Column() string { return "total_uptime_count" }
+
+type Node_UptimeRatio_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func Node_UptimeRatio(v float64) Node_UptimeRatio_Field {
+	return Node_UptimeRatio_Field{_set: true, _value: v}
+}
+
+func (f Node_UptimeRatio_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_UptimeRatio_Field) _Column() string { return "uptime_ratio" }
+
+type Node_State_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func Node_State(v int) Node_State_Field {
+	return Node_State_Field{_set: true, _value: v}
+}
+
+func (f Node_State_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_State_Field) _Column() string { return "state" }
+
+type Node_StateUpdatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Node_StateUpdatedAt(v time.Time) Node_StateUpdatedAt_Field {
+	return Node_StateUpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f Node_StateUpdatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_StateUpdatedAt_Field) _Column() string { return "state_updated_at" }
+
+type Node_StateOverride_Field struct {
+	_set   bool
+	_null  bool
+	_value bool
+}
+
+func Node_StateOverride(v bool) Node_StateOverride_Field {
+	return Node_StateOverride_Field{_set: true, _value: v}
+}
+
+func (f Node_StateOverride_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_StateOverride_Field) _Column() string { return "state_override" }
+
+type Node_LatencyList_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func Node_LatencyList(v []byte) Node_LatencyList_Field {
+	return Node_LatencyList_Field{_set: true, _value: v}
+}
+
+func (f Node_LatencyList_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_LatencyList_Field) _Column() string { return "latency_list" }
+
+type Node_Latency90_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func Node_Latency90(v int64) Node_Latency90_Field {
+	return Node_Latency90_Field{_set: true, _value: v}
+}
+
+func (f Node_Latency90_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_Latency90_Field) _Column() string { return "latency_90" }
+
+type Node_SpeedKbps_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func Node_SpeedKbps(v int64) Node_SpeedKbps_Field {
+	return Node_SpeedKbps_Field{_set: true, _value: v}
+}
+
+func (f Node_SpeedKbps_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_SpeedKbps_Field) _Column() string { return "speed_kbps" }
+
+type Node_CreatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Node_CreatedAt(v time.Time) Node_CreatedAt_Field {
+	return Node_CreatedAt_Field{_set: true, _value: v}
+}
+
+func (f Node_CreatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_CreatedAt_Field) _Column() string { return "created_at" }
+
+type Node_UpdatedAt_Field struct {
+	_set   bool
+	_null  bool
+	_value time.Time
+}
+
+func Node_UpdatedAt(v time.Time) Node_UpdatedAt_Field {
+	return Node_UpdatedAt_Field{_set: true, _value: v}
+}
+
+func (f Node_UpdatedAt_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (Node_UpdatedAt_Field) _Column() string { return "updated_at" }
+
+type OverlayCacheNode struct {
+	Key                []byte
+	Value              []byte
+	NodeType           int
+	Address            string
+	FreeBandwidth      int64
+	FreeDisk           int64
+	AuditSuccessRatio  float64
+	AuditSuccessCount  int64
+	AuditCount         int64
+	UptimeRatio        float64
+	UptimeSuccessCount int64
+	UptimeCount        int64
+	State              int
+	Latency90          int64
+	SpeedKbps          int64
+	SelectionKey       int64
+	LastContact        time.Time
+}
+
+func (OverlayCacheNode) _Table() string { return "overlay_cache_nodes" }
+
+type OverlayCacheNode_Update_Fields struct {
+	Value              OverlayCacheNode_Value_Field
+	NodeType           OverlayCacheNode_NodeType_Field
+	Address            OverlayCacheNode_Address_Field
+	FreeBandwidth      OverlayCacheNode_FreeBandwidth_Field
+	FreeDisk           OverlayCacheNode_FreeDisk_Field
+	AuditSuccessRatio  OverlayCacheNode_AuditSuccessRatio_Field
+	AuditSuccessCount  OverlayCacheNode_AuditSuccessCount_Field
+	AuditCount         OverlayCacheNode_AuditCount_Field
+	UptimeRatio        OverlayCacheNode_UptimeRatio_Field
+	UptimeSuccessCount OverlayCacheNode_UptimeSuccessCount_Field
+	UptimeCount        OverlayCacheNode_UptimeCount_Field
+	State              OverlayCacheNode_State_Field
+	Latency90          OverlayCacheNode_Latency90_Field
+	SpeedKbps          OverlayCacheNode_SpeedKbps_Field
+	SelectionKey       OverlayCacheNode_SelectionKey_Field
+	LastContact        OverlayCacheNode_LastContact_Field
+}
+
+type OverlayCacheNode_Key_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func OverlayCacheNode_Key(v []byte) OverlayCacheNode_Key_Field {
+	return OverlayCacheNode_Key_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_Key_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_Key_Field) _Column() string { return "key" }
+
+type OverlayCacheNode_Value_Field struct {
+	_set   bool
+	_null  bool
+	_value []byte
+}
+
+func OverlayCacheNode_Value(v []byte) OverlayCacheNode_Value_Field {
+	return OverlayCacheNode_Value_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_Value_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_Value_Field) _Column() string { return "value" }
+
+type OverlayCacheNode_NodeType_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func OverlayCacheNode_NodeType(v int) OverlayCacheNode_NodeType_Field {
+	return OverlayCacheNode_NodeType_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_NodeType_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_NodeType_Field) _Column() string { return "node_type" }
+
+type OverlayCacheNode_Address_Field struct {
+	_set   bool
+	_null  bool
+	_value string
+}
+
+func OverlayCacheNode_Address(v string) OverlayCacheNode_Address_Field {
+	return OverlayCacheNode_Address_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_Address_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_Address_Field) _Column() string { return "address" }
+
+type OverlayCacheNode_FreeBandwidth_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func OverlayCacheNode_FreeBandwidth(v int64) OverlayCacheNode_FreeBandwidth_Field {
+	return OverlayCacheNode_FreeBandwidth_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_FreeBandwidth_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_FreeBandwidth_Field) _Column() string { return "free_bandwidth" }
+
+type OverlayCacheNode_FreeDisk_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func OverlayCacheNode_FreeDisk(v int64) OverlayCacheNode_FreeDisk_Field {
+	return OverlayCacheNode_FreeDisk_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_FreeDisk_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_FreeDisk_Field) _Column() string { return "free_disk" }
+
+type OverlayCacheNode_AuditSuccessRatio_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func OverlayCacheNode_AuditSuccessRatio(v float64) OverlayCacheNode_AuditSuccessRatio_Field {
+	return OverlayCacheNode_AuditSuccessRatio_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_AuditSuccessRatio_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_AuditSuccessRatio_Field) _Column() string { return "audit_success_ratio" }
+
+type OverlayCacheNode_AuditSuccessCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func OverlayCacheNode_AuditSuccessCount(v int64) OverlayCacheNode_AuditSuccessCount_Field {
+	return OverlayCacheNode_AuditSuccessCount_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_AuditSuccessCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_AuditSuccessCount_Field) _Column() string { return "audit_success_count" }
+
+type OverlayCacheNode_AuditCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func OverlayCacheNode_AuditCount(v int64) OverlayCacheNode_AuditCount_Field {
+	return OverlayCacheNode_AuditCount_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_AuditCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_AuditCount_Field) _Column() string { return "audit_count" }
+
+type OverlayCacheNode_UptimeRatio_Field struct {
+	_set   bool
+	_null  bool
+	_value float64
+}
+
+func OverlayCacheNode_UptimeRatio(v float64) OverlayCacheNode_UptimeRatio_Field {
+	return OverlayCacheNode_UptimeRatio_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_UptimeRatio_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_UptimeRatio_Field) _Column() string { return "uptime_ratio" }
+
+type OverlayCacheNode_UptimeSuccessCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func OverlayCacheNode_UptimeSuccessCount(v int64) OverlayCacheNode_UptimeSuccessCount_Field {
+	return OverlayCacheNode_UptimeSuccessCount_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_UptimeSuccessCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_UptimeSuccessCount_Field) _Column() string { return "uptime_success_count" }
+
+type OverlayCacheNode_UptimeCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func OverlayCacheNode_UptimeCount(v int64) OverlayCacheNode_UptimeCount_Field {
+	return OverlayCacheNode_UptimeCount_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_UptimeCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_UptimeCount_Field) _Column() string { return "uptime_count" }
+
+type OverlayCacheNode_State_Field struct {
+	_set   bool
+	_null  bool
+	_value int
+}
+
+func OverlayCacheNode_State(v int) OverlayCacheNode_State_Field {
+	return OverlayCacheNode_State_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_State_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_State_Field) _Column() string { return "state" }
+
+type OverlayCacheNode_Latency90_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func OverlayCacheNode_Latency90(v int64) OverlayCacheNode_Latency90_Field {
+	return OverlayCacheNode_Latency90_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_Latency90_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
+}
+
+func (OverlayCacheNode_Latency90_Field) _Column() string { return "latency_90" }
+
+type OverlayCacheNode_SpeedKbps_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
+}
+
+func OverlayCacheNode_SpeedKbps(v int64) OverlayCacheNode_SpeedKbps_Field {
+	return OverlayCacheNode_SpeedKbps_Field{_set: true, _value: v}
+}
+
+func (f OverlayCacheNode_SpeedKbps_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
 }
 
-type Node_Id_Field struct {
+func (OverlayCacheNode_SpeedKbps_Field) _Column() string { return "speed_kbps" }
+
+type OverlayCacheNode_SelectionKey_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value int64
 }
 
-func Node_Id(v []byte) Node_Id_Field {
-	return Node_Id_Field{_set: true, _value: v}
+func OverlayCacheNode_SelectionKey(v int64) OverlayCacheNode_SelectionKey_Field {
+	return OverlayCacheNode_SelectionKey_Field{_set: true, _value: v}
 }
 
-func (f Node_Id_Field) value() interface{} {
+func (f OverlayCacheNode_SelectionKey_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_Id_Field) _Column() string { return "id" }
+func (OverlayCacheNode_SelectionKey_Field) _Column() string { return "selection_key" }
 
-type Node_AuditSuccessCount_Field struct {
+type OverlayCacheNode_LastContact_Field struct {
 	_set   bool
 	_null  bool
-	_value int64
+	_value time.Time
 }
 
-func Node_AuditSuccessCount(v int64) Node_AuditSuccessCount_Field {
-	return Node_AuditSuccessCount_Field{_set: true, _value: v}
+func OverlayCacheNode_LastContact(v time.Time) OverlayCacheNode_LastContact_Field {
+	return OverlayCacheNode_LastContact_Field{_set: true, _value: v}
 }
 
-func (f Node_AuditSuccessCount_Field) value() interface{} {
+func (f OverlayCacheNode_LastContact_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_AuditSuccessCount_Field) _Column() string { return "audit_success_count" }
+func (OverlayCacheNode_LastContact_Field) _Column() string { return "last_contact" }
 
-type Node_TotalAuditCount_Field struct {
+type PendingAudits struct {
+	NodeId            []byte
+	PieceId           string
+	PieceNum          int64
+	PieceSize         int64
+	StripeIndex       int64
+	ShareSize         int64
+	ExpectedShareHash []byte
+	ReverifyCount     int64
+	Path              string
+	CreatedAt         time.Time
+}
+
+func (PendingAudits) _Table() string { return "pending_audits" }
+
+type PendingAudits_Update_Fields struct {
+	ReverifyCount PendingAudits_ReverifyCount_Field
+}
+
+type PendingAudits_NodeId_Field struct {
 	_set   bool
 	_null  bool
-	_value int64
+	_value []byte
 }
 
-func Node_TotalAuditCount(v int64) Node_TotalAuditCount_Field {
-	return Node_TotalAuditCount_Field{_set: true, _value: v}
+func PendingAudits_NodeId(v []byte) PendingAudits_NodeId_Field {
+	return PendingAudits_NodeId_Field{_set: true, _value: v}
 }
 
-func (f Node_TotalAuditCount_Field) value() interface{} {
+func (f PendingAudits_NodeId_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_TotalAuditCount_Field) _Column() string { return "total_audit_count" }
+func (PendingAudits_NodeId_Field) _Column() string { return "node_id" }
 
-type Node_AuditSuccessRatio_Field struct {
+type PendingAudits_PieceId_Field struct {
 	_set   bool
 	_null  bool
-	_value float64
+	_value string
 }
 
-func Node_AuditSuccessRatio(v float64) Node_AuditSuccessRatio_Field {
-	return Node_AuditSuccessRatio_Field{_set: true, _value: v}
+func PendingAudits_PieceId(v string) PendingAudits_PieceId_Field {
+	return PendingAudits_PieceId_Field{_set: true, _value: v}
 }
 
-func (f Node_AuditSuccessRatio_Field) value() interface{} {
+func (f PendingAudits_PieceId_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_AuditSuccessRatio_Field) _Column() string { return "audit_success_ratio" }
+func (PendingAudits_PieceId_Field) _Column() string { return "piece_id" }
 
-type Node_UptimeSuccessCount_Field struct {
+type PendingAudits_PieceNum_Field struct {
 	_set   bool
 	_null  bool
 	_value int64
 }
 
-func Node_UptimeSuccessCount(v int64) Node_UptimeSuccessCount_Field {
-	return Node_UptimeSuccessCount_Field{_set: true, _value: v}
+func PendingAudits_PieceNum(v int64) PendingAudits_PieceNum_Field {
+	return PendingAudits_PieceNum_Field{_set: true, _value: v}
 }
 
-func (f Node_UptimeSuccessCount_Field) value() interface{} {
+func (f PendingAudits_PieceNum_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_UptimeSuccessCount_Field) _Column() string { return "uptime_success_count" }
+func (PendingAudits_PieceNum_Field) _Column() string { return "piece_num" }
 
-type Node_TotalUptimeCount_Field struct {
+type PendingAudits_PieceSize_Field struct {
 	_set   bool
 	_null  bool
 	_value int64
 }
 
-func Node_TotalUptimeCount(v int64) Node_TotalUptimeCount_Field {
-	return Node_TotalUptimeCount_Field{_set: true, _value: v}
+func PendingAudits_PieceSize(v int64) PendingAudits_PieceSize_Field {
+	return PendingAudits_PieceSize_Field{_set: true, _value: v}
 }
 
-func (f Node_TotalUptimeCount_Field) value() interface{} {
+func (f PendingAudits_PieceSize_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_TotalUptimeCount_Field) _Column() string { return "total_uptime_count" }
+func (PendingAudits_PieceSize_Field) _Column() string { return "piece_size" }
 
-type Node_UptimeRatio_Field struct {
+type PendingAudits_StripeIndex_Field struct {
 	_set   bool
 	_null  bool
-	_value float64
+	_value int64
 }
 
-func Node_UptimeRatio(v float64) Node_UptimeRatio_Field {
-	return Node_UptimeRatio_Field{_set: true, _value: v}
+func PendingAudits_StripeIndex(v int64) PendingAudits_StripeIndex_Field {
+	return PendingAudits_StripeIndex_Field{_set: true, _value: v}
 }
 
-func (f Node_UptimeRatio_Field) value() interface{} {
+func (f PendingAudits_StripeIndex_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_UptimeRatio_Field) _Column() string { return "uptime_ratio" }
+func (PendingAudits_StripeIndex_Field) _Column() string { return "stripe_index" }
 
-type Node_CreatedAt_Field struct {
+type PendingAudits_ShareSize_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value int64
 }
 
-func Node_CreatedAt(v time.Time) Node_CreatedAt_Field {
-	return Node_CreatedAt_Field{_set: true, _value: v}
+func PendingAudits_ShareSize(v int64) PendingAudits_ShareSize_Field {
+	return PendingAudits_ShareSize_Field{_set: true, _value: v}
 }
 
-func (f Node_CreatedAt_Field) value() interface{} {
+func (f PendingAudits_ShareSize_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_CreatedAt_Field) _Column() string { return "created_at" }
+func (PendingAudits_ShareSize_Field) _Column() string { return "share_size" }
 
-type Node_UpdatedAt_Field struct {
+type PendingAudits_ExpectedShareHash_Field struct {
 	_set   bool
 	_null  bool
-	_value time.Time
+	_value []byte
 }
 
-func Node_UpdatedAt(v time.Time) Node_UpdatedAt_Field {
-	return Node_UpdatedAt_Field{_set: true, _value: v}
+func PendingAudits_ExpectedShareHash(v []byte) PendingAudits_ExpectedShareHash_Field {
+	return PendingAudits_ExpectedShareHash_Field{_set: true, _value: v}
 }
 
-func (f Node_UpdatedAt_Field) value() interface{} {
+func (f PendingAudits_ExpectedShareHash_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (Node_UpdatedAt_Field) _Column() string { return "updated_at" }
+func (PendingAudits_ExpectedShareHash_Field) _Column() string { return "expected_share_hash" }
 
-type OverlayCacheNode struct {
-	Key   []byte
-	Value []byte
+type PendingAudits_ReverifyCount_Field struct {
+	_set   bool
+	_null  bool
+	_value int64
 }
 
-func (OverlayCacheNode) _Table() string { return "overlay_cache_nodes" }
+func PendingAudits_ReverifyCount(v int64) PendingAudits_ReverifyCount_Field {
+	return PendingAudits_ReverifyCount_Field{_set: true, _value: v}
+}
 
-type OverlayCacheNode_Update_Fields struct {
-	Value OverlayCacheNode_Value_Field
+func (f PendingAudits_ReverifyCount_Field) value() interface{} {
+	if !f._set || f._null {
+		return nil
+	}
+	return f._value
 }
 
-type OverlayCacheNode_Key_Field struct {
+func (PendingAudits_ReverifyCount_Field) _Column() string { return "reverify_count" }
+
+type PendingAudits_Path_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value string
 }
 
-func OverlayCacheNode_Key(v []byte) OverlayCacheNode_Key_Field {
-	return OverlayCacheNode_Key_Field{_set: true, _value: v}
+func PendingAudits_Path(v string) PendingAudits_Path_Field {
+	return PendingAudits_Path_Field{_set: true, _value: v}
 }
 
-func (f OverlayCacheNode_Key_Field) value() interface{} {
+func (f PendingAudits_Path_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (OverlayCacheNode_Key_Field) _Column() string { return "key" }
+func (PendingAudits_Path_Field) _Column() string { return "path" }
 
-type OverlayCacheNode_Value_Field struct {
+type PendingAudits_CreatedAt_Field struct {
 	_set   bool
 	_null  bool
-	_value []byte
+	_value time.Time
 }
 
-func OverlayCacheNode_Value(v []byte) OverlayCacheNode_Value_Field {
-	return OverlayCacheNode_Value_Field{_set: true, _value: v}
+func PendingAudits_CreatedAt(v time.Time) PendingAudits_CreatedAt_Field {
+	return PendingAudits_CreatedAt_Field{_set: true, _value: v}
 }
 
-func (f OverlayCacheNode_Value_Field) value() interface{} {
+func (f PendingAudits_CreatedAt_Field) value() interface{} {
 	if !f._set || f._null {
 		return nil
 	}
 	return f._value
 }
 
-func (OverlayCacheNode_Value_Field) _Column() string { return "value" }
+func (PendingAudits_CreatedAt_Field) _Column() string { return "created_at" }
 
 func toUTC(t time.Time) time.Time {
 	return t.UTC()
@@ -1673,7 +2401,13 @@ func (obj *postgresImpl) Create_Node(ctx context.Context,
 	node_audit_success_ratio Node_AuditSuccessRatio_Field,
 	node_uptime_success_count Node_UptimeSuccessCount_Field,
 	node_total_uptime_count Node_TotalUptimeCount_Field,
-	node_uptime_ratio Node_UptimeRatio_Field) (
+	node_uptime_ratio Node_UptimeRatio_Field,
+	node_state Node_State_Field,
+	node_state_updated_at Node_StateUpdatedAt_Field,
+	node_state_override Node_StateOverride_Field,
+	node_latency_list Node_LatencyList_Field,
+	node_latency_90 Node_Latency90_Field,
+	node_speed_kbps Node_SpeedKbps_Field) (
 	node *Node, err error) {
 
 	__now := obj.db.Hooks.Now().UTC()
@@ -1684,16 +2418,22 @@ func (obj *postgresImpl) Create_Node(ctx context.Context,
 	__uptime_success_count_val := node_uptime_success_count.value()
 	__total_uptime_count_val := node_total_uptime_count.value()
 	__uptime_ratio_val := node_uptime_ratio.value()
+	__state_val := node_state.value()
+	__state_updated_at_val := node_state_updated_at.value()
+	__state_override_val := node_state_override.value()
+	__latency_list_val := node_latency_list.value()
+	__latency_90_val := node_latency_90.value()
+	__speed_kbps_val := node_speed_kbps.value()
 	__created_at_val := __now
 	__updated_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO nodes ( id, audit_success_count, total_audit_count, audit_success_ratio, uptime_success_count, total_uptime_count, uptime_ratio, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO nodes ( id, audit_success_count, total_audit_count, audit_success_ratio, uptime_success_count, total_uptime_count, uptime_ratio, state, state_updated_at, state_override, latency_list, latency_90, speed_kbps, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.state, nodes.state_updated_at, nodes.state_override, nodes.latency_list, nodes.latency_90, nodes.speed_kbps, nodes.created_at, nodes.updated_at")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val)
+	obj.logStmt(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __state_val, __state_updated_at_val, __state_override_val, __latency_list_val, __latency_90_val, __speed_kbps_val, __created_at_val, __updated_at_val)
 
 	node = &Node{}
-	err = obj.driver.QueryRow(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt)
+	err = obj.driver.QueryRow(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __state_val, __state_updated_at_val, __state_override_val, __latency_list_val, __latency_90_val, __speed_kbps_val, __created_at_val, __updated_at_val).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.State, &node.StateUpdatedAt, &node.StateOverride, &node.LatencyList, &node.Latency90, &node.SpeedKbps, &node.CreatedAt, &node.UpdatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -1703,18 +2443,48 @@ func (obj *postgresImpl) Create_Node(ctx context.Context,
 
 func (obj *postgresImpl) Create_OverlayCacheNode(ctx context.Context,
 	overlay_cache_node_key OverlayCacheNode_Key_Field,
-	overlay_cache_node_value OverlayCacheNode_Value_Field) (
+	overlay_cache_node_value OverlayCacheNode_Value_Field,
+	overlay_cache_node_node_type OverlayCacheNode_NodeType_Field,
+	overlay_cache_node_address OverlayCacheNode_Address_Field,
+	overlay_cache_node_free_bandwidth OverlayCacheNode_FreeBandwidth_Field,
+	overlay_cache_node_free_disk OverlayCacheNode_FreeDisk_Field,
+	overlay_cache_node_audit_success_ratio OverlayCacheNode_AuditSuccessRatio_Field,
+	overlay_cache_node_audit_success_count OverlayCacheNode_AuditSuccessCount_Field,
+	overlay_cache_node_audit_count OverlayCacheNode_AuditCount_Field,
+	overlay_cache_node_uptime_ratio OverlayCacheNode_UptimeRatio_Field,
+	overlay_cache_node_uptime_success_count OverlayCacheNode_UptimeSuccessCount_Field,
+	overlay_cache_node_uptime_count OverlayCacheNode_UptimeCount_Field,
+	overlay_cache_node_state OverlayCacheNode_State_Field,
+	overlay_cache_node_latency_90 OverlayCacheNode_Latency90_Field,
+	overlay_cache_node_speed_kbps OverlayCacheNode_SpeedKbps_Field,
+	overlay_cache_node_selection_key OverlayCacheNode_SelectionKey_Field,
+	overlay_cache_node_last_contact OverlayCacheNode_LastContact_Field) (
 	overlay_cache_node *OverlayCacheNode, err error) {
 	__key_val := overlay_cache_node_key.value()
 	__value_val := overlay_cache_node_value.value()
-
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO overlay_cache_nodes ( key, value ) VALUES ( ?, ? ) RETURNING overlay_cache_nodes.key, overlay_cache_nodes.value")
+	__node_type_val := overlay_cache_node_node_type.value()
+	__address_val := overlay_cache_node_address.value()
+	__free_bandwidth_val := overlay_cache_node_free_bandwidth.value()
+	__free_disk_val := overlay_cache_node_free_disk.value()
+	__audit_success_ratio_val := overlay_cache_node_audit_success_ratio.value()
+	__audit_success_count_val := overlay_cache_node_audit_success_count.value()
+	__audit_count_val := overlay_cache_node_audit_count.value()
+	__uptime_ratio_val := overlay_cache_node_uptime_ratio.value()
+	__uptime_success_count_val := overlay_cache_node_uptime_success_count.value()
+	__uptime_count_val := overlay_cache_node_uptime_count.value()
+	__state_val := overlay_cache_node_state.value()
+	__latency_90_val := overlay_cache_node_latency_90.value()
+	__speed_kbps_val := overlay_cache_node_speed_kbps.value()
+	__selection_key_val := overlay_cache_node_selection_key.value()
+	__last_contact_val := overlay_cache_node_last_contact.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO overlay_cache_nodes ( key, value, node_type, address, free_bandwidth, free_disk, audit_success_ratio, audit_success_count, audit_count, uptime_ratio, uptime_success_count, uptime_count, state, latency_90, speed_kbps, selection_key, last_contact ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING overlay_cache_nodes.key, overlay_cache_nodes.value, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.audit_count, overlay_cache_nodes.uptime_ratio, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.state, overlay_cache_nodes.latency_90, overlay_cache_nodes.speed_kbps, overlay_cache_nodes.selection_key, overlay_cache_nodes.last_contact")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __key_val, __value_val)
+	obj.logStmt(__stmt, __key_val, __value_val, __node_type_val, __address_val, __free_bandwidth_val, __free_disk_val, __audit_success_ratio_val, __audit_success_count_val, __audit_count_val, __uptime_ratio_val, __uptime_success_count_val, __uptime_count_val, __state_val, __latency_90_val, __speed_kbps_val, __selection_key_val, __last_contact_val)
 
 	overlay_cache_node = &OverlayCacheNode{}
-	err = obj.driver.QueryRow(__stmt, __key_val, __value_val).Scan(&overlay_cache_node.Key, &overlay_cache_node.Value)
+	err = obj.driver.QueryRow(__stmt, __key_val, __value_val, __node_type_val, __address_val, __free_bandwidth_val, __free_disk_val, __audit_success_ratio_val, __audit_success_count_val, __audit_count_val, __uptime_ratio_val, __uptime_success_count_val, __uptime_count_val, __state_val, __latency_90_val, __speed_kbps_val, __selection_key_val, __last_contact_val).Scan(&overlay_cache_node.Key, &overlay_cache_node.Value, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.AuditCount, &overlay_cache_node.UptimeRatio, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.State, &overlay_cache_node.Latency90, &overlay_cache_node.SpeedKbps, &overlay_cache_node.SelectionKey, &overlay_cache_node.LastContact)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -1741,6 +2511,44 @@ func (obj *postgresImpl) Create_Injuredsegment(ctx context.Context,
 
 }
 
+func (obj *postgresImpl) Create_PendingAudits(ctx context.Context,
+	pending_audits_node_id PendingAudits_NodeId_Field,
+	pending_audits_piece_id PendingAudits_PieceId_Field,
+	pending_audits_piece_num PendingAudits_PieceNum_Field,
+	pending_audits_piece_size PendingAudits_PieceSize_Field,
+	pending_audits_stripe_index PendingAudits_StripeIndex_Field,
+	pending_audits_share_size PendingAudits_ShareSize_Field,
+	pending_audits_expected_share_hash PendingAudits_ExpectedShareHash_Field,
+	pending_audits_reverify_count PendingAudits_ReverifyCount_Field,
+	pending_audits_path PendingAudits_Path_Field) (
+	pending_audits *PendingAudits, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__node_id_val := pending_audits_node_id.value()
+	__piece_id_val := pending_audits_piece_id.value()
+	__piece_num_val := pending_audits_piece_num.value()
+	__piece_size_val := pending_audits_piece_size.value()
+	__stripe_index_val := pending_audits_stripe_index.value()
+	__share_size_val := pending_audits_share_size.value()
+	__expected_share_hash_val := pending_audits_expected_share_hash.value()
+	__reverify_count_val := pending_audits_reverify_count.value()
+	__path_val := pending_audits_path.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO pending_audits ( node_id, piece_id, piece_num, piece_size, stripe_index, share_size, expected_share_hash, reverify_count, path, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? ) RETURNING pending_audits.node_id, pending_audits.piece_id, pending_audits.piece_num, pending_audits.piece_size, pending_audits.stripe_index, pending_audits.share_size, pending_audits.expected_share_hash, pending_audits.reverify_count, pending_audits.path, pending_audits.created_at")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __node_id_val, __piece_id_val, __piece_num_val, __piece_size_val, __stripe_index_val, __share_size_val, __expected_share_hash_val, __reverify_count_val, __path_val, __created_at_val)
+
+	pending_audits = &PendingAudits{}
+	err = obj.driver.QueryRow(__stmt, __node_id_val, __piece_id_val, __piece_num_val, __piece_size_val, __stripe_index_val, __share_size_val, __expected_share_hash_val, __reverify_count_val, __path_val, __created_at_val).Scan(&pending_audits.NodeId, &pending_audits.PieceId, &pending_audits.PieceNum, &pending_audits.PieceSize, &pending_audits.StripeIndex, &pending_audits.ShareSize, &pending_audits.ExpectedShareHash, &pending_audits.ReverifyCount, &pending_audits.Path, &pending_audits.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return pending_audits, nil
+
+}
+
 func (obj *postgresImpl) Get_Bwagreement_By_Signature(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field) (
 	bwagreement *Bwagreement, err error) {
@@ -2019,7 +2827,7 @@ func (obj *postgresImpl) Get_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field) (
 	node *Node, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at FROM nodes WHERE nodes.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.state, nodes.state_updated_at, nodes.state_override, nodes.latency_list, nodes.latency_90, nodes.speed_kbps, nodes.created_at, nodes.updated_at FROM nodes WHERE nodes.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, node_id.value())
@@ -2028,7 +2836,7 @@ func (obj *postgresImpl) Get_Node_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.State, &node.StateUpdatedAt, &node.StateOverride, &node.LatencyList, &node.Latency90, &node.SpeedKbps, &node.CreatedAt, &node.UpdatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -2040,7 +2848,7 @@ func (obj *postgresImpl) Get_OverlayCacheNode_By_Key(ctx context.Context,
 	overlay_cache_node_key OverlayCacheNode_Key_Field) (
 	overlay_cache_node *OverlayCacheNode, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value FROM overlay_cache_nodes WHERE overlay_cache_nodes.key = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.audit_count, overlay_cache_nodes.uptime_ratio, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.state, overlay_cache_nodes.latency_90, overlay_cache_nodes.speed_kbps, overlay_cache_nodes.selection_key, overlay_cache_nodes.last_contact FROM overlay_cache_nodes WHERE overlay_cache_nodes.key = ?")
 
 	var __values []interface{}
 	__values = append(__values, overlay_cache_node_key.value())
@@ -2049,7 +2857,7 @@ func (obj *postgresImpl) Get_OverlayCacheNode_By_Key(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	overlay_cache_node = &OverlayCacheNode{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&overlay_cache_node.Key, &overlay_cache_node.Value)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&overlay_cache_node.Key, &overlay_cache_node.Value, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.AuditCount, &overlay_cache_node.UptimeRatio, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.State, &overlay_cache_node.Latency90, &overlay_cache_node.SpeedKbps, &overlay_cache_node.SelectionKey, &overlay_cache_node.LastContact)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -2057,11 +2865,11 @@ func (obj *postgresImpl) Get_OverlayCacheNode_By_Key(ctx context.Context,
 
 }
 
-func (obj *postgresImpl) Limited_OverlayCacheNode(ctx context.Context,
+func (obj *postgresImpl) Limited_OverlayCacheNode_OrderBy_Asc_Key(ctx context.Context,
 	limit int, offset int64) (
 	rows []*OverlayCacheNode, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value FROM overlay_cache_nodes LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.audit_count, overlay_cache_nodes.uptime_ratio, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.state, overlay_cache_nodes.latency_90, overlay_cache_nodes.speed_kbps, overlay_cache_nodes.selection_key, overlay_cache_nodes.last_contact FROM overlay_cache_nodes ORDER BY overlay_cache_nodes.key LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values)
@@ -2079,7 +2887,7 @@ func (obj *postgresImpl) Limited_OverlayCacheNode(ctx context.Context,
 
 	for __rows.Next() {
 		overlay_cache_node := &OverlayCacheNode{}
-		err = __rows.Scan(&overlay_cache_node.Key, &overlay_cache_node.Value)
+		err = __rows.Scan(&overlay_cache_node.Key, &overlay_cache_node.Value, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.AuditCount, &overlay_cache_node.UptimeRatio, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.State, &overlay_cache_node.Latency90, &overlay_cache_node.SpeedKbps, &overlay_cache_node.SelectionKey, &overlay_cache_node.LastContact)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -2092,12 +2900,12 @@ func (obj *postgresImpl) Limited_OverlayCacheNode(ctx context.Context,
 
 }
 
-func (obj *postgresImpl) Limited_OverlayCacheNode_By_Key_GreaterOrEqual(ctx context.Context,
+func (obj *postgresImpl) Limited_OverlayCacheNode_By_Key_GreaterOrEqual_OrderBy_Asc_Key(ctx context.Context,
 	overlay_cache_node_key_greater_or_equal OverlayCacheNode_Key_Field,
 	limit int, offset int64) (
 	rows []*OverlayCacheNode, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value FROM overlay_cache_nodes WHERE overlay_cache_nodes.key >= ? LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.audit_count, overlay_cache_nodes.uptime_ratio, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.state, overlay_cache_nodes.latency_90, overlay_cache_nodes.speed_kbps, overlay_cache_nodes.selection_key, overlay_cache_nodes.last_contact FROM overlay_cache_nodes WHERE overlay_cache_nodes.key >= ? ORDER BY overlay_cache_nodes.key LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values, overlay_cache_node_key_greater_or_equal.value())
@@ -2115,7 +2923,7 @@ func (obj *postgresImpl) Limited_OverlayCacheNode_By_Key_GreaterOrEqual(ctx cont
 
 	for __rows.Next() {
 		overlay_cache_node := &OverlayCacheNode{}
-		err = __rows.Scan(&overlay_cache_node.Key, &overlay_cache_node.Value)
+		err = __rows.Scan(&overlay_cache_node.Key, &overlay_cache_node.Value, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.AuditCount, &overlay_cache_node.UptimeRatio, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.State, &overlay_cache_node.Latency90, &overlay_cache_node.SpeedKbps, &overlay_cache_node.SelectionKey, &overlay_cache_node.LastContact)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -2197,6 +3005,59 @@ func (obj *postgresImpl) Limited_Injuredsegment(ctx context.Context,
 
 }
 
+func (obj *postgresImpl) Get_PendingAudits_By_NodeId(ctx context.Context,
+	pending_audits_node_id PendingAudits_NodeId_Field) (
+	pending_audits *PendingAudits, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT pending_audits.node_id, pending_audits.piece_id, pending_audits.piece_num, pending_audits.piece_size, pending_audits.stripe_index, pending_audits.share_size, pending_audits.expected_share_hash, pending_audits.reverify_count, pending_audits.path, pending_audits.created_at FROM pending_audits WHERE pending_audits.node_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, pending_audits_node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	pending_audits = &PendingAudits{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&pending_audits.NodeId, &pending_audits.PieceId, &pending_audits.PieceNum, &pending_audits.PieceSize, &pending_audits.StripeIndex, &pending_audits.ShareSize, &pending_audits.ExpectedShareHash, &pending_audits.ReverifyCount, &pending_audits.Path, &pending_audits.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return pending_audits, nil
+
+}
+
+func (obj *postgresImpl) All_PendingAudits(ctx context.Context) (
+	rows []*PendingAudits, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT pending_audits.node_id, pending_audits.piece_id, pending_audits.piece_num, pending_audits.piece_size, pending_audits.stripe_index, pending_audits.share_size, pending_audits.expected_share_hash, pending_audits.reverify_count, pending_audits.path, pending_audits.created_at FROM pending_audits")
+
+	var __values []interface{}
+	__values = append(__values)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		pending_audits := &PendingAudits{}
+		err = __rows.Scan(&pending_audits.NodeId, &pending_audits.PieceId, &pending_audits.PieceNum, &pending_audits.PieceSize, &pending_audits.StripeIndex, &pending_audits.ShareSize, &pending_audits.ExpectedShareHash, &pending_audits.ReverifyCount, &pending_audits.Path, &pending_audits.CreatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, pending_audits)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
 func (obj *postgresImpl) Update_Irreparabledb_By_Segmentpath(ctx context.Context,
 	irreparabledb_segmentpath Irreparabledb_Segmentpath_Field,
 	update Irreparabledb_Update_Fields) (
@@ -2370,7 +3231,7 @@ func (obj *postgresImpl) Update_Node_By_Id(ctx context.Context,
 	node *Node, err error) {
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE nodes SET "), __sets, __sqlbundle_Literal(" WHERE nodes.id = ? RETURNING nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE nodes SET "), __sets, __sqlbundle_Literal(" WHERE nodes.id = ? RETURNING nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.state, nodes.state_updated_at, nodes.state_override, nodes.latency_list, nodes.latency_90, nodes.speed_kbps, nodes.created_at, nodes.updated_at")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -2406,6 +3267,36 @@ func (obj *postgresImpl) Update_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_ratio = ?"))
 	}
 
+	if update.State._set {
+		__values = append(__values, update.State.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("state = ?"))
+	}
+
+	if update.StateUpdatedAt._set {
+		__values = append(__values, update.StateUpdatedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("state_updated_at = ?"))
+	}
+
+	if update.StateOverride._set {
+		__values = append(__values, update.StateOverride.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("state_override = ?"))
+	}
+
+	if update.LatencyList._set {
+		__values = append(__values, update.LatencyList.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("latency_list = ?"))
+	}
+
+	if update.Latency90._set {
+		__values = append(__values, update.Latency90.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("latency_90 = ?"))
+	}
+
+	if update.SpeedKbps._set {
+		__values = append(__values, update.SpeedKbps.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("speed_kbps = ?"))
+	}
+
 	__now := obj.db.Hooks.Now().UTC()
 
 	__values = append(__values, __now)
@@ -2420,7 +3311,7 @@ func (obj *postgresImpl) Update_Node_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.State, &node.StateUpdatedAt, &node.StateOverride, &node.LatencyList, &node.Latency90, &node.SpeedKbps, &node.CreatedAt, &node.UpdatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -2436,7 +3327,7 @@ func (obj *postgresImpl) Update_OverlayCacheNode_By_Key(ctx context.Context,
 	overlay_cache_node *OverlayCacheNode, err error) {
 	var __sets = &__sqlbundle_Hole{}
 
-	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE overlay_cache_nodes SET "), __sets, __sqlbundle_Literal(" WHERE overlay_cache_nodes.key = ? RETURNING overlay_cache_nodes.key, overlay_cache_nodes.value")}}
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE overlay_cache_nodes SET "), __sets, __sqlbundle_Literal(" WHERE overlay_cache_nodes.key = ? RETURNING overlay_cache_nodes.key, overlay_cache_nodes.value, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.audit_count, overlay_cache_nodes.uptime_ratio, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.state, overlay_cache_nodes.latency_90, overlay_cache_nodes.speed_kbps, overlay_cache_nodes.selection_key, overlay_cache_nodes.last_contact")}}
 
 	__sets_sql := __sqlbundle_Literals{Join: ", "}
 	var __values []interface{}
@@ -2447,6 +3338,81 @@ func (obj *postgresImpl) Update_OverlayCacheNode_By_Key(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("value = ?"))
 	}
 
+	if update.NodeType._set {
+		__values = append(__values, update.NodeType.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("node_type = ?"))
+	}
+
+	if update.Address._set {
+		__values = append(__values, update.Address.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("address = ?"))
+	}
+
+	if update.FreeBandwidth._set {
+		__values = append(__values, update.FreeBandwidth.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("free_bandwidth = ?"))
+	}
+
+	if update.FreeDisk._set {
+		__values = append(__values, update.FreeDisk.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("free_disk = ?"))
+	}
+
+	if update.AuditSuccessRatio._set {
+		__values = append(__values, update.AuditSuccessRatio.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_success_ratio = ?"))
+	}
+
+	if update.AuditSuccessCount._set {
+		__values = append(__values, update.AuditSuccessCount.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_success_count = ?"))
+	}
+
+	if update.AuditCount._set {
+		__values = append(__values, update.AuditCount.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_count = ?"))
+	}
+
+	if update.UptimeRatio._set {
+		__values = append(__values, update.UptimeRatio.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_ratio = ?"))
+	}
+
+	if update.UptimeSuccessCount._set {
+		__values = append(__values, update.UptimeSuccessCount.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_success_count = ?"))
+	}
+
+	if update.UptimeCount._set {
+		__values = append(__values, update.UptimeCount.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_count = ?"))
+	}
+
+	if update.State._set {
+		__values = append(__values, update.State.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("state = ?"))
+	}
+
+	if update.Latency90._set {
+		__values = append(__values, update.Latency90.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("latency_90 = ?"))
+	}
+
+	if update.SpeedKbps._set {
+		__values = append(__values, update.SpeedKbps.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("speed_kbps = ?"))
+	}
+
+	if update.SelectionKey._set {
+		__values = append(__values, update.SelectionKey.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("selection_key = ?"))
+	}
+
+	if update.LastContact._set {
+		__values = append(__values, update.LastContact.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_contact = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -2460,7 +3426,7 @@ func (obj *postgresImpl) Update_OverlayCacheNode_By_Key(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	overlay_cache_node = &OverlayCacheNode{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&overlay_cache_node.Key, &overlay_cache_node.Value)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&overlay_cache_node.Key, &overlay_cache_node.Value, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.AuditCount, &overlay_cache_node.UptimeRatio, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.State, &overlay_cache_node.Latency90, &overlay_cache_node.SpeedKbps, &overlay_cache_node.SelectionKey, &overlay_cache_node.LastContact)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -2470,6 +3436,46 @@ func (obj *postgresImpl) Update_OverlayCacheNode_By_Key(ctx context.Context,
 	return overlay_cache_node, nil
 }
 
+func (obj *postgresImpl) Update_PendingAudits_By_NodeId(ctx context.Context,
+	pending_audits_node_id PendingAudits_NodeId_Field,
+	update PendingAudits_Update_Fields) (
+	pending_audits *PendingAudits, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE pending_audits SET "), __sets, __sqlbundle_Literal(" WHERE pending_audits.node_id = ? RETURNING pending_audits.node_id, pending_audits.piece_id, pending_audits.piece_num, pending_audits.piece_size, pending_audits.stripe_index, pending_audits.share_size, pending_audits.expected_share_hash, pending_audits.reverify_count, pending_audits.path, pending_audits.created_at")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.ReverifyCount._set {
+		__values = append(__values, update.ReverifyCount.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("reverify_count = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return nil, emptyUpdate()
+	}
+
+	__args = append(__args, pending_audits_node_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	pending_audits = &PendingAudits{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&pending_audits.NodeId, &pending_audits.PieceId, &pending_audits.PieceNum, &pending_audits.PieceSize, &pending_audits.StripeIndex, &pending_audits.ShareSize, &pending_audits.ExpectedShareHash, &pending_audits.ReverifyCount, &pending_audits.Path, &pending_audits.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return pending_audits, nil
+}
+
 func (obj *postgresImpl) Delete_Bwagreement_By_Signature(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field) (
 	deleted bool, err error) {
@@ -2581,7 +3587,33 @@ func (obj *postgresImpl) Delete_Node_By_Id(ctx context.Context,
 	var __embed_stmt = __sqlbundle_Literal("DELETE FROM nodes WHERE nodes.id = ?")
 
 	var __values []interface{}
-	__values = append(__values, node_id.value())
+	__values = append(__values, node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.Exec(__stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
+func (obj *postgresImpl) Delete_OverlayCacheNode_By_Key(ctx context.Context,
+	overlay_cache_node_key OverlayCacheNode_Key_Field) (
+	deleted bool, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM overlay_cache_nodes WHERE overlay_cache_nodes.key = ?")
+
+	var __values []interface{}
+	__values = append(__values, overlay_cache_node_key.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -2600,14 +3632,14 @@ func (obj *postgresImpl) Delete_Node_By_Id(ctx context.Context,
 
 }
 
-func (obj *postgresImpl) Delete_OverlayCacheNode_By_Key(ctx context.Context,
-	overlay_cache_node_key OverlayCacheNode_Key_Field) (
+func (obj *postgresImpl) Delete_Injuredsegment_By_Id(ctx context.Context,
+	injuredsegment_id Injuredsegment_Id_Field) (
 	deleted bool, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("DELETE FROM overlay_cache_nodes WHERE overlay_cache_nodes.key = ?")
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM injuredsegments WHERE injuredsegments.id = ?")
 
 	var __values []interface{}
-	__values = append(__values, overlay_cache_node_key.value())
+	__values = append(__values, injuredsegment_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -2626,14 +3658,14 @@ func (obj *postgresImpl) Delete_OverlayCacheNode_By_Key(ctx context.Context,
 
 }
 
-func (obj *postgresImpl) Delete_Injuredsegment_By_Id(ctx context.Context,
-	injuredsegment_id Injuredsegment_Id_Field) (
+func (obj *postgresImpl) Delete_PendingAudits_By_NodeId(ctx context.Context,
+	pending_audits_node_id PendingAudits_NodeId_Field) (
 	deleted bool, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("DELETE FROM injuredsegments WHERE injuredsegments.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM pending_audits WHERE pending_audits.node_id = ?")
 
 	var __values []interface{}
-	__values = append(__values, injuredsegment_id.value())
+	__values = append(__values, pending_audits_node_id.value())
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, __values...)
@@ -2665,6 +3697,16 @@ func (impl postgresImpl) isConstraintError(err error) (
 func (obj *postgresImpl) deleteAll(ctx context.Context) (count int64, err error) {
 	var __res sql.Result
 	var __count int64
+	__res, err = obj.driver.Exec("DELETE FROM pending_audits;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
 	__res, err = obj.driver.Exec("DELETE FROM overlay_cache_nodes;")
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -2904,7 +3946,13 @@ func (obj *sqlite3Impl) Create_Node(ctx context.Context,
 	node_audit_success_ratio Node_AuditSuccessRatio_Field,
 	node_uptime_success_count Node_UptimeSuccessCount_Field,
 	node_total_uptime_count Node_TotalUptimeCount_Field,
-	node_uptime_ratio Node_UptimeRatio_Field) (
+	node_uptime_ratio Node_UptimeRatio_Field,
+	node_state Node_State_Field,
+	node_state_updated_at Node_StateUpdatedAt_Field,
+	node_state_override Node_StateOverride_Field,
+	node_latency_list Node_LatencyList_Field,
+	node_latency_90 Node_Latency90_Field,
+	node_speed_kbps Node_SpeedKbps_Field) (
 	node *Node, err error) {
 
 	__now := obj.db.Hooks.Now().UTC()
@@ -2915,15 +3963,21 @@ func (obj *sqlite3Impl) Create_Node(ctx context.Context,
 	__uptime_success_count_val := node_uptime_success_count.value()
 	__total_uptime_count_val := node_total_uptime_count.value()
 	__uptime_ratio_val := node_uptime_ratio.value()
+	__state_val := node_state.value()
+	__state_updated_at_val := node_state_updated_at.value()
+	__state_override_val := node_state_override.value()
+	__latency_list_val := node_latency_list.value()
+	__latency_90_val := node_latency_90.value()
+	__speed_kbps_val := node_speed_kbps.value()
 	__created_at_val := __now
 	__updated_at_val := __now
 
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO nodes ( id, audit_success_count, total_audit_count, audit_success_ratio, uptime_success_count, total_uptime_count, uptime_ratio, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ? )")
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO nodes ( id, audit_success_count, total_audit_count, audit_success_ratio, uptime_success_count, total_uptime_count, uptime_ratio, state, state_updated_at, state_override, latency_list, latency_90, speed_kbps, created_at, updated_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val)
+	obj.logStmt(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __state_val, __state_updated_at_val, __state_override_val, __latency_list_val, __latency_90_val, __speed_kbps_val, __created_at_val, __updated_at_val)
 
-	__res, err := obj.driver.Exec(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __created_at_val, __updated_at_val)
+	__res, err := obj.driver.Exec(__stmt, __id_val, __audit_success_count_val, __total_audit_count_val, __audit_success_ratio_val, __uptime_success_count_val, __total_uptime_count_val, __uptime_ratio_val, __state_val, __state_updated_at_val, __state_override_val, __latency_list_val, __latency_90_val, __speed_kbps_val, __created_at_val, __updated_at_val)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -2937,17 +3991,47 @@ func (obj *sqlite3Impl) Create_Node(ctx context.Context,
 
 func (obj *sqlite3Impl) Create_OverlayCacheNode(ctx context.Context,
 	overlay_cache_node_key OverlayCacheNode_Key_Field,
-	overlay_cache_node_value OverlayCacheNode_Value_Field) (
+	overlay_cache_node_value OverlayCacheNode_Value_Field,
+	overlay_cache_node_node_type OverlayCacheNode_NodeType_Field,
+	overlay_cache_node_address OverlayCacheNode_Address_Field,
+	overlay_cache_node_free_bandwidth OverlayCacheNode_FreeBandwidth_Field,
+	overlay_cache_node_free_disk OverlayCacheNode_FreeDisk_Field,
+	overlay_cache_node_audit_success_ratio OverlayCacheNode_AuditSuccessRatio_Field,
+	overlay_cache_node_audit_success_count OverlayCacheNode_AuditSuccessCount_Field,
+	overlay_cache_node_audit_count OverlayCacheNode_AuditCount_Field,
+	overlay_cache_node_uptime_ratio OverlayCacheNode_UptimeRatio_Field,
+	overlay_cache_node_uptime_success_count OverlayCacheNode_UptimeSuccessCount_Field,
+	overlay_cache_node_uptime_count OverlayCacheNode_UptimeCount_Field,
+	overlay_cache_node_state OverlayCacheNode_State_Field,
+	overlay_cache_node_latency_90 OverlayCacheNode_Latency90_Field,
+	overlay_cache_node_speed_kbps OverlayCacheNode_SpeedKbps_Field,
+	overlay_cache_node_selection_key OverlayCacheNode_SelectionKey_Field,
+	overlay_cache_node_last_contact OverlayCacheNode_LastContact_Field) (
 	overlay_cache_node *OverlayCacheNode, err error) {
 	__key_val := overlay_cache_node_key.value()
 	__value_val := overlay_cache_node_value.value()
-
-	var __embed_stmt = __sqlbundle_Literal("INSERT INTO overlay_cache_nodes ( key, value ) VALUES ( ?, ? )")
+	__node_type_val := overlay_cache_node_node_type.value()
+	__address_val := overlay_cache_node_address.value()
+	__free_bandwidth_val := overlay_cache_node_free_bandwidth.value()
+	__free_disk_val := overlay_cache_node_free_disk.value()
+	__audit_success_ratio_val := overlay_cache_node_audit_success_ratio.value()
+	__audit_success_count_val := overlay_cache_node_audit_success_count.value()
+	__audit_count_val := overlay_cache_node_audit_count.value()
+	__uptime_ratio_val := overlay_cache_node_uptime_ratio.value()
+	__uptime_success_count_val := overlay_cache_node_uptime_success_count.value()
+	__uptime_count_val := overlay_cache_node_uptime_count.value()
+	__state_val := overlay_cache_node_state.value()
+	__latency_90_val := overlay_cache_node_latency_90.value()
+	__speed_kbps_val := overlay_cache_node_speed_kbps.value()
+	__selection_key_val := overlay_cache_node_selection_key.value()
+	__last_contact_val := overlay_cache_node_last_contact.value()
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO overlay_cache_nodes ( key, value, node_type, address, free_bandwidth, free_disk, audit_success_ratio, audit_success_count, audit_count, uptime_ratio, uptime_success_count, uptime_count, state, latency_90, speed_kbps, selection_key, last_contact ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
-	obj.logStmt(__stmt, __key_val, __value_val)
+	obj.logStmt(__stmt, __key_val, __value_val, __node_type_val, __address_val, __free_bandwidth_val, __free_disk_val, __audit_success_ratio_val, __audit_success_count_val, __audit_count_val, __uptime_ratio_val, __uptime_success_count_val, __uptime_count_val, __state_val, __latency_90_val, __speed_kbps_val, __selection_key_val, __last_contact_val)
 
-	__res, err := obj.driver.Exec(__stmt, __key_val, __value_val)
+	__res, err := obj.driver.Exec(__stmt, __key_val, __value_val, __node_type_val, __address_val, __free_bandwidth_val, __free_disk_val, __audit_success_ratio_val, __audit_success_count_val, __audit_count_val, __uptime_ratio_val, __uptime_success_count_val, __uptime_count_val, __state_val, __latency_90_val, __speed_kbps_val, __selection_key_val, __last_contact_val)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -2981,6 +4065,47 @@ func (obj *sqlite3Impl) Create_Injuredsegment(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) Create_PendingAudits(ctx context.Context,
+	pending_audits_node_id PendingAudits_NodeId_Field,
+	pending_audits_piece_id PendingAudits_PieceId_Field,
+	pending_audits_piece_num PendingAudits_PieceNum_Field,
+	pending_audits_piece_size PendingAudits_PieceSize_Field,
+	pending_audits_stripe_index PendingAudits_StripeIndex_Field,
+	pending_audits_share_size PendingAudits_ShareSize_Field,
+	pending_audits_expected_share_hash PendingAudits_ExpectedShareHash_Field,
+	pending_audits_reverify_count PendingAudits_ReverifyCount_Field,
+	pending_audits_path PendingAudits_Path_Field) (
+	pending_audits *PendingAudits, err error) {
+
+	__now := obj.db.Hooks.Now().UTC()
+	__node_id_val := pending_audits_node_id.value()
+	__piece_id_val := pending_audits_piece_id.value()
+	__piece_num_val := pending_audits_piece_num.value()
+	__piece_size_val := pending_audits_piece_size.value()
+	__stripe_index_val := pending_audits_stripe_index.value()
+	__share_size_val := pending_audits_share_size.value()
+	__expected_share_hash_val := pending_audits_expected_share_hash.value()
+	__reverify_count_val := pending_audits_reverify_count.value()
+	__path_val := pending_audits_path.value()
+	__created_at_val := __now
+
+	var __embed_stmt = __sqlbundle_Literal("INSERT INTO pending_audits ( node_id, piece_id, piece_num, piece_size, stripe_index, share_size, expected_share_hash, reverify_count, path, created_at ) VALUES ( ?, ?, ?, ?, ?, ?, ?, ?, ?, ? )")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __node_id_val, __piece_id_val, __piece_num_val, __piece_size_val, __stripe_index_val, __share_size_val, __expected_share_hash_val, __reverify_count_val, __path_val, __created_at_val)
+
+	__res, err := obj.driver.Exec(__stmt, __node_id_val, __piece_id_val, __piece_num_val, __piece_size_val, __stripe_index_val, __share_size_val, __expected_share_hash_val, __reverify_count_val, __path_val, __created_at_val)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	__pk, err := __res.LastInsertId()
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return obj.getLastPendingAudits(ctx, __pk)
+
+}
+
 func (obj *sqlite3Impl) Get_Bwagreement_By_Signature(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field) (
 	bwagreement *Bwagreement, err error) {
@@ -3259,7 +4384,7 @@ func (obj *sqlite3Impl) Get_Node_By_Id(ctx context.Context,
 	node_id Node_Id_Field) (
 	node *Node, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at FROM nodes WHERE nodes.id = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.state, nodes.state_updated_at, nodes.state_override, nodes.latency_list, nodes.latency_90, nodes.speed_kbps, nodes.created_at, nodes.updated_at FROM nodes WHERE nodes.id = ?")
 
 	var __values []interface{}
 	__values = append(__values, node_id.value())
@@ -3268,7 +4393,7 @@ func (obj *sqlite3Impl) Get_Node_By_Id(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	node = &Node{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.State, &node.StateUpdatedAt, &node.StateOverride, &node.LatencyList, &node.Latency90, &node.SpeedKbps, &node.CreatedAt, &node.UpdatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -3280,7 +4405,7 @@ func (obj *sqlite3Impl) Get_OverlayCacheNode_By_Key(ctx context.Context,
 	overlay_cache_node_key OverlayCacheNode_Key_Field) (
 	overlay_cache_node *OverlayCacheNode, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value FROM overlay_cache_nodes WHERE overlay_cache_nodes.key = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.audit_count, overlay_cache_nodes.uptime_ratio, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.state, overlay_cache_nodes.latency_90, overlay_cache_nodes.speed_kbps, overlay_cache_nodes.selection_key, overlay_cache_nodes.last_contact FROM overlay_cache_nodes WHERE overlay_cache_nodes.key = ?")
 
 	var __values []interface{}
 	__values = append(__values, overlay_cache_node_key.value())
@@ -3289,7 +4414,7 @@ func (obj *sqlite3Impl) Get_OverlayCacheNode_By_Key(ctx context.Context,
 	obj.logStmt(__stmt, __values...)
 
 	overlay_cache_node = &OverlayCacheNode{}
-	err = obj.driver.QueryRow(__stmt, __values...).Scan(&overlay_cache_node.Key, &overlay_cache_node.Value)
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&overlay_cache_node.Key, &overlay_cache_node.Value, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.AuditCount, &overlay_cache_node.UptimeRatio, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.State, &overlay_cache_node.Latency90, &overlay_cache_node.SpeedKbps, &overlay_cache_node.SelectionKey, &overlay_cache_node.LastContact)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -3297,11 +4422,11 @@ func (obj *sqlite3Impl) Get_OverlayCacheNode_By_Key(ctx context.Context,
 
 }
 
-func (obj *sqlite3Impl) Limited_OverlayCacheNode(ctx context.Context,
+func (obj *sqlite3Impl) Limited_OverlayCacheNode_OrderBy_Asc_Key(ctx context.Context,
 	limit int, offset int64) (
 	rows []*OverlayCacheNode, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value FROM overlay_cache_nodes LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.audit_count, overlay_cache_nodes.uptime_ratio, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.state, overlay_cache_nodes.latency_90, overlay_cache_nodes.speed_kbps, overlay_cache_nodes.selection_key, overlay_cache_nodes.last_contact FROM overlay_cache_nodes ORDER BY overlay_cache_nodes.key LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values)
@@ -3319,7 +4444,7 @@ func (obj *sqlite3Impl) Limited_OverlayCacheNode(ctx context.Context,
 
 	for __rows.Next() {
 		overlay_cache_node := &OverlayCacheNode{}
-		err = __rows.Scan(&overlay_cache_node.Key, &overlay_cache_node.Value)
+		err = __rows.Scan(&overlay_cache_node.Key, &overlay_cache_node.Value, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.AuditCount, &overlay_cache_node.UptimeRatio, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.State, &overlay_cache_node.Latency90, &overlay_cache_node.SpeedKbps, &overlay_cache_node.SelectionKey, &overlay_cache_node.LastContact)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -3332,12 +4457,12 @@ func (obj *sqlite3Impl) Limited_OverlayCacheNode(ctx context.Context,
 
 }
 
-func (obj *sqlite3Impl) Limited_OverlayCacheNode_By_Key_GreaterOrEqual(ctx context.Context,
+func (obj *sqlite3Impl) Limited_OverlayCacheNode_By_Key_GreaterOrEqual_OrderBy_Asc_Key(ctx context.Context,
 	overlay_cache_node_key_greater_or_equal OverlayCacheNode_Key_Field,
 	limit int, offset int64) (
 	rows []*OverlayCacheNode, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value FROM overlay_cache_nodes WHERE overlay_cache_nodes.key >= ? LIMIT ? OFFSET ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.audit_count, overlay_cache_nodes.uptime_ratio, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.state, overlay_cache_nodes.latency_90, overlay_cache_nodes.speed_kbps, overlay_cache_nodes.selection_key, overlay_cache_nodes.last_contact FROM overlay_cache_nodes WHERE overlay_cache_nodes.key >= ? ORDER BY overlay_cache_nodes.key LIMIT ? OFFSET ?")
 
 	var __values []interface{}
 	__values = append(__values, overlay_cache_node_key_greater_or_equal.value())
@@ -3355,7 +4480,7 @@ func (obj *sqlite3Impl) Limited_OverlayCacheNode_By_Key_GreaterOrEqual(ctx conte
 
 	for __rows.Next() {
 		overlay_cache_node := &OverlayCacheNode{}
-		err = __rows.Scan(&overlay_cache_node.Key, &overlay_cache_node.Value)
+		err = __rows.Scan(&overlay_cache_node.Key, &overlay_cache_node.Value, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.AuditCount, &overlay_cache_node.UptimeRatio, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.State, &overlay_cache_node.Latency90, &overlay_cache_node.SpeedKbps, &overlay_cache_node.SelectionKey, &overlay_cache_node.LastContact)
 		if err != nil {
 			return nil, obj.makeErr(err)
 		}
@@ -3437,6 +4562,59 @@ func (obj *sqlite3Impl) Limited_Injuredsegment(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) Get_PendingAudits_By_NodeId(ctx context.Context,
+	pending_audits_node_id PendingAudits_NodeId_Field) (
+	pending_audits *PendingAudits, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT pending_audits.node_id, pending_audits.piece_id, pending_audits.piece_num, pending_audits.piece_size, pending_audits.stripe_index, pending_audits.share_size, pending_audits.expected_share_hash, pending_audits.reverify_count, pending_audits.path, pending_audits.created_at FROM pending_audits WHERE pending_audits.node_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, pending_audits_node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	pending_audits = &PendingAudits{}
+	err = obj.driver.QueryRow(__stmt, __values...).Scan(&pending_audits.NodeId, &pending_audits.PieceId, &pending_audits.PieceNum, &pending_audits.PieceSize, &pending_audits.StripeIndex, &pending_audits.ShareSize, &pending_audits.ExpectedShareHash, &pending_audits.ReverifyCount, &pending_audits.Path, &pending_audits.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return pending_audits, nil
+
+}
+
+func (obj *sqlite3Impl) All_PendingAudits(ctx context.Context) (
+	rows []*PendingAudits, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT pending_audits.node_id, pending_audits.piece_id, pending_audits.piece_num, pending_audits.piece_size, pending_audits.stripe_index, pending_audits.share_size, pending_audits.expected_share_hash, pending_audits.reverify_count, pending_audits.path, pending_audits.created_at FROM pending_audits")
+
+	var __values []interface{}
+	__values = append(__values)
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__rows, err := obj.driver.Query(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	defer __rows.Close()
+
+	for __rows.Next() {
+		pending_audits := &PendingAudits{}
+		err = __rows.Scan(&pending_audits.NodeId, &pending_audits.PieceId, &pending_audits.PieceNum, &pending_audits.PieceSize, &pending_audits.StripeIndex, &pending_audits.ShareSize, &pending_audits.ExpectedShareHash, &pending_audits.ReverifyCount, &pending_audits.Path, &pending_audits.CreatedAt)
+		if err != nil {
+			return nil, obj.makeErr(err)
+		}
+		rows = append(rows, pending_audits)
+	}
+	if err := __rows.Err(); err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return rows, nil
+
+}
+
 func (obj *sqlite3Impl) Update_Irreparabledb_By_Segmentpath(ctx context.Context,
 	irreparabledb_segmentpath Irreparabledb_Segmentpath_Field,
 	update Irreparabledb_Update_Fields) (
@@ -3686,6 +4864,36 @@ func (obj *sqlite3Impl) Update_Node_By_Id(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_ratio = ?"))
 	}
 
+	if update.State._set {
+		__values = append(__values, update.State.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("state = ?"))
+	}
+
+	if update.StateUpdatedAt._set {
+		__values = append(__values, update.StateUpdatedAt.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("state_updated_at = ?"))
+	}
+
+	if update.StateOverride._set {
+		__values = append(__values, update.StateOverride.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("state_override = ?"))
+	}
+
+	if update.LatencyList._set {
+		__values = append(__values, update.LatencyList.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("latency_list = ?"))
+	}
+
+	if update.Latency90._set {
+		__values = append(__values, update.Latency90.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("latency_90 = ?"))
+	}
+
+	if update.SpeedKbps._set {
+		__values = append(__values, update.SpeedKbps.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("speed_kbps = ?"))
+	}
+
 	__now := obj.db.Hooks.Now().UTC()
 
 	__values = append(__values, __now)
@@ -3705,12 +4913,12 @@ func (obj *sqlite3Impl) Update_Node_By_Id(ctx context.Context,
 		return nil, obj.makeErr(err)
 	}
 
-	var __embed_stmt_get = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at FROM nodes WHERE nodes.id = ?")
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.state, nodes.state_updated_at, nodes.state_override, nodes.latency_list, nodes.latency_90, nodes.speed_kbps, nodes.created_at, nodes.updated_at FROM nodes WHERE nodes.id = ?")
 
 	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
 	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
 
-	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt)
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.State, &node.StateUpdatedAt, &node.StateOverride, &node.LatencyList, &node.Latency90, &node.SpeedKbps, &node.CreatedAt, &node.UpdatedAt)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -3737,6 +4945,81 @@ func (obj *sqlite3Impl) Update_OverlayCacheNode_By_Key(ctx context.Context,
 		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("value = ?"))
 	}
 
+	if update.NodeType._set {
+		__values = append(__values, update.NodeType.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("node_type = ?"))
+	}
+
+	if update.Address._set {
+		__values = append(__values, update.Address.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("address = ?"))
+	}
+
+	if update.FreeBandwidth._set {
+		__values = append(__values, update.FreeBandwidth.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("free_bandwidth = ?"))
+	}
+
+	if update.FreeDisk._set {
+		__values = append(__values, update.FreeDisk.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("free_disk = ?"))
+	}
+
+	if update.AuditSuccessRatio._set {
+		__values = append(__values, update.AuditSuccessRatio.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_success_ratio = ?"))
+	}
+
+	if update.AuditSuccessCount._set {
+		__values = append(__values, update.AuditSuccessCount.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_success_count = ?"))
+	}
+
+	if update.AuditCount._set {
+		__values = append(__values, update.AuditCount.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("audit_count = ?"))
+	}
+
+	if update.UptimeRatio._set {
+		__values = append(__values, update.UptimeRatio.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_ratio = ?"))
+	}
+
+	if update.UptimeSuccessCount._set {
+		__values = append(__values, update.UptimeSuccessCount.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_success_count = ?"))
+	}
+
+	if update.UptimeCount._set {
+		__values = append(__values, update.UptimeCount.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("uptime_count = ?"))
+	}
+
+	if update.State._set {
+		__values = append(__values, update.State.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("state = ?"))
+	}
+
+	if update.Latency90._set {
+		__values = append(__values, update.Latency90.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("latency_90 = ?"))
+	}
+
+	if update.SpeedKbps._set {
+		__values = append(__values, update.SpeedKbps.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("speed_kbps = ?"))
+	}
+
+	if update.SelectionKey._set {
+		__values = append(__values, update.SelectionKey.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("selection_key = ?"))
+	}
+
+	if update.LastContact._set {
+		__values = append(__values, update.LastContact.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("last_contact = ?"))
+	}
+
 	if len(__sets_sql.SQLs) == 0 {
 		return nil, emptyUpdate()
 	}
@@ -3755,12 +5038,12 @@ func (obj *sqlite3Impl) Update_OverlayCacheNode_By_Key(ctx context.Context,
 		return nil, obj.makeErr(err)
 	}
 
-	var __embed_stmt_get = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value FROM overlay_cache_nodes WHERE overlay_cache_nodes.key = ?")
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.audit_count, overlay_cache_nodes.uptime_ratio, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.state, overlay_cache_nodes.latency_90, overlay_cache_nodes.speed_kbps, overlay_cache_nodes.selection_key, overlay_cache_nodes.last_contact FROM overlay_cache_nodes WHERE overlay_cache_nodes.key = ?")
 
 	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
 	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
 
-	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&overlay_cache_node.Key, &overlay_cache_node.Value)
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&overlay_cache_node.Key, &overlay_cache_node.Value, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.AuditCount, &overlay_cache_node.UptimeRatio, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.State, &overlay_cache_node.Latency90, &overlay_cache_node.SpeedKbps, &overlay_cache_node.SelectionKey, &overlay_cache_node.LastContact)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
@@ -3770,6 +5053,56 @@ func (obj *sqlite3Impl) Update_OverlayCacheNode_By_Key(ctx context.Context,
 	return overlay_cache_node, nil
 }
 
+func (obj *sqlite3Impl) Update_PendingAudits_By_NodeId(ctx context.Context,
+	pending_audits_node_id PendingAudits_NodeId_Field,
+	update PendingAudits_Update_Fields) (
+	pending_audits *PendingAudits, err error) {
+	var __sets = &__sqlbundle_Hole{}
+
+	var __embed_stmt = __sqlbundle_Literals{Join: "", SQLs: []__sqlbundle_SQL{__sqlbundle_Literal("UPDATE pending_audits SET "), __sets, __sqlbundle_Literal(" WHERE pending_audits.node_id = ?")}}
+
+	__sets_sql := __sqlbundle_Literals{Join: ", "}
+	var __values []interface{}
+	var __args []interface{}
+
+	if update.ReverifyCount._set {
+		__values = append(__values, update.ReverifyCount.value())
+		__sets_sql.SQLs = append(__sets_sql.SQLs, __sqlbundle_Literal("reverify_count = ?"))
+	}
+
+	if len(__sets_sql.SQLs) == 0 {
+		return nil, emptyUpdate()
+	}
+
+	__args = append(__args, pending_audits_node_id.value())
+
+	__values = append(__values, __args...)
+	__sets.SQL = __sets_sql
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	pending_audits = &PendingAudits{}
+	_, err = obj.driver.Exec(__stmt, __values...)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+
+	var __embed_stmt_get = __sqlbundle_Literal("SELECT pending_audits.node_id, pending_audits.piece_id, pending_audits.piece_num, pending_audits.piece_size, pending_audits.stripe_index, pending_audits.share_size, pending_audits.expected_share_hash, pending_audits.reverify_count, pending_audits.path, pending_audits.created_at FROM pending_audits WHERE pending_audits.node_id = ?")
+
+	var __stmt_get = __sqlbundle_Render(obj.dialect, __embed_stmt_get)
+	obj.logStmt("(IMPLIED) "+__stmt_get, __args...)
+
+	err = obj.driver.QueryRow(__stmt_get, __args...).Scan(&pending_audits.NodeId, &pending_audits.PieceId, &pending_audits.PieceNum, &pending_audits.PieceSize, &pending_audits.StripeIndex, &pending_audits.ShareSize, &pending_audits.ExpectedShareHash, &pending_audits.ReverifyCount, &pending_audits.Path, &pending_audits.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return pending_audits, nil
+}
+
 func (obj *sqlite3Impl) Delete_Bwagreement_By_Signature(ctx context.Context,
 	bwagreement_signature Bwagreement_Signature_Field) (
 	deleted bool, err error) {
@@ -3952,6 +5285,32 @@ func (obj *sqlite3Impl) Delete_Injuredsegment_By_Id(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) Delete_PendingAudits_By_NodeId(ctx context.Context,
+	pending_audits_node_id PendingAudits_NodeId_Field) (
+	deleted bool, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("DELETE FROM pending_audits WHERE pending_audits.node_id = ?")
+
+	var __values []interface{}
+	__values = append(__values, pending_audits_node_id.value())
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, __values...)
+
+	__res, err := obj.driver.Exec(__stmt, __values...)
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	__count, err := __res.RowsAffected()
+	if err != nil {
+		return false, obj.makeErr(err)
+	}
+
+	return __count > 0, nil
+
+}
+
 func (obj *sqlite3Impl) getLastBwagreement(ctx context.Context,
 	pk int64) (
 	bwagreement *Bwagreement, err error) {
@@ -4046,13 +5405,13 @@ func (obj *sqlite3Impl) getLastNode(ctx context.Context,
 	pk int64) (
 	node *Node, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.created_at, nodes.updated_at FROM nodes WHERE _rowid_ = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT nodes.id, nodes.audit_success_count, nodes.total_audit_count, nodes.audit_success_ratio, nodes.uptime_success_count, nodes.total_uptime_count, nodes.uptime_ratio, nodes.state, nodes.state_updated_at, nodes.state_override, nodes.latency_list, nodes.latency_90, nodes.speed_kbps, nodes.created_at, nodes.updated_at FROM nodes WHERE _rowid_ = ?")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, pk)
 
 	node = &Node{}
-	err = obj.driver.QueryRow(__stmt, pk).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.CreatedAt, &node.UpdatedAt)
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&node.Id, &node.AuditSuccessCount, &node.TotalAuditCount, &node.AuditSuccessRatio, &node.UptimeSuccessCount, &node.TotalUptimeCount, &node.UptimeRatio, &node.State, &node.StateUpdatedAt, &node.StateOverride, &node.LatencyList, &node.Latency90, &node.SpeedKbps, &node.CreatedAt, &node.UpdatedAt)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -4064,13 +5423,13 @@ func (obj *sqlite3Impl) getLastOverlayCacheNode(ctx context.Context,
 	pk int64) (
 	overlay_cache_node *OverlayCacheNode, err error) {
 
-	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value FROM overlay_cache_nodes WHERE _rowid_ = ?")
+	var __embed_stmt = __sqlbundle_Literal("SELECT overlay_cache_nodes.key, overlay_cache_nodes.value, overlay_cache_nodes.node_type, overlay_cache_nodes.address, overlay_cache_nodes.free_bandwidth, overlay_cache_nodes.free_disk, overlay_cache_nodes.audit_success_ratio, overlay_cache_nodes.audit_success_count, overlay_cache_nodes.audit_count, overlay_cache_nodes.uptime_ratio, overlay_cache_nodes.uptime_success_count, overlay_cache_nodes.uptime_count, overlay_cache_nodes.state, overlay_cache_nodes.latency_90, overlay_cache_nodes.speed_kbps, overlay_cache_nodes.selection_key, overlay_cache_nodes.last_contact FROM overlay_cache_nodes WHERE _rowid_ = ?")
 
 	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
 	obj.logStmt(__stmt, pk)
 
 	overlay_cache_node = &OverlayCacheNode{}
-	err = obj.driver.QueryRow(__stmt, pk).Scan(&overlay_cache_node.Key, &overlay_cache_node.Value)
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&overlay_cache_node.Key, &overlay_cache_node.Value, &overlay_cache_node.NodeType, &overlay_cache_node.Address, &overlay_cache_node.FreeBandwidth, &overlay_cache_node.FreeDisk, &overlay_cache_node.AuditSuccessRatio, &overlay_cache_node.AuditSuccessCount, &overlay_cache_node.AuditCount, &overlay_cache_node.UptimeRatio, &overlay_cache_node.UptimeSuccessCount, &overlay_cache_node.UptimeCount, &overlay_cache_node.State, &overlay_cache_node.Latency90, &overlay_cache_node.SpeedKbps, &overlay_cache_node.SelectionKey, &overlay_cache_node.LastContact)
 	if err != nil {
 		return nil, obj.makeErr(err)
 	}
@@ -4096,6 +5455,24 @@ func (obj *sqlite3Impl) getLastInjuredsegment(ctx context.Context,
 
 }
 
+func (obj *sqlite3Impl) getLastPendingAudits(ctx context.Context,
+	pk int64) (
+	pending_audits *PendingAudits, err error) {
+
+	var __embed_stmt = __sqlbundle_Literal("SELECT pending_audits.node_id, pending_audits.piece_id, pending_audits.piece_num, pending_audits.piece_size, pending_audits.stripe_index, pending_audits.share_size, pending_audits.expected_share_hash, pending_audits.reverify_count, pending_audits.path, pending_audits.created_at FROM pending_audits WHERE _rowid_ = ?")
+
+	var __stmt = __sqlbundle_Render(obj.dialect, __embed_stmt)
+	obj.logStmt(__stmt, pk)
+
+	pending_audits = &PendingAudits{}
+	err = obj.driver.QueryRow(__stmt, pk).Scan(&pending_audits.NodeId, &pending_audits.PieceId, &pending_audits.PieceNum, &pending_audits.PieceSize, &pending_audits.StripeIndex, &pending_audits.ShareSize, &pending_audits.ExpectedShareHash, &pending_audits.ReverifyCount, &pending_audits.Path, &pending_audits.CreatedAt)
+	if err != nil {
+		return nil, obj.makeErr(err)
+	}
+	return pending_audits, nil
+
+}
+
 func (impl sqlite3Impl) isConstraintError(err error) (
 	constraint string, ok bool) {
 	if e, ok := err.(sqlite3.Error); ok {
@@ -4114,6 +5491,16 @@ func (impl sqlite3Impl) isConstraintError(err error) (
 func (obj *sqlite3Impl) deleteAll(ctx context.Context) (count int64, err error) {
 	var __res sql.Result
 	var __count int64
+	__res, err = obj.driver.Exec("DELETE FROM pending_audits;")
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+
+	__count, err = __res.RowsAffected()
+	if err != nil {
+		return 0, obj.makeErr(err)
+	}
+	count += __count
 	__res, err = obj.driver.Exec("DELETE FROM overlay_cache_nodes;")
 	if err != nil {
 		return 0, obj.makeErr(err)
@@ -4280,6 +5667,15 @@ func (rx *Rx) All_Bwagreement_By_CreatedAt_Greater(ctx context.Context,
 	return tx.All_Bwagreement_By_CreatedAt_Greater(ctx, bwagreement_created_at_greater)
 }
 
+func (rx *Rx) All_PendingAudits(ctx context.Context) (
+	rows []*PendingAudits, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.All_PendingAudits(ctx)
+}
+
 func (rx *Rx) Create_AccountingRaw(ctx context.Context,
 	accounting_raw_node_id AccountingRaw_NodeId_Field,
 	accounting_raw_interval_end_time AccountingRaw_IntervalEndTime_Field,
@@ -4366,25 +5762,65 @@ func (rx *Rx) Create_Node(ctx context.Context,
 	node_audit_success_ratio Node_AuditSuccessRatio_Field,
 	node_uptime_success_count Node_UptimeSuccessCount_Field,
 	node_total_uptime_count Node_TotalUptimeCount_Field,
-	node_uptime_ratio Node_UptimeRatio_Field) (
+	node_uptime_ratio Node_UptimeRatio_Field,
+	node_state Node_State_Field,
+	node_state_updated_at Node_StateUpdatedAt_Field,
+	node_state_override Node_StateOverride_Field,
+	node_latency_list Node_LatencyList_Field,
+	node_latency_90 Node_Latency90_Field,
+	node_speed_kbps Node_SpeedKbps_Field) (
 	node *Node, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Create_Node(ctx, node_id, node_audit_success_count, node_total_audit_count, node_audit_success_ratio, node_uptime_success_count, node_total_uptime_count, node_uptime_ratio)
+	return tx.Create_Node(ctx, node_id, node_audit_success_count, node_total_audit_count, node_audit_success_ratio, node_uptime_success_count, node_total_uptime_count, node_uptime_ratio, node_state, node_state_updated_at, node_state_override, node_latency_list, node_latency_90, node_speed_kbps)
 
 }
 
 func (rx *Rx) Create_OverlayCacheNode(ctx context.Context,
 	overlay_cache_node_key OverlayCacheNode_Key_Field,
-	overlay_cache_node_value OverlayCacheNode_Value_Field) (
+	overlay_cache_node_value OverlayCacheNode_Value_Field,
+	overlay_cache_node_node_type OverlayCacheNode_NodeType_Field,
+	overlay_cache_node_address OverlayCacheNode_Address_Field,
+	overlay_cache_node_free_bandwidth OverlayCacheNode_FreeBandwidth_Field,
+	overlay_cache_node_free_disk OverlayCacheNode_FreeDisk_Field,
+	overlay_cache_node_audit_success_ratio OverlayCacheNode_AuditSuccessRatio_Field,
+	overlay_cache_node_audit_success_count OverlayCacheNode_AuditSuccessCount_Field,
+	overlay_cache_node_audit_count OverlayCacheNode_AuditCount_Field,
+	overlay_cache_node_uptime_ratio OverlayCacheNode_UptimeRatio_Field,
+	overlay_cache_node_uptime_success_count OverlayCacheNode_UptimeSuccessCount_Field,
+	overlay_cache_node_uptime_count OverlayCacheNode_UptimeCount_Field,
+	overlay_cache_node_state OverlayCacheNode_State_Field,
+	overlay_cache_node_latency_90 OverlayCacheNode_Latency90_Field,
+	overlay_cache_node_speed_kbps OverlayCacheNode_SpeedKbps_Field,
+	overlay_cache_node_selection_key OverlayCacheNode_SelectionKey_Field,
+	overlay_cache_node_last_contact OverlayCacheNode_LastContact_Field) (
 	overlay_cache_node *OverlayCacheNode, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Create_OverlayCacheNode(ctx, overlay_cache_node_key, overlay_cache_node_value)
+	return tx.Create_OverlayCacheNode(ctx, overlay_cache_node_key, overlay_cache_node_value, overlay_cache_node_node_type, overlay_cache_node_address, overlay_cache_node_free_bandwidth, overlay_cache_node_free_disk, overlay_cache_node_audit_success_ratio, overlay_cache_node_audit_success_count, overlay_cache_node_audit_count, overlay_cache_node_uptime_ratio, overlay_cache_node_uptime_success_count, overlay_cache_node_uptime_count, overlay_cache_node_state, overlay_cache_node_latency_90, overlay_cache_node_speed_kbps, overlay_cache_node_selection_key, overlay_cache_node_last_contact)
+
+}
+
+func (rx *Rx) Create_PendingAudits(ctx context.Context,
+	pending_audits_node_id PendingAudits_NodeId_Field,
+	pending_audits_piece_id PendingAudits_PieceId_Field,
+	pending_audits_piece_num PendingAudits_PieceNum_Field,
+	pending_audits_piece_size PendingAudits_PieceSize_Field,
+	pending_audits_stripe_index PendingAudits_StripeIndex_Field,
+	pending_audits_share_size PendingAudits_ShareSize_Field,
+	pending_audits_expected_share_hash PendingAudits_ExpectedShareHash_Field,
+	pending_audits_reverify_count PendingAudits_ReverifyCount_Field,
+	pending_audits_path PendingAudits_Path_Field) (
+	pending_audits *PendingAudits, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Create_PendingAudits(ctx, pending_audits_node_id, pending_audits_piece_id, pending_audits_piece_num, pending_audits_piece_size, pending_audits_stripe_index, pending_audits_share_size, pending_audits_expected_share_hash, pending_audits_reverify_count, pending_audits_path)
 
 }
 
@@ -4458,6 +5894,16 @@ func (rx *Rx) Delete_OverlayCacheNode_By_Key(ctx context.Context,
 	return tx.Delete_OverlayCacheNode_By_Key(ctx, overlay_cache_node_key)
 }
 
+func (rx *Rx) Delete_PendingAudits_By_NodeId(ctx context.Context,
+	pending_audits_node_id PendingAudits_NodeId_Field) (
+	deleted bool, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Delete_PendingAudits_By_NodeId(ctx, pending_audits_node_id)
+}
+
 func (rx *Rx) Find_AccountingTimestamps_Value_By_Name(ctx context.Context,
 	accounting_timestamps_name AccountingTimestamps_Name_Field) (
 	row *Value_Row, err error) {
@@ -4537,6 +5983,16 @@ func (rx *Rx) Get_OverlayCacheNode_By_Key(ctx context.Context,
 	return tx.Get_OverlayCacheNode_By_Key(ctx, overlay_cache_node_key)
 }
 
+func (rx *Rx) Get_PendingAudits_By_NodeId(ctx context.Context,
+	pending_audits_node_id PendingAudits_NodeId_Field) (
+	pending_audits *PendingAudits, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Get_PendingAudits_By_NodeId(ctx, pending_audits_node_id)
+}
+
 func (rx *Rx) Limited_Bwagreement(ctx context.Context,
 	limit int, offset int64) (
 	rows []*Bwagreement, err error) {
@@ -4557,25 +6013,25 @@ func (rx *Rx) Limited_Injuredsegment(ctx context.Context,
 	return tx.Limited_Injuredsegment(ctx, limit, offset)
 }
 
-func (rx *Rx) Limited_OverlayCacheNode(ctx context.Context,
+func (rx *Rx) Limited_OverlayCacheNode_By_Key_GreaterOrEqual_OrderBy_Asc_Key(ctx context.Context,
+	overlay_cache_node_key_greater_or_equal OverlayCacheNode_Key_Field,
 	limit int, offset int64) (
 	rows []*OverlayCacheNode, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Limited_OverlayCacheNode(ctx, limit, offset)
+	return tx.Limited_OverlayCacheNode_By_Key_GreaterOrEqual_OrderBy_Asc_Key(ctx, overlay_cache_node_key_greater_or_equal, limit, offset)
 }
 
-func (rx *Rx) Limited_OverlayCacheNode_By_Key_GreaterOrEqual(ctx context.Context,
-	overlay_cache_node_key_greater_or_equal OverlayCacheNode_Key_Field,
+func (rx *Rx) Limited_OverlayCacheNode_OrderBy_Asc_Key(ctx context.Context,
 	limit int, offset int64) (
 	rows []*OverlayCacheNode, err error) {
 	var tx *Tx
 	if tx, err = rx.getTx(ctx); err != nil {
 		return
 	}
-	return tx.Limited_OverlayCacheNode_By_Key_GreaterOrEqual(ctx, overlay_cache_node_key_greater_or_equal, limit, offset)
+	return tx.Limited_OverlayCacheNode_OrderBy_Asc_Key(ctx, limit, offset)
 }
 
 func (rx *Rx) Update_AccountingRaw_By_Id(ctx context.Context,
@@ -4644,6 +6100,17 @@ func (rx *Rx) Update_OverlayCacheNode_By_Key(ctx context.Context,
 	return tx.Update_OverlayCacheNode_By_Key(ctx, overlay_cache_node_key, update)
 }
 
+func (rx *Rx) Update_PendingAudits_By_NodeId(ctx context.Context,
+	pending_audits_node_id PendingAudits_NodeId_Field,
+	update PendingAudits_Update_Fields) (
+	pending_audits *PendingAudits, err error) {
+	var tx *Tx
+	if tx, err = rx.getTx(ctx); err != nil {
+		return
+	}
+	return tx.Update_PendingAudits_By_NodeId(ctx, pending_audits_node_id, update)
+}
+
 type Methods interface {
 	All_AccountingRaw_By_NodeId(ctx context.Context,
 		accounting_raw_node_id AccountingRaw_NodeId_Field) (
@@ -4660,6 +6127,9 @@ type Methods interface {
 		bwagreement_created_at_greater Bwagreement_CreatedAt_Field) (
 		rows []*Bwagreement, err error)
 
+	All_PendingAudits(ctx context.Context) (
+		rows []*PendingAudits, err error)
+
 	Create_AccountingRaw(ctx context.Context,
 		accounting_raw_node_id AccountingRaw_NodeId_Field,
 		accounting_raw_interval_end_time AccountingRaw_IntervalEndTime_Field,
@@ -4704,14 +6174,47 @@ type Methods interface {
 		node_audit_success_ratio Node_AuditSuccessRatio_Field,
 		node_uptime_success_count Node_UptimeSuccessCount_Field,
 		node_total_uptime_count Node_TotalUptimeCount_Field,
-		node_uptime_ratio Node_UptimeRatio_Field) (
+		node_uptime_ratio Node_UptimeRatio_Field,
+		node_state Node_State_Field,
+		node_state_updated_at Node_StateUpdatedAt_Field,
+		node_state_override Node_StateOverride_Field,
+		node_latency_list Node_LatencyList_Field,
+		node_latency_90 Node_Latency90_Field,
+		node_speed_kbps Node_SpeedKbps_Field) (
 		node *Node, err error)
 
 	Create_OverlayCacheNode(ctx context.Context,
 		overlay_cache_node_key OverlayCacheNode_Key_Field,
-		overlay_cache_node_value OverlayCacheNode_Value_Field) (
+		overlay_cache_node_value OverlayCacheNode_Value_Field,
+		overlay_cache_node_node_type OverlayCacheNode_NodeType_Field,
+		overlay_cache_node_address OverlayCacheNode_Address_Field,
+		overlay_cache_node_free_bandwidth OverlayCacheNode_FreeBandwidth_Field,
+		overlay_cache_node_free_disk OverlayCacheNode_FreeDisk_Field,
+		overlay_cache_node_audit_success_ratio OverlayCacheNode_AuditSuccessRatio_Field,
+		overlay_cache_node_audit_success_count OverlayCacheNode_AuditSuccessCount_Field,
+		overlay_cache_node_audit_count OverlayCacheNode_AuditCount_Field,
+		overlay_cache_node_uptime_ratio OverlayCacheNode_UptimeRatio_Field,
+		overlay_cache_node_uptime_success_count OverlayCacheNode_UptimeSuccessCount_Field,
+		overlay_cache_node_uptime_count OverlayCacheNode_UptimeCount_Field,
+		overlay_cache_node_state OverlayCacheNode_State_Field,
+		overlay_cache_node_latency_90 OverlayCacheNode_Latency90_Field,
+		overlay_cache_node_speed_kbps OverlayCacheNode_SpeedKbps_Field,
+		overlay_cache_node_selection_key OverlayCacheNode_SelectionKey_Field,
+		overlay_cache_node_last_contact OverlayCacheNode_LastContact_Field) (
 		overlay_cache_node *OverlayCacheNode, err error)
 
+	Create_PendingAudits(ctx context.Context,
+		pending_audits_node_id PendingAudits_NodeId_Field,
+		pending_audits_piece_id PendingAudits_PieceId_Field,
+		pending_audits_piece_num PendingAudits_PieceNum_Field,
+		pending_audits_piece_size PendingAudits_PieceSize_Field,
+		pending_audits_stripe_index PendingAudits_StripeIndex_Field,
+		pending_audits_share_size PendingAudits_ShareSize_Field,
+		pending_audits_expected_share_hash PendingAudits_ExpectedShareHash_Field,
+		pending_audits_reverify_count PendingAudits_ReverifyCount_Field,
+		pending_audits_path PendingAudits_Path_Field) (
+		pending_audits *PendingAudits, err error)
+
 	Delete_AccountingRaw_By_Id(ctx context.Context,
 		accounting_raw_id AccountingRaw_Id_Field) (
 		deleted bool, err error)
@@ -4740,6 +6243,10 @@ type Methods interface {
 		overlay_cache_node_key OverlayCacheNode_Key_Field) (
 		deleted bool, err error)
 
+	Delete_PendingAudits_By_NodeId(ctx context.Context,
+		pending_audits_node_id PendingAudits_NodeId_Field) (
+		deleted bool, err error)
+
 	Find_AccountingTimestamps_Value_By_Name(ctx context.Context,
 		accounting_timestamps_name AccountingTimestamps_Name_Field) (
 		row *Value_Row, err error)
@@ -4771,6 +6278,10 @@ type Methods interface {
 		overlay_cache_node_key OverlayCacheNode_Key_Field) (
 		overlay_cache_node *OverlayCacheNode, err error)
 
+	Get_PendingAudits_By_NodeId(ctx context.Context,
+		pending_audits_node_id PendingAudits_NodeId_Field) (
+		pending_audits *PendingAudits, err error)
+
 	Limited_Bwagreement(ctx context.Context,
 		limit int, offset int64) (
 		rows []*Bwagreement, err error)
@@ -4779,12 +6290,12 @@ type Methods interface {
 		limit int, offset int64) (
 		rows []*Injuredsegment, err error)
 
-	Limited_OverlayCacheNode(ctx context.Context,
+	Limited_OverlayCacheNode_By_Key_GreaterOrEqual_OrderBy_Asc_Key(ctx context.Context,
+		overlay_cache_node_key_greater_or_equal OverlayCacheNode_Key_Field,
 		limit int, offset int64) (
 		rows []*OverlayCacheNode, err error)
 
-	Limited_OverlayCacheNode_By_Key_GreaterOrEqual(ctx context.Context,
-		overlay_cache_node_key_greater_or_equal OverlayCacheNode_Key_Field,
+	Limited_OverlayCacheNode_OrderBy_Asc_Key(ctx context.Context,
 		limit int, offset int64) (
 		rows []*OverlayCacheNode, err error)
 
@@ -4817,6 +6328,11 @@ type Methods interface {
 		overlay_cache_node_key OverlayCacheNode_Key_Field,
 		update OverlayCacheNode_Update_Fields) (
 		overlay_cache_node *OverlayCacheNode, err error)
+
+	Update_PendingAudits_By_NodeId(ctx context.Context,
+		pending_audits_node_id PendingAudits_NodeId_Field,
+		update PendingAudits_Update_Fields) (
+		pending_audits *PendingAudits, err error)
 }
 
 type TxMethods interface {
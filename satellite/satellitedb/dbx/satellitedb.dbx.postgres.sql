@@ -54,6 +54,12 @@ CREATE TABLE nodes (
 	uptime_success_count bigint NOT NULL,
 	total_uptime_count bigint NOT NULL,
 	uptime_ratio double precision NOT NULL,
+	state integer NOT NULL,
+	state_updated_at timestamp with time zone NOT NULL,
+	state_override boolean NOT NULL,
+	latency_list bytea NOT NULL,
+	latency_90 bigint NOT NULL,
+	speed_kbps bigint NOT NULL,
 	created_at timestamp with time zone NOT NULL,
 	updated_at timestamp with time zone NOT NULL,
 	PRIMARY KEY ( id )
@@ -61,6 +67,39 @@ CREATE TABLE nodes (
 CREATE TABLE overlay_cache_nodes (
 	key bytea NOT NULL,
 	value bytea NOT NULL,
+	node_type integer NOT NULL,
+	address text NOT NULL,
+	free_bandwidth bigint NOT NULL,
+	free_disk bigint NOT NULL,
+	audit_success_ratio double precision NOT NULL,
+	audit_success_count bigint NOT NULL,
+	audit_count bigint NOT NULL,
+	uptime_ratio double precision NOT NULL,
+	uptime_success_count bigint NOT NULL,
+	uptime_count bigint NOT NULL,
+	state integer NOT NULL,
+	latency_90 bigint NOT NULL,
+	speed_kbps bigint NOT NULL,
+	selection_key bigint NOT NULL,
+	last_contact timestamp with time zone NOT NULL,
 	PRIMARY KEY ( key ),
 	UNIQUE ( key )
 );
+CREATE TABLE pending_audits (
+	node_id bytea NOT NULL,
+	piece_id text NOT NULL,
+	piece_num bigint NOT NULL,
+	piece_size bigint NOT NULL,
+	stripe_index bigint NOT NULL,
+	share_size bigint NOT NULL,
+	expected_share_hash bytea NOT NULL,
+	reverify_count bigint NOT NULL,
+	path text NOT NULL,
+	created_at timestamp with time zone NOT NULL,
+	PRIMARY KEY ( node_id )
+);
+CREATE INDEX overlay_cache_nodes_free_disk_index ON overlay_cache_nodes ( free_disk );
+CREATE INDEX overlay_cache_nodes_free_bandwidth_index ON overlay_cache_nodes ( free_bandwidth );
+CREATE INDEX overlay_cache_nodes_reputation_index ON overlay_cache_nodes ( audit_success_ratio, uptime_ratio );
+CREATE INDEX overlay_cache_nodes_last_contact_index ON overlay_cache_nodes ( last_contact );
+CREATE INDEX overlay_cache_nodes_selection_key_index ON overlay_cache_nodes ( selection_key );
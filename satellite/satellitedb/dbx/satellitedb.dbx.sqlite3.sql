@@ -54,6 +54,12 @@ CREATE TABLE nodes (
 	uptime_success_count INTEGER NOT NULL,
 	total_uptime_count INTEGER NOT NULL,
 	uptime_ratio REAL NOT NULL,
+	state INTEGER NOT NULL,
+	state_updated_at TIMESTAMP NOT NULL,
+	state_override INTEGER NOT NULL,
+	latency_list BLOB NOT NULL,
+	latency_90 INTEGER NOT NULL,
+	speed_kbps INTEGER NOT NULL,
 	created_at TIMESTAMP NOT NULL,
 	updated_at TIMESTAMP NOT NULL,
 	PRIMARY KEY ( id )
@@ -61,6 +67,39 @@ CREATE TABLE nodes (
 CREATE TABLE overlay_cache_nodes (
 	key BLOB NOT NULL,
 	value BLOB NOT NULL,
+	node_type INTEGER NOT NULL,
+	address TEXT NOT NULL,
+	free_bandwidth INTEGER NOT NULL,
+	free_disk INTEGER NOT NULL,
+	audit_success_ratio REAL NOT NULL,
+	audit_success_count INTEGER NOT NULL,
+	audit_count INTEGER NOT NULL,
+	uptime_ratio REAL NOT NULL,
+	uptime_success_count INTEGER NOT NULL,
+	uptime_count INTEGER NOT NULL,
+	state INTEGER NOT NULL,
+	latency_90 INTEGER NOT NULL,
+	speed_kbps INTEGER NOT NULL,
+	selection_key INTEGER NOT NULL,
+	last_contact TIMESTAMP NOT NULL,
 	PRIMARY KEY ( key ),
 	UNIQUE ( key )
 );
+CREATE TABLE pending_audits (
+	node_id BLOB NOT NULL,
+	piece_id TEXT NOT NULL,
+	piece_num INTEGER NOT NULL,
+	piece_size INTEGER NOT NULL,
+	stripe_index INTEGER NOT NULL,
+	share_size INTEGER NOT NULL,
+	expected_share_hash BLOB NOT NULL,
+	reverify_count INTEGER NOT NULL,
+	path TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY ( node_id )
+);
+CREATE INDEX overlay_cache_nodes_free_disk_index ON overlay_cache_nodes ( free_disk );
+CREATE INDEX overlay_cache_nodes_free_bandwidth_index ON overlay_cache_nodes ( free_bandwidth );
+CREATE INDEX overlay_cache_nodes_reputation_index ON overlay_cache_nodes ( audit_success_ratio, uptime_ratio );
+CREATE INDEX overlay_cache_nodes_last_contact_index ON overlay_cache_nodes ( last_contact );
+CREATE INDEX overlay_cache_nodes_selection_key_index ON overlay_cache_nodes ( selection_key );
@@ -11,9 +11,11 @@ import (
 	"time"
 
 	"storj.io/storj/pkg/accounting"
+	"storj.io/storj/pkg/audit"
 	"storj.io/storj/pkg/bwagreement"
 	"storj.io/storj/pkg/datarepair/irreparable"
 	"storj.io/storj/pkg/datarepair/queue"
+	"storj.io/storj/pkg/overlay"
 	"storj.io/storj/pkg/pb"
 	"storj.io/storj/pkg/statdb"
 	"storj.io/storj/pkg/storj"
@@ -53,6 +55,13 @@ func (m *locked) Close() error {
 	return m.db.Close()
 }
 
+// Containment returns database for storing pending audits of contained nodes
+func (m *locked) Containment() audit.Containment {
+	m.Lock()
+	defer m.Unlock()
+	return &lockedContainment{m.Locker, m.db.Containment()}
+}
+
 // CreateTables initializes the database
 func (m *locked) CreateTables() error {
 	m.Lock()
@@ -68,7 +77,7 @@ func (m *locked) Irreparable() irreparable.DB {
 }
 
 // OverlayCache returns database for caching overlay information
-func (m *locked) OverlayCache() storage.KeyValueStore {
+func (m *locked) OverlayCache() overlay.DB {
 	m.Lock()
 	defer m.Unlock()
 	return &lockedOverlayCache{m.Locker, m.db.OverlayCache()}
@@ -142,6 +151,40 @@ func (m *lockedBandwidthAgreement) GetAgreementsSince(ctx context.Context, a1 ti
 	return m.db.GetAgreementsSince(ctx, a1)
 }
 
+// lockedContainment implements locking wrapper for audit.Containment
+type lockedContainment struct {
+	sync.Locker
+	db audit.Containment
+}
+
+// Delete removes a node from containment
+func (m *lockedContainment) Delete(ctx context.Context, nodeID storj.NodeID) (bool, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Delete(ctx, nodeID)
+}
+
+// Get returns the pending audit for a contained node
+func (m *lockedContainment) Get(ctx context.Context, nodeID storj.NodeID) (*audit.PendingAudit, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.Get(ctx, nodeID)
+}
+
+// IncrementPending creates a pending audit or increments the reverify count of an existing one
+func (m *lockedContainment) IncrementPending(ctx context.Context, pendingAudit *audit.PendingAudit) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.IncrementPending(ctx, pendingAudit)
+}
+
+// List returns all pending audits
+func (m *lockedContainment) List(ctx context.Context) ([]*audit.PendingAudit, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.List(ctx)
+}
+
 // lockedIrreparable implements locking wrapper for irreparable.DB
 type lockedIrreparable struct {
 	sync.Locker
@@ -169,10 +212,10 @@ func (m *lockedIrreparable) IncrementRepairAttempts(ctx context.Context, segment
 	return m.db.IncrementRepairAttempts(ctx, segmentInfo)
 }
 
-// lockedOverlayCache implements locking wrapper for storage.KeyValueStore
+// lockedOverlayCache implements locking wrapper for overlay.DB
 type lockedOverlayCache struct {
 	sync.Locker
-	db storage.KeyValueStore
+	db overlay.DB
 }
 
 // Close closes the store
@@ -231,6 +274,20 @@ func (m *lockedOverlayCache) ReverseList(a0 storage.Key, a1 int) (storage.Keys,
 	return m.db.ReverseList(a0, a1)
 }
 
+// SelectStorageNodes returns up to count randomly sampled storage nodes matching criteria
+func (m *lockedOverlayCache) SelectStorageNodes(ctx context.Context, count int, criteria *overlay.NodeCriteria) ([]*pb.Node, error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.SelectStorageNodes(ctx, count, criteria)
+}
+
+// UpdateLastContact records that the satellite contacted the node at contacted
+func (m *lockedOverlayCache) UpdateLastContact(ctx context.Context, nodeID storj.NodeID, contacted time.Time) error {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.UpdateLastContact(ctx, nodeID, contacted)
+}
+
 // lockedRepairQueue implements locking wrapper for queue.RepairQueue
 type lockedRepairQueue struct {
 	sync.Locker
@@ -278,7 +335,7 @@ func (m *lockedStatDB) CreateEntryIfNotExists(ctx context.Context, nodeID storj.
 	return m.db.CreateEntryIfNotExists(ctx, nodeID)
 }
 
-// FindInvalidNodes finds a subset of storagenodes that have stats below provided reputation requirements.
+// FindInvalidNodes finds a subset of storagenodes that are disqualified or have stats below provided reputation requirements.
 func (m *lockedStatDB) FindInvalidNodes(ctx context.Context, nodeIDs storj.NodeIDList, maxStats *statdb.NodeStats) (invalid storj.NodeIDList, err error) {
 	m.Lock()
 	defer m.Unlock()
@@ -313,9 +370,37 @@ func (m *lockedStatDB) UpdateBatch(ctx context.Context, requests []*statdb.Updat
 	return m.db.UpdateBatch(ctx, requests)
 }
 
+// UpdateState moves a single storagenode to a new state.
+func (m *lockedStatDB) UpdateState(ctx context.Context, nodeID storj.NodeID, state statdb.NodeState) (stats *statdb.NodeStats, err error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.UpdateState(ctx, nodeID, state)
+}
+
+// OverrideState moves a single storagenode to a state chosen by an operator, which is kept until released.
+func (m *lockedStatDB) OverrideState(ctx context.Context, nodeID storj.NodeID, state statdb.NodeState) (stats *statdb.NodeStats, err error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.OverrideState(ctx, nodeID, state)
+}
+
+// ReleaseState lets a single storagenode's stats move it between states again.
+func (m *lockedStatDB) ReleaseState(ctx context.Context, nodeID storj.NodeID) (stats *statdb.NodeStats, err error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.ReleaseState(ctx, nodeID)
+}
+
 // UpdateUptime updates a single storagenode's uptime stats.
 func (m *lockedStatDB) UpdateUptime(ctx context.Context, nodeID storj.NodeID, isUp bool) (stats *statdb.NodeStats, err error) {
 	m.Lock()
 	defer m.Unlock()
 	return m.db.UpdateUptime(ctx, nodeID, isUp)
 }
+
+// UpdatePerformance records a latency and speed measurement of a single storagenode.
+func (m *lockedStatDB) UpdatePerformance(ctx context.Context, nodeID storj.NodeID, latency time.Duration, speedKbps int64) (stats *statdb.NodeStats, err error) {
+	m.Lock()
+	defer m.Unlock()
+	return m.db.UpdatePerformance(ctx, nodeID, latency, speedKbps)
+}
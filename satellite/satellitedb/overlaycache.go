@@ -5,9 +5,20 @@ package satellitedb
 
 import (
 	"context"
+	"crypto/rand"
 	"database/sql"
+	"encoding/binary"
 	"errors"
+	"math"
+	"strings"
+	"time"
 
+	"github.com/gogo/protobuf/proto"
+
+	"storj.io/storj/pkg/overlay"
+	"storj.io/storj/pkg/pb"
+	"storj.io/storj/pkg/statdb"
+	"storj.io/storj/pkg/storj"
 	"storj.io/storj/pkg/utils"
 	dbx "storj.io/storj/satellite/satellitedb/dbx"
 	"storj.io/storj/storage"
@@ -23,6 +34,14 @@ func (o *overlaycache) Put(key storage.Key, value storage.Value) error {
 	}
 	ctx := context.Background() // TODO: fix
 
+	// values that aren't nodes are stored without selection columns and never selected
+	node := &pb.Node{}
+	if err := proto.Unmarshal(value, node); err != nil {
+		node = &pb.Node{}
+	}
+	restrictions := node.GetRestrictions()
+	reputation := node.GetReputation()
+
 	tx, err := o.db.Open(ctx)
 	if err != nil {
 		return Error.Wrap(err)
@@ -30,17 +49,51 @@ func (o *overlaycache) Put(key storage.Key, value storage.Value) error {
 
 	_, err = tx.Get_OverlayCacheNode_By_Key(ctx, dbx.OverlayCacheNode_Key(key))
 	if err != nil {
+		selectionKey, err := randomSelectionKey()
+		if err != nil {
+			return Error.Wrap(utils.CombineErrors(err, tx.Rollback()))
+		}
 		_, err = tx.Create_OverlayCacheNode(
 			ctx,
 			dbx.OverlayCacheNode_Key(key),
 			dbx.OverlayCacheNode_Value(value),
+			dbx.OverlayCacheNode_NodeType(int(node.GetType())),
+			dbx.OverlayCacheNode_Address(node.GetAddress().GetAddress()),
+			dbx.OverlayCacheNode_FreeBandwidth(restrictions.GetFreeBandwidth()),
+			dbx.OverlayCacheNode_FreeDisk(restrictions.GetFreeDisk()),
+			dbx.OverlayCacheNode_AuditSuccessRatio(reputation.GetAuditSuccessRatio()),
+			dbx.OverlayCacheNode_AuditSuccessCount(reputation.GetAuditSuccessCount()),
+			dbx.OverlayCacheNode_AuditCount(reputation.GetAuditCount()),
+			dbx.OverlayCacheNode_UptimeRatio(reputation.GetUptimeRatio()),
+			dbx.OverlayCacheNode_UptimeSuccessCount(reputation.GetUptimeSuccessCount()),
+			dbx.OverlayCacheNode_UptimeCount(reputation.GetUptimeCount()),
+			dbx.OverlayCacheNode_State(int(reputation.GetState())),
+			dbx.OverlayCacheNode_Latency90(reputation.GetLatency_90()),
+			dbx.OverlayCacheNode_SpeedKbps(reputation.GetSpeedKbps()),
+			dbx.OverlayCacheNode_SelectionKey(selectionKey),
+			// nodes haven't been contacted until UpdateLastContact says so
+			dbx.OverlayCacheNode_LastContact(time.Time{}),
 		)
 		if err != nil {
 			return Error.Wrap(utils.CombineErrors(err, tx.Rollback()))
 		}
 	} else {
-		updateFields := dbx.OverlayCacheNode_Update_Fields{}
-		updateFields.Value = dbx.OverlayCacheNode_Value(value)
+		updateFields := dbx.OverlayCacheNode_Update_Fields{
+			Value:              dbx.OverlayCacheNode_Value(value),
+			NodeType:           dbx.OverlayCacheNode_NodeType(int(node.GetType())),
+			Address:            dbx.OverlayCacheNode_Address(node.GetAddress().GetAddress()),
+			FreeBandwidth:      dbx.OverlayCacheNode_FreeBandwidth(restrictions.GetFreeBandwidth()),
+			FreeDisk:           dbx.OverlayCacheNode_FreeDisk(restrictions.GetFreeDisk()),
+			AuditSuccessRatio:  dbx.OverlayCacheNode_AuditSuccessRatio(reputation.GetAuditSuccessRatio()),
+			AuditSuccessCount:  dbx.OverlayCacheNode_AuditSuccessCount(reputation.GetAuditSuccessCount()),
+			AuditCount:         dbx.OverlayCacheNode_AuditCount(reputation.GetAuditCount()),
+			UptimeRatio:        dbx.OverlayCacheNode_UptimeRatio(reputation.GetUptimeRatio()),
+			UptimeSuccessCount: dbx.OverlayCacheNode_UptimeSuccessCount(reputation.GetUptimeSuccessCount()),
+			UptimeCount:        dbx.OverlayCacheNode_UptimeCount(reputation.GetUptimeCount()),
+			State:              dbx.OverlayCacheNode_State(int(reputation.GetState())),
+			Latency90:          dbx.OverlayCacheNode_Latency90(reputation.GetLatency_90()),
+			SpeedKbps:          dbx.OverlayCacheNode_SpeedKbps(reputation.GetSpeedKbps()),
+		}
 		_, err := tx.Update_OverlayCacheNode_By_Key(
 			ctx,
 			dbx.OverlayCacheNode_Key(key),
@@ -70,15 +123,58 @@ func (o *overlaycache) Get(key storage.Key) (storage.Value, error) {
 	return node.Value, nil
 }
 
+// getAllBatch is the most keys looked up in a single query
+const getAllBatch = 500
+
+// GetAll returns the values of keys in the same order, nil for missing keys
 func (o *overlaycache) GetAll(keys storage.Keys) (storage.Values, error) {
 	values := make([]storage.Value, len(keys))
+	for start := 0; start < len(keys); start += getAllBatch {
+		end := start + getAllBatch
+		if end > len(keys) {
+			end = len(keys)
+		}
+		if err := o.getAll(keys[start:end], values[start:end]); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// getAll looks up keys with a single query and fills in values
+func (o *overlaycache) getAll(keys storage.Keys, values storage.Values) (err error) {
+	args := make([]interface{}, 0, len(keys))
+	index := make(map[string][]int, len(keys))
 	for i, key := range keys {
-		value, err := o.Get(key)
-		if err == nil {
+		if key.IsZero() {
+			continue
+		}
+		if _, ok := index[string(key)]; !ok {
+			args = append(args, []byte(key))
+		}
+		index[string(key)] = append(index[string(key)], i)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+
+	rows, err := o.db.Query(o.db.Rebind(`SELECT key, value FROM overlay_cache_nodes
+		WHERE key IN (?`+strings.Repeat(", ?", len(args)-1)+`)`), args...)
+	if err != nil {
+		return Error.Wrap(err)
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	for rows.Next() {
+		var key, value []byte
+		if err := rows.Scan(&key, &value); err != nil {
+			return Error.Wrap(err)
+		}
+		for _, i := range index[string(key)] {
 			values[i] = value
 		}
 	}
-	return values, nil
+	return Error.Wrap(rows.Err())
 }
 
 func (o *overlaycache) Delete(key storage.Key) error {
@@ -95,9 +191,9 @@ func (o *overlaycache) List(start storage.Key, limit int) (keys storage.Keys, er
 
 	var rows []*dbx.OverlayCacheNode
 	if start == nil {
-		rows, err = o.db.Limited_OverlayCacheNode(ctx, limit, 0)
+		rows, err = o.db.Limited_OverlayCacheNode_OrderBy_Asc_Key(ctx, limit, 0)
 	} else {
-		rows, err = o.db.Limited_OverlayCacheNode_By_Key_GreaterOrEqual(ctx, dbx.OverlayCacheNode_Key(start), limit, 0)
+		rows, err = o.db.Limited_OverlayCacheNode_By_Key_GreaterOrEqual_OrderBy_Asc_Key(ctx, dbx.OverlayCacheNode_Key(start), limit, 0)
 	}
 	if err != nil {
 		return []storage.Key{}, err
@@ -125,3 +221,178 @@ func (o *overlaycache) Iterate(opts storage.IterateOptions, fn func(storage.Iter
 func (o *overlaycache) Close() error {
 	return errors.New("not implemented")
 }
+
+// UpdateLastContact records that the satellite contacted the node at contacted
+func (o *overlaycache) UpdateLastContact(ctx context.Context, nodeID storj.NodeID, contacted time.Time) (err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	_, err = o.db.Update_OverlayCacheNode_By_Key(ctx,
+		dbx.OverlayCacheNode_Key(nodeID.Bytes()),
+		dbx.OverlayCacheNode_Update_Fields{LastContact: dbx.OverlayCacheNode_LastContact(contacted.UTC())},
+	)
+	return Error.Wrap(err)
+}
+
+// SelectStorageNodes returns up to count storage nodes matching criteria. Nodes are sampled
+// by reading the nodes that follow a random selection key, wrapping around at the end of the
+// index. Excluded nodes are skipped while reading, so the query doesn't grow with them. The
+// selected nodes draw new selection keys in the same transaction, so that nodes following
+// large gaps aren't selected more often than others.
+func (o *overlaycache) SelectStorageNodes(ctx context.Context, count int, criteria *overlay.NodeCriteria) (nodes []*pb.Node, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	if count <= 0 {
+		return nil, nil
+	}
+
+	pivot, err := randomSelectionKey()
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	excluded := make(map[storj.NodeID]bool, len(criteria.Excluded))
+	for _, id := range criteria.Excluded {
+		excluded[id] = true
+	}
+
+	tx, err := o.db.Open(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	defer func() {
+		if err != nil {
+			err = utils.CombineErrors(err, tx.Rollback())
+			return
+		}
+		err = Error.Wrap(tx.Commit())
+	}()
+
+	// the selection keys from the pivot to the end of the index, then the ones before it
+	ranges := [][2]int64{{pivot, math.MaxInt64}}
+	if pivot > math.MinInt64 {
+		ranges = append(ranges, [2]int64{math.MinInt64, pivot - 1})
+	}
+	for _, keys := range ranges {
+		from, to := keys[0], keys[1]
+		for len(nodes) < count {
+			page, last, err := o.selectStorageNodes(ctx, tx, from, to, count, criteria)
+			if err != nil {
+				return nil, err
+			}
+			for _, node := range page {
+				if len(nodes) < count && !excluded[node.Id] {
+					nodes = append(nodes, node)
+				}
+			}
+			if len(page) < count || last == to {
+				break
+			}
+			from = last + 1
+		}
+	}
+
+	if err := o.redrawSelectionKeys(ctx, tx, nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// selectStorageNodes returns up to limit nodes matching criteria with selection keys between
+// from and to inclusive, in selection key order, and the selection key of the last one
+func (o *overlaycache) selectStorageNodes(ctx context.Context, tx *dbx.Tx, from, to int64, limit int, criteria *overlay.NodeCriteria) (_ []*pb.Node, last int64, err error) {
+	states := criteria.States
+
+	query := `SELECT selection_key, value FROM overlay_cache_nodes
+		WHERE selection_key BETWEEN ? AND ?
+		AND node_type = ?
+		AND free_bandwidth >= ? AND free_disk >= ?
+		AND audit_count >= ? AND audit_success_ratio >= ?
+		AND uptime_count >= ? AND uptime_ratio >= ?
+		AND state NOT IN (?, ?)
+		AND NOT (audit_count >= ? AND audit_success_ratio < ?)
+		AND NOT (uptime_count >= ? AND uptime_ratio < ?)
+		AND last_contact >= ?`
+	args := []interface{}{
+		from, to,
+		int(pb.NodeType_STORAGE),
+		criteria.FreeBandwidth, criteria.FreeDisk,
+		criteria.AuditCount, criteria.AuditSuccessRatio,
+		criteria.UptimeCount, criteria.UptimeRatio,
+		int(statdb.StateSuspended), int(statdb.StateDisqualified),
+		states.DisqualificationMinAuditCount, states.DisqualificationAuditRatio,
+		states.SuspensionMinUptimeCount, states.SuspensionUptimeRatio,
+		criteria.ContactedSince.UTC(),
+	}
+
+	if criteria.NewNodes {
+		query += ` AND (audit_success_count < ? OR uptime_success_count < ?)`
+	} else {
+		query += ` AND audit_success_count >= ? AND uptime_success_count >= ?`
+	}
+	args = append(args, states.VettingAuditCount, states.VettingUptimeCount)
+
+	if criteria.MaxLatency > 0 {
+		query += ` AND (latency_90 = 0 OR latency_90 <= ?)`
+		args = append(args, criteria.MaxLatency)
+	}
+	if criteria.MinSpeedKbps > 0 {
+		query += ` AND (speed_kbps = 0 OR speed_kbps >= ?)`
+		args = append(args, criteria.MinSpeedKbps)
+	}
+
+	query += ` ORDER BY selection_key LIMIT ?`
+	args = append(args, limit)
+
+	rows, err := tx.Tx.QueryContext(ctx, o.db.Rebind(query), args...)
+	if err != nil {
+		return nil, 0, Error.Wrap(err)
+	}
+	defer func() { err = utils.CombineErrors(err, rows.Close()) }()
+
+	var nodes []*pb.Node
+	for rows.Next() {
+		var value []byte
+		if err := rows.Scan(&last, &value); err != nil {
+			return nil, 0, Error.Wrap(err)
+		}
+		node := &pb.Node{}
+		if err := proto.Unmarshal(value, node); err != nil {
+			return nil, 0, Error.Wrap(err)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, last, Error.Wrap(rows.Err())
+}
+
+// redrawSelectionKeys gives every node a new random selection key with a single statement
+func (o *overlaycache) redrawSelectionKeys(ctx context.Context, tx *dbx.Tx, nodes []*pb.Node) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, 3*len(nodes))
+	for _, node := range nodes {
+		selectionKey, err := randomSelectionKey()
+		if err != nil {
+			return Error.Wrap(err)
+		}
+		args = append(args, node.Id.Bytes(), selectionKey)
+	}
+	for _, node := range nodes {
+		args = append(args, node.Id.Bytes())
+	}
+
+	_, err := tx.Tx.ExecContext(ctx, o.db.Rebind(`UPDATE overlay_cache_nodes
+		SET selection_key = CASE key`+strings.Repeat(` WHEN ? THEN CAST(? AS BIGINT)`, len(nodes))+` END
+		WHERE key IN (?`+strings.Repeat(", ?", len(nodes)-1)+`)`), args...)
+	return Error.Wrap(err)
+}
+
+// randomSelectionKey returns a random key for sampling nodes
+func randomSelectionKey() (int64, error) {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, err
+	}
+	return int64(binary.BigEndian.Uint64(b[:])), nil
+}
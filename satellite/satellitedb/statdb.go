@@ -6,7 +6,9 @@ package satellitedb
 import (
 	"context"
 	"database/sql"
+	"encoding/binary"
 	"strings"
+	"time"
 
 	"github.com/zeebo/errs"
 	monkit "gopkg.in/spacemonkeygo/monkit.v2"
@@ -37,6 +39,12 @@ func getNodeStats(nodeID storj.NodeID, dbNode *dbx.Node) *statdb.NodeStats {
 		UptimeRatio:        dbNode.UptimeRatio,
 		UptimeSuccessCount: dbNode.UptimeSuccessCount,
 		UptimeCount:        dbNode.TotalUptimeCount,
+		State:              statdb.NodeState(dbNode.State),
+		StateUpdatedAt:     dbNode.StateUpdatedAt,
+		StateOverride:      dbNode.StateOverride,
+		LatencyList:        decodeLatencies(dbNode.LatencyList),
+		Latency90:          dbNode.Latency90,
+		SpeedKbps:          dbNode.SpeedKbps,
 	}
 	return nodeStats
 }
@@ -52,6 +60,8 @@ func (s *statDB) Create(ctx context.Context, nodeID storj.NodeID, startingStats
 		totalUptimeCount   int64
 		uptimeSuccessCount int64
 		uptimeRatio        float64
+		state              = statdb.StateVetting
+		stateOverride      bool
 	)
 
 	if startingStats != nil {
@@ -68,6 +78,9 @@ func (s *statDB) Create(ctx context.Context, nodeID storj.NodeID, startingStats
 		if err != nil {
 			return nil, errUptime.Wrap(err)
 		}
+
+		state = startingStats.State
+		stateOverride = startingStats.StateOverride
 	}
 
 	dbNode, err := s.db.Create_Node(
@@ -79,6 +92,12 @@ func (s *statDB) Create(ctx context.Context, nodeID storj.NodeID, startingStats
 		dbx.Node_UptimeSuccessCount(uptimeSuccessCount),
 		dbx.Node_TotalUptimeCount(totalUptimeCount),
 		dbx.Node_UptimeRatio(uptimeRatio),
+		dbx.Node_State(int(state)),
+		dbx.Node_StateUpdatedAt(time.Now().UTC()),
+		dbx.Node_StateOverride(stateOverride),
+		dbx.Node_LatencyList(encodeLatencies(nil)),
+		dbx.Node_Latency90(0),
+		dbx.Node_SpeedKbps(0),
 	)
 	if err != nil {
 		return nil, Error.Wrap(err)
@@ -93,6 +112,9 @@ func (s *statDB) Get(ctx context.Context, nodeID storj.NodeID) (stats *statdb.No
 	defer mon.Task()(&ctx)(&err)
 
 	dbNode, err := s.db.Get_Node_By_Id(ctx, dbx.Node_Id(nodeID.Bytes()))
+	if err == sql.ErrNoRows {
+		return nil, statdb.ErrNodeNotFound.New("%s", nodeID)
+	}
 	if err != nil {
 		return nil, Error.Wrap(err)
 	}
@@ -101,7 +123,7 @@ func (s *statDB) Get(ctx context.Context, nodeID storj.NodeID) (stats *statdb.No
 	return nodeStats, nil
 }
 
-// FindInvalidNodes finds a subset of storagenodes that fail to meet minimum reputation requirements
+// FindInvalidNodes finds a subset of storagenodes that are disqualified or fail to meet minimum reputation requirements
 func (s *statDB) FindInvalidNodes(ctx context.Context, nodeIDs storj.NodeIDList, maxStats *statdb.NodeStats) (invalidIDs storj.NodeIDList, err error) {
 	defer mon.Task()(&ctx)(&err)
 
@@ -140,18 +162,23 @@ func (s *statDB) findInvalidNodesQuery(nodeIds storj.NodeIDList, auditSuccess, u
 	for i, id := range nodeIds {
 		args[i] = id.Bytes()
 	}
-	args = append(args, auditSuccess, uptime)
+	args = append(args, int(statdb.StateDisqualified), auditSuccess, uptime)
 
 	rows, err := s.db.Query(s.db.Rebind(`SELECT nodes.id, nodes.total_audit_count,
 		nodes.total_uptime_count, nodes.audit_success_ratio,
 		nodes.uptime_ratio
 		FROM nodes
 		WHERE nodes.id IN (?`+strings.Repeat(", ?", len(nodeIds)-1)+`)
-		AND nodes.total_audit_count > 0
-		AND nodes.total_uptime_count > 0
 		AND (
-			nodes.audit_success_ratio < ?
-			OR nodes.uptime_ratio < ?
+			nodes.state = ?
+			OR (
+				nodes.total_audit_count > 0
+				AND nodes.total_uptime_count > 0
+				AND (
+					nodes.audit_success_ratio < ?
+					OR nodes.uptime_ratio < ?
+				)
+			)
 		)`), args...)
 
 	return rows, err
@@ -319,8 +346,7 @@ func (s *statDB) CreateEntryIfNotExists(ctx context.Context, nodeID storj.NodeID
 	defer mon.Task()(&ctx)(&err)
 
 	getStats, err := s.Get(ctx, nodeID)
-	// TODO: figure out better way to confirm error is type dbx.ErrorCode_NoRows
-	if err != nil && strings.Contains(err.Error(), "no rows in result set") {
+	if statdb.ErrNodeNotFound.Has(err) {
 		createStats, err := s.Create(ctx, nodeID, nil)
 		if err != nil {
 			return nil, err
@@ -333,6 +359,108 @@ func (s *statDB) CreateEntryIfNotExists(ctx context.Context, nodeID storj.NodeID
 	return getStats, nil
 }
 
+// UpdateState moves a single storagenode to a new state and records when it happened
+func (s *statDB) UpdateState(ctx context.Context, nodeID storj.NodeID, state statdb.NodeState) (stats *statdb.NodeStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return s.updateState(ctx, nodeID, dbx.Node_Update_Fields{
+		State:          dbx.Node_State(int(state)),
+		StateUpdatedAt: dbx.Node_StateUpdatedAt(time.Now().UTC()),
+	})
+}
+
+// OverrideState moves a single storagenode to a state chosen by an operator and keeps
+// it there until ReleaseState is called
+func (s *statDB) OverrideState(ctx context.Context, nodeID storj.NodeID, state statdb.NodeState) (stats *statdb.NodeStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return s.updateState(ctx, nodeID, dbx.Node_Update_Fields{
+		State:          dbx.Node_State(int(state)),
+		StateUpdatedAt: dbx.Node_StateUpdatedAt(time.Now().UTC()),
+		StateOverride:  dbx.Node_StateOverride(true),
+	})
+}
+
+// ReleaseState lets a single storagenode's stats move it between states again
+func (s *statDB) ReleaseState(ctx context.Context, nodeID storj.NodeID) (stats *statdb.NodeStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	return s.updateState(ctx, nodeID, dbx.Node_Update_Fields{
+		StateOverride: dbx.Node_StateOverride(false),
+	})
+}
+
+func (s *statDB) updateState(ctx context.Context, nodeID storj.NodeID, updateFields dbx.Node_Update_Fields) (stats *statdb.NodeStats, err error) {
+	dbNode, err := s.db.Update_Node_By_Id(ctx, dbx.Node_Id(nodeID.Bytes()), updateFields)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	if dbNode == nil {
+		return nil, Error.New("node %s not found", nodeID)
+	}
+
+	return getNodeStats(nodeID, dbNode), nil
+}
+
+// UpdatePerformance records a latency and speed measurement of a single
+// storagenode in the db. Zero values are not measurements and are ignored.
+func (s *statDB) UpdatePerformance(ctx context.Context, nodeID storj.NodeID, latency time.Duration, speedKbps int64) (stats *statdb.NodeStats, err error) {
+	defer mon.Task()(&ctx)(&err)
+
+	tx, err := s.db.Open(ctx)
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+	dbNode, err := tx.Get_Node_By_Id(ctx, dbx.Node_Id(nodeID.Bytes()))
+	if err != nil {
+		return nil, Error.Wrap(utils.CombineErrors(err, tx.Rollback()))
+	}
+
+	updateFields := dbx.Node_Update_Fields{}
+
+	if latency > 0 {
+		latencies := statdb.AddLatency(decodeLatencies(dbNode.LatencyList), latency)
+		updateFields.LatencyList = dbx.Node_LatencyList(encodeLatencies(latencies))
+		updateFields.Latency90 = dbx.Node_Latency90(statdb.Latency90(latencies))
+	}
+	if speedKbps > 0 {
+		updateFields.SpeedKbps = dbx.Node_SpeedKbps(statdb.AverageSpeed(dbNode.SpeedKbps, speedKbps))
+	}
+
+	dbNode, err = tx.Update_Node_By_Id(ctx, dbx.Node_Id(nodeID.Bytes()), updateFields)
+	if err != nil {
+		return nil, Error.Wrap(utils.CombineErrors(err, tx.Rollback()))
+	}
+
+	nodeStats := getNodeStats(nodeID, dbNode)
+	return nodeStats, Error.Wrap(tx.Commit())
+}
+
+// encodeLatencies packs latencies into varints
+func encodeLatencies(latencies []int64) []byte {
+	data := make([]byte, 0, len(latencies)*binary.MaxVarintLen64)
+	buf := make([]byte, binary.MaxVarintLen64)
+	for _, latency := range latencies {
+		n := binary.PutVarint(buf, latency)
+		data = append(data, buf[:n]...)
+	}
+	return data
+}
+
+// decodeLatencies unpacks latencies packed by encodeLatencies
+func decodeLatencies(data []byte) []int64 {
+	var latencies []int64
+	for len(data) > 0 {
+		latency, n := binary.Varint(data)
+		if n <= 0 {
+			break
+		}
+		latencies = append(latencies, latency)
+		data = data[n:]
+	}
+	return latencies
+}
+
 func updateRatioVars(newStatus bool, successCount, totalCount int64) (int64, int64, float64) {
 	totalCount++
 	if newStatus {